@@ -0,0 +1,289 @@
+package nuview
+
+// SliceAdapter binds a slice of items to a List or a Table. Each item is
+// rendered as a list item or a table row by a render function. The adapter
+// refers to the slice variable itself, so the application modifies the slice
+// as usual and then notifies the adapter of the modification:
+//
+//	adapter := NewListAdapter(list, &files, func(f File) *ListItem {
+//		return NewListItem(f.Name)
+//	})
+//	files = append(files, newFile)
+//	adapter.NotifyInserted(len(files)-1, 1)
+//
+// Only the affected list items or table rows are updated. The selection stays
+// on the selected item when items are inserted or removed before it. After
+// arbitrary modifications, Reload() renders all items again and restores the
+// selection by the identity of the selected item (see SetKeyFunc).
+//
+// An adapter is not safe for concurrent use. Like the slice, it must only be
+// used from the goroutine which runs the application's event loop, e.g. inside
+// Application.QueueUpdateDraw(). Callbacks of the primitive (e.g. the "changed"
+// callback of a List) may call the adapter.
+type SliceAdapter[T any] struct {
+	// The slice of items.
+	items *[]T
+
+	// A copy of the items as they are currently displayed.
+	shown []T
+
+	// The primitive displaying the items.
+	view sliceView
+
+	// An optional function which returns a comparable key identifying an item.
+	key func(item T) any
+}
+
+// sliceView is a primitive displaying the items of a SliceAdapter.
+type sliceView interface {
+	// Insert, remove or update the item at the given index.
+	insertItem(index int)
+	removeItem(index int)
+	updateItem(index int)
+
+	// Remove all items.
+	clearItems()
+
+	// Return the index of the selected item, -1 if there is none.
+	selectedItem() int
+
+	// Select the item at the given index.
+	selectItem(index int)
+}
+
+// NewListAdapter returns a new adapter which displays the items of the
+// provided slice in a list, replacing any items the list holds.
+func NewListAdapter[T any](list *List, items *[]T, render func(item T) *ListItem) *SliceAdapter[T] {
+	a := &SliceAdapter[T]{
+		items: items,
+	}
+	a.view = &listSliceView[T]{
+		list:   list,
+		items:  items,
+		render: render,
+	}
+	a.Reload()
+	return a
+}
+
+// NewTableAdapter returns a new adapter which displays the items of the
+// provided slice in a table, one row per item. The rows are placed below the
+// rows the table holds when the adapter is created, e.g. a header row. The
+// render function should return the same number of cells for all items.
+func NewTableAdapter[T any](table *Table, items *[]T, render func(item T) []*TableCell) *SliceAdapter[T] {
+	a := &SliceAdapter[T]{
+		items: items,
+	}
+	a.view = &tableSliceView[T]{
+		table:    table,
+		items:    items,
+		render:   render,
+		firstRow: table.GetRowCount(),
+	}
+	a.Reload()
+	return a
+}
+
+// SetKeyFunc sets a function which returns a comparable key identifying an
+// item, e.g. its ID. Reload() uses it to select the previously selected item
+// again. Without it, the selection stays at the same index. Uncomparable keys,
+// e.g. slices, never match.
+func (a *SliceAdapter[T]) SetKeyFunc(key func(item T) any) {
+	a.key = key
+}
+
+// NotifyInserted displays the provided number of items which were inserted
+// into the slice at the given index.
+func (a *SliceAdapter[T]) NotifyInserted(index, count int) {
+	if index < 0 || count <= 0 || index > len(a.shown) || index+count > len(*a.items) {
+		return
+	}
+	a.shown = append(a.shown[:index], append(append([]T(nil), (*a.items)[index:index+count]...), a.shown[index:]...)...)
+	for i := index; i < index+count; i++ {
+		a.view.insertItem(i)
+	}
+}
+
+// NotifyRemoved removes the provided number of items which were removed from
+// the slice at the given index.
+func (a *SliceAdapter[T]) NotifyRemoved(index, count int) {
+	if index < 0 || count <= 0 || index+count > len(a.shown) {
+		return
+	}
+	a.shown = append(a.shown[:index], a.shown[index+count:]...)
+	for range count {
+		a.view.removeItem(index)
+	}
+}
+
+// NotifyChanged renders the provided number of items starting at the given
+// index again after they were modified.
+func (a *SliceAdapter[T]) NotifyChanged(index, count int) {
+	if index < 0 || count <= 0 || index+count > len(a.shown) || index+count > len(*a.items) {
+		return
+	}
+	for i := index; i < index+count; i++ {
+		a.shown[i] = (*a.items)[i]
+		a.view.updateItem(i)
+	}
+}
+
+// Reload renders all items again. The previously selected item is selected
+// again if it is still found in the slice (see SetKeyFunc).
+func (a *SliceAdapter[T]) Reload() {
+	selected := a.view.selectedItem()
+	if a.key != nil && selected >= 0 && selected < len(a.shown) {
+		key := a.key(a.shown[selected])
+		for index, item := range *a.items {
+			if referencesEqual(a.key(item), key) {
+				selected = index
+				break
+			}
+		}
+	}
+
+	a.view.clearItems()
+	a.shown = append([]T(nil), *a.items...)
+	for index := range a.shown {
+		a.view.insertItem(index)
+	}
+	if selected >= 0 && len(a.shown) > 0 {
+		a.view.selectItem(min(selected, len(a.shown)-1))
+	}
+}
+
+// GetSelectedItem returns the selected item and its index in the slice. The
+// index is -1 if no item is selected.
+func (a *SliceAdapter[T]) GetSelectedItem() (item T, index int) {
+	index = a.view.selectedItem()
+	if index < 0 || index >= len(a.shown) {
+		return item, -1
+	}
+	return a.shown[index], index
+}
+
+// listSliceView displays the items of a SliceAdapter in a List.
+type listSliceView[T any] struct {
+	list   *List
+	items  *[]T
+	render func(item T) *ListItem
+}
+
+func (v *listSliceView[T]) insertItem(index int) {
+	v.list.InsertItem(index, v.render((*v.items)[index]))
+}
+
+func (v *listSliceView[T]) removeItem(index int) {
+	v.list.RemoveItem(index)
+}
+
+func (v *listSliceView[T]) updateItem(index int) {
+	item := v.render((*v.items)[index])
+	v.list.Lock()
+	defer v.list.Unlock()
+	if index < len(v.list.items) {
+		v.list.items[index] = item
+	}
+}
+
+func (v *listSliceView[T]) clearItems() {
+	v.list.Clear()
+}
+
+func (v *listSliceView[T]) selectedItem() int {
+	if v.list.GetItemCount() == 0 {
+		return -1
+	}
+	return v.list.GetCurrentItemIndex()
+}
+
+func (v *listSliceView[T]) selectItem(index int) {
+	v.list.SetCurrentItem(index)
+}
+
+// tableSliceView displays the items of a SliceAdapter in the rows of a Table.
+type tableSliceView[T any] struct {
+	table  *Table
+	items  *[]T
+	render func(item T) []*TableCell
+
+	// The row of the first item.
+	firstRow int
+}
+
+func (v *tableSliceView[T]) insertItem(index int) {
+	row := v.firstRow + index
+	cells := v.render((*v.items)[index])
+	t := v.table
+	t.Lock()
+	defer t.Unlock()
+
+	if row < t.content.GetRowCount() {
+		t.content.InsertRow(row)
+		if t.selection != nil {
+			t.selection.insert(row)
+		}
+		if t.selectedRow >= row {
+			t.selectedRow++
+		}
+	}
+	for column, cell := range cells {
+		t.content.SetCell(row, column, cell)
+	}
+}
+
+func (v *tableSliceView[T]) removeItem(index int) {
+	row := v.firstRow + index
+	t := v.table
+	t.Lock()
+	defer t.Unlock()
+
+	t.content.RemoveRow(row)
+	if t.selection != nil {
+		t.selection.remove(row)
+	}
+	if t.selectedRow > row {
+		t.selectedRow--
+	}
+}
+
+func (v *tableSliceView[T]) updateItem(index int) {
+	row := v.firstRow + index
+	cells := v.render((*v.items)[index])
+	t := v.table
+	t.Lock()
+	defer t.Unlock()
+
+	for column, cell := range cells {
+		t.content.SetCell(row, column, cell)
+	}
+}
+
+func (v *tableSliceView[T]) clearItems() {
+	t := v.table
+	t.Lock()
+	defer t.Unlock()
+
+	for row := t.content.GetRowCount() - 1; row >= v.firstRow; row-- {
+		t.content.RemoveRow(row)
+		if t.selection != nil {
+			t.selection.remove(row)
+		}
+	}
+}
+
+func (v *tableSliceView[T]) selectedItem() int {
+	t := v.table
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.selectedRow < v.firstRow || t.selectedRow >= t.content.GetRowCount() {
+		return -1
+	}
+	return t.selectedRow - v.firstRow
+}
+
+func (v *tableSliceView[T]) selectItem(index int) {
+	_, column := v.table.GetSelection()
+	v.table.Select(v.firstRow+index, column)
+}
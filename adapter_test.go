@@ -0,0 +1,120 @@
+package nuview
+
+import (
+	"fmt"
+	"testing"
+)
+
+type adapterTestItem struct {
+	id   int
+	name string
+}
+
+func TestListAdapter(t *testing.T) {
+	t.Parallel()
+
+	items := []adapterTestItem{{1, "a"}, {2, "b"}, {3, "c"}}
+	l := NewList()
+	adapter := NewListAdapter(l, &items, func(item adapterTestItem) *ListItem {
+		return NewListItem(item.name)
+	})
+	adapter.SetKeyFunc(func(item adapterTestItem) any {
+		return item.id
+	})
+	texts := func() string {
+		var s string
+		for _, item := range l.GetItems() {
+			s += item.GetMainText()
+		}
+		return s
+	}
+	if text := texts(); text != "abc" {
+		t.Fatalf("failed to render items: expected abc, got %s", text)
+	}
+
+	l.SetCurrentItem(1)
+	items = append([]adapterTestItem{{0, "z"}}, items...)
+	adapter.NotifyInserted(0, 1)
+	if text := texts(); text != "zabc" {
+		t.Errorf("failed to insert item: expected zabc, got %s", text)
+	}
+	if item, index := adapter.GetSelectedItem(); item.id != 2 || index != 2 {
+		t.Errorf("failed to preserve selection on insert: expected item 2 at index 2, got %v at %d", item, index)
+	}
+
+	items = append(items[:1], items[2:]...)
+	adapter.NotifyRemoved(1, 1)
+	if text := texts(); text != "zbc" {
+		t.Errorf("failed to remove item: expected zbc, got %s", text)
+	}
+	if item, _ := adapter.GetSelectedItem(); item.id != 2 {
+		t.Errorf("failed to preserve selection on remove: expected item 2, got %v", item)
+	}
+
+	items[2].name = "C"
+	adapter.NotifyChanged(2, 1)
+	if text := texts(); text != "zbC" {
+		t.Errorf("failed to change item: expected zbC, got %s", text)
+	}
+
+	items = []adapterTestItem{{2, "b"}, {4, "d"}, {0, "z"}}
+	adapter.Reload()
+	if text := texts(); text != "bdz" {
+		t.Errorf("failed to reload items: expected bdz, got %s", text)
+	}
+	if item, index := adapter.GetSelectedItem(); item.id != 2 || index != 0 {
+		t.Errorf("failed to preserve selection by identity: expected item 2 at index 0, got %v at %d", item, index)
+	}
+
+	// Uncomparable keys do not match but don't panic either.
+	adapter.SetKeyFunc(func(item adapterTestItem) any {
+		return []int{item.id}
+	})
+	l.SetCurrentItem(1)
+	adapter.Reload()
+	if item, index := adapter.GetSelectedItem(); item.id != 4 || index != 1 {
+		t.Errorf("failed to keep selection index for uncomparable keys: expected item 4 at index 1, got %v at %d", item, index)
+	}
+}
+
+func TestTableAdapter(t *testing.T) {
+	t.Parallel()
+
+	items := []adapterTestItem{{1, "a"}, {2, "b"}}
+	tb := NewTable()
+	tb.SetSelectable(true, false)
+	tb.SetCellSimple(0, 0, "ID")
+	tb.SetCellSimple(0, 1, "Name")
+	adapter := NewTableAdapter(tb, &items, func(item adapterTestItem) []*TableCell {
+		return []*TableCell{NewTableCell(fmt.Sprint(item.id)), NewTableCell(item.name)}
+	})
+	rows := func() string {
+		var s string
+		for row := 0; row < tb.GetRowCount(); row++ {
+			s += tb.GetCell(row, 1).Text + " "
+		}
+		return s
+	}
+	if text := rows(); text != "Name a b " {
+		t.Fatalf("failed to render rows: expected \"Name a b \", got %q", text)
+	}
+
+	tb.Select(2, 0)
+	items = append(items[:1], append([]adapterTestItem{{3, "c"}}, items[1:]...)...)
+	adapter.NotifyInserted(1, 1)
+	if text := rows(); text != "Name a c b " {
+		t.Errorf("failed to insert row: expected \"Name a c b \", got %q", text)
+	}
+	if item, index := adapter.GetSelectedItem(); item.id != 2 || index != 2 {
+		t.Errorf("failed to preserve selection on insert: expected item 2 at index 2, got %v at %d", item, index)
+	}
+
+	items = items[1:]
+	adapter.NotifyRemoved(0, 1)
+	if text := rows(); text != "Name c b " {
+		t.Errorf("failed to remove row: expected \"Name c b \", got %q", text)
+	}
+	if row, _ := tb.GetSelection(); row != 2 {
+		t.Errorf("failed to preserve selection on remove: expected row 2, got %d", row)
+	}
+}
@@ -0,0 +1,167 @@
+package nuview
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// AnimationFrameInterval is the time between two frames of an animation,
+	// e.g. of smooth scrolling.
+	AnimationFrameInterval = time.Second / 30
+
+	// DisableSmoothScrolling disables smooth scrolling in all primitives,
+	// regardless of their individual settings.
+	DisableSmoothScrolling = false
+
+	// PressedDuration is the time a Button or Checkbox shows its pressed state
+	// after it was activated with the keyboard. A duration of 0 disables the
+	// pressed state.
+	PressedDuration = 150 * time.Millisecond
+)
+
+// The animation ticker. Primitives request frames while they are animating,
+// each running application then redraws after AnimationFrameInterval.
+var animation struct {
+	// The running applications and whether or not a frame is scheduled for
+	// them.
+	applications map[*Application]bool
+
+	sync.Mutex
+}
+
+// startAnimations registers a running application with the animation ticker.
+func startAnimations(a *Application) {
+	animation.Lock()
+	defer animation.Unlock()
+
+	if animation.applications == nil {
+		animation.applications = make(map[*Application]bool)
+	}
+	animation.applications[a] = false
+}
+
+// stopAnimations unregisters an application from the animation ticker.
+func stopAnimations(a *Application) {
+	animation.Lock()
+	defer animation.Unlock()
+
+	delete(animation.applications, a)
+}
+
+// requestAnimationFrame schedules a redraw of all running applications after
+// AnimationFrameInterval. Multiple requests before the next frame result in a
+// single redraw.
+func requestAnimationFrame() {
+	animation.Lock()
+	defer animation.Unlock()
+
+	for a, scheduled := range animation.applications {
+		if scheduled {
+			continue
+		}
+		animation.applications[a] = true
+		a := a // Capture
+		time.AfterFunc(AnimationFrameInterval, func() {
+			animation.Lock()
+			_, running := animation.applications[a]
+			if running {
+				animation.applications[a] = false
+			}
+			animation.Unlock()
+
+			if running {
+				a.QueueUpdateDraw(func() {})
+			}
+		})
+	}
+}
+
+// smoothScroll animates the scroll offset of a primitive. The primitive keeps
+// its offset as usual and calls offset() when drawing to receive the offset
+// to be shown in the current frame.
+type smoothScroll struct {
+	// Whether or not smooth scrolling is enabled for the primitive.
+	enabled bool
+
+	// Whether or not the next change of the offset is animated.
+	pending bool
+
+	// Whether or not an animation is in progress.
+	active bool
+
+	// The offset shown in the last frame.
+	shown int
+}
+
+// animate causes the next change of the offset to be animated, e.g. after
+// scrolling with the mouse wheel or by a page.
+func (s *smoothScroll) animate() {
+	s.pending = true
+}
+
+// stop ends any animation in progress. The next frame shows the target
+// offset.
+func (s *smoothScroll) stop() {
+	s.pending = false
+	s.active = false
+}
+
+// offset returns the offset to be shown in the current frame, given the
+// target offset. If an animation is in progress, the shown offset moves
+// towards the target and another frame is requested.
+func (s *smoothScroll) offset(target int) int {
+	if !s.enabled || DisableSmoothScrolling {
+		s.stop()
+		s.shown = target
+		return target
+	}
+	if s.pending {
+		s.pending = false
+		s.active = s.shown != target
+	}
+	if !s.active {
+		s.shown = target
+		return target
+	}
+
+	// Cover half of the remaining distance with each frame.
+	step := (target - s.shown) / 2
+	if step == 0 {
+		step = target - s.shown
+	}
+	s.shown += step
+	if s.shown == target {
+		s.active = false
+	} else {
+		requestAnimationFrame()
+	}
+	return s.shown
+}
+
+// pressedState shows the pressed state of a primitive for PressedDuration
+// after it was activated.
+type pressedState struct {
+	// The time the pressed state ends.
+	until time.Time
+}
+
+// press starts showing the pressed state.
+func (p *pressedState) press() {
+	if PressedDuration <= 0 {
+		return
+	}
+	p.until = time.Now().Add(PressedDuration)
+	requestAnimationFrame()
+}
+
+// pressed returns whether or not the pressed state is to be shown in the
+// current frame. While it is, further frames are requested so the primitive
+// is redrawn when it ends.
+func (p *pressedState) pressed() bool {
+	if time.Now().Before(p.until) {
+		requestAnimationFrame()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package nuview
+
+import "testing"
+
+func TestSmoothScroll(t *testing.T) {
+	t.Parallel()
+
+	var s smoothScroll
+	if offset := s.offset(5); offset != 5 {
+		t.Errorf("failed to jump when disabled: expected 5, got %d", offset)
+	}
+
+	s.enabled = true
+	if offset := s.offset(10); offset != 10 {
+		t.Errorf("failed to jump without animation: expected 10, got %d", offset)
+	}
+
+	s.animate()
+	var frames []int
+	for i := 0; i < 10 && (i == 0 || s.active); i++ {
+		frames = append(frames, s.offset(26))
+	}
+	expected := []int{18, 22, 24, 25, 26}
+	if len(frames) != len(expected) {
+		t.Fatalf("failed to animate offset: expected %v, got %v", expected, frames)
+	}
+	for i := range expected {
+		if frames[i] != expected[i] {
+			t.Fatalf("failed to animate offset: expected %v, got %v", expected, frames)
+		}
+	}
+
+	s.animate()
+	s.offset(0)
+	s.stop()
+	if offset := s.offset(0); offset != 0 {
+		t.Errorf("failed to stop animation: expected 0, got %d", offset)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
 )
 
 // The states of the ANSI escape code parser.
@@ -30,9 +33,17 @@ type ansi struct {
 	state int
 }
 
+// lineRewriter is implemented by writers which interpret carriage returns and
+// cursor movements (see TextView.SetLineRewriting()).
+type lineRewriter interface {
+	rewritesLines() bool
+}
+
 // ANSIWriter returns an io.Writer which translates any ANSI escape codes
 // written to it into cview color tags. Other escape codes don't have an effect
-// and are simply removed. The translated text is written to the provided
+// and are simply removed, except for cursor movements and line erasures written
+// to a TextView with line rewriting enabled (see TextView.SetLineRewriting()),
+// which are passed through. The translated text is written to the provided
 // writer.
 func ANSIWriter(writer io.Writer) io.Writer {
 	return &ansi{
@@ -83,6 +94,10 @@ func (a *ansi) Write(text []byte) (int, error) {
 				}
 			case r >= 0x40 && r <= 0x7e: // Final byte.
 				switch r {
+				case 'A', 'B', 'F', 'K': // Cursor movements and line erasure.
+					if w, ok := a.Writer.(lineRewriter); ok && w.rewritesLines() {
+						fmt.Fprintf(a.buffer, "\x1b[%s%c", a.csiParameter.String(), r)
+					}
 				case 'E': // Next line.
 					count, _ := strconv.Atoi(a.csiParameter.String())
 					if count == 0 {
@@ -268,3 +283,87 @@ func TranslateANSI(text string) string {
 	writer.Write([]byte(text))
 	return buffer.String()
 }
+
+// CellsToANSI converts a grid of cells (rows first), e.g. as returned by
+// Table.RenderRegion(), into text with ANSI escape sequences for colors and
+// attributes. Rows are separated by newlines and each row ends with a reset of
+// all attributes. The cell following a wide character is skipped.
+func CellsToANSI(cells [][]ImageCell) string {
+	var buffer strings.Builder
+	for y, row := range cells {
+		if y > 0 {
+			buffer.WriteByte('\n')
+		}
+		var skip bool
+		lastStyle := tcell.StyleDefault
+		for _, cell := range row {
+			if skip {
+				skip = false
+				continue
+			}
+			if cell.Style != lastStyle {
+				buffer.WriteString(ansiStyle(cell.Style))
+				lastStyle = cell.Style
+			}
+			r := cell.Rune
+			if r == 0 {
+				r = ' '
+			}
+			buffer.WriteRune(r)
+			skip = runewidth.RuneWidth(r) > 1
+		}
+		if lastStyle != tcell.StyleDefault {
+			buffer.WriteString("\x1b[0m")
+		}
+	}
+	return buffer.String()
+}
+
+// ansiStyle returns the SGR escape sequence which switches to the provided
+// style, starting with a reset of all attributes.
+func ansiStyle(style tcell.Style) string {
+	foreground, background, attributes := style.Decompose()
+	parameters := []string{"0"}
+	for _, attribute := range []struct {
+		mask      tcell.AttrMask
+		parameter string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attributes&attribute.mask != 0 {
+			parameters = append(parameters, attribute.parameter)
+		}
+	}
+	if parameter := ansiColor(foreground, 30); parameter != "" {
+		parameters = append(parameters, parameter)
+	}
+	if parameter := ansiColor(background, 40); parameter != "" {
+		parameters = append(parameters, parameter)
+	}
+	return "\x1b[" + strings.Join(parameters, ";") + "m"
+}
+
+// ansiColor returns the SGR parameter which selects the provided color, based
+// on 30 for foreground and 40 for background colors. An empty string is
+// returned for the default color.
+func ansiColor(color tcell.Color, base int) string {
+	switch {
+	case color.IsRGB():
+		r, g, b := color.RGB()
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	case !color.Valid() || color > tcell.Color255:
+		return ""
+	case color < tcell.ColorBlack+8:
+		return strconv.Itoa(base + int(color-tcell.ColorBlack))
+	case color < tcell.Color16:
+		return strconv.Itoa(base + 52 + int(color-tcell.ColorBlack))
+	default:
+		return fmt.Sprintf("%d;5;%d", base+8, color-tcell.ColorBlack)
+	}
+}
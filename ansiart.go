@@ -0,0 +1,713 @@
+package nuview
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// The size of a SAUCE record and of a line of a SAUCE comment block.
+const (
+	sauceRecordSize  = 128
+	sauceCommentSize = 64
+)
+
+// SAUCE data types and file types relevant to ANSI art.
+const (
+	SAUCEDataTypeCharacter = 1
+
+	SAUCEFileTypeASCII      = 0
+	SAUCEFileTypeANSI       = 1
+	SAUCEFileTypeANSIMation = 2
+)
+
+// SAUCE holds the metadata of a SAUCE (Standard Architecture for Universal
+// Comment Extensions) record which is appended to many ANSI art files.
+type SAUCE struct {
+	// The title of the art.
+	Title string
+
+	// The name or handle of the artist.
+	Author string
+
+	// The name of the group the artist belongs to.
+	Group string
+
+	// The creation date in the format CCYYMMDD.
+	Date string
+
+	// The type of data and the type of file, e.g. SAUCEDataTypeCharacter and
+	// SAUCEFileTypeANSI.
+	DataType, FileType int
+
+	// The width and height of the art in characters. Both are 0 if unknown.
+	Width, Height int
+
+	// Whether or not the blink attribute selects a bright background color
+	// ("iCE colors") instead of blinking.
+	ICEColors bool
+
+	// The name of the font the art was created with, e.g. "IBM VGA".
+	Font string
+
+	// The lines of the comment block.
+	Comments []string
+}
+
+// ParseSAUCE looks for a SAUCE record at the end of the provided data. It
+// returns the record (nil if there is none) and the data without the record,
+// its comment block and the end-of-file marker preceding them.
+func ParseSAUCE(data []byte) (sauce *SAUCE, content []byte) {
+	if len(data) < sauceRecordSize {
+		return nil, data
+	}
+	record := data[len(data)-sauceRecordSize:]
+	if !bytes.HasPrefix(record, []byte("SAUCE00")) {
+		return nil, data
+	}
+	content = data[:len(data)-sauceRecordSize]
+
+	sauceString := func(field []byte) string {
+		return strings.TrimRight(decodeCP437(field), " \x00")
+	}
+	sauce = &SAUCE{
+		Title:    sauceString(record[7:42]),
+		Author:   sauceString(record[42:62]),
+		Group:    sauceString(record[62:82]),
+		Date:     sauceString(record[82:90]),
+		DataType: int(record[94]),
+		FileType: int(record[95]),
+		Font:     sauceString(record[106:128]),
+	}
+	if sauce.DataType == SAUCEDataTypeCharacter {
+		sauce.Width = int(binary.LittleEndian.Uint16(record[96:98]))
+		sauce.Height = int(binary.LittleEndian.Uint16(record[98:100]))
+		sauce.ICEColors = record[105]&1 != 0
+	}
+
+	// Comment block.
+	if comments := int(record[104]); comments > 0 {
+		size := 5 + comments*sauceCommentSize
+		if len(content) >= size && bytes.HasPrefix(content[len(content)-size:], []byte("COMNT")) {
+			block := content[len(content)-size+5:]
+			for index := 0; index < comments; index++ {
+				sauce.Comments = append(sauce.Comments, sauceString(block[index*sauceCommentSize:(index+1)*sauceCommentSize]))
+			}
+			content = content[:len(content)-size]
+		}
+	}
+
+	// End-of-file marker.
+	if len(content) > 0 && content[len(content)-1] == 0x1a {
+		content = content[:len(content)-1]
+	}
+
+	return sauce, content
+}
+
+// cp437 maps the IBM PC character set (code page 437) to Unicode. The control
+// characters are mapped to their glyphs, NUL to a space.
+var cp437 = []rune(" ☺☻♥♦♣♠•◘○◙♂♀♪♫☼►◄↕‼¶§▬↨↑↓→←∟↔▲▼" +
+	" !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~⌂" +
+	"ÇüéâäàåçêëèïîìÄÅÉæÆôöòûùÿÖÜ¢£¥₧ƒáíóúñÑªº¿⌐¬½¼¡«»" +
+	"░▒▓│┤╡╢╖╕╣║╗╝╜╛┐└┴┬├─┼╞╟╚╔╩╦╠═╬╧╨╤╥╙╘╒╓╫╪┘┌█▄▌▐▀" +
+	"αßΓπΣσµτΦΘΩδ∞φε∩≡±≥≤⌠⌡÷≈°∙·√ⁿ²■\u00a0")
+
+// decodeCP437 converts text in the IBM PC character set to a string.
+func decodeCP437(text []byte) string {
+	var builder strings.Builder
+	for _, b := range text {
+		builder.WriteRune(cp437[b])
+	}
+	return builder.String()
+}
+
+// ansiArtPalette holds the 16 colors of the VGA text mode in the order of the
+// ANSI color numbers.
+var ansiArtPalette = [16]tcell.Color{
+	tcell.NewRGBColor(0x00, 0x00, 0x00),
+	tcell.NewRGBColor(0xaa, 0x00, 0x00),
+	tcell.NewRGBColor(0x00, 0xaa, 0x00),
+	tcell.NewRGBColor(0xaa, 0x55, 0x00),
+	tcell.NewRGBColor(0x00, 0x00, 0xaa),
+	tcell.NewRGBColor(0xaa, 0x00, 0xaa),
+	tcell.NewRGBColor(0x00, 0xaa, 0xaa),
+	tcell.NewRGBColor(0xaa, 0xaa, 0xaa),
+	tcell.NewRGBColor(0x55, 0x55, 0x55),
+	tcell.NewRGBColor(0xff, 0x55, 0x55),
+	tcell.NewRGBColor(0x55, 0xff, 0x55),
+	tcell.NewRGBColor(0xff, 0xff, 0x55),
+	tcell.NewRGBColor(0x55, 0x55, 0xff),
+	tcell.NewRGBColor(0xff, 0x55, 0xff),
+	tcell.NewRGBColor(0x55, 0xff, 0xff),
+	tcell.NewRGBColor(0xff, 0xff, 0xff),
+}
+
+// ansiArtCell is one character of ANSI art.
+type ansiArtCell struct {
+	r     rune
+	style tcell.Style
+}
+
+// ansiArtAttributes holds the graphic rendition while ANSI art is parsed.
+type ansiArtAttributes struct {
+	// The palette indices of the foreground and background colors.
+	foreground, background int
+
+	// Colors selected explicitly (256 colors or true color). These override
+	// the palette indices unless they are tcell.ColorDefault.
+	foregroundColor, backgroundColor tcell.Color
+
+	// Whether or not the bold, blink and reverse attributes are set.
+	bold, blink, reverse bool
+}
+
+// reset restores the default graphic rendition, light gray on black.
+func (a *ansiArtAttributes) reset() {
+	*a = ansiArtAttributes{
+		foreground:      7,
+		foregroundColor: tcell.ColorDefault,
+		backgroundColor: tcell.ColorDefault,
+	}
+}
+
+// style returns the style of the current graphic rendition. As in the VGA text
+// mode, bold selects bright foreground colors. If iceColors is true, blink
+// selects bright background colors, otherwise characters blink.
+func (a *ansiArtAttributes) style(iceColors bool) tcell.Style {
+	foreground, background := a.foreground, a.background
+	if a.bold && foreground < 8 {
+		foreground += 8
+	}
+	style := tcell.StyleDefault
+	if a.blink {
+		if iceColors {
+			if background < 8 {
+				background += 8
+			}
+		} else {
+			style = style.Blink(true)
+		}
+	}
+	foregroundColor, backgroundColor := ansiArtPalette[foreground], ansiArtPalette[background]
+	if a.foregroundColor != tcell.ColorDefault {
+		foregroundColor = a.foregroundColor
+	}
+	if a.backgroundColor != tcell.ColorDefault {
+		backgroundColor = a.backgroundColor
+	}
+	if a.reverse {
+		foregroundColor, backgroundColor = backgroundColor, foregroundColor
+	}
+	return style.Foreground(foregroundColor).Background(backgroundColor)
+}
+
+// selectGraphicRendition applies the parameters of an SGR control sequence.
+func (a *ansiArtAttributes) selectGraphicRendition(params []int) {
+	if len(params) == 0 {
+		a.reset()
+		return
+	}
+	for index := 0; index < len(params); index++ {
+		switch param := params[index]; {
+		case param == 0:
+			a.reset()
+		case param == 1:
+			a.bold = true
+		case param == 5 || param == 6:
+			a.blink = true
+		case param == 7:
+			a.reverse = true
+		case param == 22:
+			a.bold = false
+		case param == 25:
+			a.blink = false
+		case param == 27:
+			a.reverse = false
+		case param >= 30 && param <= 37:
+			a.foreground, a.foregroundColor = param-30, tcell.ColorDefault
+		case param == 39:
+			a.foreground, a.foregroundColor = 7, tcell.ColorDefault
+		case param >= 40 && param <= 47:
+			a.background, a.backgroundColor = param-40, tcell.ColorDefault
+		case param == 49:
+			a.background, a.backgroundColor = 0, tcell.ColorDefault
+		case param >= 90 && param <= 97:
+			a.foreground, a.foregroundColor = param-82, tcell.ColorDefault
+		case param >= 100 && param <= 107:
+			a.background, a.backgroundColor = param-92, tcell.ColorDefault
+		case param == 38 || param == 48:
+			color := tcell.ColorDefault
+			if index+2 < len(params) && params[index+1] == 5 {
+				color = tcell.PaletteColor(params[index+2])
+				if params[index+2] < 16 {
+					color = ansiArtPalette[params[index+2]]
+				}
+				index += 2
+			} else if index+4 < len(params) && params[index+1] == 2 {
+				color = tcell.NewRGBColor(int32(params[index+2]), int32(params[index+3]), int32(params[index+4]))
+				index += 4
+			}
+			if param == 38 {
+				a.foregroundColor = color
+			} else {
+				a.backgroundColor = color
+			}
+		}
+	}
+}
+
+// parseANSIArt parses ANSI art in the IBM PC character set into rows of cells
+// of the given width.
+func parseANSIArt(data []byte, width int, iceColors bool) [][]ansiArtCell {
+	var (
+		rows                  [][]ansiArtCell
+		row, column           int
+		savedRow, savedColumn int
+		attributes            ansiArtAttributes
+	)
+	attributes.reset()
+	blank := ansiArtCell{r: ' ', style: attributes.style(iceColors)}
+
+	// cell returns the cell at the cursor position, adding rows as needed.
+	cell := func() *ansiArtCell {
+		for row >= len(rows) {
+			line := make([]ansiArtCell, width)
+			for index := range line {
+				line[index] = blank
+			}
+			rows = append(rows, line)
+		}
+		return &rows[row][column]
+	}
+
+	for index := 0; index < len(data); index++ {
+		b := data[index]
+		switch b {
+		case 0x1a: // End of file.
+			return rows
+		case '\r':
+			column = 0
+			continue
+		case '\n':
+			row++
+			column = 0
+			continue
+		case '\t':
+			column = (column/8 + 1) * 8
+			if column >= width {
+				column = width - 1
+			}
+			continue
+		case 0x1b:
+			if index+1 >= len(data) || data[index+1] != '[' {
+				continue // Not a control sequence, ignore.
+			}
+
+			// Collect the control sequence.
+			start := index + 2
+			end := start
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+			if end >= len(data) {
+				return rows
+			}
+			index = end
+			parameters := string(data[start:end])
+			if strings.ContainsAny(parameters, "<=>?") {
+				continue // Private sequences, e.g. ESC[?7h.
+			}
+			var params []int
+			if len(parameters) > 0 {
+				for _, field := range strings.Split(parameters, ";") {
+					param, _ := strconv.Atoi(field)
+					params = append(params, param)
+				}
+			}
+			count := 1
+			if len(params) > 0 && params[0] > 0 {
+				count = params[0]
+			}
+
+			// Execute it.
+			switch data[end] {
+			case 'm':
+				attributes.selectGraphicRendition(params)
+			case 't': // PabloDraw 24-bit colors: ESC[0;R;G;Bt for the background, ESC[1;R;G;Bt for the foreground.
+				if len(params) == 4 {
+					color := tcell.NewRGBColor(int32(params[1]), int32(params[2]), int32(params[3]))
+					if params[0] == 0 {
+						attributes.backgroundColor = color
+					} else {
+						attributes.foregroundColor = color
+					}
+				}
+			case 'A':
+				row -= count
+				if row < 0 {
+					row = 0
+				}
+			case 'B':
+				row += count
+			case 'C':
+				column += count
+				if column >= width {
+					column = width - 1
+				}
+			case 'D':
+				if column >= width {
+					column = width - 1
+				}
+				column -= count
+				if column < 0 {
+					column = 0
+				}
+			case 'H', 'f':
+				row, column = 0, 0
+				if len(params) > 0 && params[0] > 0 {
+					row = params[0] - 1
+				}
+				if len(params) > 1 && params[1] > 0 {
+					column = params[1] - 1
+				}
+				if column >= width {
+					column = width - 1
+				}
+			case 's':
+				savedRow, savedColumn = row, column
+			case 'u':
+				row, column = savedRow, savedColumn
+			case 'J':
+				if len(params) > 0 && params[0] == 2 {
+					rows = nil
+					row, column = 0, 0
+				}
+			case 'K':
+				if len(params) == 0 || params[0] == 0 {
+					cursor := column
+					for ; column < width; column++ {
+						*cell() = ansiArtCell{r: ' ', style: attributes.style(iceColors)}
+					}
+					column = cursor
+				}
+			}
+			continue
+		}
+
+		// A printable character. Wrap if the previous one filled the row.
+		if column >= width {
+			row++
+			column = 0
+		}
+		*cell() = ansiArtCell{r: cp437[b], style: attributes.style(iceColors)}
+		column++
+	}
+
+	return rows
+}
+
+// ANSIArt is a primitive which displays ANSI art, i.e. text in the IBM PC
+// character set (code page 437) colored and positioned by ANSI escape
+// sequences, as found in the .ans files of BBS-style applications. A SAUCE
+// record at the end of the art is parsed and determines the width of the art
+// and whether or not blinking text is shown with bright background colors
+// (iCE colors) instead.
+//
+// Art which does not fit into the primitive can be scrolled with the arrow
+// keys, the page keys, Home and End as well as the mouse wheel.
+type ANSIArt struct {
+	*Box
+
+	// The raw art without its SAUCE record.
+	data []byte
+
+	// The SAUCE record of the art, nil if there is none.
+	sauce *SAUCE
+
+	// The width of the art in characters as set with SetArtWidth(), 0 to use
+	// the width of the SAUCE record.
+	width int
+
+	// Whether or not the blink attribute selects bright background colors.
+	iceColors bool
+
+	// The parsed art, one slice of cells per row.
+	rows [][]ansiArtCell
+
+	// The first visible row and column.
+	lineOffset, columnOffset int
+
+	// The number of rows shown as of the last call to Draw().
+	pageSize int
+
+	// The vertical scroll bar.
+	scrollBar *ScrollBar
+
+	sync.RWMutex
+}
+
+// NewANSIArt returns a new ANSI art primitive without any art.
+func NewANSIArt() *ANSIArt {
+	return &ANSIArt{
+		Box:       NewBox(),
+		scrollBar: NewScrollBar(),
+	}
+}
+
+// SetArt sets the ANSI art to display. Any SAUCE record at the end of the art
+// is removed and determines the width of the art and its iCE colors mode. The
+// art is scrolled to the top left corner.
+func (a *ANSIArt) SetArt(art []byte) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.sauce, a.data = ParseSAUCE(art)
+	a.iceColors = a.sauce != nil && a.sauce.ICEColors
+	a.lineOffset, a.columnOffset = 0, 0
+	a.parse()
+}
+
+// ReadFrom reads ANSI art from the provided reader until EOF and displays it
+// (see SetArt). It implements io.ReaderFrom so art can be loaded from files:
+//
+//	file, err := os.Open("splash.ans")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer file.Close()
+//	art := nuview.NewANSIArt()
+//	art.ReadFrom(file)
+func (a *ANSIArt) ReadFrom(r io.Reader) (int64, error) {
+	var buffer bytes.Buffer
+	n, err := buffer.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	a.SetArt(buffer.Bytes())
+	return n, nil
+}
+
+// GetSAUCE returns the SAUCE record of the art or nil if the art does not
+// have one.
+func (a *ANSIArt) GetSAUCE() *SAUCE {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.sauce
+}
+
+// SetArtWidth sets the width of the art in characters, i.e. the column after
+// which text wraps to the next row. A value of 0 (the default) uses the width
+// of the SAUCE record or 80 characters if there is none.
+func (a *ANSIArt) SetArtWidth(width int) {
+	a.Lock()
+	defer a.Unlock()
+
+	if width < 0 {
+		width = 0
+	}
+	a.width = width
+	a.parse()
+}
+
+// SetICEColors sets whether or not the blink attribute selects bright
+// background colors instead of blinking text. This mode is taken from the
+// SAUCE record when the art is set and may be overridden afterwards.
+func (a *ANSIArt) SetICEColors(iceColors bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.iceColors = iceColors
+	a.parse()
+}
+
+// GetICEColors returns whether or not the blink attribute selects bright
+// background colors instead of blinking text.
+func (a *ANSIArt) GetICEColors() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.iceColors
+}
+
+// GetArtSize returns the width and height of the art in characters.
+func (a *ANSIArt) GetArtSize() (width, height int) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.artWidth(), len(a.rows)
+}
+
+// SetOffset sets the first visible row and column of the art.
+func (a *ANSIArt) SetOffset(row, column int) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.lineOffset, a.columnOffset = row, column
+}
+
+// GetOffset returns the first visible row and column of the art.
+func (a *ANSIArt) GetOffset() (row, column int) {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.lineOffset, a.columnOffset
+}
+
+// SetScrollBarVisibility specifies the display of the scroll bar.
+func (a *ANSIArt) SetScrollBarVisibility(visibility ScrollBarVisibility) {
+	a.scrollBar.SetVisibility(visibility)
+}
+
+// SetScrollBarColor sets the color of the scroll bar.
+func (a *ANSIArt) SetScrollBarColor(color tcell.Color) {
+	a.scrollBar.SetColor(color)
+}
+
+// GetScrollBar returns the vertical scroll bar of the ANSI art.
+func (a *ANSIArt) GetScrollBar() *ScrollBar {
+	return a.scrollBar
+}
+
+// artWidth returns the width of the art in characters.
+func (a *ANSIArt) artWidth() int {
+	if a.width > 0 {
+		return a.width
+	}
+	if a.sauce != nil && a.sauce.Width > 0 {
+		return a.sauce.Width
+	}
+	return 80
+}
+
+// parse parses the raw art into rows of cells.
+func (a *ANSIArt) parse() {
+	a.rows = parseANSIArt(a.data, a.artWidth(), a.iceColors)
+}
+
+// Draw draws this primitive onto the screen.
+func (a *ANSIArt) Draw(screen tcell.Screen) {
+	if !a.GetVisible() {
+		return
+	}
+
+	a.Box.Draw(screen)
+
+	a.Lock()
+	defer a.Unlock()
+
+	x, y, width, height := a.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	a.pageSize = height
+
+	artWidth, artHeight := a.artWidth(), len(a.rows)
+	showVerticalScrollBar := a.scrollBar.IsVisible(artHeight, height)
+	if showVerticalScrollBar {
+		width-- // Subtract space for scroll bar.
+	}
+
+	// Clamp offsets.
+	if a.lineOffset > artHeight-height {
+		a.lineOffset = artHeight - height
+	}
+	if a.lineOffset < 0 {
+		a.lineOffset = 0
+	}
+	if a.columnOffset > artWidth-width {
+		a.columnOffset = artWidth - width
+	}
+	if a.columnOffset < 0 {
+		a.columnOffset = 0
+	}
+
+	// Draw the art.
+	for line := 0; line < height && a.lineOffset+line < artHeight; line++ {
+		row := a.rows[a.lineOffset+line]
+		for column := 0; column < width && a.columnOffset+column < artWidth; column++ {
+			cell := row[a.columnOffset+column]
+			screen.SetContent(x+column, y+line, cell.r, nil, cell.style)
+		}
+	}
+
+	if showVerticalScrollBar {
+		a.scrollBar.Draw(screen, x+width, y, height, artHeight, height, a.lineOffset, a.hasFocus)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (a *ANSIArt) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return a.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		a.Lock()
+		defer a.Unlock()
+
+		if a.hitShortcut(event, a.keys().MoveFirst, a.keys().MoveFirst2) {
+			a.lineOffset = 0
+			a.columnOffset = 0
+		} else if a.hitShortcut(event, a.keys().MoveLast, a.keys().MoveLast2) {
+			a.lineOffset = len(a.rows)
+			a.columnOffset = 0
+		} else if a.hitShortcut(event, a.keys().MoveUp, a.keys().MoveUp2) {
+			a.lineOffset--
+		} else if a.hitShortcut(event, a.keys().MoveDown, a.keys().MoveDown2) {
+			a.lineOffset++
+		} else if a.hitShortcut(event, a.keys().MoveLeft, a.keys().MoveLeft2) {
+			a.columnOffset--
+		} else if a.hitShortcut(event, a.keys().MoveRight, a.keys().MoveRight2) {
+			a.columnOffset++
+		} else if a.hitShortcut(event, a.keys().MovePreviousPage) {
+			a.lineOffset -= a.pageSize
+		} else if a.hitShortcut(event, a.keys().MoveNextPage) {
+			a.lineOffset += a.pageSize
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (a *ANSIArt) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return a.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Pass events to the scroll bar.
+		if offset, ok, dragging := a.scrollBar.HandleMouse(action, event); ok {
+			a.Lock()
+			a.lineOffset = offset
+			a.Unlock()
+			if dragging {
+				capture = a
+			}
+			return true, capture
+		}
+
+		if !a.InRect(event.Position()) {
+			return false, nil
+		}
+
+		a.Lock()
+		defer a.Unlock()
+
+		switch action {
+		case MouseLeftClick:
+			setFocus(a)
+			consumed = true
+		case MouseScrollUp:
+			a.lineOffset--
+			consumed = true
+		case MouseScrollDown:
+			a.lineOffset++
+			consumed = true
+		case MouseScrollLeft:
+			a.columnOffset--
+			consumed = true
+		case MouseScrollRight:
+			a.columnOffset++
+			consumed = true
+		}
+		return
+	})
+}
@@ -0,0 +1,114 @@
+package nuview
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// testSAUCE returns a SAUCE record with the provided width, height, flags and
+// comment lines, preceded by an end-of-file marker and a comment block.
+func testSAUCE(width, height int, flags byte, comments ...string) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteByte(0x1a)
+	if len(comments) > 0 {
+		buffer.WriteString("COMNT")
+		for _, comment := range comments {
+			line := make([]byte, sauceCommentSize)
+			copy(line, comment)
+			buffer.Write(line)
+		}
+	}
+	record := make([]byte, sauceRecordSize)
+	copy(record, "SAUCE00")
+	copy(record[7:], "Test Art")
+	copy(record[42:], "Artist")
+	copy(record[82:], "20261016")
+	record[94] = SAUCEDataTypeCharacter
+	record[95] = SAUCEFileTypeANSI
+	binary.LittleEndian.PutUint16(record[96:], uint16(width))
+	binary.LittleEndian.PutUint16(record[98:], uint16(height))
+	record[104] = byte(len(comments))
+	record[105] = flags
+	copy(record[106:], "IBM VGA")
+	buffer.Write(record)
+	return buffer.Bytes()
+}
+
+func TestParseSAUCE(t *testing.T) {
+	t.Parallel()
+
+	art := []byte("\x1b[0mHello")
+	sauce, content := ParseSAUCE(append(append([]byte{}, art...), testSAUCE(40, 2, 1, "A comment")...))
+	if sauce == nil {
+		t.Fatal("failed to parse SAUCE: expected record, got nil")
+	}
+	if !bytes.Equal(content, art) {
+		t.Errorf("failed to parse SAUCE: incorrect content: expected %q, got %q", art, content)
+	}
+	if sauce.Title != "Test Art" || sauce.Author != "Artist" || sauce.Date != "20261016" || sauce.Font != "IBM VGA" {
+		t.Errorf("failed to parse SAUCE: incorrect fields: got %+v", sauce)
+	}
+	if sauce.Width != 40 || sauce.Height != 2 || !sauce.ICEColors {
+		t.Errorf("failed to parse SAUCE: incorrect character info: got %dx%d, iCE colors %t", sauce.Width, sauce.Height, sauce.ICEColors)
+	}
+	if len(sauce.Comments) != 1 || sauce.Comments[0] != "A comment" {
+		t.Errorf("failed to parse SAUCE: incorrect comments: got %q", sauce.Comments)
+	}
+
+	if sauce, content := ParseSAUCE(art); sauce != nil || !bytes.Equal(content, art) {
+		t.Error("failed to parse SAUCE: expected no record")
+	}
+}
+
+func TestANSIArt(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	// Bright red "A", a full block, a wrapped row and a blinking "B" on blue.
+	art := []byte("\x1b[1;31mA\x1b[0m\xdb\r\n0123456789X\r\n\x1b[5;44mB")
+
+	a := NewANSIArt()
+	a.SetRect(0, 0, 10, 3)
+	a.SetArt(append(append([]byte{}, art...), testSAUCE(10, 0, 0)...))
+	if width, height := a.GetArtSize(); width != 10 || height != 4 {
+		t.Errorf("failed to parse art: incorrect size: expected 10x4, got %dx%d", width, height)
+	}
+
+	a.Draw(sc)
+	if r, _, style, _ := sc.GetContent(0, 0); r != 'A' || style != tcell.StyleDefault.Foreground(ansiArtPalette[9]).Background(ansiArtPalette[0]) {
+		t.Errorf("failed to draw art: incorrect bright red character: got %q %v", r, style)
+	}
+	if r, _, _, _ := sc.GetContent(1, 0); r != '█' {
+		t.Errorf("failed to draw art: incorrect CP437 character: expected '█', got %q", r)
+	}
+	if r, _, _, _ := sc.GetContent(0, 2); r != 'X' {
+		t.Errorf("failed to draw art: expected wrapped row, got %q", r)
+	}
+
+	// Scrolling.
+
+	a.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	a.Draw(sc)
+	if row, _ := a.GetOffset(); row != 1 {
+		t.Errorf("failed to scroll art: expected offset 1, got %d", row)
+	}
+	if r, _, style, _ := sc.GetContent(0, 2); r != 'B' || style != tcell.StyleDefault.Blink(true).Foreground(ansiArtPalette[7]).Background(ansiArtPalette[4]) {
+		t.Errorf("failed to draw art: incorrect blinking character: got %q %v", r, style)
+	}
+
+	// iCE colors.
+
+	a.SetICEColors(true)
+	a.Draw(sc)
+	if _, _, style, _ := sc.GetContent(0, 2); style != tcell.StyleDefault.Foreground(ansiArtPalette[7]).Background(ansiArtPalette[12]) {
+		t.Errorf("failed to draw art: expected bright background with iCE colors, got %v", style)
+	}
+}
@@ -0,0 +1,166 @@
+package nuview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// screenApplications maps the screens of applications to the applications
+// drawing on them. Primitives use it to find the configuration of the
+// application they are drawn by.
+var screenApplications sync.Map
+
+// applicationOf returns the application drawing on the provided screen, nil if
+// there is none, e.g. for screen writers which draw into a buffer. Clipped
+// screens, such as the ones windows draw their contents on, are looked up by
+// the screen they clip.
+func applicationOf(screen ScreenWriter) *Application {
+	s, ok := screen.(tcell.Screen)
+	if !ok {
+		return nil
+	}
+	if a, ok := screenApplications.Load(UnclippedScreen(s)); ok {
+		return a.(*Application)
+	}
+	return nil
+}
+
+// SetStyles sets the theme of the application. Primitives drawn by the
+// application use it instead of the global Styles while drawing and handling
+// events, e.g. for the loading spinner or the minimum size of windows. The
+// colors which primitives take from the theme when they are created are
+// always taken from the global Styles, set them on the primitives to differ.
+//
+// This allows several applications in one process, e.g. one per SSH session,
+// to use different configurations without modifying the global variables
+// while other applications are running. See also SetBorders() and SetKeys().
+func (a *Application) SetStyles(theme Theme) {
+	a.styles.Store(&theme)
+}
+
+// GetStyles returns the theme of the application, the global Styles if none
+// was set with SetStyles().
+func (a *Application) GetStyles() Theme {
+	return *a.theme()
+}
+
+// SetBorders sets the runes of the borders drawn by the primitives of the
+// application instead of those of the global Borders.
+func (a *Application) SetBorders(borders BorderSet) {
+	a.borders.Store(&borders)
+}
+
+// GetBorders returns the runes of the borders drawn by the primitives of the
+// application, the global Borders if none were set with SetBorders().
+func (a *Application) GetBorders() BorderSet {
+	return *a.borderSet()
+}
+
+// SetKeys sets the keyboard shortcuts of the application and its primitives
+// instead of those of the global Keys. Key chords found in them are
+// recognized as well, see AddKeyChords().
+func (a *Application) SetKeys(keys Key) {
+	a.shortcuts.Store(&keys)
+}
+
+// GetKeys returns the keyboard shortcuts of the application, the global Keys
+// if none were set with SetKeys().
+func (a *Application) GetKeys() Key {
+	return *a.keys()
+}
+
+// SetClipboard sets the clipboard manager of the application and its
+// primitives instead of the global Clipboard, e.g. to enable copying on
+// selection or to install a write function for one application only.
+func (a *Application) SetClipboard(clipboard *ClipboardManager) {
+	a.clipboard.Store(clipboard)
+}
+
+// GetClipboard returns the clipboard manager of the application, the global
+// Clipboard if none was set with SetClipboard().
+func (a *Application) GetClipboard() *ClipboardManager {
+	return a.clipboardManager()
+}
+
+// Copy stores the provided text in the clipboard manager of the application
+// and passes it on to the system clipboard. Unless the clipboard manager has a
+// write function, the text is sent to the terminal of the application which
+// forwards it to the system clipboard if it supports it (OSC 52).
+func (a *Application) Copy(text string) {
+	if a.clipboardManager().copy(text) || a == nil {
+		return
+	}
+	if screen := a.GetScreen(); screen != nil {
+		screen.SetClipboard([]byte(text))
+	}
+}
+
+// theme returns the theme of the application, or the global Styles if it has
+// none or the application is nil.
+func (a *Application) theme() *Theme {
+	if a != nil {
+		if theme := a.styles.Load(); theme != nil {
+			return theme
+		}
+	}
+	return &Styles
+}
+
+// borderSet returns the borders of the application, or the global Borders if
+// it has none or the application is nil.
+func (a *Application) borderSet() *BorderSet {
+	if a != nil {
+		if borders := a.borders.Load(); borders != nil {
+			return borders
+		}
+	}
+	return &Borders
+}
+
+// keys returns the keyboard shortcuts of the application, or the global Keys
+// if it has none or the application is nil.
+func (a *Application) keys() *Key {
+	if a != nil {
+		if keys := a.shortcuts.Load(); keys != nil {
+			return keys
+		}
+	}
+	return &Keys
+}
+
+// clipboardManager returns the clipboard manager of the application, or the
+// global Clipboard if it has none or the application is nil.
+func (a *Application) clipboardManager() *ClipboardManager {
+	if a != nil {
+		if clipboard := a.clipboard.Load(); clipboard != nil {
+			return clipboard
+		}
+	}
+	return Clipboard
+}
+
+// theme returns the theme of the application which last drew the box, see
+// Application.SetStyles().
+func (b *Box) theme() *Theme {
+	return b.app.Load().theme()
+}
+
+// borderSet returns the borders of the application which last drew the box,
+// see Application.SetBorders().
+func (b *Box) borderSet() *BorderSet {
+	return b.app.Load().borderSet()
+}
+
+// keys returns the keyboard shortcuts of the application which last drew the
+// box, see Application.SetKeys().
+func (b *Box) keys() *Key {
+	return b.app.Load().keys()
+}
+
+// hitShortcut returns whether the key event is present in one or more sets of
+// keybindings, matching the key chords of the application which last drew the
+// box, see Application.HitShortcut().
+func (b *Box) hitShortcut(event *tcell.EventKey, keybindings ...[]string) bool {
+	return b.app.Load().HitShortcut(event, keybindings...)
+}
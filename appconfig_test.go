@@ -0,0 +1,90 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplicationConfig(t *testing.T) {
+	t.Parallel()
+
+	start := func(list *List) *Headless {
+		list.AddItem(NewListItem("one"))
+		list.AddItem(NewListItem("two"))
+		list.SetBorder(true)
+		app := NewApplication()
+		app.SetRoot(list, true)
+		return NewHeadless(app, 10, 4)
+	}
+
+	custom, standard := NewList(), NewList()
+	h1, h2 := start(custom), start(standard)
+
+	borders := Borders
+	borders.TopLeft, borders.TopLeftFocus = '+', '+'
+	h1.GetApplication().SetBorders(borders)
+	keys := Keys
+	keys.MoveDown = []string{"x"}
+	h1.GetApplication().SetKeys(keys)
+
+	for _, h := range []*Headless{h1, h2} {
+		if err := h.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer h.Stop()
+	}
+
+	if frame := h1.GetFrame(); !strings.HasPrefix(frame[0], "+") {
+		t.Errorf("failed to draw application borders: got %q", frame[0])
+	}
+	if frame := h2.GetFrame(); strings.HasPrefix(frame[0], "+") {
+		t.Errorf("failed to draw global borders: got %q", frame[0])
+	}
+
+	for _, h := range []*Headless{h1, h2} {
+		if err := h.SendKeys("x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if index := custom.GetCurrentItemIndex(); index != 1 {
+		t.Errorf("failed to use application keys: expected item 1, got %d", index)
+	}
+	if index := standard.GetCurrentItemIndex(); index != 0 {
+		t.Errorf("failed to use global keys: expected item 0, got %d", index)
+	}
+	if k := h2.GetApplication().GetKeys(); k.MoveDown[0] != Keys.MoveDown[0] {
+		t.Errorf("failed to get global keys: got %v", k.MoveDown)
+	}
+}
+
+func TestApplicationConfigWindow(t *testing.T) {
+	t.Parallel()
+
+	list := NewList()
+	list.AddItem(NewListItem("one"))
+	list.AddItem(NewListItem("two"))
+	window := NewWindow(list)
+	window.SetRect(0, 0, 10, 6)
+	wm := NewWindowManager()
+	wm.Add(window)
+
+	app := NewApplication()
+	app.SetRoot(wm, true)
+	keys := Keys
+	keys.MoveDown = []string{"x"}
+	app.SetKeys(keys)
+	app.SetFocus(list)
+
+	h := NewHeadless(app, 20, 10)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	if err := h.SendKeys("x"); err != nil {
+		t.Fatal(err)
+	}
+	if index := list.GetCurrentItemIndex(); index != 1 {
+		t.Errorf("failed to use application keys inside window: expected item 1, got %d", index)
+	}
+}
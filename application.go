@@ -2,9 +2,16 @@ package nuview
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"codeberg.org/tslocum/cbind"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -103,34 +110,124 @@ type Application struct {
 	mouseCapture func(event *tcell.EventMouse, action MouseAction) (*tcell.EventMouse, MouseAction)
 
 	// doubleClickInterval specifies the maximum time between clicks to register a
-	// double click rather than a single click.
+	// double (or triple) click.
 	doubleClickInterval time.Duration
 
 	mouseCapturingPrimitive Primitive        // A Primitive returned by a MouseHandler which will capture future mouse events.
 	lastMouseX, lastMouseY  int              // The last position of the mouse.
 	mouseDownX, mouseDownY  int              // The position of the mouse when its button was last pressed.
 	lastMouseClick          time.Time        // The time when a mouse button was last clicked.
+	lastClickButton         tcell.ButtonMask // The mouse button which was last clicked.
+	lastClickX, lastClickY  int              // The position of the mouse when a button was last clicked.
+	clickCount              int              // The number of consecutive clicks, used to detect double and triple clicks.
 	lastMouseButtons        tcell.ButtonMask // The last mouse button state.
+	enableMouseHover        bool             // Whether or not MouseEnter and MouseLeave actions are delivered.
+	mouseHoverPrimitive     Primitive        // The innermost primitive containing the mouse (if hover is enabled).
+
+	// Tracks the innermost primitive containing the mouse while a MouseMove
+	// action is dispatched (if hover is enabled).
+	mouseHover mouseHoverTracker
 
 	enableCtrlCQuit bool // Whether or not Ctrl-C should quit the application. Enabled by default.
 
+	idleTimeout    time.Duration // The period without input events after which the application is idle (0 = disabled).
+	idleFunc       func()        // An optional callback function which is invoked when the application becomes idle.
+	activeFunc     func()        // An optional callback function which is invoked when an idle application becomes active.
+	idleTimer      *time.Timer   // The timer which fires when the application becomes idle.
+	idleGeneration int           // Incremented each time the idle timer is reset, used to ignore stale timers.
+	idle           bool          // Whether or not the application is currently idle.
+
+	eventFilters []*eventFilter // Filters applied to each event before it is dispatched, in order.
+
+	keyChords       []string           // Key chords in addition to those found in Keys.
+	chordTimeout    time.Duration      // The maximum time between the keystrokes of a key chord.
+	pendingChord    []*tcell.EventKey  // The keystrokes of a key chord which was started but not completed.
+	pendingKeys     []string           // The encoded keystrokes of the pending key chord.
+	chordTimer      *time.Timer        // The timer which fires when a pending key chord times out.
+	chordGeneration int                // Incremented each time the chord timer is reset, used to ignore stale timers.
+	chordChanged    func(chord string) // An optional callback function which is invoked when the pending key chord changes.
+
+	// The key events which completed a key chord while they are dispatched,
+	// mapped to the chords' keybindings (see HitShortcut).
+	completedChords map[*tcell.EventKey]string
+
+	keyRepeatInterval time.Duration   // The minimum time between repeated navigation keys (0 = no filtering).
+	lastRepeatKey     *tcell.EventKey // The last navigation key which was dispatched.
+	lastRepeatDone    time.Time       // The time when the last key event was processed.
+
+	findEnabled bool        // Whether or not the Find shortcut opens the find bar.
+	findField   *InputField // The input field of the find bar.
+	findTarget  Searchable  // The primitive being searched while the find bar is open (nil if closed).
+
+	jumpEnabled bool       // Whether or not the Jump shortcut shows jump hints.
+	jumpHints   *JumpHints // The labels of the jump targets.
+	jumpTarget  Jumpable   // The primitive whose jump hints are shown (nil if none).
+
+	focusScopes []*FocusScope // The active focus scopes, the innermost one last.
+
+	panicFunc      func(p interface{}, stack []byte) // An optional callback function which is invoked when a panic is handled.
+	crashReportDir string                            // The directory crash reports are written to (empty = disabled).
+	crashOutput    io.Writer                         // Where the crash message is printed, os.Stderr by default.
+
+	watchdogTimeout time.Duration                            // The time after which a blocked event loop is reported (0 = disabled).
+	watchdogFunc    func(blocked time.Duration, dump []byte) // An optional callback function which is invoked when the event loop is blocked.
+	watchdogOverlay bool                                     // Whether or not a warning is displayed while the event loop is blocked.
+	watchdogStop    chan struct{}                            // Closed to stop the watchdog (nil if Run() is not in progress).
+	busySince       atomic.Int64                             // The time the current event or update started being processed in Unix nanoseconds (0 = waiting).
+
+	slowDrawThreshold time.Duration                            // The time after which drawing a primitive is reported (0 = disabled).
+	slowDrawFunc      func(p Primitive, elapsed time.Duration) // An optional callback function which is invoked when drawing a primitive was slow.
+	drawNested        time.Duration                            // The time spent drawing the primitives nested in the one being drawn. Only used while drawing.
+
+	outputSink  io.Writer    // Where writes to standard output and standard error are redirected while the screen is active (nil = disabled).
+	outputGuard *outputGuard // The active redirection of standard output and standard error (nil if none).
+
+	persistent map[string]Persistent // The primitives whose state is saved by SaveSession(), by persistence ID.
+
+	arrangeEnabled bool      // Whether or not the Arrange shortcut enters arrange mode.
+	arrangeStepX   int       // The number of columns windows and splitters move per key press.
+	arrangeStepY   int       // The number of rows windows and splitters move per key press.
+	arrangeWindow  *Window   // The window being arranged (nil if none).
+	arrangeFlex    *Flex     // The flex whose splitter is being moved (nil if none).
+	arrangeItem    Primitive // The item of arrangeFlex next to the splitter.
+
+	styles    atomic.Pointer[Theme]            // The theme used instead of Styles (nil = Styles).
+	borders   atomic.Pointer[BorderSet]        // The borders used instead of Borders (nil = Borders).
+	shortcuts atomic.Pointer[Key]              // The keyboard shortcuts used instead of Keys (nil = Keys).
+	clipboard atomic.Pointer[ClipboardManager] // The clipboard manager used instead of Clipboard (nil = Clipboard).
+
 	sync.RWMutex
 }
 
 // NewApplication creates and returns a new application.
 func NewApplication() *Application {
+	findField := NewInputField()
+	findField.SetLabel("Find: ")
+
 	return &Application{
 		enableBracketedPaste: true,
 		events:               make(chan tcell.Event, queueSize),
 		updates:              make(chan func(), queueSize),
 		screenReplacement:    make(chan tcell.Screen, 1),
 		enableCtrlCQuit:      true,
+		doubleClickInterval:  StandardDoubleClick,
+		chordTimeout:         StandardChordTimeout,
+		findField:            findField,
+		jumpHints:            NewJumpHints(),
+		crashOutput:          os.Stderr,
+		arrangeStepX:         1,
+		arrangeStepY:         1,
 	}
 }
 
 // HandlePanic (when deferred at the start of a goroutine) handles panics
 // gracefully. The terminal is returned to its original state before the panic
-// message is printed.
+// message is printed. This is also the case when the application is locked by
+// the panicking goroutine, e.g. while drawing.
+//
+// After the terminal was restored, the handler set with SetPanicFunc() is
+// called and, if enabled with SetCrashReportDir(), a crash report is written
+// and a message pointing to it is printed. Finally, the panic continues.
 //
 // Panics may only be handled by the panicking goroutine. Because of this,
 // HandlePanic must be deferred at the start of each goroutine (including main).
@@ -139,12 +236,76 @@ func (a *Application) HandlePanic() {
 	if p == nil {
 		return
 	}
+	stack := debug.Stack()
 
-	a.finalizeScreen()
+	// The panicking goroutine may hold the lock. Finalize the screen anyway.
+	locked := a.TryLock()
+	a.restoreScreen()
+	panicFunc, dir, output := a.panicFunc, a.crashReportDir, a.crashOutput
+	if locked {
+		a.Unlock()
+	}
+
+	if panicFunc != nil {
+		panicFunc(p, stack)
+	}
+
+	if dir != "" {
+		path, err := writeCrashReport(dir, p, stack)
+		if err != nil {
+			fmt.Fprintf(output, "The application crashed. The crash report could not be written: %s\n", err)
+		} else {
+			fmt.Fprintf(output, "The application crashed. A crash report was written to %s\n", path)
+		}
+	}
 
 	panic(p)
 }
 
+// SetPanicFunc sets a handler which is called by HandlePanic() with the
+// recovered value and the stack trace of the panicking goroutine. It is called
+// after the terminal was returned to its original state, so the handler may
+// print to the terminal or log the panic. The panic continues after the
+// handler returns.
+//
+// Provide nil to uninstall the handler.
+func (a *Application) SetPanicFunc(handler func(p interface{}, stack []byte)) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.panicFunc = handler
+}
+
+// SetCrashReportDir enables crash reports. When a panic is handled by
+// HandlePanic(), a report containing the recovered value and the stack trace
+// is written to a new file in the provided directory (e.g. os.TempDir()) and
+// the message "The application crashed" along with the path of the report is
+// printed to standard error. Provide an empty string to disable crash reports,
+// which is the default.
+func (a *Application) SetCrashReportDir(dir string) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.crashReportDir = dir
+}
+
+// writeCrashReport writes a crash report to a new file in the provided
+// directory and returns its path.
+func writeCrashReport(dir string, p interface{}, stack []byte) (string, error) {
+	name := filepath.Base(os.Args[0])
+	file, err := os.CreateTemp(dir, name+"-crash-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s crashed at %s\n\npanic: %v\n\n%s", name, time.Now().Format(time.RFC3339), p, stack)
+	if err != nil {
+		return "", err
+	}
+	return file.Name(), file.Close()
+}
+
 // SetInputCapture sets a function which captures all key events before they are
 // forwarded to the key event handler of the primitive which currently has
 // focus. This function can then choose to forward that key event (or a
@@ -186,6 +347,504 @@ func (a *Application) EnableCtrlCQuit(enable bool) {
 	a.enableCtrlCQuit = enable
 }
 
+// OnIdle installs a callback function which is invoked once no key or mouse
+// events were received for the provided duration. This may be used to blank
+// sensitive data, reduce refresh rates or show a lock screen. The application
+// is redrawn after the callback returns. The next key or mouse event makes the
+// application active again (see OnActive) and is then processed as usual.
+//
+// Provide a duration of 0 or a nil function to disable idle detection.
+func (a *Application) OnIdle(d time.Duration, handler func()) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.idleTimeout = d
+	a.idleFunc = handler
+	a.resetIdleTimer()
+}
+
+// OnActive installs a callback function which is invoked when an idle
+// application (see OnIdle) receives a key or mouse event. The callback is
+// invoked before the event is processed. Events dropped by event filters (see
+// AddEventFilter) or by the key repeat filter do not make the application
+// active.
+func (a *Application) OnActive(handler func()) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.activeFunc = handler
+}
+
+// IsIdle returns whether or not the application is currently idle.
+func (a *Application) IsIdle() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.idle
+}
+
+// resetIdleTimer restarts the idle timer. The application must be locked.
+func (a *Application) resetIdleTimer() {
+	if a.idleTimer != nil {
+		a.idleTimer.Stop()
+		a.idleTimer = nil
+	}
+	a.idleGeneration++
+	if a.idleTimeout <= 0 || a.idleFunc == nil {
+		return
+	}
+
+	generation := a.idleGeneration
+	a.idleTimer = time.AfterFunc(a.idleTimeout, func() {
+		a.QueueUpdateDraw(func() {
+			a.Lock()
+			if generation != a.idleGeneration || a.idle {
+				a.Unlock()
+				return
+			}
+			a.idle = true
+			idleFunc := a.idleFunc
+			a.Unlock()
+
+			if idleFunc != nil {
+				idleFunc()
+			}
+		})
+	})
+}
+
+// activity records that an input event was received, making an idle
+// application active again.
+func (a *Application) activity() {
+	a.Lock()
+	wasIdle := a.idle
+	a.idle = false
+	activeFunc := a.activeFunc
+	a.resetIdleTimer()
+	a.Unlock()
+
+	if wasIdle && activeFunc != nil {
+		activeFunc()
+	}
+}
+
+// AddEventFilter installs a filter which is applied to each tcell event (key,
+// mouse, paste, resize and others) before the application dispatches it,
+// including before any input capture function. Filters are applied in the
+// order they were added; the output of one filter is the input of the next.
+// A filter returning nil swallows the event.
+//
+// If a filter with the same name is already installed, it is replaced in
+// place.
+func (a *Application) AddEventFilter(name string, filter EventFilter) {
+	a.Lock()
+	defer a.Unlock()
+
+	for _, f := range a.eventFilters {
+		if f.name == name {
+			f.filter = filter
+			return
+		}
+	}
+	a.eventFilters = append(a.eventFilters, &eventFilter{name: name, filter: filter})
+}
+
+// RemoveEventFilter removes the filter with the provided name. Nothing happens
+// if there is no such filter.
+func (a *Application) RemoveEventFilter(name string) {
+	a.Lock()
+	defer a.Unlock()
+
+	for index, f := range a.eventFilters {
+		if f.name == name {
+			a.eventFilters = append(a.eventFilters[:index], a.eventFilters[index+1:]...)
+			return
+		}
+	}
+}
+
+// filterEvent applies all event filters to the provided event. Returns nil if
+// the event was swallowed.
+func (a *Application) filterEvent(event tcell.Event) tcell.Event {
+	a.RLock()
+	filters := make([]EventFilter, len(a.eventFilters))
+	for index, f := range a.eventFilters {
+		filters[index] = f.filter
+	}
+	a.RUnlock()
+
+	for _, filter := range filters {
+		event = filter(event)
+		if event == nil {
+			return nil
+		}
+	}
+	return event
+}
+
+// AddKeyChords registers key chords such as "Ctrl+X Ctrl+S", i.e. sequences
+// of keystrokes separated by spaces. Key chords found in Keys (or in the
+// application's shortcuts, see SetKeys()) are registered automatically. Once
+// a key chord is completed, its last key event is dispatched and the
+// application's HitShortcut() matches it against the chord's keybinding:
+//
+//	app.AddKeyChords("Ctrl+X Ctrl+S")
+//	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+//		if app.HitShortcut(event, []string{"Ctrl+X Ctrl+S"}) {
+//			save()
+//			return nil
+//		}
+//		return event
+//	})
+//
+// Keystrokes which start a key chord are held back until the chord is either
+// completed, broken by a keystroke which does not continue it, or times out
+// (see SetChordTimeout). In the latter two cases, the held back keystrokes are
+// dispatched as single keys. This way, single keybindings which conflict with
+// the start of a key chord still work, albeit with a delay.
+func (a *Application) AddKeyChords(chords ...string) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.keyChords = append(a.keyChords, chords...)
+}
+
+// HitShortcut returns whether the EventKey provided is present in one or more
+// sets of keybindings. Unlike the package-level HitShortcut(), it matches key
+// chords (see AddKeyChords): if the event completed a key chord, only that
+// chord's keybinding matches, single keybindings of the event's key do not.
+// Primitives drawn by the application match key chords the same way.
+func (a *Application) HitShortcut(event *tcell.EventKey, keybindings ...[]string) bool {
+	var chord string
+	if a != nil {
+		a.RLock()
+		chord = a.completedChords[event]
+		a.RUnlock()
+	}
+	return hitShortcut(event, chord, keybindings)
+}
+
+// setCompletedChord sets the keybinding of the key chord which the key event
+// completed. An empty chord removes the event.
+func (a *Application) setCompletedChord(event *tcell.EventKey, chord string) {
+	a.Lock()
+	defer a.Unlock()
+
+	if chord == "" {
+		delete(a.completedChords, event)
+		return
+	}
+	if a.completedChords == nil {
+		a.completedChords = make(map[*tcell.EventKey]string)
+	}
+	a.completedChords[event] = chord
+}
+
+// SetChordTimeout sets the maximum time between the keystrokes of a key chord.
+// The default is StandardChordTimeout.
+func (a *Application) SetChordTimeout(timeout time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.chordTimeout = timeout
+}
+
+// SetKeyRepeatFilter enables filtering of repeated navigation keys (the arrow
+// keys, PageUp and PageDown), as they are sent by the terminal while such a
+// key is held down. A navigation key which is identical to the previous one is
+// dropped if it arrived less than the provided interval after it or while the
+// previous one was still being processed. This way, at most one navigation key
+// is processed per frame and bursts of keys queued up while the application
+// was busy (e.g. drawing a huge table) are coalesced, so the application stops
+// moving as soon as the key is released. A typical interval is
+// StandardKeyRepeatInterval. An interval of 0 (the default) disables the
+// filter.
+func (a *Application) SetKeyRepeatFilter(interval time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.keyRepeatInterval = interval
+	a.lastRepeatKey = nil
+}
+
+// skipKeyRepeat returns whether or not the provided key event is a repeated
+// navigation key which is to be dropped (see SetKeyRepeatFilter).
+func (a *Application) skipKeyRepeat(event *tcell.EventKey) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.keyRepeatInterval <= 0 || !isNavigationKey(event) {
+		a.lastRepeatKey = nil
+		return false
+	}
+	last := a.lastRepeatKey
+	if last != nil && last.Key() == event.Key() && last.Modifiers() == event.Modifiers() &&
+		(event.When().Before(a.lastRepeatDone) || event.When().Sub(last.When()) < a.keyRepeatInterval) {
+		return true
+	}
+	a.lastRepeatKey = event
+	return false
+}
+
+// isNavigationKey returns whether or not the provided key event is subject to
+// key repeat filtering.
+func isNavigationKey(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight, tcell.KeyPgUp, tcell.KeyPgDn:
+		return true
+	}
+	return false
+}
+
+// GetPendingChord returns the keystrokes of a key chord which was started but
+// not yet completed, separated by spaces, e.g. "Ctrl+X". An empty string is
+// returned if there is no pending key chord.
+func (a *Application) GetPendingChord() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return strings.Join(a.pendingKeys, " ")
+}
+
+// SetPendingChordFunc installs a callback function which is invoked when the
+// pending key chord (see GetPendingChord) changes. This may be used to display
+// the pending keystrokes in a status bar. The application is redrawn after the
+// callback returns.
+func (a *Application) SetPendingChordFunc(handler func(chord string)) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.chordChanged = handler
+}
+
+// keyChordMatch is a key event to be dispatched and the key chord it
+// completes ("" for none).
+type keyChordMatch struct {
+	event *tcell.EventKey
+	chord string
+}
+
+// resolveChord adds the provided key event to the pending key chord and
+// returns the key events to be dispatched. Characters typed into an input
+// field do not start a key chord.
+func (a *Application) resolveChord(event *tcell.EventKey) (keys []keyChordMatch) {
+	enc, err := cbind.Encode(event.Modifiers(), event.Key(), event.Rune())
+	if err != nil {
+		return []keyChordMatch{{event: event}}
+	}
+
+	a.Lock()
+	chords := append(keyChords(a.keys()), a.keyChords...)
+	_, typing := a.focus.(*InputField)
+	typing = typing && event.Key() == tcell.KeyRune && event.Modifiers()&^tcell.ModShift == 0
+	if (len(chords) == 0 || typing) && len(a.pendingKeys) == 0 {
+		a.Unlock()
+		return []keyChordMatch{{event: event}}
+	}
+
+	pendingBefore := strings.Join(a.pendingKeys, " ")
+	for {
+		sequence := strings.Join(append(a.pendingKeys, enc), " ")
+		var exact, prefix bool
+		for _, chord := range chords {
+			if chord == sequence {
+				exact = true
+			} else if strings.HasPrefix(chord, sequence+" ") {
+				prefix = true
+			}
+		}
+
+		if prefix {
+			// Wait for the next keystroke.
+			a.pendingChord = append(a.pendingChord, event)
+			a.pendingKeys = append(a.pendingKeys, enc)
+			a.resetChordTimer()
+			break
+		} else if exact && len(a.pendingKeys) > 0 {
+			// The chord is complete.
+			a.clearPendingChord()
+			keys = append(keys, keyChordMatch{event: event, chord: sequence})
+			break
+		} else if len(a.pendingKeys) == 0 {
+			// Not part of any chord.
+			keys = append(keys, keyChordMatch{event: event})
+			break
+		}
+
+		// The pending chord was broken. Dispatch its keystrokes and start over.
+		keys = append(keys, a.flushPendingChord(chords)...)
+	}
+	pendingAfter := strings.Join(a.pendingKeys, " ")
+	chordChanged := a.chordChanged
+	a.Unlock()
+
+	if chordChanged != nil && pendingBefore != pendingAfter {
+		chordChanged(pendingAfter)
+	}
+	return
+}
+
+// flushPendingChord clears the pending key chord and returns its keystrokes
+// to be dispatched. If the pending keystrokes form a key chord themselves,
+// only the last keystroke is returned as completing that chord. The
+// application must be locked.
+func (a *Application) flushPendingChord(chords []string) (keys []keyChordMatch) {
+	sequence := strings.Join(a.pendingKeys, " ")
+	for _, chord := range chords {
+		if chord == sequence && len(a.pendingKeys) > 1 {
+			keys = []keyChordMatch{{event: a.pendingChord[len(a.pendingChord)-1], chord: sequence}}
+			a.clearPendingChord()
+			return
+		}
+	}
+	for _, event := range a.pendingChord {
+		keys = append(keys, keyChordMatch{event: event})
+	}
+	a.clearPendingChord()
+	return
+}
+
+// clearPendingChord discards the pending key chord. The application must be
+// locked.
+func (a *Application) clearPendingChord() {
+	a.pendingChord = nil
+	a.pendingKeys = nil
+	if a.chordTimer != nil {
+		a.chordTimer.Stop()
+		a.chordTimer = nil
+	}
+	a.chordGeneration++
+}
+
+// resetChordTimer restarts the timer after which a pending key chord times
+// out. The application must be locked.
+func (a *Application) resetChordTimer() {
+	if a.chordTimer != nil {
+		a.chordTimer.Stop()
+	}
+	a.chordGeneration++
+	if a.chordTimeout <= 0 {
+		a.chordTimer = nil
+		return
+	}
+
+	generation := a.chordGeneration
+	a.chordTimer = time.AfterFunc(a.chordTimeout, func() {
+		a.QueueUpdateDraw(func() {
+			a.Lock()
+			if generation != a.chordGeneration {
+				a.Unlock()
+				return
+			}
+			keys := a.flushPendingChord(append(keyChords(a.keys()), a.keyChords...))
+			chordChanged := a.chordChanged
+			a.Unlock()
+
+			if chordChanged != nil {
+				chordChanged("")
+			}
+			for _, key := range keys {
+				a.dispatchKey(key.event, key.chord)
+			}
+		})
+	})
+}
+
+// EnableFind sets whether or not the Find shortcut (see Keys) opens a find bar
+// at the bottom of the screen when the focused primitive implements the
+// Searchable interface. While the find bar is open, all key events are sent to
+// it: Enter and the down arrow search forwards, the up arrow searches
+// backwards and Escape closes the find bar. This is disabled by default.
+func (a *Application) EnableFind(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.findEnabled = enable
+	if !enable {
+		a.closeFind()
+	}
+}
+
+// GetFindField returns the input field of the find bar. It may be used to
+// customize the find bar, e.g. by setting a label.
+func (a *Application) GetFindField() *InputField {
+	return a.findField
+}
+
+// openFind opens the find bar for the provided primitive.
+func (a *Application) openFind(target Searchable) {
+	a.findTarget = target
+	a.findField.Focus(nil)
+}
+
+// closeFind closes the find bar if it is open.
+func (a *Application) closeFind() {
+	if a.findTarget == nil {
+		return
+	}
+	a.findTarget = nil
+	a.findField.Blur()
+	if a.screen != nil {
+		a.screen.HideCursor()
+	}
+}
+
+// handleFind processes a key event while the find bar is open.
+func (a *Application) handleFind(event *tcell.EventKey, target Searchable) {
+	switch {
+	case a.HitShortcut(event, a.keys().Cancel):
+		a.Lock()
+		a.closeFind()
+		a.Unlock()
+	case a.HitShortcut(event, a.keys().Select, a.keys().MoveDown, a.keys().Find):
+		target.Search(a.findField.GetText(), false)
+	case a.HitShortcut(event, a.keys().MoveUp):
+		target.Search(a.findField.GetText(), true)
+	default:
+		if handler := a.findField.InputHandler(); handler != nil {
+			handler(event, func(p Primitive) {})
+		}
+	}
+}
+
+// EnableJumpHints sets whether or not the Jump shortcut (see Keys) shows short
+// labels on the visible items of the focused primitive if it implements the
+// Jumpable interface, e.g. on the items of a List or the cells of a Table.
+// Typing a label selects its item, any other key (e.g. Escape) hides the
+// labels. This is disabled by default.
+func (a *Application) EnableJumpHints(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.jumpEnabled = enable
+	if !enable {
+		a.jumpTarget = nil
+		a.jumpHints.Stop()
+	}
+}
+
+// GetJumpHints returns the jump hints of the application. They may be used to
+// customize the labels, e.g. by setting their alphabet.
+func (a *Application) GetJumpHints() *JumpHints {
+	return a.jumpHints
+}
+
+// handleJump processes a key event while jump hints are shown.
+func (a *Application) handleJump(event *tcell.EventKey, target Jumpable) {
+	if event.Key() == tcell.KeyRune && event.Modifiers()&^tcell.ModShift == 0 {
+		if jumpTarget, ok := a.jumpHints.Type(event.Rune()); ok {
+			target.Jump(jumpTarget)
+		}
+	} else {
+		a.jumpHints.Stop()
+	}
+	if !a.jumpHints.IsActive() {
+		a.Lock()
+		a.jumpTarget = nil
+		a.Unlock()
+	}
+}
+
 // SetMouseCapture sets a function which captures mouse events (consisting of
 // the original tcell mouse event and the semantic mouse action) before they are
 // forwarded to the appropriate mouse event handler. This function can then
@@ -202,13 +861,29 @@ func (a *Application) GetMouseCapture() func(event *tcell.EventMouse, action Mou
 	return a.mouseCapture
 }
 
-// SetDoubleClickInterval sets the maximum time between clicks to register a
-// double click rather than a single click. A standard duration is provided as
-// StandardDoubleClick. No interval is set by default, disabling double clicks.
+// SetDoubleClickInterval sets the maximum time between clicks of the same
+// mouse button at the same position to register a double or triple click. The
+// click action (e.g. MouseLeftClick) is always delivered first; the second
+// click is followed by a double click action (e.g. MouseLeftDoubleClick) and
+// the third click by a triple click action (e.g. MouseLeftTripleClick). The
+// default is StandardDoubleClick. An interval of 0 disables double and triple
+// clicks.
 func (a *Application) SetDoubleClickInterval(interval time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+
 	a.doubleClickInterval = interval
 }
 
+// GetDoubleClickInterval returns the maximum time between clicks to register a
+// double or triple click.
+func (a *Application) GetDoubleClickInterval() time.Duration {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.doubleClickInterval
+}
+
 // SetScreen allows you to provide your own tcell.Screen object. For most
 // applications, this is not needed and you should be familiar with
 // tcell.Screen when using this function.
@@ -231,6 +906,7 @@ func (a *Application) SetScreen(screen tcell.Screen) {
 	// Run() is already in progress. Exchange screen.
 	oldScreen := a.screen
 	a.Unlock()
+	screenApplications.Delete(oldScreen)
 	oldScreen.Fini()
 	a.screenReplacement <- screen
 }
@@ -281,6 +957,7 @@ func (a *Application) init() error {
 	if a.enableMouse {
 		a.screen.EnableMouse()
 	}
+	a.startOutputGuard()
 	return nil
 }
 
@@ -312,6 +989,24 @@ func (a *Application) EnableMouse(enable bool) {
 	a.enableMouse = enable
 }
 
+// EnableMouseHover sets whether or not MouseEnter and MouseLeave actions are
+// delivered to primitives. When enabled, each time the mouse moves, the
+// innermost primitive containing the mouse is determined. When it changes, the
+// previous primitive receives a MouseLeave action and the new one a MouseEnter
+// action, in addition to the MouseMove action delivered as usual. Primitives
+// may handle these actions with SetMouseCapture() to implement hover styles or
+// tooltips. Mouse support must be enabled as well (see EnableMouse). This is
+// disabled by default.
+func (a *Application) EnableMouseHover(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.enableMouseHover = enable
+	if !enable {
+		a.mouseHoverPrimitive = nil
+	}
+}
+
 // Run starts the application and thus the event loop. This function returns
 // when Stop() was called.
 func (a *Application) Run() error {
@@ -326,6 +1021,16 @@ func (a *Application) Run() error {
 
 	defer a.HandlePanic()
 
+	// Redraw while primitives are animating.
+	startAnimations(a)
+	defer stopAnimations(a)
+
+	// Start idle detection.
+	a.resetIdleTimer()
+
+	// Start the watchdog.
+	a.startWatchdog()
+
 	// Draw the screen for the first time.
 	a.Unlock()
 	a.draw()
@@ -376,38 +1081,35 @@ func (a *Application) Run() error {
 	}()
 
 	handle := func(event interface{}) {
+		// Headless runners wait for sync events to be processed.
+		if event, ok := event.(*headlessSync); ok {
+			close(event.done)
+			return
+		}
+
 		a.RLock()
-		p := a.focus
-		inputCapture := a.inputCapture
 		screen := a.screen
 		a.RUnlock()
 
-		switch event := event.(type) {
-		case *tcell.EventKey:
-			// Intercept keys.
-			if inputCapture != nil {
-				event = inputCapture(event)
-				if event == nil {
-					a.draw()
-					return // Don't forward event.
-				}
+		// Apply event filters.
+		if e, ok := event.(tcell.Event); ok && e != nil {
+			if event = a.filterEvent(e); event == nil {
+				return
 			}
+		}
 
-			// Ctrl-C closes the application.
-			if a.enableCtrlCQuit && event.Key() == tcell.KeyCtrlC {
-				a.Stop()
+		switch event := event.(type) {
+		case *tcell.EventKey:
+			if a.skipKeyRepeat(event) {
 				return
 			}
-
-			// Pass other key events to the currently focused primitive.
-			if p != nil {
-				if handler := p.InputHandler(); handler != nil {
-					handler(event, func(p Primitive) {
-						a.SetFocus(p)
-					})
-					a.draw()
-				}
+			a.activity()
+			for _, key := range a.resolveChord(event) {
+				a.dispatchKey(key.event, key.chord)
 			}
+			a.Lock()
+			a.lastRepeatDone = time.Now()
+			a.Unlock()
 		case *tcell.EventResize:
 			// Throttle resize events.
 			if time.Since(a.lastResize) < resizeEventThrottle {
@@ -445,6 +1147,7 @@ func (a *Application) Run() error {
 
 			a.draw()
 		case *tcell.EventMouse:
+			a.activity()
 			consumed, isMouseDownAction := a.fireMouseActions(event)
 			if consumed {
 				a.draw()
@@ -463,7 +1166,9 @@ func (a *Application) Run() error {
 
 		for update := range a.updates {
 			semaphore.Lock()
+			a.busy()
 			update()
+			a.done()
 			semaphore.Unlock()
 		}
 	}()
@@ -473,7 +1178,9 @@ func (a *Application) Run() error {
 
 		for event := range a.events {
 			semaphore.Lock()
+			a.busy()
 			handle(event)
+			a.done()
 			semaphore.Unlock()
 		}
 	}()
@@ -495,17 +1202,134 @@ func (a *Application) Run() error {
 		}
 
 		semaphore.Lock()
+		a.busy()
 		handle(event)
+		a.done()
 		semaphore.Unlock()
 	}
 
 	// Wait for the screen replacement event loop to finish.
 	wg.Wait()
+
+	// Release the screen and stop the watchdog.
+	a.Lock()
+	if a.screen != nil {
+		screenApplications.Delete(a.screen)
+	}
 	a.screen = nil
+	a.stopWatchdog()
+	a.stopOutputGuard()
+	a.Unlock()
 
 	return nil
 }
 
+// dispatchKey processes a key event. If the event completes a key chord, the
+// chord's keybinding is provided (see HitShortcut).
+func (a *Application) dispatchKey(event *tcell.EventKey, chord string) {
+	a.RLock()
+	p := a.focus
+	inputCapture := a.inputCapture
+	findEnabled := a.findEnabled
+	findTarget := a.findTarget
+	jumpEnabled := a.jumpEnabled
+	jumpTarget := a.jumpTarget
+	a.RUnlock()
+
+	if chord != "" {
+		a.setCompletedChord(event, chord)
+		defer a.setCompletedChord(event, "")
+	}
+
+	// Intercept keys.
+	if inputCapture != nil {
+		event = inputCapture(event)
+		if event == nil {
+			a.draw()
+			return // Don't forward event.
+		}
+	}
+
+	// Ctrl-C closes the application.
+	if a.enableCtrlCQuit && event.Key() == tcell.KeyCtrlC {
+		a.Stop()
+		return
+	}
+
+	// Pass key events to the find bar while it is open.
+	if findTarget != nil {
+		a.handleFind(event, findTarget)
+		a.draw()
+		return
+	}
+
+	// Open the find bar.
+	if findEnabled && a.HitShortcut(event, a.keys().Find) {
+		if target, ok := p.(Searchable); ok {
+			a.Lock()
+			a.openFind(target)
+			a.Unlock()
+			a.draw()
+			return
+		}
+	}
+
+	// Pass key events to the jump hints while they are shown.
+	if jumpTarget != nil {
+		a.handleJump(event, jumpTarget)
+		a.draw()
+		return
+	}
+
+	// Show jump hints.
+	if jumpEnabled && a.HitShortcut(event, a.keys().Jump) {
+		if target, ok := p.(Jumpable); ok {
+			if targets := target.JumpTargets(); len(targets) > 0 {
+				a.jumpHints.Start(targets)
+				a.Lock()
+				a.jumpTarget = target
+				a.Unlock()
+				a.draw()
+				return
+			}
+		}
+	}
+
+	// Move and resize windows and splitters in arrange mode.
+	if a.handleArrange(event) {
+		a.draw()
+		return
+	}
+
+	// Select items of lists with global shortcuts.
+	if a.handleGlobalShortcuts(event, p) {
+		a.draw()
+		return
+	}
+
+	// Activate a command bar.
+	if a.handleCommandBar(event, p) {
+		a.draw()
+		return
+	}
+
+	// Navigate within the active focus scope.
+	if a.handleFocusScope(event) {
+		a.draw()
+		return
+	}
+
+	// Pass other key events to the currently focused primitive.
+	if p != nil {
+		if handler := p.InputHandler(); handler != nil {
+			handler(event, func(p Primitive) {
+				a.SetFocus(p)
+			})
+			a.draw()
+		}
+	}
+}
+
 // fireMouseActions analyzes the provided mouse event, derives mouse actions
 // from it and then forwards them to the corresponding primitives.
 func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMouseDownAction bool) {
@@ -552,24 +1376,59 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 		a.mouseCapturingPrimitive = capturingPrimitive
 	}
 
+	// Helper function to fire a mouse action at a specific primitive.
+	fireAt := func(primitive Primitive, action MouseAction) {
+		e := event
+		if a.mouseCapture != nil {
+			if e, action = a.mouseCapture(e, action); e == nil {
+				consumed = true
+				return // Don't forward event.
+			}
+		}
+		if handler := primitive.MouseHandler(); handler != nil {
+			if wasConsumed, _ := handler(action, e, func(p Primitive) {
+				a.SetFocus(p)
+			}); wasConsumed {
+				consumed = true
+			}
+		}
+	}
+
 	x, y := event.Position()
 	buttons := event.Buttons()
 	clickMoved := x != a.mouseDownX || y != a.mouseDownY
 	buttonChanges := buttons ^ a.lastMouseButtons
 
 	if x != a.lastMouseX || y != a.lastMouseY {
+		if a.enableMouseHover {
+			a.mouseHover.start()
+		}
 		fire(MouseMove)
 		a.lastMouseX = x
 		a.lastMouseY = y
+
+		// Deliver hover actions.
+		if a.enableMouseHover {
+			hovered := a.mouseHover.finish()
+			if hovered != a.mouseHoverPrimitive {
+				if a.mouseHoverPrimitive != nil {
+					fireAt(a.mouseHoverPrimitive, MouseLeave)
+				}
+				if hovered != nil {
+					fireAt(hovered, MouseEnter)
+				}
+				a.mouseHoverPrimitive = hovered
+			}
+		}
 	}
 
 	for _, buttonEvent := range []struct {
-		button                  tcell.ButtonMask
-		down, up, click, dclick MouseAction
+		button                          tcell.ButtonMask
+		down, up, click, dclick, tclick MouseAction
 	}{
-		{tcell.ButtonPrimary, MouseLeftDown, MouseLeftUp, MouseLeftClick, MouseLeftDoubleClick},
-		{tcell.ButtonMiddle, MouseMiddleDown, MouseMiddleUp, MouseMiddleClick, MouseMiddleDoubleClick},
-		{tcell.ButtonSecondary, MouseRightDown, MouseRightUp, MouseRightClick, MouseRightDoubleClick},
+		{tcell.ButtonPrimary, MouseLeftDown, MouseLeftUp, MouseLeftClick, MouseLeftDoubleClick, MouseLeftTripleClick},
+		{tcell.ButtonMiddle, MouseMiddleDown, MouseMiddleUp, MouseMiddleClick, MouseMiddleDoubleClick, MouseMiddleTripleClick},
+		{tcell.ButtonSecondary, MouseRightDown, MouseRightUp, MouseRightClick, MouseRightDoubleClick, MouseRightTripleClick},
 	} {
 		if buttonChanges&buttonEvent.button != 0 {
 			if buttons&buttonEvent.button != 0 {
@@ -577,12 +1436,23 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (consumed, isMou
 			} else {
 				fire(buttonEvent.up)
 				if !clickMoved {
-					if a.doubleClickInterval == 0 || a.lastMouseClick.Add(a.doubleClickInterval).Before(time.Now()) {
-						fire(buttonEvent.click)
-						a.lastMouseClick = time.Now()
-					} else {
+					now := time.Now()
+					if a.doubleClickInterval == 0 || a.clickCount >= 3 ||
+						a.lastClickButton != buttonEvent.button || a.lastClickX != x || a.lastClickY != y ||
+						a.lastMouseClick.Add(a.doubleClickInterval).Before(now) {
+						a.clickCount = 0
+					}
+					a.clickCount++
+					a.lastMouseClick = now
+					a.lastClickButton = buttonEvent.button
+					a.lastClickX, a.lastClickY = x, y
+
+					fire(buttonEvent.click)
+					switch a.clickCount {
+					case 2:
 						fire(buttonEvent.dclick)
-						a.lastMouseClick = time.Time{} // reset
+					case 3:
+						fire(buttonEvent.tclick)
 					}
 				}
 			}
@@ -610,17 +1480,35 @@ func (a *Application) Stop() {
 	a.Lock()
 	defer a.Unlock()
 
+	if a.idleTimer != nil {
+		a.idleTimer.Stop()
+		a.idleTimer = nil
+	}
+	a.clearPendingChord()
+
 	a.finalizeScreen()
 	a.screenReplacement <- nil
 }
 
+// restoreScreen finalizes the screen after a panic. Panics raised by the
+// screen itself are ignored so the original panic can be reported.
+func (a *Application) restoreScreen() {
+	defer func() {
+		recover()
+	}()
+
+	a.finalizeScreen()
+}
+
 func (a *Application) finalizeScreen() {
 	screen := a.screen
 	if screen == nil {
 		return
 	}
 
+	screenApplications.Delete(screen)
 	a.screen = nil
+	a.stopOutputGuard()
 	screen.Fini()
 }
 
@@ -638,6 +1526,8 @@ func (a *Application) Suspend(f func()) bool {
 		return false // Screen has not yet been initialized.
 	}
 	err := a.screen.Suspend()
+	a.stopOutputGuard()
+	a.clearPendingChord()
 	a.Unlock()
 	if err != nil {
 		panic(err)
@@ -648,6 +1538,7 @@ func (a *Application) Suspend(f func()) bool {
 
 	a.Lock()
 	err = a.screen.Resume()
+	a.startOutputGuard()
 	a.Unlock()
 	if err != nil {
 		panic(err)
@@ -674,13 +1565,15 @@ func (a *Application) Draw(p ...Primitive) {
 		}
 
 		a.Lock()
+		defer a.Unlock()
+
 		if a.screen != nil {
+			screenApplications.Store(a.screen, a)
 			for _, primitive := range p {
 				primitive.Draw(a.screen)
 			}
 			a.screen.Show()
 		}
-		a.Unlock()
 	})
 }
 
@@ -693,6 +1586,9 @@ func (a *Application) draw() {
 	fullscreen := a.rootFullscreen
 	before := a.beforeDraw
 	after := a.afterDraw
+	findOpen := a.findTarget != nil
+	jumpOpen := a.jumpTarget != nil
+	width, height := a.width, a.height
 
 	// Maybe we're not ready yet or not anymore.
 	if screen == nil || root == nil {
@@ -717,7 +1613,20 @@ func (a *Application) draw() {
 	}
 
 	// Draw all primitives.
-	root.Draw(screen)
+	screenApplications.Store(screen, a)
+	drawPrimitive(screen, root)
+
+	// Draw the jump hints on top of them.
+	if jumpOpen {
+		theme := a.theme()
+		a.jumpHints.Draw(screen, tcell.StyleDefault.Foreground(theme.InverseTextColor).Background(theme.SecondaryTextColor).Bold(true))
+	}
+
+	// Draw the find bar on top of them.
+	if findOpen && height > 0 {
+		a.findField.SetRect(0, height-1, width, 1)
+		a.findField.Draw(screen)
+	}
 
 	// Call after handler if there is one.
 	if after != nil {
@@ -866,6 +1775,213 @@ func (a *Application) SetFocus(p Primitive) {
 			a.SetFocus(p)
 		})
 	}
+
+	a.keepFocusInScope()
+}
+
+// PushFocusScope activates a focus scope, see FocusScope for details. The
+// first visible primitive of the scope receives focus. Scopes may be nested,
+// e.g. for a dialog opened from another dialog. The innermost scope is
+// active.
+func (a *Application) PushFocusScope(scope *FocusScope) {
+	a.Lock()
+	scope.Lock()
+	scope.previous = a.focus
+	scope.focused = 0
+	scope.Unlock()
+	a.focusScopes = append(a.focusScopes, scope)
+	a.Unlock()
+
+	if p := scope.current(); p != nil {
+		a.SetFocus(p)
+	}
+}
+
+// PopFocusScope deactivates the innermost focus scope and returns the focus
+// to the primitive which had it before the scope was activated. The scope is
+// returned, or nil if there was no active scope.
+func (a *Application) PopFocusScope() *FocusScope {
+	a.Lock()
+	if len(a.focusScopes) == 0 {
+		a.Unlock()
+		return nil
+	}
+	scope := a.focusScopes[len(a.focusScopes)-1]
+	a.focusScopes = a.focusScopes[:len(a.focusScopes)-1]
+	a.Unlock()
+
+	scope.RLock()
+	previous := scope.previous
+	scope.RUnlock()
+	if previous != nil {
+		a.SetFocus(previous)
+	}
+	return scope
+}
+
+// GetFocusScope returns the active focus scope or nil if there is none.
+func (a *Application) GetFocusScope() *FocusScope {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.focusScopes) == 0 {
+		return nil
+	}
+	return a.focusScopes[len(a.focusScopes)-1]
+}
+
+// handleFocusScope moves the focus within the active focus scope or exits the
+// scope if the provided key event calls for it. It returns whether or not the
+// event was handled.
+func (a *Application) handleFocusScope(event *tcell.EventKey) bool {
+	scope := a.GetFocusScope()
+	if scope == nil {
+		return false
+	}
+
+	var offset int
+	switch {
+	case a.HitShortcut(event, a.keys().MoveNextField):
+		offset = 1
+	case a.HitShortcut(event, a.keys().MovePreviousField):
+		offset = -1
+	case a.HitShortcut(event, a.keys().Cancel):
+		a.PopFocusScope()
+		scope.RLock()
+		exit := scope.exit
+		scope.RUnlock()
+		if exit != nil {
+			exit()
+		}
+		return true
+	default:
+		return false
+	}
+
+	if p := scope.next(offset); p != nil {
+		a.SetFocus(p)
+	}
+	return true
+}
+
+// handleGlobalShortcuts selects the item of a visible list with global
+// shortcuts (see List.SetGlobalShortcuts()) whose shortcut matches the key
+// event, pressed with the Alt key. Keys the focused primitive (provided) has a
+// use for are left to it: keys bound in the application's Keys or with the
+// primitive's AddKeyBinding(), and all keys typed into an input field. It
+// returns whether or not an item was selected.
+func (a *Application) handleGlobalShortcuts(event *tcell.EventKey, focused Primitive) bool {
+	if event.Key() != tcell.KeyRune || event.Modifiers() != tcell.ModAlt {
+		return false
+	}
+	if _, ok := focused.(*InputField); ok || keyBound(a.keys(), event) {
+		return false
+	}
+	if bound, ok := focused.(interface {
+		hasKeyBinding(event *tcell.EventKey) bool
+	}); ok && bound.hasKeyBinding(event) {
+		return false
+	}
+
+	a.RLock()
+	root := a.root
+	a.RUnlock()
+
+	primitives := []Primitive{root}
+	for len(primitives) > 0 {
+		p := primitives[0]
+		primitives = primitives[1:]
+		if p == nil || !p.GetVisible() {
+			continue
+		}
+		if l, ok := p.(*List); ok && l != focused && l.GetGlobalShortcuts() {
+			l.Lock()
+			activated := l.activateShortcut(event.Rune())
+			l.Unlock()
+			if activated {
+				return true
+			}
+		}
+		primitives = append(primitives, childPrimitives(p)...)
+	}
+	return false
+}
+
+// handleCommandBar activates the first visible command bar (see CommandBar)
+// if the key event matches Keys.ActivateCommandBar and no input field has
+// focus. The focused primitive is provided. It returns whether or not a
+// command bar was activated.
+func (a *Application) handleCommandBar(event *tcell.EventKey, focused Primitive) bool {
+	if !a.HitShortcut(event, a.keys().ActivateCommandBar) {
+		return false
+	}
+	if _, ok := focused.(*InputField); ok {
+		return false
+	}
+
+	a.RLock()
+	root := a.root
+	a.RUnlock()
+
+	primitives := []Primitive{root}
+	for len(primitives) > 0 {
+		p := primitives[0]
+		primitives = primitives[1:]
+		if p == nil || !p.GetVisible() {
+			continue
+		}
+		if c, ok := p.(*CommandBar); ok {
+			c.activate(focused)
+			a.SetFocus(c)
+			return true
+		}
+		primitives = append(primitives, childPrimitives(p)...)
+	}
+	return false
+}
+
+// keepFocusInScope moves the focus back into the active focus scope if it
+// has left it.
+func (a *Application) keepFocusInScope() {
+	scope := a.GetFocusScope()
+	if scope == nil || scope.HasFocus() {
+		return
+	}
+
+	scope.Lock()
+	if scope.restoring {
+		scope.Unlock()
+		return // The scope cannot receive focus.
+	}
+	scope.restoring = true
+	scope.Unlock()
+
+	if p := scope.current(); p != nil {
+		a.SetFocus(p)
+	}
+
+	scope.Lock()
+	scope.restoring = false
+	scope.Unlock()
+}
+
+// OpenContextMenu opens the context menu of the focused primitive (a List or
+// a Table) for its selected item at the provided screen position. Provide -1
+// for both to show the menu next to the selected item. This allows opening the
+// menu with custom keys. Returns whether a menu was opened, i.e. false if the
+// focused primitive has no context menu or its menu has no items.
+//
+// Like the primitives' handlers, this function must be called from the
+// goroutine which runs the event loop, e.g. in an input capture function.
+func (a *Application) OpenContextMenu(x, y int) bool {
+	a.RLock()
+	host, ok := a.focus.(contextMenuHost)
+	a.RUnlock()
+
+	if !ok {
+		return false
+	}
+	return host.openContextMenu(x, y, a.SetFocus)
 }
 
 // GetFocus returns the primitive which has the current focus. If none has it,
@@ -923,6 +2039,7 @@ func (a *Application) QueueUpdateDraw(f func(), p ...Primitive) {
 		}
 		a.Lock()
 		if a.screen != nil {
+			screenApplications.Store(a.screen, a)
 			for _, primitive := range p {
 				primitive.Draw(a.screen)
 			}
@@ -0,0 +1,357 @@
+package nuview
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHandlePanic(t *testing.T) {
+	t.Parallel()
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	var recovered interface{}
+	var stack []byte
+	app.SetPanicFunc(func(p interface{}, s []byte) {
+		recovered, stack = p, s
+	})
+	var output bytes.Buffer
+	app.crashOutput = &output
+	app.SetCrashReportDir(t.TempDir())
+
+	// Panic while the application is locked, e.g. while drawing.
+	repanicked := func() (p interface{}) {
+		defer func() {
+			p = recover()
+		}()
+		defer app.HandlePanic()
+
+		app.Lock()
+		panic("boom")
+	}()
+
+	if repanicked != "boom" {
+		t.Errorf("failed to continue panic: expected boom, got %v", repanicked)
+	}
+	if recovered != "boom" || !bytes.Contains(stack, []byte("TestHandlePanic")) {
+		t.Errorf("failed to call panic handler: got %v with stack %q", recovered, stack)
+	}
+	if app.screen != nil {
+		t.Error("failed to finalize screen: expected no screen")
+	}
+
+	message := output.String()
+	const prefix = "The application crashed. A crash report was written to "
+	if !strings.HasPrefix(message, prefix) {
+		t.Fatalf("failed to print crash message: got %q", message)
+	}
+	report, err := os.ReadFile(strings.TrimSpace(strings.TrimPrefix(message, prefix)))
+	if err != nil {
+		t.Fatalf("failed to read crash report: %s", err)
+	}
+	if !bytes.Contains(report, []byte("panic: boom")) {
+		t.Errorf("failed to write crash report: expected panic value, got %q", report)
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	t.Parallel()
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	reports := make(chan []byte, 2)
+	app.SetWatchdog(50*time.Millisecond, func(blocked time.Duration, dump []byte) {
+		reports <- dump
+	})
+	app.SetWatchdogOverlay(true)
+	app.Lock()
+	app.startWatchdog()
+	app.Unlock()
+	defer app.SetWatchdog(0, nil)
+
+	// Simulate a handler which blocks the event loop.
+	app.busy()
+	select {
+	case dump := <-reports:
+		if !bytes.Contains(dump, []byte("goroutine")) {
+			t.Errorf("failed to dump goroutines: got %q", dump)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed to detect blocked event loop")
+	}
+	var row string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var b strings.Builder
+		for x := 0; x < 80; x++ {
+			r, _, _, _ := app.screen.GetContent(x, 0)
+			b.WriteRune(r)
+		}
+		if row = b.String(); strings.Contains(row, "not responding") {
+			break
+		}
+	}
+	if !strings.Contains(row, "Application not responding") {
+		t.Errorf("failed to draw overlay: got %q", row)
+	}
+
+	// Each blocking event is reported once.
+	time.Sleep(150 * time.Millisecond)
+	app.done()
+	if len(reports) != 0 {
+		t.Error("failed to report blocking event once: got multiple reports")
+	}
+}
+
+func TestOutputGuard(t *testing.T) {
+	// Not parallel: the standard streams are replaced while the guard is
+	// active.
+
+	app, err := newTestApp(NewBox())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+
+	tv := NewTextView()
+	app.SetOutputGuard(tv)
+	app.RLock()
+	guard := app.outputGuard
+	app.RUnlock()
+	if guard == nil || os.Stdout == stdout {
+		t.Fatal("failed to start output guard: expected standard output to be redirected")
+	}
+	fmt.Println("stray")
+	if guard.logRedirected {
+		log.SetFlags(0)
+		defer log.SetFlags(log.LstdFlags)
+		log.Print("logged")
+	}
+
+	app.SetOutputGuard(nil)
+	<-guard.done
+	if os.Stdout != stdout || os.Stderr != guard.stderr {
+		t.Error("failed to restore standard streams")
+	}
+	expected := "stray\n"
+	if guard.logRedirected {
+		expected += "logged\n"
+	}
+	if text := tv.GetText(true); text != expected {
+		t.Errorf("failed to redirect output: expected %q, got %q", expected, text)
+	}
+}
+
+func TestFocusScope(t *testing.T) {
+	t.Parallel()
+
+	background := NewButton("Background")
+	name := NewInputField()
+	hidden := NewButton("Hidden")
+	hidden.SetVisible(false)
+	ok := NewButton("OK")
+
+	flex := NewFlex()
+	flex.AddItem(background, 1, 0, true)
+	flex.AddItem(name, 1, 0, false)
+	flex.AddItem(hidden, 1, 0, false)
+	flex.AddItem(ok, 1, 0, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetFocus(background)
+
+	var exited bool
+	scope := NewFocusScope(name, hidden, ok)
+	scope.SetExitFunc(func() {
+		exited = true
+	})
+	app.PushFocusScope(scope)
+	if focus := app.GetFocus(); focus != name {
+		t.Fatalf("failed to focus scope: expected first primitive, got %v", focus)
+	}
+
+	tab := tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)
+	app.dispatchKey(tab, "")
+	if focus := app.GetFocus(); focus != ok {
+		t.Errorf("failed to skip hidden primitive: expected OK button, got %v", focus)
+	}
+	app.dispatchKey(tab, "")
+	if focus := app.GetFocus(); focus != name {
+		t.Errorf("failed to cycle within scope: expected input field, got %v", focus)
+	}
+
+	app.SetFocus(background)
+	if focus := app.GetFocus(); focus != name {
+		t.Errorf("failed to keep focus within scope: expected input field, got %v", focus)
+	}
+
+	app.dispatchKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), "")
+	if focus := app.GetFocus(); focus != background || !exited {
+		t.Errorf("failed to exit scope: expected background button and exit handler call, got %v, %t", focus, exited)
+	}
+	if app.GetFocusScope() != nil {
+		t.Error("failed to exit scope: scope still active")
+	}
+}
+
+func TestKeyRepeatFilter(t *testing.T) {
+	t.Parallel()
+
+	app := NewApplication()
+	down := func() *tcell.EventKey {
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	}
+
+	// Disabled by default.
+	app.skipKeyRepeat(down())
+	if app.skipKeyRepeat(down()) {
+		t.Error("failed to pass repeated key: filter is not enabled")
+	}
+
+	app.SetKeyRepeatFilter(time.Hour)
+	if app.skipKeyRepeat(down()) {
+		t.Error("failed to pass first key")
+	}
+	if !app.skipKeyRepeat(down()) {
+		t.Error("failed to drop repeated key within interval")
+	}
+	if app.skipKeyRepeat(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone)) {
+		t.Error("failed to pass rune")
+	}
+	if app.skipKeyRepeat(down()) {
+		t.Error("failed to pass key after a different key")
+	}
+
+	// Keys queued up while the previous one was processed are coalesced.
+	app.SetKeyRepeatFilter(time.Nanosecond)
+	app.skipKeyRepeat(down())
+	queued := down()
+	time.Sleep(time.Millisecond)
+	app.lastRepeatDone = time.Now()
+	if !app.skipKeyRepeat(queued) {
+		t.Error("failed to drop key queued while busy")
+	}
+	time.Sleep(time.Millisecond)
+	if app.skipKeyRepeat(down()) {
+		t.Error("failed to pass key after processing")
+	}
+}
+
+func TestSlowDraw(t *testing.T) {
+	t.Parallel()
+
+	slow := NewBox()
+	slow.SetDrawFunc(func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		time.Sleep(30 * time.Millisecond)
+		return x, y, width, height
+	})
+	flex := NewFlex()
+	flex.AddItem(NewBox(), 0, 1, false)
+	flex.AddItem(slow, 0, 1, false)
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []Primitive
+	app.SetSlowDrawFunc(20*time.Millisecond, func(p Primitive, elapsed time.Duration) {
+		if elapsed < 20*time.Millisecond {
+			t.Errorf("failed to measure draw time: expected at least 20ms, got %s", elapsed)
+		}
+		reported = append(reported, p)
+	})
+	app.SetRoot(flex, false)
+	flex.SetRect(0, 0, 80, 24)
+	app.draw()
+
+	// The flex containing the slow box is not reported.
+	if len(reported) != 1 || reported[0] != slow {
+		t.Errorf("failed to report slow primitive only: got %v", reported)
+	}
+}
+
+func TestIdle(t *testing.T) {
+	t.Parallel()
+
+	app := NewApplication()
+	app.SetRoot(NewBox(), true)
+	idle, active := make(chan struct{}, 10), make(chan struct{}, 10)
+	app.OnIdle(200*time.Millisecond, func() {
+		idle <- struct{}{}
+	})
+	app.OnActive(func() {
+		active <- struct{}{}
+	})
+	app.AddEventFilter("drop", func(event tcell.Event) tcell.Event {
+		if key, ok := event.(*tcell.EventKey); ok && key.Rune() == 'x' {
+			return nil
+		}
+		return event
+	})
+
+	h := NewHeadless(app, 10, 4)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	waitIdle := func(message string) {
+		select {
+		case <-idle:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("failed to become idle %s: timed out", message)
+		}
+		h.Sync()
+		if !app.IsIdle() {
+			t.Errorf("failed to report idle state %s", message)
+		}
+	}
+	waitIdle("without input")
+
+	// Dropped events do not make the application active.
+	if err := h.SendKeys("x"); err != nil {
+		t.Fatal(err)
+	}
+	h.Sync()
+	if !app.IsIdle() || len(active) != 0 {
+		t.Error("failed to ignore filtered key: application became active")
+	}
+
+	if err := h.SendKeys("a"); err != nil {
+		t.Fatal(err)
+	}
+	h.Sync()
+	if app.IsIdle() || len(active) != 1 {
+		t.Errorf("failed to become active on key: expected 1 OnActive call, got %d", len(active))
+	}
+
+	// Idle detection is re-armed after activity.
+	waitIdle("after activity")
+}
@@ -0,0 +1,140 @@
+package nuview
+
+import "github.com/gdamore/tcell/v2"
+
+// EnableArrange sets whether or not the Arrange shortcut (see Keys, Ctrl+W by
+// default) enters arrange mode, which moves and resizes windows and Flex
+// splitters with the keyboard. The shortcut acts on the focused Window or, if
+// no window has focus, on the innermost Flex containing the focused primitive.
+// If there is neither, the shortcut is passed on to the focused primitive.
+//
+// In arrange mode, the arrow keys move the window or the edge between the
+// focused Flex item and its neighbor (see Flex.MoveSplitter()). Shift and the
+// arrow keys resize the window. Escape, Enter and the Arrange shortcut leave
+// arrange mode, other keys are ignored. This is disabled by default.
+func (a *Application) EnableArrange(enable bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.arrangeEnabled = enable
+	if !enable {
+		a.arrangeWindow, a.arrangeFlex, a.arrangeItem = nil, nil, nil
+	}
+}
+
+// SetArrangeStep sets the number of columns and rows by which windows and
+// splitters are moved and resized per key press in arrange mode (see
+// EnableArrange()). The default is 1 for both.
+func (a *Application) SetArrangeStep(columns, rows int) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.arrangeStepX, a.arrangeStepY = max(columns, 1), max(rows, 1)
+}
+
+// IsArranging returns whether or not the application is in arrange mode (see
+// EnableArrange()).
+func (a *Application) IsArranging() bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.arrangeWindow != nil || a.arrangeFlex != nil
+}
+
+// handleArrange enters, processes and leaves arrange mode. It returns whether
+// or not the key event was consumed.
+func (a *Application) handleArrange(event *tcell.EventKey) bool {
+	a.RLock()
+	enabled, root := a.arrangeEnabled, a.root
+	window, flex, item := a.arrangeWindow, a.arrangeFlex, a.arrangeItem
+	stepX, stepY := a.arrangeStepX, a.arrangeStepY
+	a.RUnlock()
+
+	if window == nil && flex == nil {
+		if !enabled || !a.HitShortcut(event, a.keys().Arrange) {
+			return false
+		}
+		window, flex, item = findArrangeTarget(root)
+		if window == nil && flex == nil {
+			return false
+		}
+		a.Lock()
+		a.arrangeWindow, a.arrangeFlex, a.arrangeItem = window, flex, item
+		a.Unlock()
+		return true
+	}
+
+	if a.HitShortcut(event, a.keys().Cancel, a.keys().Select, a.keys().Arrange) {
+		a.Lock()
+		a.arrangeWindow, a.arrangeFlex, a.arrangeItem = nil, nil, nil
+		a.Unlock()
+		return true
+	}
+
+	var dx, dy int
+	switch {
+	case event.Key() == tcell.KeyLeft || a.HitShortcut(event, a.keys().MoveLeft, a.keys().MoveLeft2):
+		dx = -stepX
+	case event.Key() == tcell.KeyRight || a.HitShortcut(event, a.keys().MoveRight, a.keys().MoveRight2):
+		dx = stepX
+	case event.Key() == tcell.KeyUp || a.HitShortcut(event, a.keys().MoveUp, a.keys().MoveUp2):
+		dy = -stepY
+	case event.Key() == tcell.KeyDown || a.HitShortcut(event, a.keys().MoveDown, a.keys().MoveDown2):
+		dy = stepY
+	default:
+		return true
+	}
+
+	if window != nil {
+		arrangeWindow(window, dx, dy, event.Modifiers()&tcell.ModShift != 0)
+	} else if flex.GetDirection() == FlexColumn {
+		flex.MoveSplitter(item, dx)
+	} else {
+		flex.MoveSplitter(item, dy)
+	}
+	return true
+}
+
+// arrangeWindow moves the window by the provided number of columns and rows,
+// or resizes it if resize is true. Fullscreen windows are not changed.
+func arrangeWindow(w *Window, dx, dy int, resize bool) {
+	w.RLock()
+	fullscreen := w.fullscreen
+	w.RUnlock()
+	if fullscreen {
+		return
+	}
+
+	x, y, width, height := w.GetRect()
+	if resize {
+		theme := w.theme()
+		width = max(width+dx, theme.WindowMinWidth)
+		height = max(height+dy, theme.WindowMinHeight)
+	} else {
+		x, y = x+dx, y+dy
+	}
+	w.SetRect(x, y, width, height)
+}
+
+// findArrangeTarget returns the focused window below the provided primitive
+// or, if there is none, the innermost flex containing the focused primitive
+// and its item which contains the focused primitive.
+func findArrangeTarget(p Primitive) (window *Window, flex *Flex, item Primitive) {
+	for p != nil {
+		if w, ok := p.(*Window); ok {
+			return w, nil, nil
+		}
+		var next Primitive
+		for _, child := range childPrimitives(p) {
+			if child != nil && child.GetFocusable().HasFocus() {
+				next = child
+				break
+			}
+		}
+		if f, ok := p.(*Flex); ok && next != nil {
+			flex, item = f, next
+		}
+		p = next
+	}
+	return nil, flex, item
+}
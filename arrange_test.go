@@ -0,0 +1,66 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestArrange(t *testing.T) {
+	t.Parallel()
+
+	key := func(k tcell.Key, mod tcell.ModMask) *tcell.EventKey {
+		return tcell.NewEventKey(k, 0, mod)
+	}
+
+	// Splitters
+
+	left, right := NewBox(), NewBox()
+	flex := NewFlex()
+	flex.AddItem(left, 0, 1, true)
+	flex.AddItem(right, 0, 1, false)
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.EnableArrange(true)
+	app.SetArrangeStep(2, 1)
+	app.SetRoot(flex, false)
+	flex.SetRect(0, 0, 80, 24)
+	app.draw()
+	app.SetFocus(left)
+
+	app.dispatchKey(key(tcell.KeyCtrlW, tcell.ModCtrl), "")
+	if !app.IsArranging() {
+		t.Fatal("failed to enter arrange mode")
+	}
+	app.dispatchKey(key(tcell.KeyRight, tcell.ModNone), "")
+	app.dispatchKey(key(tcell.KeyRight, tcell.ModNone), "")
+	if _, _, width, _ := left.GetRect(); width != 44 {
+		t.Errorf("failed to move splitter: expected width 44, got %d", width)
+	}
+	app.dispatchKey(key(tcell.KeyEscape, tcell.ModNone), "")
+	if app.IsArranging() {
+		t.Error("failed to leave arrange mode")
+	}
+
+	// Windows
+
+	w := NewWindow(NewBox())
+	w.SetRect(10, 5, 20, 10)
+	wm := NewWindowManager()
+	wm.Add(w)
+	app.SetRoot(wm, false)
+	wm.SetRect(0, 0, 80, 24)
+	app.SetFocus(w)
+
+	app.dispatchKey(key(tcell.KeyCtrlW, tcell.ModCtrl), "")
+	app.dispatchKey(key(tcell.KeyDown, tcell.ModNone), "")
+	app.dispatchKey(key(tcell.KeyLeft, tcell.ModShift), "")
+	if x, y, width, height := w.GetRect(); x != 10 || y != 6 || width != 18 || height != 10 {
+		t.Errorf("failed to arrange window: expected rect 10,6,18,10, got %d,%d,%d,%d", x, y, width, height)
+	}
+}
@@ -0,0 +1,53 @@
+package nuview
+
+import "github.com/gdamore/tcell/v2"
+
+// BorderRenderer draws the border and the title of a Box in place of the
+// default border, e.g. to integrate tabs into the top border or to show status
+// indicators in its corners. Set it with Box.SetBorderRenderer().
+//
+// Renderers are invoked while the box is drawn, after its background was
+// filled and before its content is drawn. The box is locked at that time so
+// renderers must not call its functions.
+type BorderRenderer interface {
+	// DrawBorder draws the border described by the provided frame. The
+	// inner area of the box is drawn afterwards and may overwrite anything
+	// drawn inside the border.
+	DrawBorder(screen tcell.Screen, frame BorderFrame)
+}
+
+// BorderFrame describes the border of a box drawn by a BorderRenderer.
+type BorderFrame struct {
+	// The position and size of the box, including the border.
+	X, Y, Width, Height int
+
+	// The style of the border. Its colors and attributes already reflect
+	// whether or not the box has focus (see Box.SetBorderColorFocused).
+	Style tcell.Style
+
+	// Whether or not the border is drawn with the focus runes of the
+	// application's borders, i.e. whether the box has focus and shows it (see
+	// Box.ShowFocus).
+	Focused bool
+
+	// The title of the box, which may contain style tags, its alignment and
+	// its color.
+	Title      string
+	TitleAlign int
+	TitleColor tcell.Color
+}
+
+// DefaultBorderRenderer draws the border boxes draw without a border renderer:
+// the border runes of the application (see Application.SetBorders) with the
+// title in the top border. Custom renderers may call it to decorate the
+// default border.
+type DefaultBorderRenderer struct{}
+
+// DrawBorder draws the default border and the title.
+func (DefaultBorderRenderer) DrawBorder(screen tcell.Screen, frame BorderFrame) {
+	DrawBorder(screen, frame.X, frame.Y, frame.Width, frame.Height, frame.Style, frame.Focused)
+	if len(frame.Title) > 0 && frame.Width >= 4 {
+		title := TruncateTagged(frame.Title, frame.Width-2, TruncateEnd)
+		Print(screen, []byte(title), frame.X+1, frame.Y, frame.Width-2, frame.TitleAlign, frame.TitleColor)
+	}
+}
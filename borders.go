@@ -1,8 +1,7 @@
 package nuview
 
-// Borders defines various borders used when primitives are drawn.
-// These may be changed to accommodate a different look and feel.
-var Borders = struct {
+// BorderSet defines the runes of the borders drawn by primitives.
+type BorderSet struct {
 	Horizontal  rune
 	Vertical    rune
 	TopLeft     rune
@@ -22,7 +21,12 @@ var Borders = struct {
 	TopRightFocus    rune
 	BottomLeftFocus  rune
 	BottomRightFocus rune
-}{
+}
+
+// Borders defines various borders used when primitives are drawn.
+// These may be changed to accommodate a different look and feel. An
+// application may use its own borders instead, see Application.SetBorders().
+var Borders = BorderSet{
 	Horizontal:  BoxDrawingsLightHorizontal,
 	Vertical:    BoxDrawingsLightVertical,
 	TopLeft:     BoxDrawingsLightDownAndRight,
@@ -2,10 +2,17 @@ package nuview
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// keyBinding is a handler for a key event installed with Box.AddKeyBinding().
+type keyBinding struct {
+	key     string
+	handler func(event *tcell.EventKey) *tcell.EventKey
+}
+
 // Box is the base Primitive for all widgets. It has a background color and
 // optional surrounding elements such as a border and a title. It does not have
 // inner text. Widgets embed Box and draw their text over it.
@@ -33,6 +40,10 @@ type Box struct {
 	// Whether or not the box's background is transparent.
 	backgroundTransparent bool
 
+	// Whether or not the box's background is left to the terminal, see
+	// SetDefaultBackground().
+	defaultBackground bool
+
 	// Whether or not a border is drawn, reducing the box's space for content by
 	// two in width and height.
 	border bool
@@ -52,6 +63,10 @@ type Box struct {
 	// The alignment of the title.
 	titleAlign int
 
+	// An optional renderer which draws the border and the title in place of
+	// the default border.
+	borderRenderer BorderRenderer
+
 	// Provides a way to find out if this box has focus. We always go through
 	// this interface because it may be overridden by implementing classes.
 	focus Focusable
@@ -67,14 +82,29 @@ type Box struct {
 	// nothing should be forwarded).
 	inputCapture func(event *tcell.EventKey) *tcell.EventKey
 
+	// Key bindings which are checked after the input capture function and
+	// before the primitive's default input handler, in the order they were added.
+	keyBindings []*keyBinding
+
 	// An optional function which is called before the box is drawn.
 	draw func(screen tcell.Screen, x, y, width, height int) (int, int, int, int)
 
+	// Optional scrollable content, see SetScrollableContent().
+	content *boxContent
+
 	// An optional capture function which receives a mouse event and returns the
 	// event to be forwarded to the primitive's default mouse event handler (at
 	// least one nil if nothing should be forwarded).
 	mouseCapture func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse)
 
+	// Optional functions which are called when the box is double-clicked with
+	// the left mouse button or clicked with the right mouse button.
+	doubleClicked, rightClicked func(x, y int) bool
+
+	// The application which last drew the box, nil if none did. Its
+	// configuration is used instead of the global variables.
+	app atomic.Pointer[Application]
+
 	l sync.RWMutex
 }
 
@@ -124,6 +154,21 @@ func (b *Box) updateInnerRect() {
 	b.innerX, b.innerY, b.innerWidth, b.innerHeight = x, y, width, height
 }
 
+// frameSize returns the horizontal and vertical space taken up by the border
+// and the padding of the box.
+func (b *Box) frameSize() (width, height int) {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	width = b.paddingLeft + b.paddingRight
+	height = b.paddingTop + b.paddingBottom
+	if b.border {
+		width += 2
+		height += 2
+	}
+	return width, height
+}
+
 // Impl GetName() and SetName() to satisfy the Named interface.
 func (b *Box) GetName() string {
 	b.l.RLock()
@@ -196,6 +241,16 @@ func (b *Box) SetVisible(v bool) {
 	b.visible = v
 }
 
+// ToggleVisible shows the box if it is hidden and hides it otherwise. Hidden
+// primitives are not drawn. Layouts (Flex, Grid, Form) allocate no space for
+// them and skip them when passing on the focus.
+func (b *Box) ToggleVisible() {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.visible = !b.visible
+}
+
 // GetVisible returns a value indicating whether or not the box is visible.
 func (b *Box) GetVisible() bool {
 	b.l.RLock()
@@ -204,6 +259,26 @@ func (b *Box) GetVisible() bool {
 	return b.visible
 }
 
+// SetBorderRenderer sets a renderer which draws the border and the title of the
+// box in place of the default border, see BorderRenderer. It is only used if
+// the box has a border (see SetBorder()). Provide nil to draw the default
+// border again.
+func (b *Box) SetBorderRenderer(renderer BorderRenderer) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.borderRenderer = renderer
+}
+
+// GetBorderRenderer returns the renderer which was set with
+// SetBorderRenderer() or nil if none was set.
+func (b *Box) GetBorderRenderer() BorderRenderer {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	return b.borderRenderer
+}
+
 // SetDrawFunc sets a callback function which is invoked after the box primitive
 // has been drawn. This allows you to add a more individual style to the box
 // (and all primitives which extend it).
@@ -228,9 +303,152 @@ func (b *Box) GetDrawFunc() func(screen tcell.Screen, x, y, width, height int) (
 	return b.draw
 }
 
+// boxContent is the scrollable content of a box.
+type boxContent struct {
+	// The height of the content and the function which draws it.
+	height int
+	draw   func(screen ScreenWriter, width int)
+
+	// The index of the first line shown in the box.
+	offset int
+
+	// The number of lines shown as of the last call to Draw().
+	pageSize int
+
+	// The vertical scroll bar.
+	scrollBar *ScrollBar
+}
+
+// SetScrollableContent lets the box host custom-drawn content which may be
+// taller than the box. The provided function draws content of the given
+// height (in lines) whenever the box is drawn. It receives a ScreenWriter
+// whose coordinates are relative to the top left corner of the content, and
+// the width available to the content. The box shows the lines of the content
+// which fit into its inner rect (see GetInnerRect()) and discards anything
+// drawn outside of them.
+//
+// A plain Box then scrolls the content with the arrow keys, Page Up/Down,
+// Home/End (see Keys) and the mouse wheel, and shows a scroll bar when the
+// content is taller than the box. Primitives embedding Box draw the content
+// but keep their own key and mouse handling.
+//
+// Call this function again when the height of the content changes, the scroll
+// position is kept. Provide a nil function to remove the content.
+func (b *Box) SetScrollableContent(height int, draw func(screen ScreenWriter, width int)) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if draw == nil {
+		b.content = nil
+		return
+	}
+	if b.content == nil {
+		b.content = &boxContent{scrollBar: NewScrollBar()}
+	}
+	b.content.height, b.content.draw = max(height, 0), draw
+}
+
+// SetContentOffset scrolls the scrollable content (see SetScrollableContent())
+// so that the line at the given offset is shown at the top of the box.
+func (b *Box) SetContentOffset(offset int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.content != nil {
+		b.content.offset = offset
+		b.clampContentOffset()
+	}
+}
+
+// GetContentOffset returns the index of the first line of the scrollable
+// content (see SetScrollableContent()) shown in the box.
+func (b *Box) GetContentOffset() int {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	if b.content == nil {
+		return 0
+	}
+	return b.content.offset
+}
+
+// GetContentScrollBar returns the scroll bar of the scrollable content (see
+// SetScrollableContent()), nil if there is no such content.
+func (b *Box) GetContentScrollBar() *ScrollBar {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	if b.content == nil {
+		return nil
+	}
+	return b.content.scrollBar
+}
+
+// clampContentOffset keeps the offset of the scrollable content in the valid
+// range. The box must be locked.
+func (b *Box) clampContentOffset() {
+	c := b.content
+	c.offset = max(min(c.offset, c.height-c.pageSize), 0)
+}
+
+// drawContent draws the scrollable content and its scroll bar.
+func (b *Box) drawContent(screen tcell.Screen) {
+	b.l.Lock()
+	c := b.content
+	if c == nil {
+		b.l.Unlock()
+		return
+	}
+	x, y, width, height := b.innerX, b.innerY, b.innerWidth, b.innerHeight
+	showScrollBar := c.scrollBar.IsVisible(c.height, height)
+	if showScrollBar {
+		width--
+	}
+	c.pageSize = height
+	b.clampContentOffset()
+	offset, contentHeight, draw := c.offset, c.height, c.draw
+	hasFocus := b.hasFocus
+	b.l.Unlock()
+
+	if width > 0 && height > 0 {
+		draw(NewClippingScreenWriter(screen, x, y, width, height).NewTranslate(0, -offset), width)
+	}
+	c.scrollBar.Draw(screen, x+width, y, height, contentHeight, height, offset, hasFocus)
+}
+
+// scrollContent handles the keys which scroll the scrollable content. It
+// returns whether or not the event was consumed.
+func (b *Box) scrollContent(event *tcell.EventKey) bool {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	c := b.content
+	if c == nil {
+		return false
+	}
+	switch {
+	case b.hitShortcut(event, b.keys().MoveFirst, b.keys().MoveFirst2):
+		c.offset = 0
+	case b.hitShortcut(event, b.keys().MoveLast, b.keys().MoveLast2):
+		c.offset = c.height
+	case b.hitShortcut(event, b.keys().MoveUp, b.keys().MoveUp2):
+		c.offset--
+	case b.hitShortcut(event, b.keys().MoveDown, b.keys().MoveDown2):
+		c.offset++
+	case b.hitShortcut(event, b.keys().MovePreviousPage):
+		c.offset -= c.pageSize
+	case b.hitShortcut(event, b.keys().MoveNextPage):
+		c.offset += c.pageSize
+	default:
+		return false
+	}
+	b.clampContentOffset()
+	return true
+}
+
 // WrapInputHandler wraps an input handler (see InputHandler()) with the
-// functionality to capture input (see SetInputCapture()) before passing it
-// on to the provided (default) input handler.
+// functionality to capture input (see SetInputCapture() and AddKeyBinding())
+// before passing it on to the provided (default) input handler.
 //
 // This is only meant to be used by subclassing primitives.
 func (b *Box) WrapInputHandler(inputHandler func(*tcell.EventKey, func(p Primitive))) func(*tcell.EventKey, func(p Primitive)) {
@@ -238,18 +456,21 @@ func (b *Box) WrapInputHandler(inputHandler func(*tcell.EventKey, func(p Primiti
 		if b.inputCapture != nil {
 			event = b.inputCapture(event)
 		}
+		if event != nil {
+			event = b.handleKeyBindings(event)
+		}
 		if event != nil && inputHandler != nil {
 			inputHandler(event, setFocus)
 		}
 	}
 }
 
-// InputHandler returns nil.
+// InputHandler returns the handler for this primitive. It scrolls the
+// scrollable content, if any (see SetScrollableContent()).
 func (b *Box) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
-	b.l.RLock()
-	defer b.l.RUnlock()
-
-	return b.WrapInputHandler(nil)
+	return b.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		b.scrollContent(event)
+	})
 }
 
 // SetInputCapture installs a function which captures key events before they are
@@ -282,16 +503,96 @@ func (b *Box) GetInputCapture() func(event *tcell.EventKey) *tcell.EventKey {
 	return b.inputCapture
 }
 
+// AddKeyBinding installs a handler for the provided keybinding, e.g. "Ctrl+D"
+// or "Alt+Enter" (see Keys for the format; key chords must also be registered
+// with Application.AddKeyChords()). The handler is called instead of the
+// primitive's default key event handler, after any input capture function
+// (see SetInputCapture()). It may return the event (or a different one) to
+// forward it to the default handler, or nil to consume it.
+//
+// If a handler is already installed for the keybinding, it is replaced.
+// Like input capture functions, key bindings have no effect on primitives
+// composed of other primitives, such as Form, Flex, or Grid.
+func (b *Box) AddKeyBinding(key string, handler func(event *tcell.EventKey) *tcell.EventKey) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	for _, binding := range b.keyBindings {
+		if binding.key == key {
+			binding.handler = handler
+			return
+		}
+	}
+	b.keyBindings = append(b.keyBindings, &keyBinding{key: key, handler: handler})
+}
+
+// RemoveKeyBinding removes the handler for the provided keybinding which was
+// installed with AddKeyBinding(). Nothing happens if there is no such handler.
+func (b *Box) RemoveKeyBinding(key string) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	for index, binding := range b.keyBindings {
+		if binding.key == key {
+			b.keyBindings = append(b.keyBindings[:index], b.keyBindings[index+1:]...)
+			return
+		}
+	}
+}
+
+// hasKeyBinding returns whether or not a handler is installed for the
+// keybinding matching the provided event, see AddKeyBinding().
+func (b *Box) hasKeyBinding(event *tcell.EventKey) bool {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	for _, binding := range b.keyBindings {
+		if b.hitShortcut(event, []string{binding.key}) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKeyBindings calls the key binding handler matching the provided event
+// and returns the event to be forwarded to the default input handler.
+func (b *Box) handleKeyBindings(event *tcell.EventKey) *tcell.EventKey {
+	b.l.RLock()
+	var handler func(event *tcell.EventKey) *tcell.EventKey
+	for _, binding := range b.keyBindings {
+		if b.hitShortcut(event, []string{binding.key}) {
+			handler = binding.handler
+			break
+		}
+	}
+	b.l.RUnlock()
+
+	if handler == nil {
+		return event
+	}
+	return handler(event)
+}
+
 // WrapMouseHandler wraps a mouse event handler (see MouseHandler()) with the
-// functionality to capture mouse events (see SetMouseCapture()) before passing
-// them on to the provided (default) event handler.
+// functionality to capture mouse events (see SetMouseCapture()) and to handle
+// double and right clicks (see SetDoubleClickedFunc() and
+// SetRightClickedFunc()) before passing them on to the provided (default)
+// event handler.
 //
 // This is only meant to be used by subclassing primitives.
 func (b *Box) WrapMouseHandler(mouseHandler func(MouseAction, *tcell.EventMouse, func(p Primitive)) (bool, Primitive)) func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if app := b.app.Load(); app != nil && action == MouseMove && b.InRect(event.Position()) {
+			if p, ok := b.focus.(Primitive); ok && app.mouseHover.enter(p) {
+				defer app.mouseHover.leave(p)
+			}
+		}
 		if b.mouseCapture != nil {
 			action, event = b.mouseCapture(action, event)
 		}
+		if event != nil && b.handleClicks(action, event) {
+			return true, nil
+		}
 		if event != nil && mouseHandler != nil {
 			consumed, capture = mouseHandler(action, event, setFocus)
 		}
@@ -299,12 +600,67 @@ func (b *Box) WrapMouseHandler(mouseHandler func(MouseAction, *tcell.EventMouse,
 	}
 }
 
-// MouseHandler returns nil.
+// handleClicks calls the double-clicked or the right-clicked handler if the
+// provided mouse action is such a click within the box. It returns whether or
+// not the handler consumed the event.
+func (b *Box) handleClicks(action MouseAction, event *tcell.EventMouse) bool {
+	b.l.RLock()
+	var handler func(x, y int) bool
+	switch action {
+	case MouseLeftDoubleClick:
+		handler = b.doubleClicked
+	case MouseRightClick:
+		handler = b.rightClicked
+	}
+	b.l.RUnlock()
+
+	x, y := event.Position()
+	if handler == nil || !b.InRect(x, y) {
+		return false
+	}
+	return handler(x, y)
+}
+
+// MouseHandler returns the mouse handler for this primitive. It scrolls the
+// scrollable content, if any (see SetScrollableContent()).
 func (b *Box) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return b.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
-		if action == MouseLeftClick && b.InRect(event.Position()) {
+		inRect := b.InRect(event.Position())
+
+		b.l.Lock()
+		defer b.l.Unlock()
+
+		// Pass events to the scroll bar.
+		if c := b.content; c != nil {
+			if offset, ok, dragging := c.scrollBar.HandleMouse(action, event); ok {
+				c.offset = offset
+				b.clampContentOffset()
+				if dragging {
+					capture = b
+				}
+				return true, capture
+			}
+		}
+
+		if !inRect {
+			return false, nil
+		}
+		switch action {
+		case MouseLeftClick:
+			b.l.Unlock()
 			setFocus(b)
+			b.l.Lock()
 			consumed = true
+		case MouseScrollUp, MouseScrollDown:
+			if c := b.content; c != nil {
+				if action == MouseScrollUp {
+					c.offset--
+				} else {
+					c.offset++
+				}
+				b.clampContentOffset()
+				consumed = true
+			}
 		}
 		return
 	})
@@ -322,6 +678,33 @@ func (b *Box) SetMouseCapture(capture func(action MouseAction, event *tcell.Even
 	b.mouseCapture = capture
 }
 
+// SetDoubleClickedFunc sets a handler which is called when the box is
+// double-clicked with the left mouse button, with the screen coordinates of
+// the mouse. Double clicks are recognized by the application, see
+// Application.SetDoubleClickInterval(). If the handler returns true, the event
+// is not passed on to the primitive's default mouse handler. This works for
+// all primitives. Note that containers such as Flex receive the event before
+// the primitives they contain. Provide nil to remove the handler.
+func (b *Box) SetDoubleClickedFunc(handler func(x, y int) bool) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.doubleClicked = handler
+}
+
+// SetRightClickedFunc sets a handler which is called when the box is clicked
+// with the right mouse button, with the screen coordinates of the mouse, e.g.
+// to show a context menu. If the handler returns true, the event is not
+// passed on to the primitive's default mouse handler. This works for all
+// primitives. Note that containers such as Flex receive the event before the
+// primitives they contain. Provide nil to remove the handler.
+func (b *Box) SetRightClickedFunc(handler func(x, y int) bool) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.rightClicked = handler
+}
+
 // InRect returns true if the given coordinate is within the bounds of the box's
 // rectangle.
 func (b *Box) InRect(x, y int) bool {
@@ -363,6 +746,39 @@ func (b *Box) SetBackgroundTransparent(transparent bool) {
 	b.backgroundTransparent = transparent
 }
 
+// SetDefaultBackground sets the flag indicating whether or not the box leaves
+// the background of its cells unset (tcell.ColorDefault) instead of filling
+// them with its background color. This lets the terminal's own background,
+// e.g. a background image or a transparent window, show through the box and
+// its contents. Unlike SetBackgroundTransparent, the box still clears its area
+// before drawing. The background color is retained and used again once this
+// flag is reset.
+func (b *Box) SetDefaultBackground(defaultBackground bool) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.defaultBackground = defaultBackground
+}
+
+// GetDefaultBackground returns whether or not the box leaves the background
+// of its cells to the terminal.
+func (b *Box) GetDefaultBackground() bool {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	return b.defaultBackground
+}
+
+// backgroundFill returns the color with which the box's background is filled:
+// tcell.ColorDefault if the background is left to the terminal, the background
+// color otherwise.
+func (b *Box) backgroundFill() tcell.Color {
+	if b.defaultBackground {
+		return tcell.ColorDefault
+	}
+	return b.backgroundColor
+}
+
 // GetBorder returns a value indicating whether the box have a border
 // or not.
 func (b *Box) GetBorder() bool {
@@ -443,6 +859,10 @@ func (b *Box) SetTitleAlign(align int) {
 
 // Draw draws this primitive onto the screen.
 func (b *Box) Draw(screen tcell.Screen) {
+	if app := applicationOf(screen); app != nil {
+		b.app.Store(app)
+	}
+
 	b.l.Lock()
 	defer b.l.Unlock()
 
@@ -459,19 +879,14 @@ func (b *Box) Draw(screen tcell.Screen) {
 	def := tcell.StyleDefault
 
 	// Fill background.
-	background := def.Background(b.backgroundColor)
+	background := def.Background(b.backgroundFill())
 	if !b.backgroundTransparent {
-		for y := b.y; y < b.y+b.height; y++ {
-			for x := b.x; x < b.x+b.width; x++ {
-				screen.SetContent(x, y, ' ', nil, background)
-			}
-		}
+		FillRect(screen, b.x, b.y, b.width, b.height, ' ', background)
 	}
 
 	// Draw border.
 	if b.border && b.width >= 2 && b.height >= 2 {
 		border := SetAttributes(background.Foreground(b.borderColor), b.borderAttributes)
-		var vertical, horizontal, topLeft, topRight, bottomLeft, bottomRight rune
 
 		var hasFocus bool
 		if b.focus == b {
@@ -484,49 +899,34 @@ func (b *Box) Draw(screen tcell.Screen) {
 			border = SetAttributes(background.Foreground(b.borderColorFocused), b.borderAttributes)
 		}
 
-		if hasFocus && b.showFocus {
-			horizontal = Borders.HorizontalFocus
-			vertical = Borders.VerticalFocus
-			topLeft = Borders.TopLeftFocus
-			topRight = Borders.TopRightFocus
-			bottomLeft = Borders.BottomLeftFocus
-			bottomRight = Borders.BottomRightFocus
-		} else {
-			horizontal = Borders.Horizontal
-			vertical = Borders.Vertical
-			topLeft = Borders.TopLeft
-			topRight = Borders.TopRight
-			bottomLeft = Borders.BottomLeft
-			bottomRight = Borders.BottomRight
-		}
-		for x := b.x + 1; x < b.x+b.width-1; x++ {
-			screen.SetContent(x, b.y, horizontal, nil, border)
-			screen.SetContent(x, b.y+b.height-1, horizontal, nil, border)
-		}
-		for y := b.y + 1; y < b.y+b.height-1; y++ {
-			screen.SetContent(b.x, y, vertical, nil, border)
-			screen.SetContent(b.x+b.width-1, y, vertical, nil, border)
-		}
-		screen.SetContent(b.x, b.y, topLeft, nil, border)
-		screen.SetContent(b.x+b.width-1, b.y, topRight, nil, border)
-		screen.SetContent(b.x, b.y+b.height-1, bottomLeft, nil, border)
-		screen.SetContent(b.x+b.width-1, b.y+b.height-1, bottomRight, nil, border)
-
-		// Draw title.
-		if len(b.title) > 0 && b.width >= 4 {
-			printed, _ := Print(screen, b.title, b.x+1, b.y, b.width-2, b.titleAlign, b.titleColor)
-			if len(b.title)-printed > 0 && printed > 0 {
-				_, _, style, _ := screen.GetContent(b.x+b.width-2, b.y)
-				fg, _, _ := style.Decompose()
-				Print(screen, []byte(string(SemigraphicsHorizontalEllipsis)), b.x+b.width-2, b.y, 1, AlignLeft, fg)
-			}
+		renderer := b.borderRenderer
+		if renderer == nil {
+			renderer = DefaultBorderRenderer{}
 		}
+		renderer.DrawBorder(screen, BorderFrame{
+			X:          b.x,
+			Y:          b.y,
+			Width:      b.width,
+			Height:     b.height,
+			Style:      border,
+			Focused:    hasFocus && b.showFocus,
+			Title:      string(b.title),
+			TitleAlign: b.titleAlign,
+			TitleColor: b.titleColor,
+		})
 	}
 
 	// Call custom draw function.
 	if b.draw != nil {
 		b.innerX, b.innerY, b.innerWidth, b.innerHeight = b.draw(screen, b.x, b.y, b.width, b.height)
 	}
+
+	// Draw the scrollable content.
+	if b.content != nil {
+		b.l.Unlock()
+		b.drawContent(screen)
+		b.l.Lock()
+	}
 }
 
 // ShowFocus sets the flag indicating whether or not the borders of this
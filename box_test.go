@@ -1,7 +1,11 @@
 package nuview
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -53,4 +57,167 @@ func TestBox(t *testing.T) {
 	}
 
 	b.Draw(app.screen)
+
+	// Default background
+
+	b.SetBackgroundColor(tcell.ColorBlue)
+	b.SetDefaultBackground(true)
+	b.Draw(app.screen)
+	if _, _, style, _ := app.screen.GetContent(0, 0); style != tcell.StyleDefault {
+		t.Errorf("failed to draw Box: incorrect background: expected default style, got %v", style)
+	}
+
+	b.SetDefaultBackground(false)
+	b.Draw(app.screen)
+	if _, _, style, _ := app.screen.GetContent(0, 0); style != tcell.StyleDefault.Background(tcell.ColorBlue) {
+		t.Errorf("failed to draw Box: incorrect background: expected %v, got %v", tcell.StyleDefault.Background(tcell.ColorBlue), style)
+	}
+}
+
+func TestBoxKeyBinding(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+
+	var called int
+	l.AddKeyBinding("Down", func(event *tcell.EventKey) *tcell.EventKey {
+		called++
+		return nil
+	})
+
+	down := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	l.InputHandler()(down, func(p Primitive) {})
+	if called != 1 {
+		t.Errorf("failed to call key binding: expected 1 call, got %d", called)
+	} else if l.GetCurrentItemIndex() != 0 {
+		t.Errorf("failed to consume key event: expected current item 0, got %d", l.GetCurrentItemIndex())
+	}
+
+	l.RemoveKeyBinding("Down")
+	l.InputHandler()(down, func(p Primitive) {})
+	if called != 1 {
+		t.Errorf("failed to remove key binding: expected 1 call, got %d", called)
+	} else if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to forward key event: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+}
+
+func TestBoxClickedFuncs(t *testing.T) {
+	t.Parallel()
+
+	b := NewBox()
+	b.SetRect(0, 0, 10, 5)
+
+	var clicks []string
+	b.SetDoubleClickedFunc(func(x, y int) bool {
+		clicks = append(clicks, fmt.Sprintf("double %d,%d", x, y))
+		return true
+	})
+	b.SetRightClickedFunc(func(x, y int) bool {
+		clicks = append(clicks, fmt.Sprintf("right %d,%d", x, y))
+		return false
+	})
+
+	handler := b.MouseHandler()
+	if consumed, _ := handler(MouseLeftDoubleClick, tcell.NewEventMouse(2, 3, tcell.ButtonNone, tcell.ModNone), func(Primitive) {}); !consumed {
+		t.Error("failed to consume double click")
+	}
+	handler(MouseRightClick, tcell.NewEventMouse(4, 1, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	handler(MouseRightClick, tcell.NewEventMouse(20, 1, tcell.ButtonNone, tcell.ModNone), func(Primitive) {}) // Outside.
+	if fmt.Sprint(clicks) != "[double 2,3 right 4,1]" {
+		t.Errorf("failed to call click handlers: expected [double 2,3 right 4,1], got %v", clicks)
+	}
+}
+
+func TestBoxScrollableContent(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 5)
+
+	b := NewBox()
+	b.SetRect(0, 0, 10, 5)
+	b.SetScrollableContent(20, func(screen ScreenWriter, width int) {
+		for y := 0; y < 20; y++ {
+			screen.SetContent(0, y, rune('a'+y), nil, tcell.StyleDefault)
+		}
+	})
+
+	b.Draw(sc)
+	if r, _, _, _ := sc.GetContent(0, 0); r != 'a' {
+		t.Errorf("failed to draw content: expected 'a', got %q", r)
+	}
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone), func(p Primitive) {})
+	if offset := b.GetContentOffset(); offset != 6 {
+		t.Errorf("failed to scroll content: expected offset 6, got %d", offset)
+	}
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone), func(p Primitive) {})
+	if offset := b.GetContentOffset(); offset != 15 {
+		t.Errorf("failed to scroll to end: expected offset 15, got %d", offset)
+	}
+	sc.Clear()
+	b.Draw(sc)
+	if r, _, _, _ := sc.GetContent(0, 4); r != 't' {
+		t.Errorf("failed to draw scrolled content: expected 't', got %q", r)
+	}
+
+	b.MouseHandler()(MouseScrollUp, tcell.NewEventMouse(1, 1, tcell.WheelUp, tcell.ModNone), func(p Primitive) {})
+	if offset := b.GetContentOffset(); offset != 14 {
+		t.Errorf("failed to scroll with mouse wheel: expected offset 14, got %d", offset)
+	}
+}
+
+// statusBorderRenderer draws the default border with a status dot in the top
+// right corner.
+type statusBorderRenderer struct {
+	frames []BorderFrame
+}
+
+func (r *statusBorderRenderer) DrawBorder(screen tcell.Screen, frame BorderFrame) {
+	r.frames = append(r.frames, frame)
+	DefaultBorderRenderer{}.DrawBorder(screen, frame)
+	screen.SetContent(frame.X+frame.Width-1, frame.Y, '●', nil, frame.Style)
+}
+
+func TestBoxBorderRenderer(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	renderer := &statusBorderRenderer{}
+	b := NewBox()
+	b.SetRect(0, 0, 10, 3)
+	b.SetTitle("ab")
+	b.SetTitleAlign(AlignLeft)
+	b.SetBorderRenderer(renderer)
+	b.Draw(sc)
+	if len(renderer.frames) != 0 {
+		t.Errorf("failed to skip renderer without border: got %d calls", len(renderer.frames))
+	}
+
+	b.SetBorder(true)
+	b.Draw(sc)
+	if len(renderer.frames) != 1 || renderer.frames[0].Title != "ab" || renderer.frames[0].Width != 10 {
+		t.Fatalf("failed to call renderer: got %+v", renderer.frames)
+	}
+	var text string
+	for x := 0; x < 10; x++ {
+		r, _, _, _ := sc.GetContent(x, 0)
+		text += string(r)
+	}
+	if text != string(Borders.TopLeft)+"ab"+strings.Repeat(string(Borders.Horizontal), 6)+"●" {
+		t.Errorf("failed to draw custom border: got %q", text)
+	}
 }
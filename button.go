@@ -2,6 +2,7 @@ package nuview
 
 import (
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -16,28 +17,27 @@ type Button struct {
 	// The text to be displayed before the input area.
 	label []byte
 
-	// The label color.
-	labelColor tcell.Color
+	// The styles of the button. The normal background is the box's background
+	// color.
+	styles ButtonStyles
 
-	// The label color when the button is in focus.
-	labelFocusedColor tcell.Color
+	// An optional function which is called when the button was selected.
+	selected func()
 
-	// The background color when the button is in focus.
-	backgroundFocusedColor tcell.Color
+	// Shows the pressed state after the button was activated by keyboard.
+	press pressedState
 
-	backgroundDisabledColor tcell.Color
-	labelDisabledColor      tcell.Color
+	// Whether or not the button's action is running, and since when.
+	loading      bool
+	loadingSince time.Time
 
-	// An optional function which is called when the button was selected.
-	selected func()
+	// The label shown while loading, the regular label if empty.
+	loadingLabel []byte
 
 	// An optional function which is called when the user leaves the button. A
 	// key is provided indicating which key was pressed to leave (tab or backtab).
 	blur func(tcell.Key)
 
-	// An optional rune which is drawn after the label when the button is focused.
-	cursorRune rune
-
 	sync.RWMutex
 }
 
@@ -45,20 +45,43 @@ type Button struct {
 func NewButton(label string) *Button {
 	box := NewBox()
 	box.SetRect(0, 0, TaggedStringWidth(label)+4, 1)
-	box.SetBackgroundColor(Styles.ButtonBackgroundColor)
+	_, background, _ := Styles.Button.Normal.Decompose()
+	box.SetBackgroundColor(background)
 	return &Button{
-		Box:                     box,
-		enabled:                 true,
-		label:                   []byte(label),
-		labelColor:              Styles.ButtonLabelColor,
-		labelFocusedColor:       Styles.ButtonLabelFocusedColor,
-		cursorRune:              Styles.ButtonCursorRune,
-		backgroundFocusedColor:  Styles.ButtonBackgroundFocusedColor,
-		backgroundDisabledColor: Styles.ButtonBackgroundDisabledColor,
-		labelDisabledColor:      Styles.ButtonLabelDisabledColor,
+		Box:     box,
+		enabled: true,
+		label:   []byte(label),
+		styles:  Styles.Button,
 	}
 }
 
+// SetStyles sets the styles of the button, overriding Styles.Button.
+func (b *Button) SetStyles(styles ButtonStyles) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.styles = styles
+	_, background, _ := styles.Normal.Decompose()
+	b.Box.SetBackgroundColor(background)
+}
+
+// GetStyles returns the styles of the button.
+func (b *Button) GetStyles() ButtonStyles {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.styles
+}
+
+// SetBackgroundColor sets the background color of the button.
+func (b *Button) SetBackgroundColor(color tcell.Color) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.styles.Normal = b.styles.Normal.Background(color)
+	b.Box.SetBackgroundColor(color)
+}
+
 // SetLabel sets the button text.
 func (b *Button) SetLabel(label string) {
 	b.Lock()
@@ -75,12 +98,39 @@ func (b *Button) GetLabel() string {
 	return string(b.label)
 }
 
+// PreferredWidth returns the width of the label plus some space around it and
+// the border and padding. While loading, the spinner is included.
+func (b *Button) PreferredWidth(maxWidth int) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	frameWidth, _ := b.frameSize()
+	width := TaggedTextWidth(b.label) + 4 + frameWidth
+	if b.loading {
+		label := b.label
+		if len(b.loadingLabel) > 0 {
+			label = b.loadingLabel
+		}
+		width = max(width, TaggedTextWidth(label)+6+frameWidth) // Spinner and space.
+	}
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// PreferredHeight returns the height of the label plus the border and padding.
+func (b *Button) PreferredHeight(width int) int {
+	_, frameHeight := b.frameSize()
+	return 1 + frameHeight
+}
+
 // SetLabelColor sets the color of the button text.
 func (b *Button) SetLabelColor(color tcell.Color) {
 	b.Lock()
 	defer b.Unlock()
 
-	b.labelColor = color
+	b.styles.Normal = b.styles.Normal.Foreground(color)
 }
 
 // SetLabelColorFocused sets the color of the button text when the button is
@@ -89,7 +139,7 @@ func (b *Button) SetLabelColorFocused(color tcell.Color) {
 	b.Lock()
 	defer b.Unlock()
 
-	b.labelFocusedColor = color
+	b.styles.Focused = b.styles.Style(true, false, false).Foreground(color)
 }
 
 // SetEnabled sets whether or not the item is disabled / read-only.
@@ -99,12 +149,46 @@ func (b *Button) SetEnabled(enabled bool) {
 	b.enabled = enabled
 }
 
+// SetLoading sets whether or not the action of the button is running, e.g.
+// while saving or connecting in a separate goroutine. While loading, an
+// animated spinner (see SpinnerFrames) is shown before the label and the
+// button cannot be activated. The label may be replaced with
+// SetLoadingLabel(). Call SetLoading(false) when the action is done.
+func (b *Button) SetLoading(loading bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	if loading && !b.loading {
+		b.loadingSince = time.Now()
+		requestAnimationFrame()
+	}
+	b.loading = loading
+}
+
+// GetLoading returns whether or not the action of the button is running.
+func (b *Button) GetLoading() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.loading
+}
+
+// SetLoadingLabel sets the label shown after the spinner while the button is
+// loading (see SetLoading()), e.g. "Saving…". If empty (the default), the
+// regular label is shown.
+func (b *Button) SetLoadingLabel(label string) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.loadingLabel = []byte(label)
+}
+
 // SetCursorRune sets the rune to show within the button when it is focused.
 func (b *Button) SetCursorRune(rune rune) {
 	b.Lock()
 	defer b.Unlock()
 
-	b.cursorRune = rune
+	b.styles.CursorRune = rune
 }
 
 // SetBackgroundColorFocused sets the background color of the button text when
@@ -113,7 +197,7 @@ func (b *Button) SetBackgroundColorFocused(color tcell.Color) {
 	b.Lock()
 	defer b.Unlock()
 
-	b.backgroundFocusedColor = color
+	b.styles.Focused = b.styles.Style(true, false, false).Background(color)
 }
 
 // SetSelectedFunc sets a handler which is called when the button was selected.
@@ -148,13 +232,19 @@ func (b *Button) Draw(screen tcell.Screen) {
 	defer b.Unlock()
 
 	// Draw the box.
-	if !b.enabled {
-		b.Unlock()
-		b.drawBox(screen, b.backgroundDisabledColor, b.labelDisabledColor)
-		b.Lock()
-	} else if b.focus.HasFocus() {
+	hasFocus := b.focus.HasFocus()
+	style := b.styles.Style(hasFocus, !b.enabled, false)
+	pressed := b.enabled && !b.loading && b.press.pressed()
+	if pressed {
+		style = b.styles.Pressed
+		if style == tcell.StyleDefault {
+			style = b.styles.Style(true, false, false).Reverse(true)
+		}
+	}
+	labelColor, backgroundColor, _ := style.Decompose()
+	if !b.enabled || hasFocus || pressed {
 		b.Unlock()
-		b.drawBox(screen, b.backgroundFocusedColor, b.labelFocusedColor)
+		b.drawBox(screen, backgroundColor, labelColor)
 		b.Lock()
 	} else {
 		b.Unlock()
@@ -166,23 +256,24 @@ func (b *Button) Draw(screen tcell.Screen) {
 	x, y, width, height := b.GetInnerRect()
 	if width > 0 && height > 0 {
 		y = y + height/2
-		labelColor := b.labelColor
-		if !b.enabled {
-			labelColor = b.labelDisabledColor
-		} else if b.focus.HasFocus() {
-			labelColor = b.labelFocusedColor
+		label := b.label
+		if b.loading {
+			if len(b.loadingLabel) > 0 {
+				label = b.loadingLabel
+			}
+			label = append([]byte(string(spinnerFrame(b.loadingSince))+" "), label...)
 		}
-		_, pw := Print(screen, b.label, x, y, width, AlignCenter, labelColor)
+		_, pw := PrintStyle(screen, label, x, y, width, AlignCenter, style.Background(tcell.ColorDefault), ' ')
 
 		// Draw cursor.
-		if b.focus.HasFocus() && b.cursorRune != 0 {
+		if hasFocus && b.styles.CursorRune != 0 {
 			cursorX := x + int(float64(width)/2+float64(pw)/2)
 			if cursorX > x+width-1 {
 				cursorX = x + width - 1
 			} else if cursorX < x+width {
 				cursorX++
 			}
-			Print(screen, []byte(string(b.cursorRune)), cursorX, y, width, AlignLeft, labelColor)
+			PrintStyle(screen, []byte(string(b.styles.CursorRune)), cursorX, y, width, AlignLeft, style.Background(tcell.ColorDefault))
 		}
 	}
 }
@@ -204,11 +295,15 @@ func (b *Button) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 			return
 		}
 		// Process key event.
-		if HitShortcut(event, Keys.Select, Keys.Select2) {
+		if b.hitShortcut(event, b.keys().Select, b.keys().Select2) {
+			if b.loading {
+				return
+			}
+			b.press.press()
 			if b.selected != nil {
 				b.selected()
 			}
-		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+		} else if b.hitShortcut(event, b.keys().Cancel, b.keys().MovePreviousField, b.keys().MoveNextField) {
 			if b.blur != nil {
 				b.blur(event.Key())
 			}
@@ -226,7 +321,7 @@ func (b *Button) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 		// Process mouse event.
 		if action == MouseLeftClick {
 			setFocus(b)
-			if b.selected != nil {
+			if b.selected != nil && !b.loading {
 				b.selected()
 			}
 			consumed = true
@@ -2,6 +2,9 @@ package nuview
 
 import (
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -39,4 +42,91 @@ func TestButton(t *testing.T) {
 	}
 
 	b.Draw(app.screen)
+
+	// Styles
+
+	b.SetBackgroundColorFocused(tcell.ColorRed)
+	styles := b.GetStyles()
+	if _, background, _ := styles.Style(true, false, false).Decompose(); background != tcell.ColorRed {
+		t.Errorf("failed to update Button: incorrect focused background: expected %v, got %v", tcell.ColorRed, background)
+	}
+	if styles.Style(false, true, false) != styles.Normal && styles.Disabled == tcell.StyleDefault {
+		t.Error("failed to update Button: expected disabled style to fall back to normal style")
+	}
+
+	styles.Normal = tcell.StyleDefault.Foreground(tcell.ColorBlue).Background(tcell.ColorGreen)
+	b.SetStyles(styles)
+	if b.GetBackgroundColor() != tcell.ColorGreen {
+		t.Errorf("failed to update Button: incorrect background: expected %v, got %v", tcell.ColorGreen, b.GetBackgroundColor())
+	}
+}
+
+func TestButtonPressed(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 1)
+
+	b := NewButton("OK")
+	b.SetRect(0, 0, 10, 1)
+	styles := b.GetStyles()
+	styles.Pressed = tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow)
+	b.SetStyles(styles)
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	b.Draw(sc)
+	_, _, style, _ := sc.GetContent(0, 0)
+	if _, background, _ := style.Decompose(); background != tcell.ColorYellow {
+		t.Errorf("failed to draw pressed state: expected yellow background, got %v", background)
+	}
+
+	// The pressed state ends after PressedDuration.
+	b.press.until = time.Time{}
+	b.Draw(sc)
+	_, _, style, _ = sc.GetContent(0, 0)
+	if _, background, _ := style.Decompose(); background == tcell.ColorYellow {
+		t.Error("failed to end pressed state")
+	}
+}
+
+func TestButtonLoading(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 1)
+
+	var selected int
+	b := NewButton("Save")
+	b.SetRect(0, 0, 12, 1)
+	b.SetSelectedFunc(func() {
+		selected++
+	})
+	b.SetLoading(true)
+	b.SetLoadingLabel("Saving")
+
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	b.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if selected != 0 {
+		t.Errorf("failed to disable activation while loading: expected 0 selections, got %d", selected)
+	}
+
+	b.Draw(sc)
+	if r, _, _, _ := sc.GetContent(2, 0); r != SpinnerFrames[0] {
+		t.Errorf("failed to draw spinner: expected %q, got %q", SpinnerFrames[0], r)
+	}
+	if r, _, _, _ := sc.GetContent(4, 0); r != 'S' {
+		t.Errorf("failed to draw loading label: expected 'S', got %q", r)
+	}
+
+	b.SetLoading(false)
+	b.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	if selected != 1 {
+		t.Errorf("failed to activate button after loading: expected 1 selection, got %d", selected)
+	}
 }
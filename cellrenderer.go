@@ -0,0 +1,319 @@
+package nuview
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// CellRenderer draws the content of a table cell in place of its text, e.g. a
+// progress bar or a sparkline. Set it with TableCell.SetRenderer() or, for all
+// cells of a column, with Table.SetColumnRenderer().
+//
+// Renderers are invoked while the table is drawn. Any changes to a renderer
+// which is shown by a table should therefore be made with
+// Application.QueueUpdateDraw().
+type CellRenderer interface {
+	// Draw draws the content of the cell. The origin of the screen writer is
+	// the top left corner of the cell and anything drawn outside of the cell,
+	// which is width cells wide and one cell high, is discarded. The provided
+	// style is the style of the cell whose background has already been drawn.
+	// Selection highlighting is applied after the content was drawn, see
+	// SelectionCellRenderer.
+	Draw(screen ScreenWriter, width int, style tcell.Style)
+
+	// Width returns the width the content prefers. The width of a column is
+	// the maximum of the widths of its cells. It is queried when the renderer
+	// is set.
+	Width() int
+}
+
+// SelectionCellRenderer is a CellRenderer which also draws the content of
+// selected cells, e.g. to invert the colors of a color swatch. In tables, the
+// content of selected cells is otherwise drawn in the selection style.
+type SelectionCellRenderer interface {
+	CellRenderer
+
+	// DrawSelection draws the content of the cell like Draw() does. For
+	// selected cells, it is called again after the selection highlighting was
+	// applied, with selected set to true and the selection style.
+	DrawSelection(screen ScreenWriter, width int, style tcell.Style, selected bool)
+}
+
+// drawCellRenderer draws the content of a cell with the provided renderer.
+func drawCellRenderer(renderer CellRenderer, screen ScreenWriter, width int, style tcell.Style, selected bool) {
+	if renderer, ok := renderer.(SelectionCellRenderer); ok {
+		renderer.DrawSelection(screen, width, style, selected)
+		return
+	}
+	renderer.Draw(screen, width, style)
+}
+
+// The runes of the block elements filled from the left (progress bars) and
+// from the bottom (sparklines) by one eighth to eight eighths.
+var (
+	cellRendererHorizontalBlocks = []rune("▏▎▍▌▋▊▉█")
+	cellRendererVerticalBlocks   = []rune("▁▂▃▄▅▆▇█")
+)
+
+// ProgressCellRenderer draws a horizontal bar which is filled according to a
+// progress value, with a resolution of one eighth of a cell.
+type ProgressCellRenderer struct {
+	// The current progress and the progress required to fill the bar.
+	Progress, Max int
+
+	// The color of the filled part of the bar. If this is tcell.ColorDefault,
+	// the text color of the cell is used.
+	Color tcell.Color
+
+	// The preferred width of the bar.
+	BarWidth int
+}
+
+// NewProgressCellRenderer returns a new progress bar renderer for the given
+// progress and maximum.
+func NewProgressCellRenderer(progress, max int) *ProgressCellRenderer {
+	return &ProgressCellRenderer{
+		Progress: progress,
+		Max:      max,
+		Color:    tcell.ColorDefault,
+		BarWidth: 10,
+	}
+}
+
+// Draw draws the progress bar.
+func (r *ProgressCellRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	if r.Max <= 0 || width <= 0 {
+		return
+	}
+	if r.Color != tcell.ColorDefault {
+		style = style.Foreground(r.Color)
+	}
+	progress := r.Progress
+	if progress < 0 {
+		progress = 0
+	} else if progress > r.Max {
+		progress = r.Max
+	}
+
+	eighths := progress * width * 8 / r.Max
+	for x := 0; x < eighths/8; x++ {
+		screen.SetContent(x, 0, cellRendererHorizontalBlocks[7], nil, style)
+	}
+	if remainder := eighths % 8; remainder > 0 {
+		screen.SetContent(eighths/8, 0, cellRendererHorizontalBlocks[remainder-1], nil, style)
+	}
+}
+
+// Width returns the preferred width of the progress bar.
+func (r *ProgressCellRenderer) Width() int {
+	return r.BarWidth
+}
+
+// SparklineCellRenderer draws a series of values as a sparkline, one value per
+// cell, scaled from the smallest to the largest value. If there are more
+// values than fit into the cell, only the last ones are drawn.
+type SparklineCellRenderer struct {
+	// The values of the sparkline.
+	Values []float64
+
+	// The color of the sparkline. If this is tcell.ColorDefault, the text
+	// color of the cell is used.
+	Color tcell.Color
+}
+
+// NewSparklineCellRenderer returns a new sparkline renderer for the given
+// values.
+func NewSparklineCellRenderer(values ...float64) *SparklineCellRenderer {
+	return &SparklineCellRenderer{
+		Values: values,
+		Color:  tcell.ColorDefault,
+	}
+}
+
+// Draw draws the sparkline.
+func (r *SparklineCellRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	values := r.Values
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return
+	}
+	if r.Color != tcell.ColorDefault {
+		style = style.Foreground(r.Color)
+	}
+
+	minimum, maximum := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		minimum = math.Min(minimum, value)
+		maximum = math.Max(maximum, value)
+	}
+	for x, value := range values {
+		screen.SetContent(x, 0, sparklineRune(value, minimum, maximum), nil, style)
+	}
+}
+
+// sparklineRune returns the block element representing a value of a
+// sparkline which is scaled from the minimum to the maximum value. Values
+// outside of this range are clamped.
+func sparklineRune(value, minimum, maximum float64) rune {
+	level := len(cellRendererVerticalBlocks) - 1
+	if maximum > minimum {
+		level = int((value - minimum) / (maximum - minimum) * float64(len(cellRendererVerticalBlocks)-1))
+		level = max(0, min(level, len(cellRendererVerticalBlocks)-1))
+	}
+	return cellRendererVerticalBlocks[level]
+}
+
+// Width returns the number of values.
+func (r *SparklineCellRenderer) Width() int {
+	return len(r.Values)
+}
+
+// CheckboxCellRenderer draws the state of a checkbox using the strings of
+// Styles.Checkbox. It does not handle any input, use TableCell.SetClickedFunc()
+// to toggle it.
+type CheckboxCellRenderer struct {
+	// Whether or not the checkbox is checked.
+	Checked bool
+}
+
+// NewCheckboxCellRenderer returns a new checkbox renderer.
+func NewCheckboxCellRenderer(checked bool) *CheckboxCellRenderer {
+	return &CheckboxCellRenderer{
+		Checked: checked,
+	}
+}
+
+// Draw draws the checkbox.
+func (r *CheckboxCellRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	PrintStyle(screen, []byte(r.text()), 0, 0, width, AlignLeft, style)
+}
+
+// Width returns the width of the checkbox string.
+func (r *CheckboxCellRenderer) Width() int {
+	return TaggedStringWidth(r.text())
+}
+
+// text returns the string representing the checkbox state.
+func (r *CheckboxCellRenderer) text() string {
+	if r.Checked {
+		return Styles.Checkbox.CheckedString
+	}
+	return Styles.Checkbox.UncheckedString
+}
+
+// toggleCellRenderer draws a bool which is bound to a table cell, see
+// NewCheckboxCell() and NewMarkerCell().
+type toggleCellRenderer struct {
+	// The bound bool.
+	value *bool
+
+	// The strings shown when the bool is true and when it is false.
+	on, off string
+
+	// An optional function which is called after the bool was toggled.
+	changed func(value bool)
+}
+
+// Draw draws the string representing the bool. The strings are drawn as they
+// are, without interpreting any tags.
+func (r *toggleCellRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	text := r.off
+	if *r.value {
+		text = r.on
+	}
+	iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+		if screenPos+screenWidth > width {
+			return true
+		}
+		screen.SetContent(screenPos, 0, main, comb, style)
+		return false
+	})
+}
+
+// Width returns the width of the wider string.
+func (r *toggleCellRenderer) Width() int {
+	return max(runewidth.StringWidth(r.on), runewidth.StringWidth(r.off))
+}
+
+// toggle toggles the bool and calls the changed handler.
+func (r *toggleCellRenderer) toggle() {
+	*r.value = !*r.value
+	if r.changed != nil {
+		r.changed(*r.value)
+	}
+}
+
+// newToggleCell returns a cell which shows the bound bool with the provided
+// strings and toggles it when clicked.
+func newToggleCell(value *bool, on, off string, changed func(value bool)) *TableCell {
+	renderer := &toggleCellRenderer{
+		value:   value,
+		on:      on,
+		off:     off,
+		changed: changed,
+	}
+	cell := NewTableCell("")
+	cell.SetRenderer(renderer)
+	cell.SetClickedFunc(func() bool {
+		renderer.toggle()
+		return true
+	})
+	return cell
+}
+
+// NewCheckboxCell returns a table cell which shows a checkbox for the bool
+// that checked points to, using the strings of Styles.Checkbox. Clicking the
+// cell toggles the bool and calls the changed handler, if provided, instead of
+// selecting the cell. So does pressing Keys.Select2 while the cell is
+// selected or, if only rows are selectable, while its row is selected. The
+// bool may also be changed elsewhere, it is shown as it is whenever the table
+// is drawn.
+func NewCheckboxCell(checked *bool, changed func(checked bool)) *TableCell {
+	return newToggleCell(checked, Styles.Checkbox.CheckedString, Styles.Checkbox.UncheckedString, changed)
+}
+
+// NewMarkerCell returns a table cell which shows Styles.Table.Marker if the
+// bool that marked points to is true and nothing otherwise, e.g. to mark the
+// items to be processed in a list. It is toggled like a checkbox cell, see
+// NewCheckboxCell().
+func NewMarkerCell(marked *bool, changed func(marked bool)) *TableCell {
+	return newToggleCell(marked, Styles.Table.Marker, "", changed)
+}
+
+// BadgeCellRenderer draws a short text padded with one space on each side in
+// its own style, e.g. to show a status in a colored box.
+type BadgeCellRenderer struct {
+	// The text of the badge. It may contain color tags.
+	Text string
+
+	// The style of the badge.
+	Style tcell.Style
+
+	// The alignment of the badge within the cell. One of AlignLeft (default),
+	// AlignCenter, or AlignRight.
+	Align int
+}
+
+// NewBadgeCellRenderer returns a new badge renderer with the given text and
+// style.
+func NewBadgeCellRenderer(text string, style tcell.Style) *BadgeCellRenderer {
+	return &BadgeCellRenderer{
+		Text:  text,
+		Style: style,
+		Align: AlignLeft,
+	}
+}
+
+// Draw draws the badge.
+func (r *BadgeCellRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	PrintStyle(screen, []byte(" "+r.Text+" "), 0, 0, width, r.Align, r.Style)
+}
+
+// Width returns the width of the badge.
+func (r *BadgeCellRenderer) Width() int {
+	return TaggedStringWidth(r.Text) + 2
+}
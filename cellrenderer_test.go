@@ -0,0 +1,170 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCellRenderer(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 3)
+
+	table := NewTable()
+	table.SetRect(0, 0, 20, 3)
+	table.SetCell(0, 0, NewTableCell("ab"))
+	table.SetCell(1, 0, NewTableCell("cd"))
+
+	full := NewTableCell("50%")
+	full.SetRenderer(NewProgressCellRenderer(50, 100))
+	table.SetCell(0, 1, full)
+	partial := NewTableCell("31%")
+	partial.SetRenderer(NewProgressCellRenderer(5, 16))
+	table.SetCell(1, 1, partial)
+	table.Draw(sc)
+
+	// The column is as wide as the preferred width of the bars (10).
+	for x, expected := range []rune("█████     ") {
+		if r, _, _, _ := sc.GetContent(3+x, 0); r != expected {
+			t.Errorf("failed to draw progress bar: expected %q at column %d, got %q", expected, x, r)
+		}
+	}
+	for x, expected := range []rune("███▏      ") {
+		if r, _, _, _ := sc.GetContent(3+x, 1); r != expected {
+			t.Errorf("failed to draw partial progress bar: expected %q at column %d, got %q", expected, x, r)
+		}
+	}
+	if _, _, width := full.GetLastPosition(); width != 10 {
+		t.Errorf("failed to determine cell width: expected 10, got %d", width)
+	}
+
+	// Sparklines only show the last values which fit.
+	sparkline := NewSparklineCellRenderer(9, 0, 1, 2, 3, 4, 5, 6, 7)
+	if width := sparkline.Width(); width != 9 {
+		t.Errorf("failed to determine sparkline width: expected 9, got %d", width)
+	}
+	sc.Clear()
+	sparkline.Draw(NewClippingScreenWriter(NewTranslateScreenWriterAdapter(sc), 0, 2, 8, 1), 8, tcell.StyleDefault)
+	for x, expected := range cellRendererVerticalBlocks {
+		if r, _, _, _ := sc.GetContent(x, 2); r != expected {
+			t.Errorf("failed to draw sparkline: expected %q at column %d, got %q", expected, x, r)
+		}
+	}
+}
+
+// swatchRenderer draws a color swatch, marking it if it is selected.
+type swatchRenderer struct {
+	color tcell.Color
+}
+
+func (r *swatchRenderer) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	r.DrawSelection(screen, width, style, false)
+}
+
+func (r *swatchRenderer) DrawSelection(screen ScreenWriter, width int, style tcell.Style, selected bool) {
+	mark := ' '
+	if selected {
+		mark = '*'
+	}
+	screen.SetContent(0, 0, mark, nil, style.Background(r.color))
+}
+
+func (r *swatchRenderer) Width() int {
+	return 3
+}
+
+func TestTableColumnRenderer(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 2)
+
+	table := NewTable()
+	table.SetRect(0, 0, 10, 2)
+	table.SetSelectable(true, false)
+	table.SetCell(0, 0, NewTableCell("red"))
+	table.SetCell(1, 0, NewTableCell("blue"))
+	table.SetCell(0, 1, NewTableCell("x"))
+	table.SetCell(1, 1, NewTableCell("y"))
+	table.SetColumnRenderer(0, func(row int, cell *TableCell) CellRenderer {
+		return &swatchRenderer{color: tcell.GetColor(cell.Text)}
+	})
+	table.Draw(sc)
+
+	// The column is as wide as the renderer prefers, not as the text.
+	if r, _, _, _ := sc.GetContent(4, 1); r != 'y' {
+		t.Errorf("failed to determine column width: expected %q at column 4, got %q", 'y', r)
+	}
+	for row, expected := range []rune{'*', ' '} {
+		r, _, style, _ := sc.GetContent(0, row)
+		if r != expected {
+			t.Errorf("failed to draw selection state in row %d: expected %q, got %q", row, expected, r)
+		}
+		if _, background, _ := style.Decompose(); background != tcell.GetColor([]string{"red", "blue"}[row]) {
+			t.Errorf("failed to draw swatch in row %d: expected %v, got %v", row, tcell.GetColor([]string{"red", "blue"}[row]), background)
+		}
+	}
+
+	table.SetColumnRenderer(0, nil)
+	table.Draw(sc)
+	if r, _, _, _ := sc.GetContent(0, 1); r != 'b' {
+		t.Errorf("failed to remove column renderer: expected %q, got %q", 'b', r)
+	}
+}
+
+func TestTableToggleCells(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 2)
+
+	var checked, marked bool
+	var changes []bool
+	table := NewTable()
+	table.SetRect(0, 0, 12, 2)
+	table.SetSelectable(true, false)
+	table.SetCell(0, 0, NewTableCell("item"))
+	table.SetCell(0, 1, NewCheckboxCell(&checked, func(checked bool) {
+		changes = append(changes, checked)
+	}))
+	table.SetCell(1, 0, NewTableCell("other"))
+	table.SetCell(1, 1, NewMarkerCell(&marked, nil))
+	var selected int
+	table.SetSelectedFunc(func(row, column int) {
+		selected++
+	})
+	table.Draw(sc)
+
+	// Toggle the checkbox with the keyboard, then with the mouse.
+	table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), func(Primitive) {})
+	if !checked {
+		t.Error("failed to toggle checkbox with the keyboard")
+	}
+	table.MouseHandler()(MouseLeftDown, tcell.NewEventMouse(6, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if checked || selected != 0 {
+		t.Errorf("failed to toggle checkbox with the mouse: got %v and %d selected events", checked, selected)
+	}
+	if len(changes) != 2 || changes[0] != true || changes[1] != false {
+		t.Errorf("failed to report changes: expected [true false], got %v", changes)
+	}
+
+	marked = true
+	table.Draw(sc)
+	if r, _, _, _ := sc.GetContent(6, 1); r != '●' {
+		t.Errorf("failed to draw marker: expected %q, got %q", '●', r)
+	}
+	if r, _, _, _ := sc.GetContent(7, 0); r != ' ' {
+		t.Errorf("failed to draw unchecked checkbox: expected %q, got %q", ' ', r)
+	}
+}
@@ -29,27 +29,17 @@ type Checkbox struct {
 	labelRight      string
 	labelRightWidth int
 
-	// The label style.
-	labelStyle tcell.Style
-
-	// The style of the unchecked checkbox.
-	uncheckedStyle tcell.Style
-
-	// The style of the checked checkbox.
-	checkedStyle tcell.Style
-
-	// The style of the checkbox when it is currently focused.
-	focusStyle tcell.Style
-
-	uncheckedString       string // String shown when unchecked
-	checkedString         string // String shown when checked
-	cursorCheckedString   string // String shown when checked and the cursor is on it
-	cursorUncheckedString string // String shown when unchecked and the cursor is on it
+	// The styles of the labels and the checkbox, and the strings shown for the
+	// checkbox.
+	styles CheckboxStyles
 
 	// An optional function which is called when the user changes the checked
 	// state of this checkbox.
 	changed func(checked bool)
 
+	// Shows the pressed state after the checkbox was toggled by keyboard.
+	press pressedState
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -67,18 +57,26 @@ func NewCheckbox() *Checkbox {
 	return &Checkbox{
 		Box:     NewBox(),
 		enabled: true,
-
-		labelStyle:            Styles.CheckboxLabelStyle,
-		uncheckedStyle:        Styles.CheckboxUncheckedStyle,
-		checkedStyle:          Styles.CheckboxCheckedStyle,
-		focusStyle:            Styles.CheckboxFocusStyle,
-		uncheckedString:       Styles.CheckboxUncheckedString,
-		checkedString:         Styles.CheckboxCheckedString,
-		cursorCheckedString:   Styles.CheckboxCursorCheckedString,
-		cursorUncheckedString: Styles.CheckboxCursorUncheckedString,
+		styles:  Styles.Checkbox,
 	}
 }
 
+// SetStyles sets the styles of the checkbox, overriding Styles.Checkbox.
+func (c *Checkbox) SetStyles(styles CheckboxStyles) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.styles = styles
+}
+
+// GetStyles returns the styles of the checkbox.
+func (c *Checkbox) GetStyles() CheckboxStyles {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.styles
+}
+
 // SetChecked sets the state of the checkbox. This also triggers the "changed"
 // callback if the state changes with this call.
 func (c *Checkbox) SetChecked(checked bool) {
@@ -148,64 +146,64 @@ func (c *Checkbox) SetLabelRightWidth(width int) {
 func (c *Checkbox) SetLabelColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.labelStyle = c.labelStyle.Foreground(color)
+	c.styles.Label.Normal = c.styles.Label.Normal.Foreground(color)
 }
 
 // SetLabelStyle sets the style of the label.
 func (c *Checkbox) SetLabelStyle(style tcell.Style) {
 	c.Lock()
 	defer c.Unlock()
-	c.labelStyle = style
+	c.styles.Label.Normal = style
 }
 
 func (c *Checkbox) SetLabelFocusedColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.labelStyle = c.labelStyle.Foreground(color)
+	c.styles.Label.Focused = c.styles.Label.Style(true, false, false).Foreground(color)
 }
 
 func (c *Checkbox) SetFieldTextFocusedColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.focusStyle = c.focusStyle.Foreground(color)
+	c.styles.Box.Focused = c.styles.Box.Focused.Foreground(color)
 }
 
 func (c *Checkbox) SetFieldBackgroundFocusedColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.focusStyle = c.focusStyle.Background(color)
+	c.styles.Box.Focused = c.styles.Box.Focused.Background(color)
 }
 
 // SetFieldBackgroundColor sets the background color of the input area.
 func (c *Checkbox) SetFieldBackgroundColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.uncheckedStyle = c.uncheckedStyle.Background(color)
-	c.checkedStyle = c.checkedStyle.Background(color)
-	c.focusStyle = c.focusStyle.Foreground(color)
+	c.styles.Box.Normal = c.styles.Box.Normal.Background(color)
+	c.styles.Box.Selected = c.styles.Box.Selected.Background(color)
+	c.styles.Box.Focused = c.styles.Box.Focused.Foreground(color)
 }
 
 // SetFieldTextColor sets the text color of the input area.
 func (c *Checkbox) SetFieldTextColor(color tcell.Color) {
 	c.Lock()
 	defer c.Unlock()
-	c.uncheckedStyle = c.uncheckedStyle.Foreground(color)
-	c.checkedStyle = c.checkedStyle.Foreground(color)
-	c.focusStyle = c.focusStyle.Background(color)
+	c.styles.Box.Normal = c.styles.Box.Normal.Foreground(color)
+	c.styles.Box.Selected = c.styles.Box.Selected.Foreground(color)
+	c.styles.Box.Focused = c.styles.Box.Focused.Background(color)
 }
 
 // SetUncheckedStyle sets the style of the unchecked checkbox.
 func (c *Checkbox) SetUncheckedStyle(style tcell.Style) {
 	c.Lock()
 	defer c.Unlock()
-	c.uncheckedStyle = style
+	c.styles.Box.Normal = style
 }
 
 // SetCheckedStyle sets the style of the checked checkbox.
 func (c *Checkbox) SetCheckedStyle(style tcell.Style) {
 	c.Lock()
 	defer c.Unlock()
-	c.checkedStyle = style
+	c.styles.Box.Selected = style
 }
 
 // SetActivatedStyle sets the style of the checkbox when it is currently
@@ -213,7 +211,15 @@ func (c *Checkbox) SetCheckedStyle(style tcell.Style) {
 func (c *Checkbox) SetActivatedStyle(style tcell.Style) {
 	c.Lock()
 	defer c.Unlock()
-	c.focusStyle = style
+	c.styles.Box.Focused = style
+}
+
+// SetPressedStyle sets the style of the checkbox shown briefly after it was
+// toggled with the keyboard.
+func (c *Checkbox) SetPressedStyle(style tcell.Style) {
+	c.Lock()
+	defer c.Unlock()
+	c.styles.Pressed = style
 }
 
 // SetCheckedString sets the string to be displayed when the checkbox is
@@ -223,7 +229,7 @@ func (c *Checkbox) SetActivatedStyle(style tcell.Style) {
 func (c *Checkbox) SetCheckedString(checked string) {
 	c.Lock()
 	defer c.Unlock()
-	c.checkedString = checked
+	c.styles.CheckedString = checked
 }
 
 // SetUncheckedString sets the string to be displayed when the checkbox is
@@ -233,7 +239,7 @@ func (c *Checkbox) SetCheckedString(checked string) {
 func (c *Checkbox) SetUncheckedString(unchecked string) {
 	c.Lock()
 	defer c.Unlock()
-	c.uncheckedString = unchecked
+	c.styles.UncheckedString = unchecked
 }
 
 // SetFormAttributes sets attributes shared by all form items.
@@ -261,6 +267,34 @@ func (c *Checkbox) GetFieldHeight() int {
 	return 1
 }
 
+// PreferredWidth returns the width of the labels and the checkbox plus the
+// border and padding.
+func (c *Checkbox) PreferredWidth(maxWidth int) int {
+	c.RLock()
+	defer c.RUnlock()
+
+	labelWidth := c.labelWidth
+	if labelWidth <= 0 {
+		labelWidth = TaggedStringWidth(c.label)
+	}
+	labelRightWidth := c.labelRightWidth
+	if labelRightWidth <= 0 {
+		labelRightWidth = TaggedStringWidth(c.labelRight)
+	}
+	var boxWidth int
+	for _, str := range []string{c.styles.CheckedString, c.styles.UncheckedString, c.styles.CursorCheckedString, c.styles.CursorUncheckedString} {
+		boxWidth = max(boxWidth, TaggedStringWidth(str))
+	}
+	return preferredFormItemWidth(c.Box, labelWidth, boxWidth+labelRightWidth, maxWidth)
+}
+
+// PreferredHeight returns the height of the checkbox plus the border and
+// padding.
+func (c *Checkbox) PreferredHeight(width int) int {
+	_, frameHeight := c.frameSize()
+	return 1 + frameHeight
+}
+
 // SetEnabled sets whether or not the item is disabled / read-only.
 func (c *Checkbox) SetEnabled(enabled bool) {
 	c.Lock()
@@ -327,55 +361,66 @@ func (c *Checkbox) Draw(screen tcell.Screen) {
 	}
 
 	// Draw label.
-	_, labelBg, _ := c.labelStyle.Decompose()
+	hasFocus := c.HasFocus()
+	labelStyle := c.styles.Label.Style(hasFocus, !c.enabled, false)
+	_, labelBg, _ := labelStyle.Decompose()
 	if c.labelWidth > 0 {
 		labelWidth := c.labelWidth
 		if labelWidth > width {
 			labelWidth = width
 		}
-		printWithStyle(screen, c.label, x, y, 0, labelWidth, AlignLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		printWithStyle(screen, c.label, x, y, 0, labelWidth, AlignLeft, labelStyle, labelBg == tcell.ColorDefault, ' ')
 		x += labelWidth
 		width -= labelWidth
 	} else {
-		_, _, drawnWidth := printWithStyle(screen, c.label, x, y, 0, width, AlignLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		_, _, drawnWidth := printWithStyle(screen, c.label, x, y, 0, width, AlignLeft, labelStyle, labelBg == tcell.ColorDefault, 0)
 		x += drawnWidth
 		width -= drawnWidth
 	}
 
 	// Draw checkbox.
-	str := c.uncheckedString
-	style := c.uncheckedStyle
+	str := c.styles.UncheckedString
+	style := c.styles.Box.Normal
 	if !c.enabled {
-		style = style.Background(c.backgroundColor)
+		style = style.Background(c.backgroundFill())
 	}
 	if !c.checked {
-		str = c.checkedString
-		style = c.checkedStyle
+		str = c.styles.CheckedString
+		style = c.styles.Box.Selected
 	}
-	if c.HasFocus() {
-		style = c.focusStyle
+	if hasFocus {
+		style = c.styles.Box.Focused
 		if c.checked {
-			str = c.cursorCheckedString
+			str = c.styles.CursorCheckedString
 		} else {
-			str = c.cursorUncheckedString
+			str = c.styles.CursorUncheckedString
+		}
+	}
+	if !c.enabled && c.styles.Box.Disabled != tcell.StyleDefault {
+		style = c.styles.Box.Disabled
+	}
+	if c.enabled && c.press.pressed() {
+		style = c.styles.Pressed
+		if style == tcell.StyleDefault {
+			style = c.styles.Box.Focused.Reverse(true)
 		}
 	}
 
-	_, _, drawnWidth := printWithStyle(screen, str, x, y, 0, width, AlignLeft, style, !c.enabled)
+	_, _, drawnWidth := printWithStyle(screen, str, x, y, 0, width, AlignLeft, style, !c.enabled, 0)
 	x += drawnWidth
 	width -= drawnWidth
 
 	if c.labelRight != "" {
 		// Draw label right.
-		_, labelRightBg, _ := c.labelStyle.Decompose()
+		labelRightBg := labelBg
 		if c.labelRightWidth > 0 {
 			labelRightWidth := c.labelRightWidth
 			if labelRightWidth > width {
 				labelRightWidth = width
 			}
-			printWithStyle(screen, c.labelRight, x, y, 0, labelRightWidth, AlignLeft, c.labelStyle, labelRightBg == tcell.ColorDefault)
+			printWithStyle(screen, c.labelRight, x, y, 0, labelRightWidth, AlignLeft, labelStyle, labelRightBg == tcell.ColorDefault, ' ')
 		} else {
-			printWithStyle(screen, c.labelRight, x, y, 0, width, AlignLeft, c.labelStyle, labelRightBg == tcell.ColorDefault)
+			printWithStyle(screen, c.labelRight, x, y, 0, width, AlignLeft, labelStyle, labelRightBg == tcell.ColorDefault, 0)
 		}
 	}
 }
@@ -394,6 +439,7 @@ func (c *Checkbox) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 				break
 			}
 			c.checked = !c.checked
+			c.press.press()
 			if c.changed != nil {
 				c.changed(c.checked)
 			}
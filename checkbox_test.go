@@ -2,6 +2,8 @@ package nuview
 
 import (
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -54,3 +56,34 @@ func TestCheckBox(t *testing.T) {
 
 	c.Draw(app.screen)
 }
+
+func TestCheckBoxLabelWidth(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 1)
+
+	c := NewCheckbox()
+	c.SetLabel("Go")
+	c.SetLabelWidth(6)
+	c.SetLabelStyle(tcell.StyleDefault.Background(tcell.ColorBlue))
+	c.SetRect(0, 0, 20, 1)
+	c.Draw(sc)
+
+	// The label style covers the entire label width.
+	for x := 0; x < 6; x++ {
+		if _, _, style, _ := sc.GetContent(x, 0); !hasBackground(style, tcell.ColorBlue) {
+			t.Errorf("failed to fill label width: cell %d has style %#v", x, style)
+		}
+	}
+}
+
+// hasBackground returns whether or not the provided style has the provided
+// background color.
+func hasBackground(style tcell.Style, color tcell.Color) bool {
+	_, background, _ := style.Decompose()
+	return background == color
+}
@@ -0,0 +1,89 @@
+package nuview
+
+import (
+	"sync"
+)
+
+// ClipboardManager holds the text most recently copied by primitives and
+// passes it on to the system clipboard. By default, Application.Copy() sends
+// the text to the terminal of the application which forwards it to the system
+// clipboard if it supports it (OSC 52). A different mechanism, e.g. an
+// external program, can be installed with SetWriteFunc.
+//
+// Primitives use the ClipboardManager of the application drawing them, see
+// Application.SetClipboard(), which defaults to Clipboard.
+type ClipboardManager struct {
+	// The text most recently copied.
+	text string
+
+	// If set to true, text is copied as soon as it is selected with the mouse.
+	copyOnSelect bool
+
+	// An optional function which replaces writing to the terminal.
+	write func(text string)
+
+	sync.RWMutex
+}
+
+// Clipboard is the clipboard manager used by primitives of applications which
+// have none of their own.
+var Clipboard = &ClipboardManager{}
+
+// SetCopyOnSelect sets whether or not completing a mouse selection, e.g. in a
+// TextView, automatically copies the selected text, similar to the primary
+// selection of X11.
+func (c *ClipboardManager) SetCopyOnSelect(copyOnSelect bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.copyOnSelect = copyOnSelect
+}
+
+// GetCopyOnSelect returns whether or not completing a mouse selection
+// automatically copies the selected text.
+func (c *ClipboardManager) GetCopyOnSelect() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.copyOnSelect
+}
+
+// SetWriteFunc sets a handler which is called with copied text instead of
+// sending it to the terminal of the application. Provide nil to restore the
+// default behavior.
+func (c *ClipboardManager) SetWriteFunc(handler func(text string)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.write = handler
+}
+
+// Copy stores the provided text and passes it on to the handler set with
+// SetWriteFunc(), if any. Use Application.Copy() to send it to the terminal of
+// an application otherwise.
+func (c *ClipboardManager) Copy(text string) {
+	c.copy(text)
+}
+
+// copy stores the provided text and passes it on to the handler set with
+// SetWriteFunc(). It returns false if there is no such handler.
+func (c *ClipboardManager) copy(text string) bool {
+	c.Lock()
+	c.text = text
+	write := c.write
+	c.Unlock()
+
+	if write == nil {
+		return false
+	}
+	write(text)
+	return true
+}
+
+// GetText returns the text most recently copied.
+func (c *ClipboardManager) GetText() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.text
+}
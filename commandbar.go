@@ -0,0 +1,359 @@
+package nuview
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// CommandBar is a single line, usually at the bottom of the screen, in which
+// the user enters commands, like the command line of vim. Pressing
+// Keys.ActivateCommandBar (":" by default) while no input field has focus
+// activates the first visible command bar of the application and gives it
+// focus. So does clicking it.
+//
+// Enter executes the command (see SetExecuteFunc), Escape cancels it. Both
+// return the focus to the primitive which had it before the command bar was
+// activated. The Up and Down keys browse the commands entered before, Tab and
+// Backtab cycle through the completions of the entered text (see
+// SetCompleteFunc). While the command bar is not active, it shows the result
+// or the error of the last command for a while (see SetMessageDuration).
+type CommandBar struct {
+	*Box
+
+	// The field in which commands are entered.
+	field *InputField
+
+	// Whether or not a command is being entered.
+	active bool
+
+	// The primitive which had focus before the command bar was activated.
+	previous Primitive
+
+	// The function which sets the focus, as provided to Focus().
+	setFocus func(p Primitive)
+
+	// The commands entered so far, the oldest first, the position in the
+	// history while browsing it (len(history) = the text being entered), and
+	// the text being entered while browsing.
+	history      []string
+	historyIndex int
+	draft        string
+
+	// The completions of the text being completed and the index of the one
+	// shown (-1 if the user is not cycling through completions).
+	completions []string
+	completion  int
+
+	// The message shown while the command bar is not active, whether or not
+	// it is an error, and when it expires (zero = never).
+	message      string
+	messageError bool
+	messageUntil time.Time
+
+	// The time messages are shown (0 = until the next activation).
+	messageDuration time.Duration
+
+	// An optional function which executes commands.
+	execute func(command string) (result string, err error)
+
+	// An optional function which returns the completions of a text.
+	complete func(text string) []string
+
+	sync.RWMutex
+}
+
+// NewCommandBar returns a new command bar with the prompt ":".
+func NewCommandBar() *CommandBar {
+	c := &CommandBar{
+		Box:             NewBox(),
+		field:           NewInputField(),
+		completion:      -1,
+		messageDuration: 5 * time.Second,
+	}
+	c.field.SetLabel(":")
+	c.field.SetLabelColor(Styles.PrimaryTextColor)
+	c.field.SetFieldBackgroundColor(Styles.PrimitiveBackgroundColor)
+	c.field.SetFieldTextColor(Styles.PrimaryTextColor)
+	c.field.SetChangedFunc(func(text string) {
+		c.Lock()
+		defer c.Unlock()
+		if c.completion < 0 || text != c.completions[c.completion] {
+			c.completions, c.completion = nil, -1 // The user edited the text.
+		}
+	})
+	c.field.SetDoneFunc(c.done)
+	c.focus = c
+	return c
+}
+
+// SetPrompt sets the text shown in front of the entered command.
+func (c *CommandBar) SetPrompt(prompt string) {
+	c.field.SetLabel(prompt)
+}
+
+// GetPrompt returns the text shown in front of the entered command.
+func (c *CommandBar) GetPrompt() string {
+	return c.field.GetLabel()
+}
+
+// SetExecuteFunc sets a handler which is called with the entered command when
+// the user presses Enter. The returned result, or the error if it is not nil,
+// is shown in the command bar. Empty commands are not executed.
+func (c *CommandBar) SetExecuteFunc(handler func(command string) (result string, err error)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.execute = handler
+}
+
+// SetCompleteFunc sets a handler which returns the completions of the entered
+// text, e.g. the commands starting with it. Tab replaces the text with the
+// first completion, pressing it again with the next one.
+func (c *CommandBar) SetCompleteFunc(handler func(text string) []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.complete = handler
+}
+
+// SetHistory replaces the commands entered so far, the oldest first, e.g. to
+// restore them from a previous session.
+func (c *CommandBar) SetHistory(history []string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.history = append([]string(nil), history...)
+	c.historyIndex = len(c.history)
+}
+
+// GetHistory returns the commands entered so far, the oldest first.
+func (c *CommandBar) GetHistory() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return append([]string(nil), c.history...)
+}
+
+// SetMessageDuration sets how long the result or the error of a command is
+// shown. A duration of 0 shows it until the command bar is activated again.
+// The default is 5 seconds.
+func (c *CommandBar) SetMessageDuration(duration time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.messageDuration = duration
+}
+
+// ShowMessage shows the provided message in the command bar while it is not
+// active, in the error color if isError is true, see SetMessageDuration.
+func (c *CommandBar) ShowMessage(message string, isError bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.showMessage(message, isError)
+}
+
+// showMessage shows a message and schedules a redraw when it expires. The
+// command bar must be locked.
+func (c *CommandBar) showMessage(message string, isError bool) {
+	c.message, c.messageError = message, isError
+	c.messageUntil = time.Time{}
+	if c.messageDuration > 0 {
+		c.messageUntil = time.Now().Add(c.messageDuration)
+		time.AfterFunc(c.messageDuration, requestAnimationFrame)
+	}
+}
+
+// IsActive returns whether or not the user is entering a command.
+func (c *CommandBar) IsActive() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.active
+}
+
+// Activate activates the command bar and gives it focus, as if the user had
+// pressed Keys.ActivateCommandBar. The command bar must have been drawn by a
+// running application. Like all functions which change the focus, it must be
+// called from the event loop, e.g. with Application.QueueUpdateDraw().
+func (c *CommandBar) Activate() {
+	app := c.app.Load()
+	if app == nil {
+		return
+	}
+	c.activate(app.GetFocus())
+	app.SetFocus(c)
+}
+
+// activate starts the entry of a command. The focus is returned to the
+// provided primitive when it is done.
+func (c *CommandBar) activate(previous Primitive) {
+	c.Lock()
+	if previous != c && previous != c.field {
+		c.previous = previous
+	}
+	c.active = true
+	c.message = ""
+	c.historyIndex = len(c.history)
+	c.completions, c.completion = nil, -1
+	c.Unlock()
+
+	c.field.SetText("")
+}
+
+// done handles the keys which the field does not process itself.
+func (c *CommandBar) done(key tcell.Key) {
+	switch key {
+	case tcell.KeyEnter:
+		c.finish(c.field.GetText())
+	case tcell.KeyEscape:
+		c.finish("")
+	case tcell.KeyUp, tcell.KeyDown:
+		c.Lock()
+		index := c.historyIndex
+		if index == len(c.history) {
+			c.draft = c.field.GetText()
+		}
+		if key == tcell.KeyUp && index > 0 {
+			index--
+		} else if key == tcell.KeyDown && index < len(c.history) {
+			index++
+		}
+		c.historyIndex = index
+		text := c.draft
+		if index < len(c.history) {
+			text = c.history[index]
+		}
+		c.Unlock()
+		c.field.SetText(text)
+	case tcell.KeyTab, tcell.KeyBacktab:
+		c.Lock()
+		if c.completions == nil && c.complete != nil {
+			complete := c.complete
+			c.Unlock()
+			completions := complete(c.field.GetText())
+			c.Lock()
+			c.completions, c.completion = completions, -1
+		}
+		if len(c.completions) == 0 {
+			c.Unlock()
+			return
+		}
+		if key == tcell.KeyTab {
+			c.completion = (c.completion + 1) % len(c.completions)
+		} else if c.completion <= 0 {
+			c.completion = len(c.completions) - 1
+		} else {
+			c.completion--
+		}
+		text := c.completions[c.completion]
+		c.Unlock()
+		c.field.SetText(text)
+	}
+}
+
+// finish ends the entry of a command, returns the focus, and executes the
+// provided command unless it is empty.
+func (c *CommandBar) finish(command string) {
+	c.Lock()
+	c.active = false
+	previous, setFocus, execute := c.previous, c.setFocus, c.execute
+	c.previous = nil
+	if command != "" && (len(c.history) == 0 || c.history[len(c.history)-1] != command) {
+		c.history = append(c.history, command)
+	}
+	c.historyIndex = len(c.history)
+	c.Unlock()
+
+	c.field.SetText("")
+	if previous != nil && setFocus != nil {
+		setFocus(previous)
+	}
+	if command == "" || execute == nil {
+		return
+	}
+
+	result, err := execute(command)
+	c.Lock()
+	defer c.Unlock()
+	if err != nil {
+		c.showMessage(err.Error(), true)
+	} else {
+		c.showMessage(result, false)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (c *CommandBar) Focus(delegate func(p Primitive)) {
+	c.Lock()
+	c.setFocus = delegate
+	c.active = true
+	c.Unlock()
+
+	delegate(c.field)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (c *CommandBar) HasFocus() bool {
+	return c.field.HasFocus()
+}
+
+// Draw draws this primitive onto the screen.
+func (c *CommandBar) Draw(screen tcell.Screen) {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.Box.Draw(screen)
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	c.Lock()
+	if c.active && !c.field.HasFocus() {
+		c.active = false // The focus moved elsewhere.
+		c.previous = nil
+	}
+	active := c.active
+	message, isError := c.message, c.messageError
+	if !c.messageUntil.IsZero() && time.Now().After(c.messageUntil) {
+		message = ""
+	}
+	c.Unlock()
+
+	if active {
+		c.field.SetRect(x, y, width, 1)
+		c.field.Draw(screen)
+		return
+	}
+	color := c.theme().PrimaryTextColor
+	if isError {
+		color = c.theme().ErrorTextColor
+	}
+	Print(screen, []byte(Escape(message)), x, y, width, AlignLeft, color)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *CommandBar) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !c.InRect(event.Position()) {
+			return false, nil
+		}
+
+		if c.IsActive() {
+			return c.field.MouseHandler()(action, event, setFocus)
+		}
+		if action == MouseLeftClick {
+			if app := c.app.Load(); app != nil {
+				c.activate(app.GetFocus())
+			}
+			setFocus(c)
+			return true, nil
+		}
+		return false, nil
+	})
+}
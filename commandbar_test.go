@@ -0,0 +1,130 @@
+package nuview
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCommandBar(t *testing.T) {
+	t.Parallel()
+
+	list := NewList()
+	list.AddItem(NewListItem("Item"))
+	bar := NewCommandBar()
+	var executed []string
+	bar.SetExecuteFunc(func(command string) (string, error) {
+		executed = append(executed, command)
+		if command == "fail" {
+			return "", errors.New("unknown command")
+		}
+		return "done " + command, nil
+	})
+	bar.SetCompleteFunc(func(text string) []string {
+		var completions []string
+		for _, command := range []string{"quit", "quiet"} {
+			if strings.HasPrefix(command, text) {
+				completions = append(completions, command)
+			}
+		}
+		return completions
+	})
+	flex := NewFlex()
+	flex.SetDirection(FlexRow)
+	flex.AddItem(list, 0, 1, true)
+	flex.AddItem(bar, 1, 0, false)
+
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := app.GetScreen()
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetRoot(flex, false)
+	flex.SetRect(0, 0, 80, 24)
+	app.draw()
+	app.SetFocus(list)
+
+	typeText := func(text string) {
+		for _, r := range text {
+			app.dispatchKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), "")
+		}
+	}
+	press := func(k tcell.Key) {
+		app.dispatchKey(tcell.NewEventKey(k, 0, tcell.ModNone), "")
+	}
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 80; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return strings.TrimRight(b.String(), " ")
+	}
+
+	// Activate and execute.
+	typeText(":")
+	if !bar.IsActive() || !bar.HasFocus() {
+		t.Fatal("failed to activate command bar")
+	}
+	typeText("open")
+	press(tcell.KeyEnter)
+	if bar.IsActive() || !list.HasFocus() {
+		t.Error("failed to return focus after executing command")
+	}
+	if len(executed) != 1 || executed[0] != "open" {
+		t.Errorf("failed to execute command: expected [open], got %v", executed)
+	}
+	app.draw()
+	if got := row(23); got != "done open" {
+		t.Errorf("failed to show result: expected %q, got %q", "done open", got)
+	}
+
+	// Errors.
+	typeText(":fail")
+	press(tcell.KeyEnter)
+	app.draw()
+	if got := row(23); got != "unknown command" {
+		t.Errorf("failed to show error: expected %q, got %q", "unknown command", got)
+	}
+
+	// History.
+	typeText(":")
+	press(tcell.KeyUp)
+	press(tcell.KeyUp)
+	if got := bar.field.GetText(); got != "open" {
+		t.Errorf("failed to browse history: expected %q, got %q", "open", got)
+	}
+	press(tcell.KeyDown)
+	press(tcell.KeyDown)
+	if got := bar.field.GetText(); got != "" {
+		t.Errorf("failed to return to draft: expected empty text, got %q", got)
+	}
+
+	// Completion.
+	typeText("qu")
+	press(tcell.KeyTab)
+	if got := bar.field.GetText(); got != "quit" {
+		t.Errorf("failed to complete command: expected %q, got %q", "quit", got)
+	}
+	press(tcell.KeyTab)
+	if got := bar.field.GetText(); got != "quiet" {
+		t.Errorf("failed to cycle completions: expected %q, got %q", "quiet", got)
+	}
+
+	// Cancel.
+	press(tcell.KeyEscape)
+	if bar.IsActive() || !list.HasFocus() {
+		t.Error("failed to cancel command")
+	}
+	if len(executed) != 2 {
+		t.Errorf("failed to cancel command: expected 2 executed commands, got %d", len(executed))
+	}
+	if history := bar.GetHistory(); len(history) != 2 || history[0] != "open" || history[1] != "fail" {
+		t.Errorf("failed to record history: expected [open fail], got %v", history)
+	}
+}
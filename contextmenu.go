@@ -1,9 +1,14 @@
 package nuview
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // ContextMenu is a menu that appears upon user interaction, such as right
-// clicking or pressing Alt+Enter.
+// clicking or pressing Keys.ShowContextMenu (Alt+Enter or Shift+F10). It may
+// also be opened programmatically, see Application.OpenContextMenu().
 type ContextMenu struct {
 	parent   Primitive
 	item     int
@@ -16,6 +21,15 @@ type ContextMenu struct {
 	l sync.RWMutex
 }
 
+// contextMenuHost is implemented by primitives with a context menu which may
+// be opened by Application.OpenContextMenu().
+type contextMenuHost interface {
+	// Show the context menu for the selected item at the provided screen
+	// position (-1, -1 for next to the selected item). Returns whether the
+	// menu was opened.
+	openContextMenu(x, y int, setFocus func(Primitive)) bool
+}
+
 // NewContextMenu returns a new context menu.
 func NewContextMenu(parent Primitive) *ContextMenu {
 	return &ContextMenu{
@@ -176,3 +190,63 @@ func (c *ContextMenu) hide(setFocus func(Primitive)) {
 		setFocus(c.parent)
 	}
 }
+
+// drawMenu draws the open context menu. If it was shown without a position,
+// it is placed at the provided anchor position, e.g. next to the selected
+// item of the parent primitive.
+func (c *ContextMenu) drawMenu(screen tcell.Screen, anchorX, anchorY int) {
+	c.l.Lock()
+	c.initializeList()
+	ctx := c.list
+	cx, cy := c.x, c.y
+	c.l.Unlock()
+
+	// What's the longest option text?
+	maxWidth := 0
+	for _, option := range ctx.items {
+		strWidth := TaggedTextWidth(option.mainText)
+		if option.shortcut != 0 {
+			strWidth += 4
+		}
+		if strWidth > maxWidth {
+			maxWidth = strWidth
+		}
+	}
+
+	lheight := len(ctx.items)
+	lwidth := maxWidth
+
+	// Add space for borders
+	lwidth += 2
+	lheight += 2
+
+	lwidth += ctx.paddingLeft + ctx.paddingRight
+	lheight += ctx.paddingTop + ctx.paddingBottom
+
+	if cx < 0 || cy < 0 {
+		cx, cy = anchorX, anchorY
+	}
+
+	_, sheight := screen.Size()
+	if cy+lheight >= sheight && cy-2 > lheight-cy {
+		for i := (cy + lheight) - sheight; i > 0; i-- {
+			cy--
+			if cy+lheight < sheight {
+				break
+			}
+		}
+		if cy < 0 {
+			cy = 0
+		}
+	}
+	if cy+lheight >= sheight {
+		lheight = sheight - cy
+	}
+
+	if ctx.scrollBar.IsVisible(len(ctx.items), ctx.pageSize(lheight)) {
+		lwidth++ // Add space for scroll bar
+	}
+
+	ctx.SetRect(cx, cy, lwidth, lheight)
+	ctx.Draw(UnclippedScreen(screen)) // The menu may extend beyond the parent.
+}
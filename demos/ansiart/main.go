@@ -0,0 +1,44 @@
+// Demo code for the ANSIArt primitive.
+package main
+
+import (
+	"os"
+
+	cview "github.com/sedwards2009/nuview"
+)
+
+// logo is shown if no .ans file is provided on the command line.
+const logo = "\x1b[0;1;34m\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\xdc\r\n" +
+	"\x1b[0;44m \x1b[1;37mn u v i e w  \x1b[33m\xfe \x1b[36mANSI \x1b[0;44m \r\n" +
+	"\x1b[0;1;34m\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\xdf\x1b[0m\r\n"
+
+func main() {
+	app := cview.NewApplication()
+	defer app.HandlePanic()
+
+	app.EnableMouse(true)
+
+	art := cview.NewANSIArt()
+	art.SetBorder(true)
+	if len(os.Args) > 1 {
+		file, err := os.Open(os.Args[1])
+		if err != nil {
+			panic(err)
+		}
+		_, err = art.ReadFrom(file)
+		file.Close()
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		art.SetArt([]byte(logo))
+	}
+	if sauce := art.GetSAUCE(); sauce != nil {
+		art.SetTitle(sauce.Title + " by " + sauce.Author)
+	}
+
+	app.SetRoot(art, true)
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
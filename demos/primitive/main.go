@@ -2,17 +2,28 @@
 package main
 
 import (
-	"fmt"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
 	cview "github.com/sedwards2009/nuview"
 )
 
-// RadioButtons implements a simple primitive for radio button selections.
+// RadioButtons implements a simple primitive for radio button selections. It
+// serves as a template for custom primitives: it embeds Box, guards its state
+// with a sync.RWMutex, draws through the helpers for custom primitives and
+// wraps its input and mouse handlers.
 type RadioButtons struct {
 	*cview.Box
+
+	// The options to choose from and the index of the chosen one.
 	options       []string
 	currentOption int
+
+	// The styles of the options. Selected is used for the chosen option while
+	// the primitive has focus.
+	style cview.WidgetStyle
+
+	sync.RWMutex
 }
 
 // NewRadioButtons returns a new radio button primitive.
@@ -20,48 +31,95 @@ func NewRadioButtons(options []string) *RadioButtons {
 	return &RadioButtons{
 		Box:     cview.NewBox(),
 		options: options,
+		style: cview.WidgetStyle{
+			Normal:   tcell.StyleDefault.Foreground(cview.Styles.PrimaryTextColor),
+			Selected: tcell.StyleDefault.Foreground(cview.Styles.InverseTextColor).Background(cview.Styles.PrimaryTextColor),
+		},
 	}
 }
 
+// GetCurrentOption returns the index of the chosen option.
+func (r *RadioButtons) GetCurrentOption() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.currentOption
+}
+
 // Draw draws this primitive onto the screen.
 func (r *RadioButtons) Draw(screen tcell.Screen) {
+	if !r.GetVisible() {
+		return
+	}
+
 	r.Box.Draw(screen)
-	x, y, width, height := r.GetInnerRect()
+	hasFocus := r.GetFocusable().HasFocus()
+
+	r.RLock()
+	defer r.RUnlock()
 
+	x, y, width, height := r.GetInnerRect()
 	for index, option := range r.options {
 		if index >= height {
 			break
 		}
-		radioButton := "\u25ef" // Unchecked.
+		radioButton := "◯" // Unchecked.
 		if index == r.currentOption {
-			radioButton = "\u25c9" // Checked.
+			radioButton = "◉" // Checked.
 		}
-		line := fmt.Sprintf(`%s[white]  %s`, radioButton, option)
-		cview.Print(screen, []byte(line), x, y+index, width, cview.AlignLeft, tcell.ColorYellow.TrueColor())
+		style := r.style.Style(hasFocus, false, hasFocus && index == r.currentOption)
+		cview.PrintWithStyle(screen, radioButton+"  "+cview.Escape(option), x, y+index, 0, width, cview.AlignLeft, style, false, ' ')
 	}
 }
 
 // InputHandler returns the handler for this primitive.
 func (r *RadioButtons) InputHandler() func(event *tcell.EventKey, setFocus func(p cview.Primitive)) {
 	return r.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p cview.Primitive)) {
-		switch event.Key() {
-		case tcell.KeyUp:
-			r.currentOption--
-			if r.currentOption < 0 {
-				r.currentOption = 0
-			}
-		case tcell.KeyDown:
-			r.currentOption++
-			if r.currentOption >= len(r.options) {
-				r.currentOption = len(r.options) - 1
+		r.Lock()
+		defer r.Unlock()
+
+		if cview.HitShortcut(event, cview.Keys.MoveUp, cview.Keys.MoveUp2) {
+			r.currentOption = max(r.currentOption-1, 0)
+		} else if cview.HitShortcut(event, cview.Keys.MoveDown, cview.Keys.MoveDown2) {
+			r.currentOption = min(r.currentOption+1, len(r.options)-1)
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (r *RadioButtons) MouseHandler() func(action cview.MouseAction, event *tcell.EventMouse, setFocus func(p cview.Primitive)) (consumed bool, capture cview.Primitive) {
+	return r.WrapMouseHandler(func(action cview.MouseAction, event *tcell.EventMouse, setFocus func(p cview.Primitive)) (consumed bool, capture cview.Primitive) {
+		x, y := event.Position()
+		if !r.InRect(x, y) {
+			return false, nil
+		}
+
+		r.Lock()
+		defer r.Unlock()
+
+		switch action {
+		case cview.MouseLeftClick:
+			_, rectY, _, _ := r.GetInnerRect()
+			if index := y - rectY; index >= 0 && index < len(r.options) {
+				r.currentOption = index
 			}
+			setFocus(r)
+			consumed = true
+		case cview.MouseScrollUp:
+			r.currentOption = max(r.currentOption-1, 0)
+			consumed = true
+		case cview.MouseScrollDown:
+			r.currentOption = min(r.currentOption+1, len(r.options)-1)
+			consumed = true
 		}
+		return
 	})
 }
 
 func main() {
 	app := cview.NewApplication()
 	defer app.HandlePanic()
+	app.EnableMouse(true)
 
 	radioButtons := NewRadioButtons([]string{"Lions", "Elephants", "Giraffes"})
 	radioButtons.SetBorder(true)
@@ -106,7 +106,7 @@ func main() {
 	buttonPanel.AddItem(plus10Button, 3, 0, false)
 	buttonPanel.AddItem(minus10Button, 3, 0, false)
 
-	innerLayout.AddItem(buttonPanel, 10, 0, false)
+	innerLayout.AddItem(buttonPanel, nuview.AutoSize, 1, false)
 	innerLayout.AddItem(table, 0, 1, true)
 
 	rightBox := nuview.NewBox()
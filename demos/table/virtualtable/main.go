@@ -0,0 +1,54 @@
+// Demo code for a Table backed by virtual data. The table has a million rows
+// but only the visible cells are ever created.
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sedwards2009/nuview"
+)
+
+// tableData computes its cells on demand.
+type tableData struct {
+	nuview.TableContentReadOnly
+}
+
+func (d *tableData) GetCell(row, column int) *nuview.TableCell {
+	switch {
+	case row == 0:
+		return nuview.NewTableCell([]string{"Number", "Square", "Square Root"}[column])
+	case column == 0:
+		return nuview.NewTableCell(fmt.Sprintf("%d", row))
+	case column == 1:
+		return nuview.NewTableCell(fmt.Sprintf("%d", row*row))
+	default:
+		return nuview.NewTableCell(fmt.Sprintf("%.4f", math.Sqrt(float64(row))))
+	}
+}
+
+func (d *tableData) GetRowCount() int {
+	return 1000000
+}
+
+func (d *tableData) GetColumnCount() int {
+	return 3
+}
+
+func main() {
+	app := nuview.NewApplication()
+	defer app.HandlePanic()
+
+	app.EnableMouse(true)
+
+	table := nuview.NewTable()
+	table.SetBorders(true)
+	table.SetFixed(1, 0)
+	table.SetSelectable(true, false)
+	table.SetContent(&tableData{})
+
+	app.SetRoot(table, true)
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
@@ -19,31 +19,103 @@ primitive, Box, and thus inherit its functions. This isn't necessarily
 required, but it makes more sense than reimplementing Box's functionality in
 each widget.
 
+The print functions of this package (e.g. Print, PrintStyle) draw onto a
+ScreenWriter, which may be the tcell.Screen itself or a clipping and
+translating writer. Containers such as Window and WindowManager draw their
+children onto a ClippedScreen so that no primitive, including custom ones,
+draws outside of the area assigned to it. Popups which need to extend beyond
+that area draw onto UnclippedScreen(screen).
+
+Primitives which can calculate the size of their content implement the
+optional PreferredSize interface. Flex items and Grid rows and columns with a
+size of AutoSize are sized accordingly, as are windows with
+Window.SizeToContent.
+
+# Custom Primitives
+
+Custom primitives usually embed Box and wrap their handlers with
+Box.WrapInputHandler and Box.WrapMouseHandler. The following helpers are
+provided for them:
+
+  - AnyHasFocus determines whether any of a container's children has focus.
+  - ScrollBar draws a scroll bar and handles mouse interaction with it.
+  - Print, PrintStyle and PrintWithStyle render text containing color tags.
+  - FillRect, DrawBorder, ClippedScreen and the ScreenWriter implementations
+    draw onto arbitrary areas of the screen.
+  - WidgetStyle holds the styles of a primitive for its different states.
+
+Simple custom views need not implement a primitive at all: a plain Box with
+Box.SetScrollableContent draws content taller than itself and scrolls it with
+the keyboard, the mouse wheel and a scroll bar. Likewise, a BorderRenderer (see
+Box.SetBorderRenderer) changes how the border and the title of any primitive
+are drawn.
+
+To find out why an item of a Flex or a Grid did not get the expected size,
+enable its debug overlay with Flex.SetDebug or Grid.SetDebug. It shows the
+index and size of each item as well as the items which ended up with no space.
+
+See the RadioButtons primitive in demos/primitive for a template of a custom
+primitive which uses these helpers.
+
 # Widgets
 
 The following widgets are available:
 
+	ANSIArt - A scrollable display of ANSI art (.ans files) with SAUCE support.
 	Button - Button which is activated when the user selects it.
 	CheckBox - Selectable checkbox for boolean values.
-	DropDown - Drop-down selection field.
+	CommandBar - A vim-like command line with history and completion, activated
+	  by typing ":".
+	DropDown - Drop-down selection field. An editable drop-down also accepts
+	  free text (a combo box), see DropDown.SetEditable.
 	Flex - A Flexbox based layout manager.
 	Form - Form composed of input fields, drop down selections, checkboxes, and
-	  buttons.
+	  buttons. Forms may be split into pages with next/back navigation. Other
+	  primitives such as headings may be placed between the items.
 	Grid - A grid based layout manager.
+	Image - Displays an image using half block characters and true colors.
 	InputField - Single-line text entry field.
+	Link - Underlined text which triggers an action when selected, optionally
+	  a terminal hyperlink.
 	List - A navigable text list with optional keyboard shortcuts.
 	Modal - A centered window with a text message and one or more buttons.
 	Panels - A panel based layout manager.
 	ProgressBar - Indicates the progress of an operation.
-	TabbedPanels - Panels widget with tabbed navigation.
+	TabbedPanels - Panels widget with tabbed navigation. Tabs may show badges
+	  and be switched with shortcuts, see TabbedPanels.SetTabShortcut.
 	Table - A scrollable display of tabular data. Table cells, rows, or columns
-	  may also be highlighted.
+	  may also be highlighted. Cells may draw progress bars, sparklines and
+	  more via a CellRenderer, or checkboxes bound to bools, see
+	  NewCheckboxCell. A header row stays visible while scrolling and
+	  shows the sort order, see Table.SetHeader. Rows and columns may be
+	  reordered by the user, see Table.SetRowsMovable and
+	  Table.SetColumnsMovable, and hidden, see Table.SetColumnVisible. The
+	  row under the mouse may be highlighted, see Table.SetHoverStyle.
+	  Columns may shrink to fit the table, see Table.SetAutoFitColumns.
+	TableSplitView - Shows a Table in two vertically stacked panes which scroll
+	  independently.
+	TaskList - Shows the progress of tasks running in the background and lets
+	  the user cancel them.
 	TextView - A scrollable window that displays multi-colored text. Text may
-	  also be highlighted.
+	  also be highlighted and annotated, e.g. by a spell checker or a linter.
+	TimeSeriesChart - Plots series of timestamped values over a sliding time
+	  window.
 	TreeView - A scrollable display for hierarchical data. Tree nodes can be
 	  highlighted, collapsed, expanded, and more.
 	Window - A draggable and resizable container.
 
+FuzzyMatch, FuzzyFilter and FuzzyHighlight implement fuzzy search, e.g. for
+filtering a List (see List.FuzzyFindItems) or for the autocomplete entries of
+an InputField (see FuzzyAutocomplete).
+
+A SliceAdapter (see NewListAdapter and NewTableAdapter) displays the items of
+a Go slice in a List or a Table and updates only the affected items when the
+slice changes.
+
+Settings holds the declared settings of an application, loads and writes them
+as JSON or TOML and notifies about changes. A SettingsView lets the user search
+and edit them in a Form.
+
 Widgets may be used without an application created via NewApplication, allowing
 them to be integrated into any tcell-based application.
 
@@ -54,9 +126,14 @@ from multiple threads, functions will block until the application or widget
 becomes available. Function calls may be queued with Application.QueueUpdate to
 avoid blocking.
 
+Output printed by other goroutines while the application is running corrupts
+the screen. Application.SetOutputGuard redirects it, e.g. to a TextView.
+
 # Unicode Support
 
 This package supports unicode characters including wide characters.
+MeasureTagged and TruncateTagged measure and shorten texts containing style
+tags without splitting grapheme clusters or wide characters.
 
 # Keyboard Shortcuts
 
@@ -73,6 +150,19 @@ file.
 
 cbind: https://codeberg.org/tslocum/cbind
 
+Dialogs and other composite widgets may confine the focus to their primitives
+with a FocusScope (see Application.PushFocusScope). Tab and Backtab then cycle
+through the primitives of the scope and Escape exits it.
+
+Holding down an arrow key may queue up more key events than the application
+can process, e.g. when moving through a huge table. Application.SetKeyRepeatFilter
+drops such repeated navigation keys so the application stops moving as soon as
+the key is released.
+
+Windows and the splitters between Flex items may be moved without a mouse in
+arrange mode, see Application.EnableArrange: Ctrl+W followed by the arrow keys
+moves the focused window or splitter, Shift and the arrow keys resize windows.
+
 # Bracketed Paste Mode
 
 Bracketed paste mode is enabled by default. It may be disabled by calling
@@ -87,9 +177,27 @@ Mouse support may be enabled by calling Application.EnableMouse before
 Application.Run. See the example application provided with the
 Application.EnableMouse documentation.
 
-Double clicks are treated single clicks by default. Specify a maximum duration
-between clicks with Application.SetDoubleClickInterval to enable double clicks.
-A standard duration is provided as StandardDoubleClick.
+Clicks of the same button at the same position in quick succession are
+delivered as a click action followed by a double click action (second click) or
+a triple click action (third click). The maximum duration between clicks is
+StandardDoubleClick by default and may be changed with
+Application.SetDoubleClickInterval. An interval of 0 disables double and triple
+clicks. Any widget may respond to double clicks and right clicks without
+overriding its mouse handler, see Box.SetDoubleClickedFunc and
+Box.SetRightClickedFunc.
+
+MouseEnter and MouseLeave actions are delivered to the innermost widget under
+the mouse when it changes, after enabling them with
+Application.EnableMouseHover.
+
+Text in a TextView may be selected by dragging the mouse over it, words and
+lines by double- and triple-clicking them. Selected text is copied to the
+system clipboard when the selection is complete if SetCopyOnSelect was enabled
+on the application's clipboard manager, similar to the primary selection of
+X11. Applications use the global Clipboard unless one is set with
+Application.SetClipboard. By default, the text is sent to the application's
+terminal (OSC 52). ClipboardManager.SetWriteFunc replaces this, e.g. with an
+external program.
 
 Mouse events are passed to:
 
@@ -168,7 +276,11 @@ character that may be used in color or region tags will be recognized. Examples:
 You can use the Escape() function to insert brackets automatically where needed.
 
 Setting the background color of a primitive to tcell.ColorDefault will use the
-default terminal background color. To enable transparency (allowing one or more
+default terminal background color. Alternatively, call SetDefaultBackground
+to keep a primitive's background color but leave the background of its cells
+to the terminal, e.g. to let a terminal background image show through. The
+primitive's contents and selections are drawn accordingly. To enable
+transparency (allowing one or more
 primitives to display behind a primitive) call SetBackgroundTransparent. The
 screen is not cleared before drawing the application. Overlaying transparent
 widgets directly onto the screen may result in artifacts. To resolve this, add
@@ -186,6 +298,69 @@ feel of the primitives to your preferred style.
 Scroll bars are supported by the following widgets: List, Table, TextView and
 TreeView. Each widget will display scroll bars automatically when there are
 additional items offscreen. See SetScrollBarColor and SetScrollBarVisibility.
+Tables also show a horizontal scroll bar for columns which are offscreen, see
+Table.SetHorizontalScrollBarVisibility.
+
+List, Table and TextView optionally scroll smoothly (see SetSmoothScrolling):
+scrolling with the mouse wheel or by pages is then animated over a few frames,
+redrawing the application every AnimationFrameInterval. Set
+DisableSmoothScrolling to true to turn this off for all primitives.
+
+# Selections
+
+List, Table and TreeView select their current item. To select more items, set
+a SelectionModel via SetSelectionModel. The model supports single, multiple
+and range selections, notifies a handler of changes and may be saved and
+restored as text. Keys.ToggleSelection, Keys.ExtendSelectionUp and
+Keys.ExtendSelectionDown as well as Ctrl and Shift clicks change the
+selection.
+
+Tables may also select a rectangular range of cells, see
+Table.SetRangeSelectable. Shift and the arrow keys or dragging the mouse extend
+the range, Table.GetSelectedRange and Table.GetSelectedCells return it.
+
+# Placeholders
+
+List, Table and TreeView draw a placeholder in place of their content when
+they have none (see SetPlaceholder and SetPlaceholderText) and a spinner while
+their content is loading (see SetLoading). Like TextView, they also draw an
+error message in place of their content (see SetError). Buttons show a spinner
+while their action is running and cannot be activated meanwhile (see
+Button.SetLoading).
+
+A Refresher loads content in the background, periodically or on demand, and
+delivers it in the event loop while the primitive shows the loading and error
+states.
+
+# Overlays
+
+Dialogs and other overlays are added to Panels via AddOverlayPanel. A modal
+overlay keeps all input from the panels below it, a pass-through overlay only
+mouse events inside its rect. See Panels.SetDimOverlaid to dim the panels
+below an overlay.
+
+# Sessions
+
+Primitives implementing Persistent save and restore their view state, such as
+scroll positions, selections, expanded tree nodes, the current tab or the
+position of a window. Register them with Application.SetPersistenceID, then
+Application.SaveSession and Application.RestoreSession save and restore all
+of them at once, to reopen the application where the user left off.
+
+# Headless Mode
+
+Applications may be run without a terminal via NewHeadless. Events are sent
+programmatically and the rendered frames are retrieved as text, either
+in-process or by other processes connecting to Headless.Serve. This enables
+end-to-end tests and the automation of terminal user interfaces.
+
+# Multiple Applications
+
+A process may run several applications at once, each on its own screen, e.g.
+one per SSH session or tty device. The global Styles, Borders and Keys are the
+defaults of all applications. Instead of modifying them while applications are
+running, give each application its own configuration with
+Application.SetStyles, Application.SetBorders and Application.SetKeys.
 
 # Hello World
 
@@ -1,8 +1,11 @@
 package nuview
 
 import (
+	"reflect"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
@@ -22,6 +25,13 @@ func NewDropDownOption(text string) *DropDownOption {
 	return &DropDownOption{text: text}
 }
 
+// NewDropDownOptionWithReference returns a new option for a dropdown which
+// carries the provided reference value. See DropDown.GetCurrentOptionReference
+// and DropDown.SetCurrentOptionByReference.
+func NewDropDownOptionWithReference(text string, reference interface{}) *DropDownOption {
+	return &DropDownOption{text: text, reference: reference}
+}
+
 // GetText returns the text of this dropdown option.
 func (d *DropDownOption) GetText() string {
 	d.RLock()
@@ -50,6 +60,9 @@ func (d *DropDownOption) GetReference() interface{} {
 
 // SetReference allows you to store a reference of any type in this option.
 func (d *DropDownOption) SetReference(reference interface{}) {
+	d.Lock()
+	defer d.Unlock()
+
 	d.reference = reference
 }
 
@@ -146,9 +159,29 @@ type DropDown struct {
 	// A flag that determines whether the drop down symbol is always drawn.
 	alwaysDrawDropDownSymbol bool
 
+	// Whether or not the selection may be changed with the keyboard while the
+	// drop-down is closed.
+	selectWithoutOpening bool
+
+	// The runes typed so far while the drop-down is closed and the time the
+	// last one was typed.
+	typeAhead     string
+	typeAheadTime time.Time
+
+	// Whether or not the user may enter any text, see SetEditable(), the
+	// field in which it is entered, and whether or not its autocomplete list
+	// currently offers all options.
+	editable       bool
+	field          *InputField
+	showAllOptions bool
+
 	sync.RWMutex
 }
 
+// typeAheadTimeout is the maximum time between two runes typed into a closed
+// drop-down which are matched together against the options.
+const typeAheadTimeout = time.Second
+
 // NewDropDown returns a new drop-down.
 func NewDropDown() *DropDown {
 	list := NewList()
@@ -223,6 +256,23 @@ func (d *DropDown) SetAlwaysDrawDropDownSymbol(alwaysDraw bool) {
 	d.alwaysDrawDropDownSymbol = alwaysDraw
 }
 
+// SetSelectWithoutOpening sets a flag which determines whether or not the
+// selection may be changed while the drop-down is closed, as is common for
+// combo boxes in graphical user interfaces. If enabled, the up and down keys
+// select the previous and next option and typing selects the next option
+// starting with the typed text. Typing the same letter repeatedly cycles
+// through the options starting with that letter. The "selected" callbacks are
+// triggered for every change. Enter and space still open the drop-down.
+//
+// This is disabled by default, i.e. any key opens the drop-down.
+func (d *DropDown) SetSelectWithoutOpening(selectWithoutOpening bool) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.selectWithoutOpening = selectWithoutOpening
+	d.typeAhead = ""
+}
+
 // SetCurrentOption sets the index of the currently selected option. This may
 // be a negative value to indicate that no option is currently selected. Calling
 // this function will also trigger the "selected" callback (if there is one).
@@ -250,7 +300,16 @@ func (d *DropDown) SetCurrentOption(index int) {
 			d.Lock()
 		}
 	}
+	field, text := d.field, ""
+	if d.currentOption >= 0 {
+		text = d.options[d.currentOption].text
+	}
+	editable := d.editable
 	d.Unlock()
+
+	if editable && field != nil {
+		field.SetText(text)
+	}
 }
 
 // GetCurrentOption returns the index of the currently selected option as well
@@ -266,6 +325,74 @@ func (d *DropDown) GetCurrentOption() (int, *DropDownOption) {
 	return d.currentOption, option
 }
 
+// GetCurrentOptionReference returns the reference object of the currently
+// selected option. If no option is selected, nil is returned.
+func (d *DropDown) GetCurrentOptionReference() interface{} {
+	_, option := d.GetCurrentOption()
+	if option == nil {
+		return nil
+	}
+	return option.GetReference()
+}
+
+// SetCurrentOptionByReference selects the first option whose reference object
+// equals the provided reference. Returns false when no option matches, in
+// which case the selection remains unchanged. Like SetCurrentOption, this
+// triggers the "selected" callback.
+func (d *DropDown) SetCurrentOptionByReference(reference interface{}) bool {
+	index := d.findOption(func(option *DropDownOption) bool {
+		return referencesEqual(option.GetReference(), reference)
+	})
+	if index < 0 {
+		return false
+	}
+	d.SetCurrentOption(index)
+	return true
+}
+
+// SetCurrentOptionByText selects the first option whose text equals the
+// provided text. Returns false when no option matches, in which case the
+// selection remains unchanged. Like SetCurrentOption, this triggers the
+// "selected" callback.
+func (d *DropDown) SetCurrentOptionByText(text string) bool {
+	index := d.findOption(func(option *DropDownOption) bool {
+		return option.GetText() == text
+	})
+	if index < 0 {
+		return false
+	}
+	d.SetCurrentOption(index)
+	return true
+}
+
+// findOption returns the index of the first option for which match returns
+// true, or -1.
+func (d *DropDown) findOption(match func(option *DropDownOption) bool) int {
+	d.RLock()
+	defer d.RUnlock()
+
+	for index, option := range d.options {
+		if match(option) {
+			return index
+		}
+	}
+	return -1
+}
+
+// referencesEqual compares two reference objects without panicking on
+// uncomparable values such as slices or maps, or structs holding them in
+// interface fields. Such values are never equal.
+func referencesEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() || !va.Comparable() || !vb.Comparable() {
+		return false
+	}
+	return a == b
+}
+
 // SetTextOptions sets the text to be placed before and after each drop-down
 // option (prefix/suffix), the text placed before and after the currently
 // selected option (currentPrefix/currentSuffix) as well as the text to be
@@ -423,6 +550,26 @@ func (d *DropDown) GetFieldWidth() int {
 	return d.getFieldWidth()
 }
 
+// PreferredWidth returns the width of the label and the field plus the border
+// and padding.
+func (d *DropDown) PreferredWidth(maxWidth int) int {
+	d.RLock()
+	defer d.RUnlock()
+
+	labelWidth := d.labelWidth
+	if labelWidth <= 0 {
+		labelWidth = TaggedStringWidth(d.label)
+	}
+	return preferredFormItemWidth(d.Box, labelWidth, d.getFieldWidth(), maxWidth)
+}
+
+// PreferredHeight returns the height of the closed drop-down plus the border
+// and padding.
+func (d *DropDown) PreferredHeight(width int) int {
+	_, frameHeight := d.frameSize()
+	return 1 + frameHeight
+}
+
 func (d *DropDown) getFieldWidth() int {
 	if d.fieldWidth > 0 {
 		return d.fieldWidth
@@ -613,7 +760,9 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 	}
 
 	// Draw selected text.
-	if d.open && len(d.prefix) > 0 {
+	if d.editable && d.field != nil {
+		d.drawField(screen, x, y, fieldWidth, fieldTextColor, fieldBackgroundColor)
+	} else if d.open && len(d.prefix) > 0 {
 		// Show the prefix.
 		currentOptionPrefixWidth := TaggedStringWidth(d.currentOptionPrefix)
 		prefixWidth := runewidth.StringWidth(d.prefix)
@@ -664,20 +813,33 @@ func (d *DropDown) Draw(screen tcell.Screen) {
 			lheight = sheight - ly
 		}
 		lwidth := maxWidth
-		if d.list.scrollBarVisibility == ScrollBarAlways || (d.list.scrollBarVisibility == ScrollBarAuto && len(d.options) > lheight) {
+		if d.list.scrollBar.IsVisible(len(d.options), lheight) {
 			lwidth++ // Add space for scroll bar
 		}
 		if lwidth < fieldWidth {
 			lwidth = fieldWidth
 		}
 		d.list.SetRect(lx, ly, lwidth, lheight)
-		d.list.Draw(screen)
+		d.list.Draw(UnclippedScreen(screen)) // The list may extend beyond the drop-down.
 	}
 }
 
 // InputHandler returns the handler for this primitive.
 func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return d.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		d.RLock()
+		field := d.field
+		editable := d.editable
+		d.RUnlock()
+		if editable && field != nil {
+			field.InputHandler()(event, setFocus)
+			return
+		}
+
+		if d.selectClosed(event) {
+			return
+		}
+
 		// Process key event.
 		switch key := event.Key(); key {
 		case tcell.KeyEnter, tcell.KeyRune, tcell.KeyDown:
@@ -704,6 +866,86 @@ func (d *DropDown) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 	})
 }
 
+// selectClosed changes the selection of a closed drop-down according to the
+// provided key event if this is enabled. It returns whether or not the event
+// was handled.
+func (d *DropDown) selectClosed(event *tcell.EventKey) bool {
+	d.Lock()
+	if !d.selectWithoutOpening || d.open || len(d.options) == 0 {
+		d.Unlock()
+		return false
+	}
+
+	index := d.currentOption
+	if d.hitShortcut(event, d.keys().MoveUp) {
+		d.typeAhead = ""
+		if index < 0 {
+			index = len(d.options)
+		}
+		index = max(index-1, 0)
+	} else if d.hitShortcut(event, d.keys().MoveDown) {
+		d.typeAhead = ""
+		index = min(index+1, len(d.options)-1)
+	} else if event.Key() == tcell.KeyRune && event.Rune() != ' ' {
+		index = d.matchTypeAhead(event.Rune())
+	} else {
+		d.Unlock()
+		return false
+	}
+	changed := index >= 0 && index != d.currentOption
+	d.Unlock()
+
+	if changed {
+		d.SetCurrentOption(index)
+	}
+	return true
+}
+
+// matchTypeAhead adds the provided rune to the runes typed into the closed
+// drop-down and returns the index of the option which they select, or -1 if
+// they match no option. The drop-down must be locked.
+func (d *DropDown) matchTypeAhead(r rune) int {
+	now := time.Now()
+	if now.Sub(d.typeAheadTime) > typeAheadTimeout {
+		d.typeAhead = ""
+	}
+	d.typeAheadTime = now
+	d.typeAhead += string(unicode.ToLower(r))
+
+	// find returns the first option starting with the prefix, searching from
+	// the provided index and wrapping around.
+	find := func(prefix string, from int) int {
+		for offset := range d.options {
+			index := (max(from, 0) + offset) % len(d.options)
+			if strings.HasPrefix(strings.ToLower(d.options[index].text), prefix) {
+				return index
+			}
+		}
+		return -1
+	}
+
+	// A single rune moves on to the next matching option. Further runes
+	// refine the current match.
+	if len([]rune(d.typeAhead)) == 1 {
+		return find(d.typeAhead, d.currentOption+1)
+	}
+	if index := find(d.typeAhead, d.currentOption); index >= 0 {
+		return index
+	}
+
+	// Typing the same rune repeatedly cycles through the options starting
+	// with it.
+	if strings.Trim(d.typeAhead, string(unicode.ToLower(r))) == "" {
+		d.typeAhead = string(unicode.ToLower(r))
+		return find(d.typeAhead, d.currentOption+1)
+	}
+
+	// The rune does not match any option. Remove it.
+	runes := []rune(d.typeAhead)
+	d.typeAhead = string(runes[:len(runes)-1])
+	return -1
+}
+
 // evalPrefix selects an item in the drop-down list based on the current prefix.
 func (d *DropDown) evalPrefix() {
 	if len(d.prefix) > 0 {
@@ -782,6 +1024,15 @@ func (d *DropDown) closeList(setFocus func(Primitive)) {
 
 // Focus is called by the application when the primitive receives focus.
 func (d *DropDown) Focus(delegate func(p Primitive)) {
+	d.RLock()
+	field := d.field
+	editable := d.editable
+	d.RUnlock()
+	if editable && field != nil {
+		delegate(field)
+		return
+	}
+
 	d.Box.Focus(delegate)
 	if d.open {
 		delegate(d.list)
@@ -797,6 +1048,9 @@ func (d *DropDown) HasFocus() bool {
 }
 
 func (d *DropDown) _hasFocus() bool {
+	if d.editable && d.field != nil {
+		return d.field.HasFocus()
+	}
 	if d.open {
 		return d.list.HasFocus()
 	}
@@ -810,6 +1064,18 @@ func (d *DropDown) MouseHandler() func(action MouseAction, event *tcell.EventMou
 		x, y := event.Position()
 		_, rectY, _, _ := d.GetInnerRect()
 		inRect := y == rectY
+		if d.editable && d.field != nil {
+			if !inRect || !d.InRect(x, y) {
+				return false, nil
+			}
+			if action == MouseLeftClick {
+				if fieldX, _, _, _ := d.field.GetRect(); x < fieldX {
+					setFocus(d.field) // The label was clicked.
+					return true, nil
+				}
+			}
+			return d.field.MouseHandler()(action, event, setFocus)
+		}
 		if !d.open && !inRect {
 			return d.InRect(x, y), nil // No, and it's not expanded either. Ignore.
 		}
@@ -0,0 +1,198 @@
+package nuview
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDropDownReference(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.AddOptions(
+		NewDropDownOptionWithReference("Red", 1),
+		NewDropDownOptionWithReference("Green", 2),
+		NewDropDownOptionWithReference("Blue", []int{3}),
+	)
+
+	if ref := d.GetCurrentOptionReference(); ref != nil {
+		t.Errorf("failed to initialize DropDown: expected nil reference, got %v", ref)
+	}
+
+	if !d.SetCurrentOptionByReference(2) {
+		t.Errorf("failed to select DropDown option by reference: no match for 2")
+	} else if index, _ := d.GetCurrentOption(); index != 1 {
+		t.Errorf("failed to select DropDown option by reference: expected index 1, got %d", index)
+	} else if ref := d.GetCurrentOptionReference(); ref != 2 {
+		t.Errorf("failed to get DropDown reference: expected 2, got %v", ref)
+	}
+
+	if d.SetCurrentOptionByReference([]int{3}) {
+		t.Errorf("failed to select DropDown option by reference: uncomparable reference matched")
+	} else if index, _ := d.GetCurrentOption(); index != 1 {
+		t.Errorf("failed to keep DropDown selection: expected index 1, got %d", index)
+	}
+
+	type key struct{ value interface{} }
+	d.AddOptions(
+		NewDropDownOptionWithReference("Violet", key{[]int{4}}),
+		NewDropDownOptionWithReference("Yellow", key{5}),
+	)
+	if d.SetCurrentOptionByReference(key{[]int{4}}) {
+		t.Errorf("failed to select DropDown option by reference: struct with uncomparable field matched")
+	} else if index, _ := d.GetCurrentOption(); index != 1 {
+		t.Errorf("failed to keep DropDown selection: expected index 1, got %d", index)
+	}
+	if !d.SetCurrentOptionByReference(key{5}) {
+		t.Errorf("failed to select DropDown option by reference: no match for comparable struct")
+	} else if index, _ := d.GetCurrentOption(); index != 4 {
+		t.Errorf("failed to select DropDown option by reference: expected index 4, got %d", index)
+	}
+
+	if !d.SetCurrentOptionByText("Blue") {
+		t.Errorf("failed to select DropDown option by text: no match for Blue")
+	} else if index, _ := d.GetCurrentOption(); index != 2 {
+		t.Errorf("failed to select DropDown option by text: expected index 2, got %d", index)
+	}
+
+	if d.SetCurrentOptionByText("Purple") {
+		t.Errorf("failed to select DropDown option by text: unexpected match for Purple")
+	}
+}
+
+func TestDropDownSelectWithoutOpening(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.SetOptions(nil, NewDropDownOption("Apple"), NewDropDownOption("Banana"), NewDropDownOption("Blueberry"), NewDropDownOption("Cherry"))
+	d.SetSelectWithoutOpening(true)
+
+	var selected []int
+	d.SetSelectedFunc(func(index int, option *DropDownOption) {
+		selected = append(selected, index)
+	})
+
+	handler := d.InputHandler()
+	press := func(key tcell.Key, r rune) {
+		handler(tcell.NewEventKey(key, r, tcell.ModNone), func(p Primitive) {})
+	}
+
+	press(tcell.KeyDown, 0)
+	press(tcell.KeyDown, 0)
+	press(tcell.KeyUp, 0)
+	press(tcell.KeyUp, 0)
+	if d.open {
+		t.Fatal("failed to keep drop-down closed: list was opened")
+	}
+	if expected := []int{0, 1, 0}; !reflect.DeepEqual(selected, expected) {
+		t.Errorf("failed to select with up and down keys: expected %v, got %v", expected, selected)
+	}
+
+	press(tcell.KeyRune, 'b')
+	press(tcell.KeyRune, 'l')
+	if index, _ := d.GetCurrentOption(); index != 2 {
+		t.Errorf("failed to select by prefix: expected index 2, got %d", index)
+	}
+
+	d.SetSelectWithoutOpening(true) // Resets the typed prefix.
+	press(tcell.KeyRune, 'b')
+	press(tcell.KeyRune, 'b')
+	if index, _ := d.GetCurrentOption(); index != 2 {
+		t.Errorf("failed to cycle through options by repeated letter: expected index 2, got %d", index)
+	}
+
+	press(tcell.KeyRune, 'x')
+	if index, _ := d.GetCurrentOption(); index != 2 || d.open {
+		t.Errorf("failed to ignore unmatched letter: expected index 2, got %d", index)
+	}
+
+	press(tcell.KeyEnter, 0)
+	if !d.open {
+		t.Error("failed to open drop-down with enter")
+	}
+}
+
+func TestDropDownEditable(t *testing.T) {
+	t.Parallel()
+
+	d := NewDropDown()
+	d.SetOptions(nil, NewDropDownOption("Apple"), NewDropDownOption("Banana"), NewDropDownOption("Blueberry"))
+	d.SetCurrentOption(0)
+	d.SetEditable(true)
+	if text := d.GetText(); text != "Apple" {
+		t.Errorf("failed to take over current option: expected Apple, got %q", text)
+	}
+
+	type selection struct {
+		index int
+		text  string
+	}
+	var selected []selection
+	d.SetSelectedFunc(func(index int, option *DropDownOption) {
+		selected = append(selected, selection{index, d.GetText()})
+	})
+	var done []tcell.Key
+	d.SetDoneFunc(func(key tcell.Key) {
+		done = append(done, key)
+	})
+
+	handler := d.InputHandler()
+	press := func(key tcell.Key, r rune) {
+		handler(tcell.NewEventKey(key, r, tcell.ModNone), func(Primitive) {})
+	}
+
+	// Free text.
+	press(tcell.KeyCtrlU, 0)
+	for _, r := range "Cherry" {
+		press(tcell.KeyRune, r)
+	}
+	if index, option := d.GetCurrentOption(); index != -1 || option != nil {
+		t.Errorf("failed to accept free text: expected no option, got %d", index)
+	}
+	press(tcell.KeyEnter, 0)
+
+	// A suggested option.
+	press(tcell.KeyCtrlU, 0)
+	press(tcell.KeyRune, 'b')
+	if entries := d.editSuggestions("b"); len(entries) != 2 {
+		t.Errorf("failed to suggest options: expected 2, got %d", len(entries))
+	}
+	press(tcell.KeyDown, 0)
+	press(tcell.KeyEnter, 0)
+	if index, _ := d.GetCurrentOption(); index != 2 || d.GetText() != "Blueberry" {
+		t.Errorf("failed to choose suggestion: expected 2 (Blueberry), got %d (%s)", index, d.GetText())
+	}
+	press(tcell.KeyTab, 0)
+
+	expected := []selection{{-1, "Cherry"}, {2, "Blueberry"}}
+	if !reflect.DeepEqual(selected, expected) {
+		t.Errorf("failed to trigger selected callback: expected %v, got %v", expected, selected)
+	}
+	if !reflect.DeepEqual(done, []tcell.Key{tcell.KeyTab}) {
+		t.Errorf("failed to trigger done callback: expected [Tab], got %v", done)
+	}
+
+	d.SetCurrentOption(1)
+	if text := d.GetText(); text != "Banana" {
+		t.Errorf("failed to set text of current option: expected Banana, got %q", text)
+	}
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+	d.SetLabel("Fruit ")
+	d.SetRect(0, 0, 20, 1)
+	d.Draw(sc)
+	var drawn []rune
+	for x := 6; x < 12; x++ {
+		r, _, _, _ := sc.GetContent(x, 0)
+		drawn = append(drawn, r)
+	}
+	if string(drawn) != "Banana" {
+		t.Errorf("failed to draw field: expected Banana, got %q", string(drawn))
+	}
+}
@@ -0,0 +1,174 @@
+package nuview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetEditable sets whether or not the user may type any text into the
+// drop-down instead of only choosing one of its options, turning it into a
+// combo box. The field then behaves like an InputField whose autocomplete list
+// suggests the options starting with the entered text. Down shows all options
+// and Enter fills in the highlighted one.
+//
+// Pressing Enter (with no suggestions shown), Tab, or Backtab triggers the
+// "selected" callbacks with the option whose text equals the entered text, or
+// with -1 and nil for free text. Use GetText() to retrieve the text in either
+// case.
+//
+// This is disabled by default.
+func (d *DropDown) SetEditable(editable bool) {
+	d.Lock()
+	d.editable = editable
+	if !editable || d.field != nil {
+		d.Unlock()
+		return
+	}
+	d.field = NewInputField()
+	text := ""
+	if d.currentOption >= 0 && d.currentOption < len(d.options) {
+		text = d.options[d.currentOption].text
+	}
+	d.Unlock()
+
+	d.field.SetText(text)
+	d.field.SetChangedFunc(d.editChanged)
+	d.field.SetDoneFunc(d.editDone)
+	d.field.SetAutocompleteFunc(d.editSuggestions)
+}
+
+// GetText returns the text entered into an editable drop-down (see
+// SetEditable). For other drop-downs, it returns the text of the current
+// option or an empty string if no option is selected.
+func (d *DropDown) GetText() string {
+	d.RLock()
+	field := d.field
+	if !d.editable || field == nil {
+		defer d.RUnlock()
+		if d.currentOption >= 0 && d.currentOption < len(d.options) {
+			return d.options[d.currentOption].text
+		}
+		return ""
+	}
+	d.RUnlock()
+
+	return field.GetText()
+}
+
+// SetText sets the text of an editable drop-down (see SetEditable). The
+// option with that text, if any, becomes the current option, without
+// triggering the "selected" callbacks. For other drop-downs, this is the same
+// as SetCurrentOptionByText().
+func (d *DropDown) SetText(text string) {
+	d.RLock()
+	field := d.field
+	editable := d.editable
+	d.RUnlock()
+
+	if !editable || field == nil {
+		d.SetCurrentOptionByText(text)
+		return
+	}
+	field.SetText(text)
+}
+
+// editChanged makes the option whose text equals the entered text the
+// current option.
+func (d *DropDown) editChanged(text string) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.currentOption = -1
+	for index, option := range d.options {
+		if option.text == text {
+			d.currentOption = index
+			d.list.SetCurrentItem(index)
+			break
+		}
+	}
+}
+
+// editSuggestions returns the autocomplete entries of the editable field: the
+// options starting with the entered text, or all options if the user asked
+// for them.
+func (d *DropDown) editSuggestions(text string) (entries []*ListItem) {
+	d.RLock()
+	defer d.RUnlock()
+
+	if !d.showAllOptions {
+		if text == "" {
+			return nil
+		}
+		for _, option := range d.options {
+			if option.text == text {
+				return nil // Don't reopen the list for a chosen option.
+			}
+		}
+	}
+	lower := strings.ToLower(text)
+	for _, option := range d.options {
+		if d.showAllOptions || strings.HasPrefix(strings.ToLower(option.text), lower) {
+			entries = append(entries, NewListItem(option.text))
+		}
+	}
+	return
+}
+
+// editDone handles the keys which the editable field does not process
+// itself.
+func (d *DropDown) editDone(key tcell.Key) {
+	switch key {
+	case tcell.KeyDown:
+		d.Lock()
+		d.showAllOptions = true
+		d.Unlock()
+
+		d.field.Autocomplete()
+
+		d.Lock()
+		d.showAllOptions = false
+		d.Unlock()
+		return
+	case tcell.KeyEnter, tcell.KeyTab, tcell.KeyBacktab:
+		d.RLock()
+		index, selected := d.currentOption, d.selected
+		var option *DropDownOption
+		if index >= 0 && index < len(d.options) {
+			option = d.options[index]
+		}
+		d.RUnlock()
+
+		if selected != nil {
+			selected(index, option)
+		}
+		if option != nil && option.selected != nil {
+			option.selected(index, option)
+		}
+		if key == tcell.KeyEnter {
+			return
+		}
+	case tcell.KeyEscape:
+	default:
+		return
+	}
+
+	d.RLock()
+	done, finished := d.done, d.finished
+	d.RUnlock()
+	if done != nil {
+		done(key)
+	}
+	if finished != nil {
+		finished(key)
+	}
+}
+
+// drawField draws the field of an editable drop-down at the provided position
+// with the provided colors. The drop-down must be locked.
+func (d *DropDown) drawField(screen tcell.Screen, x, y, width int, textColor, backgroundColor tcell.Color) {
+	d.field.SetFieldTextColor(textColor)
+	d.field.SetFieldBackgroundColor(backgroundColor)
+	d.field.SetRect(x, y, width, 1)
+	d.field.Draw(screen)
+}
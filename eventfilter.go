@@ -0,0 +1,66 @@
+package nuview
+
+import (
+	"codeberg.org/tslocum/cbind"
+	"github.com/gdamore/tcell/v2"
+)
+
+// EventFilter transforms a tcell event before the application dispatches it.
+// It returns the event to be processed further (which may be the provided
+// event, a modified copy or an entirely different event) or nil to swallow the
+// event. Filters may queue additional events with Application.QueueEvent().
+type EventFilter func(event tcell.Event) tcell.Event
+
+// eventFilter is a named EventFilter installed in an Application.
+type eventFilter struct {
+	name   string
+	filter EventFilter
+}
+
+// NewKeyRemapFilter returns an EventFilter which replaces key events by other
+// key events. The keys and values of the provided mapping are keybindings in
+// the format used by Keys, e.g. "Ctrl+H" or "Alt+Enter". Key events whose
+// keybinding is not part of the mapping are passed on unchanged. An error is
+// returned if a keybinding cannot be decoded.
+func NewKeyRemapFilter(mapping map[string]string) (EventFilter, error) {
+	type keyEvent struct {
+		mod tcell.ModMask
+		key tcell.Key
+		ch  rune
+	}
+
+	remap := make(map[string]keyEvent, len(mapping))
+	for from, to := range mapping {
+		// Normalize the source keybinding so it matches encoded events.
+		fromMod, fromKey, fromCh, err := cbind.Decode(from)
+		if err != nil {
+			return nil, err
+		}
+		enc, err := cbind.Encode(fromMod, fromKey, fromCh)
+		if err != nil {
+			return nil, err
+		}
+
+		mod, key, ch, err := cbind.Decode(to)
+		if err != nil {
+			return nil, err
+		}
+		remap[enc] = keyEvent{mod: mod, key: key, ch: ch}
+	}
+
+	return func(event tcell.Event) tcell.Event {
+		keyEvent, ok := event.(*tcell.EventKey)
+		if !ok {
+			return event
+		}
+		enc, err := cbind.Encode(keyEvent.Modifiers(), keyEvent.Key(), keyEvent.Rune())
+		if err != nil {
+			return event
+		}
+		to, ok := remap[enc]
+		if !ok {
+			return event
+		}
+		return tcell.NewEventKey(to.key, to.ch, to.mod)
+	}, nil
+}
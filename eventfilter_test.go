@@ -0,0 +1,35 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestKeyRemapFilter(t *testing.T) {
+	t.Parallel()
+
+	filter, err := NewKeyRemapFilter(map[string]string{"Ctrl+H": "Escape", "q": "Ctrl+C"})
+	if err != nil {
+		t.Fatalf("failed to create key remap filter: %s", err)
+	}
+
+	event := filter(tcell.NewEventKey(tcell.KeyCtrlH, 0, tcell.ModCtrl))
+	if key, ok := event.(*tcell.EventKey); !ok || key.Key() != tcell.KeyEscape {
+		t.Errorf("failed to remap key: expected Escape, got %v", event)
+	}
+
+	event = filter(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone))
+	if key, ok := event.(*tcell.EventKey); !ok || key.Key() != tcell.KeyCtrlC {
+		t.Errorf("failed to remap key: expected Ctrl+C, got %v", event)
+	}
+
+	original := tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)
+	if event = filter(original); event != original {
+		t.Errorf("failed to pass through unmapped key: got %v", event)
+	}
+
+	if _, err := NewKeyRemapFilter(map[string]string{"Nonsense+Key": "Escape"}); err == nil {
+		t.Error("failed to reject invalid keybinding")
+	}
+}
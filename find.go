@@ -0,0 +1,46 @@
+package nuview
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Searchable is implemented by primitives which support the application's
+// find bar (see Application.EnableFind). TextView, Table, List and TreeView
+// implement this interface.
+type Searchable interface {
+	// Search selects (or scrolls to) the next match of the provided query after
+	// the current position, or the previous match if backwards is true. The
+	// search wraps around. Returns whether a match was found.
+	Search(query string, backwards bool) bool
+}
+
+// searchMatches returns whether the provided text contains the query,
+// ignoring case. Tags are not stripped from the text.
+func searchMatches(text []byte, query string) bool {
+	if query == "" {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(text), []byte(strings.ToLower(query)))
+}
+
+// searchIndex returns the index of the first item after current (or before
+// current if backwards is true) for which the provided function returns true,
+// wrapping around and checking the current item last. Returns -1 if there is
+// no such item.
+func searchIndex(count, current int, backwards bool, matches func(index int) bool) int {
+	if count <= 0 {
+		return -1
+	}
+	for step := 1; step <= count; step++ {
+		index := current + step
+		if backwards {
+			index = current - step
+		}
+		index = ((index % count) + count) % count
+		if matches(index) {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package nuview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSearchable(t *testing.T) {
+	t.Parallel()
+
+	// List
+
+	l := NewList()
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+	l.AddItem(NewListItem(listTextC))
+
+	if !l.Search("hello", false) {
+		t.Fatal("failed to search List: expected match")
+	} else if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to search List: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+	if !l.Search("hello", false) {
+		t.Fatal("failed to search List: expected match")
+	} else if l.GetCurrentItemIndex() != 0 {
+		t.Errorf("failed to search List: expected wrap around to item 0, got %d", l.GetCurrentItemIndex())
+	}
+	if !l.Search("MOON", true) {
+		t.Fatal("failed to search List: expected match")
+	} else if l.GetCurrentItemIndex() != 1 {
+		t.Errorf("failed to search List: expected current item 1, got %d", l.GetCurrentItemIndex())
+	}
+	if l.Search("missing", false) {
+		t.Error("failed to search List: expected no match")
+	}
+
+	// Table
+
+	tb := NewTable()
+	tb.SetSelectable(true, true)
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(1, 0, "[red]c")
+	tb.SetCellSimple(1, 1, "b")
+
+	if !tb.Search("b", false) {
+		t.Fatal("failed to search Table: expected match")
+	} else if row, column := tb.GetSelection(); row != 0 || column != 1 {
+		t.Errorf("failed to search Table: expected selection 0,1, got %d,%d", row, column)
+	}
+	if !tb.Search("b", false) {
+		t.Fatal("failed to search Table: expected match")
+	} else if row, column := tb.GetSelection(); row != 1 || column != 1 {
+		t.Errorf("failed to search Table: expected selection 1,1, got %d,%d", row, column)
+	}
+	if !tb.Search("c", true) {
+		t.Fatal("failed to search Table: expected match")
+	} else if row, column := tb.GetSelection(); row != 1 || column != 0 {
+		t.Errorf("failed to search Table: expected selection 1,0, got %d,%d", row, column)
+	}
+	if tb.Search("red", false) {
+		t.Error("failed to search Table: expected color tags to be ignored")
+	}
+
+	// TreeView
+
+	root := NewTreeNode("root")
+	child := NewTreeNode("child")
+	leaf := NewTreeNode("leaf")
+	child.AddChild(leaf)
+	child.SetExpanded(false)
+	root.AddChild(child)
+	tv := NewTreeView()
+	tv.SetRoot(root)
+	tv.SetCurrentNode(root)
+
+	if !tv.Search("leaf", false) {
+		t.Fatal("failed to search TreeView: expected match")
+	} else if tv.GetCurrentNode() != leaf {
+		t.Error("failed to search TreeView: expected leaf to be selected")
+	} else if !child.IsExpanded() {
+		t.Error("failed to search TreeView: expected parent to be expanded")
+	}
+}
+
+func TestFindBar(t *testing.T) {
+	t.Parallel()
+
+	var text strings.Builder
+	for line := 0; line < 20; line++ {
+		word := "line"
+		if line == 5 || line == 12 {
+			word = "match"
+		}
+		fmt.Fprintf(&text, "%s %d\n", word, line)
+	}
+	tv := NewTextView()
+	tv.SetText(text.String())
+	app := NewApplication()
+	app.SetRoot(tv, true)
+	app.EnableFind(true)
+
+	h := NewHeadless(app, 20, 5)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	for _, test := range []struct {
+		name   string
+		keys   []string
+		offset int
+	}{
+		{"match", []string{"F3", "m", "a", "t", "c", "h", "Enter"}, 5},
+		{"next match", []string{"Enter"}, 12},
+		{"wrap around", []string{"Down"}, 5},
+		{"previous match", []string{"Up"}, 12},
+		{"no match", []string{"x", "Enter"}, 12},
+	} {
+		if err := h.SendKeys(test.keys...); err != nil {
+			t.Fatal(err)
+		}
+		h.Sync()
+		if row, _ := tv.GetScrollOffset(); row != test.offset {
+			t.Errorf("failed to find %s: expected offset %d, got %d", test.name, test.offset, row)
+		}
+	}
+	if text := app.GetFindField().GetText(); text != "matchx" {
+		t.Errorf("failed to type into find bar: expected \"matchx\", got %q", text)
+	}
+
+	if err := h.SendKeys("Escape", "j"); err != nil {
+		t.Fatal(err)
+	}
+	h.Sync()
+	if row, _ := tv.GetScrollOffset(); row != 13 {
+		t.Errorf("failed to close find bar: expected keys to reach the text view, got offset %d", row)
+	}
+}
@@ -18,6 +18,56 @@ type flexItem struct {
 	FixedSize  int       // The item's fixed size which may not be changed, 0 if it has no fixed size.
 	Proportion int       // The item's proportion.
 	Focus      bool      // Whether or not this item attracts the layout's focus.
+
+	spacer  bool         // Whether or not this item is empty space.
+	visible bool         // Whether or not the item was visible the last time the flex was laid out.
+	size    smoothScroll // Animates the size of the item when it is shown or hidden.
+}
+
+// newFlexItem returns a new flex item for the provided primitive. A nil
+// primitive represents empty space.
+func newFlexItem(p Primitive, fixedSize, proportion int, focus bool) *flexItem {
+	item := &flexItem{Item: p, FixedSize: fixedSize, Proportion: proportion, Focus: focus}
+	if p == nil {
+		item.Item = NewBox()
+		item.Item.SetVisible(false)
+		item.spacer = true
+	}
+	item.visible = !item.hidden()
+	return item
+}
+
+// hidden returns whether or not the item's primitive is hidden. Hidden items
+// take up no space and do not receive focus. Empty space is never hidden.
+func (item *flexItem) hidden() bool {
+	return !item.spacer && !item.Item.GetVisible()
+}
+
+// fixedSize returns the fixed size of the item along the provided direction,
+// given the space available along the direction (mainSize) and perpendicular
+// to it (crossSize). AutoSize is resolved to the preferred size of the item's
+// primitive. A value of 0 or less means the item has a proportional size.
+func (item *flexItem) fixedSize(direction, mainSize, crossSize int) int {
+	if item.FixedSize != AutoSize {
+		return item.FixedSize
+	}
+	var size int
+	if direction == FlexColumn {
+		size, _ = preferredWidth(item.Item, mainSize)
+	} else {
+		size, _ = preferredHeight(item.Item, crossSize)
+	}
+	return size
+}
+
+// wrapSize returns the size of the item along a line of the provided length
+// when wrapping.
+func (item *flexItem) wrapSize(direction, lineSize, crossSize int) int {
+	size := item.fixedSize(direction, lineSize, crossSize)
+	if size <= 0 || size > lineSize {
+		return lineSize
+	}
+	return size
 }
 
 // Flex is a basic implementation of the Flexbox layout. The contained
@@ -37,6 +87,25 @@ type Flex struct {
 	// instead its box dimensions.
 	fullScreen bool
 
+	// If set to true, fixed-size items flow onto the next line when they do
+	// not fit in the current one.
+	wrap bool
+
+	// The size of each line perpendicular to the direction when wrapping.
+	wrapLineSize int
+
+	// The space between items on a line and between lines when wrapping.
+	wrapItemGap, wrapLineGap int
+
+	// The alignment of the items on each line when wrapping.
+	wrapAlign int
+
+	// If set to true, items which are shown or hidden grow or shrink gradually.
+	animateVisibility bool
+
+	// Whether or not the debug overlay is drawn.
+	debug bool
+
 	sync.RWMutex
 }
 
@@ -51,8 +120,10 @@ type Flex struct {
 //	flex.SetBackgroundTransparent(false)
 func NewFlex() *Flex {
 	f := &Flex{
-		Box:       NewBox(),
-		direction: FlexColumn,
+		Box:          NewBox(),
+		direction:    FlexColumn,
+		wrapLineSize: 1,
+		wrapAlign:    AlignLeft,
 	}
 	f.SetBackgroundTransparent(true)
 	f.focus = f
@@ -85,6 +156,79 @@ func (f *Flex) SetFullScreen(fullScreen bool) {
 	f.fullScreen = fullScreen
 }
 
+// SetWrap sets the flag which, when true, causes items to flow onto the next
+// line when they do not fit in the current one. With FlexColumn, items are
+// placed from left to right and wrap onto the next row. With FlexRow, items
+// are placed from top to bottom and wrap onto the next column.
+//
+// In wrap mode, each item occupies its fixed size. Items without a fixed size
+// occupy an entire line. Lines which do not fit into the available space are
+// not drawn.
+func (f *Flex) SetWrap(wrap bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.wrap = wrap
+}
+
+// GetWrap returns whether or not items flow onto the next line when they do
+// not fit in the current one.
+func (f *Flex) GetWrap() bool {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.wrap
+}
+
+// SetWrapLineSize sets the height of each row (FlexColumn) or the width of
+// each column (FlexRow) when wrapping. The default is 1.
+func (f *Flex) SetWrapLineSize(size int) {
+	f.Lock()
+	defer f.Unlock()
+
+	if size < 1 {
+		size = 1
+	}
+	f.wrapLineSize = size
+}
+
+// SetWrapGap sets the space between items on the same line and the space
+// between lines when wrapping.
+func (f *Flex) SetWrapGap(itemGap, lineGap int) {
+	f.Lock()
+	defer f.Unlock()
+
+	if itemGap < 0 {
+		itemGap = 0
+	}
+	if lineGap < 0 {
+		lineGap = 0
+	}
+	f.wrapItemGap, f.wrapLineGap = itemGap, lineGap
+}
+
+// SetWrapAlign sets the alignment of the items on each line when wrapping.
+// This must be either AlignLeft (default), AlignCenter, or AlignRight. With
+// FlexRow, AlignLeft aligns items to the top and AlignRight to the bottom.
+func (f *Flex) SetWrapAlign(align int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.wrapAlign = align
+}
+
+// SetAnimateVisibility sets the flag which, when true, causes items to expand
+// and collapse gradually instead of instantly when they are shown or hidden
+// (see Box.ToggleVisible()). Hidden primitives are not drawn, so only the
+// space they take up is animated. Wrapped layouts are not animated. The
+// animation is also disabled by DisableSmoothScrolling.
+func (f *Flex) SetAnimateVisibility(animate bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.animateVisibility = animate
+}
+
 // AddItem adds a new item to the container. The "fixedSize" argument is a width
 // or height that may not be changed by the layout algorithm. A value of 0 means
 // that its size is flexible and may be changed. The "proportion" argument
@@ -93,21 +237,21 @@ func (f *Flex) SetFullScreen(fullScreen bool) {
 // with a proportion of 1. The proportion must be at least 1 if fixedSize == 0
 // (ignored otherwise).
 //
+// If "fixedSize" is AutoSize, the item's size is determined by the preferred
+// size of the primitive (see PreferredSize), i.e. its preferred width with
+// FlexColumn and its preferred height with FlexRow.
+//
 // If "focus" is set to true, the item will receive focus when the Flex
 // primitive receives focus. If multiple items have the "focus" flag set to
 // true, the first one will receive focus.
 //
-// A nil value for the primitive represents empty space.
+// A nil value for the primitive represents empty space. Hidden primitives (see
+// Box.SetVisible()) take up no space.
 func (f *Flex) AddItem(item Primitive, fixedSize, proportion int, focus bool) {
 	f.Lock()
 	defer f.Unlock()
 
-	if item == nil {
-		item = NewBox()
-		item.SetVisible(false)
-	}
-
-	f.items = append(f.items, &flexItem{Item: item, FixedSize: fixedSize, Proportion: proportion, Focus: focus})
+	f.items = append(f.items, newFlexItem(item, fixedSize, proportion, focus))
 }
 
 // AddItemAtIndex adds an item to the flex at a given index.
@@ -115,7 +259,7 @@ func (f *Flex) AddItem(item Primitive, fixedSize, proportion int, focus bool) {
 func (f *Flex) AddItemAtIndex(index int, item Primitive, fixedSize, proportion int, focus bool) {
 	f.Lock()
 	defer f.Unlock()
-	newItem := &flexItem{Item: item, FixedSize: fixedSize, Proportion: proportion, Focus: focus}
+	newItem := newFlexItem(item, fixedSize, proportion, focus)
 
 	if index == 0 {
 		f.items = append([]*flexItem{newItem}, f.items...)
@@ -159,6 +303,101 @@ func (f *Flex) ResizeItem(p Primitive, fixedSize, proportion int) {
 	}
 }
 
+// MoveSplitter moves the edge between the item with the given primitive and
+// the next visible item (or the previous one if it is the last visible item)
+// by the given number of cells, to the right (or down) if positive, to the
+// left (or up) if negative. No item shrinks below a size of 1. Proportional
+// items keep their proportional size, their proportions are set to their
+// current sizes. Returns whether the edge was moved. This is what arrange mode
+// does, see Application.EnableArrange().
+func (f *Flex) MoveSplitter(p Primitive, delta int) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.wrap || delta == 0 {
+		return false
+	}
+
+	// Find the items on both sides of the edge.
+	var visible []int
+	position := -1
+	for index, item := range f.items {
+		if item.hidden() {
+			continue
+		}
+		if item.Item == p && position < 0 {
+			position = len(visible)
+		}
+		visible = append(visible, index)
+	}
+	if position < 0 || len(visible) < 2 {
+		return false
+	}
+	if position == len(visible)-1 {
+		position--
+	}
+	before, after := visible[position], visible[position+1]
+
+	// Freeze the current sizes.
+	_, _, width, height := f.GetInnerRect()
+	mainSize, crossSize := width, height
+	if f.direction == FlexRow {
+		mainSize, crossSize = height, width
+	}
+	sizes := f.distribute(width, height, nil)
+	delta = max(min(delta, sizes[after]-1), 1-sizes[before])
+	if delta == 0 {
+		return false
+	}
+	for _, index := range visible {
+		if item := f.items[index]; item.fixedSize(f.direction, mainSize, crossSize) <= 0 {
+			item.Proportion = max(sizes[index], 1)
+		}
+	}
+
+	resize := func(item *flexItem, size int) {
+		if item.fixedSize(f.direction, mainSize, crossSize) > 0 {
+			item.FixedSize = size
+		} else {
+			item.Proportion = size
+		}
+	}
+	resize(f.items[before], sizes[before]+delta)
+	resize(f.items[after], sizes[after]-delta)
+	return true
+}
+
+// SetDebug sets whether or not a debug overlay is drawn on top of the items.
+// It labels each item with its index and size (e.g. "#2 40x10") and lists
+// items with a size of 0 and hidden items at the bottom of the flex. This
+// helps finding out why an item did not get the expected size. It may be
+// toggled at any time.
+func (f *Flex) SetDebug(debug bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.debug = debug
+}
+
+// GetDebug returns whether or not the debug overlay is drawn.
+func (f *Flex) GetDebug() bool {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.debug
+}
+
+// drawDebug draws the debug overlay, see SetDebug(). The flex must be locked.
+func (f *Flex) drawDebug(screen tcell.Screen) {
+	items := make([]layoutDebugItem, len(f.items))
+	for index, item := range f.items {
+		x, y, width, height := item.Item.GetRect()
+		items[index] = layoutDebugItem{index: index, x: x, y: y, width: width, height: height, hidden: item.hidden()}
+	}
+	x, y, width, height := f.GetInnerRect()
+	drawLayoutDebug(screen, x, y, width, height, nil, nil, items)
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Flex) Draw(screen tcell.Screen) {
 	if !f.GetVisible() {
@@ -169,6 +408,9 @@ func (f *Flex) Draw(screen tcell.Screen) {
 
 	f.Lock()
 	defer f.Unlock()
+	if f.debug {
+		defer f.drawDebug(screen) // After the focused item.
+	}
 
 	// Calculate size and position of the items.
 
@@ -180,35 +422,19 @@ func (f *Flex) Draw(screen tcell.Screen) {
 
 	// How much space can we distribute?
 	x, y, width, height := f.GetInnerRect()
-	var proportionSum int
-	distSize := width
-	if f.direction == FlexRow {
-		distSize = height
-	}
-	for _, item := range f.items {
-		if item.FixedSize > 0 {
-			distSize -= item.FixedSize
-		} else {
-			proportionSum += item.Proportion
-		}
+	if f.wrap {
+		f.drawWrapped(screen, x, y, width, height)
+		return
 	}
+	sizes := f.animate(f.distribute(width, height, nil), width, height)
 
 	// Calculate positions and draw items.
 	pos := x
 	if f.direction == FlexRow {
 		pos = y
 	}
-	for _, item := range f.items {
-		size := item.FixedSize
-		if size <= 0 {
-			if proportionSum > 0 {
-				size = distSize * item.Proportion / proportionSum
-				distSize -= size
-				proportionSum -= item.Proportion
-			} else {
-				size = 0
-			}
-		}
+	for index, item := range f.items {
+		size := sizes[index]
 		if item.Item != nil {
 			if f.direction == FlexColumn {
 				item.Item.SetRect(pos, y, size, height)
@@ -220,20 +446,229 @@ func (f *Flex) Draw(screen tcell.Screen) {
 
 		if item.Item != nil {
 			if item.Item.GetFocusable().HasFocus() {
-				defer item.Item.Draw(screen)
+				defer drawPrimitive(screen, item.Item)
 			} else {
-				item.Item.Draw(screen)
+				drawPrimitive(screen, item.Item)
 			}
 		}
 	}
 }
 
+// distribute returns the sizes of the items along the direction of the
+// layout, given the available width and height. Hidden items have a size of 0.
+// The provided map may hold the sizes of items (by index) which are not to be
+// changed.
+func (f *Flex) distribute(width, height int, fixed map[int]int) []int {
+	mainSize, crossSize := width, height
+	if f.direction == FlexRow {
+		mainSize, crossSize = height, width
+	}
+
+	sizes := make([]int, len(f.items))
+	proportional := make([]bool, len(f.items))
+	var proportionSum int
+	distSize := mainSize
+	for index, item := range f.items {
+		size, ok := fixed[index]
+		if !ok && !item.hidden() {
+			size = item.fixedSize(f.direction, mainSize, crossSize)
+			if size <= 0 {
+				proportional[index] = true
+				proportionSum += item.Proportion
+				continue
+			}
+		}
+		sizes[index] = size
+		distSize -= size
+	}
+	for index, item := range f.items {
+		if !proportional[index] || proportionSum <= 0 {
+			continue
+		}
+		size := distSize * item.Proportion / proportionSum
+		distSize -= size
+		proportionSum -= item.Proportion
+		sizes[index] = size
+	}
+	return sizes
+}
+
+// animate returns the sizes of the items while items which were shown or
+// hidden expand or collapse, given the sizes they will eventually have.
+func (f *Flex) animate(sizes []int, width, height int) []int {
+	var animated map[int]int
+	for index, item := range f.items {
+		if visible := !item.hidden(); visible != item.visible {
+			item.visible = visible
+			item.size.animate()
+		}
+		item.size.enabled = f.animateVisibility
+		if size := item.size.offset(sizes[index]); size != sizes[index] {
+			if animated == nil {
+				animated = make(map[int]int)
+			}
+			animated[index] = size
+		}
+	}
+	if animated == nil {
+		return sizes
+	}
+	return f.distribute(width, height, animated)
+}
+
+// PreferredWidth returns the width needed by the items plus the border and
+// padding. Items whose primitives do not implement PreferredSize only take up
+// their fixed size, if any.
+func (f *Flex) PreferredWidth(maxWidth int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	frameWidth, _ := f.frameSize()
+	if maxWidth > 0 {
+		maxWidth = max(maxWidth-frameWidth, 1)
+	}
+	var width int
+	for _, item := range f.items {
+		if item.hidden() {
+			continue
+		}
+		if f.direction == FlexColumn && item.FixedSize > 0 {
+			width += item.FixedSize
+			continue
+		}
+		itemWidth, _ := preferredWidth(item.Item, maxWidth)
+		if f.direction == FlexColumn {
+			width += itemWidth
+		} else {
+			width = max(width, itemWidth)
+		}
+	}
+	return width + frameWidth
+}
+
+// PreferredHeight returns the height needed by the items plus the border and
+// padding if the layout has the provided width. Items whose primitives do not
+// implement PreferredSize only take up their fixed size, if any.
+func (f *Flex) PreferredHeight(width int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	frameWidth, frameHeight := f.frameSize()
+	width = max(width-frameWidth, 0)
+	var widths []int
+	if f.direction == FlexColumn {
+		widths = f.distribute(width, 0, nil)
+	}
+	var height int
+	for index, item := range f.items {
+		if item.hidden() {
+			continue
+		}
+		if f.direction == FlexRow && item.FixedSize > 0 {
+			height += item.FixedSize
+			continue
+		}
+		itemWidth := width
+		if widths != nil {
+			itemWidth = widths[index]
+		}
+		itemHeight, _ := preferredHeight(item.Item, itemWidth)
+		if f.direction == FlexRow {
+			height += itemHeight
+		} else {
+			height = max(height, itemHeight)
+		}
+	}
+	return height + frameHeight
+}
+
+// drawWrapped positions and draws the items in wrap mode.
+func (f *Flex) drawWrapped(screen tcell.Screen, x, y, width, height int) {
+	mainSize, crossSize := width, height
+	if f.direction == FlexRow {
+		mainSize, crossSize = height, width
+	}
+
+	// Break the items into lines.
+	var lines [][]*flexItem
+	var line []*flexItem
+	lineLength := 0
+	for _, item := range f.items {
+		if item.hidden() {
+			item.Item.SetRect(x, y, 0, 0)
+			continue
+		}
+		size := item.wrapSize(f.direction, mainSize, f.wrapLineSize)
+		if len(line) > 0 && lineLength+f.wrapItemGap+size > mainSize {
+			lines = append(lines, line)
+			line, lineLength = nil, 0
+		}
+		if len(line) > 0 {
+			lineLength += f.wrapItemGap
+		}
+		line = append(line, item)
+		lineLength += size
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	// Calculate positions and draw items.
+	var focused []Primitive
+	cross := 0
+	for _, line := range lines {
+		visible := cross+f.wrapLineSize <= crossSize
+
+		lineLength := -f.wrapItemGap
+		for _, item := range line {
+			size := item.wrapSize(f.direction, mainSize, f.wrapLineSize)
+			lineLength += size + f.wrapItemGap
+		}
+		pos := 0
+		switch f.wrapAlign {
+		case AlignCenter:
+			pos = (mainSize - lineLength) / 2
+		case AlignRight:
+			pos = mainSize - lineLength
+		}
+
+		for _, item := range line {
+			size := item.wrapSize(f.direction, mainSize, f.wrapLineSize)
+			if item.Item != nil {
+				if !visible {
+					item.Item.SetRect(x, y, 0, 0)
+				} else if f.direction == FlexColumn {
+					item.Item.SetRect(x+pos, y+cross, size, f.wrapLineSize)
+				} else {
+					item.Item.SetRect(x+cross, y+pos, f.wrapLineSize, size)
+				}
+				if visible {
+					if item.Item.GetFocusable().HasFocus() {
+						focused = append(focused, item.Item)
+					} else {
+						drawPrimitive(screen, item.Item)
+					}
+				}
+			}
+			pos += size + f.wrapItemGap
+		}
+
+		cross += f.wrapLineSize + f.wrapLineGap
+	}
+
+	// Draw focused items last so that overlapping elements such as open
+	// drop-downs appear on top.
+	for _, p := range focused {
+		drawPrimitive(screen, p)
+	}
+}
+
 // Focus is called when this primitive receives focus.
 func (f *Flex) Focus(delegate func(p Primitive)) {
 	f.Lock()
 
 	for _, item := range f.items {
-		if item.Item != nil && item.Focus {
+		if item.Item != nil && item.Focus && !item.hidden() {
 			f.Unlock()
 			delegate(item.Item)
 			return
@@ -265,7 +700,7 @@ func (f *Flex) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 
 		// Pass mouse events along to the first child item that takes it.
 		for _, item := range f.items {
-			if item.Item == nil {
+			if item.Item == nil || item.hidden() {
 				continue
 			}
 
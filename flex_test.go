@@ -0,0 +1,117 @@
+package nuview
+
+import (
+	"testing"
+)
+
+func TestFlexWrap(t *testing.T) {
+	t.Parallel()
+
+	f := NewFlex()
+	f.SetWrap(true)
+	f.SetWrapGap(1, 1)
+
+	var boxes []*Box
+	for i := 0; i < 5; i++ {
+		b := NewBox()
+		boxes = append(boxes, b)
+		f.AddItem(b, 4, 0, false)
+	}
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.SetRect(0, 0, 10, 5)
+	f.Draw(app.screen)
+
+	expected := [][2]int{{0, 0}, {5, 0}, {0, 2}, {5, 2}, {0, 4}}
+	for i, b := range boxes {
+		x, y, width, height := b.GetRect()
+		if x != expected[i][0] || y != expected[i][1] || width != 4 || height != 1 {
+			t.Errorf("failed to wrap Flex item %d: expected %d,%d 4x1, got %d,%d %dx%d", i, expected[i][0], expected[i][1], x, y, width, height)
+		}
+	}
+}
+
+func TestFlexAutoSize(t *testing.T) {
+	t.Parallel()
+
+	button := NewButton("OK")
+	button.SetBorder(true)
+	textView := NewTextView()
+	textView.SetText("one\ntwo")
+	box := NewBox()
+
+	f := NewFlex()
+	f.SetDirection(FlexRow)
+	f.AddItem(textView, AutoSize, 0, false)
+	f.AddItem(box, 0, 1, false)
+	f.AddItem(button, AutoSize, 0, false)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.SetRect(0, 0, 20, 10)
+	f.Draw(app.screen)
+
+	expected := [][4]int{{0, 0, 20, 2}, {0, 2, 20, 5}, {0, 7, 20, 3}}
+	for i, p := range []Primitive{textView, box, button} {
+		x, y, width, height := p.GetRect()
+		if e := expected[i]; x != e[0] || y != e[1] || width != e[2] || height != e[3] {
+			t.Errorf("failed to size Flex item %d: expected %d,%d %dx%d, got %d,%d %dx%d", i, e[0], e[1], e[2], e[3], x, y, width, height)
+		}
+	}
+
+	// Items without a preferred size only take up their fixed size.
+	if width, height := f.PreferredWidth(0), f.PreferredHeight(20); width != 8 || height != 5 {
+		t.Errorf("failed to get preferred size: expected 8x5, got %dx%d", width, height)
+	}
+}
+
+func TestFlexHiddenItems(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewBox(), NewBox()
+
+	f := NewFlex()
+	f.SetDirection(FlexRow)
+	f.AddItem(a, 0, 1, false)
+	f.AddItem(nil, 2, 0, false)
+	f.AddItem(b, 0, 1, true)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	f.SetRect(0, 0, 10, 12)
+	b.ToggleVisible()
+	f.Draw(app.screen)
+	if _, _, _, height := a.GetRect(); height != 10 {
+		t.Errorf("failed to skip hidden item: expected height 10, got %d", height)
+	}
+
+	var focused Primitive
+	f.Focus(func(p Primitive) { focused = p })
+	if focused != nil {
+		t.Errorf("failed to skip hidden item when focusing: got %v", focused)
+	}
+
+	// Showing an item again expands it gradually.
+	f.SetAnimateVisibility(true)
+	b.ToggleVisible()
+	f.Draw(app.screen)
+	if _, _, _, height := b.GetRect(); height <= 0 || height >= 5 {
+		t.Errorf("failed to animate item: expected height between 0 and 5, got %d", height)
+	}
+	for i := 0; i < 10; i++ {
+		f.Draw(app.screen)
+	}
+	if _, _, _, height := b.GetRect(); height != 5 {
+		t.Errorf("failed to finish animation: expected height 5, got %d", height)
+	}
+}
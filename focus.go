@@ -9,6 +9,21 @@ type Focusable interface {
 	HasFocus() bool
 }
 
+// AnyHasFocus returns whether or not any of the provided primitives has focus.
+// Nil primitives are ignored. Container primitives may use it to implement
+// HasFocus() based on their children.
+func AnyHasFocus(primitives ...Primitive) bool {
+	for _, p := range primitives {
+		if p == nil {
+			continue
+		}
+		if focusable := p.GetFocusable(); focusable != nil && focusable.HasFocus() {
+			return true
+		}
+	}
+	return false
+}
+
 type focusElement struct {
 	primitive Primitive
 	disabled  bool
@@ -177,3 +192,107 @@ func (f *FocusManager) Transform(tr Transformation) {
 	}
 	f.updateFocusIndex(decreasing)
 }
+
+// FocusScope confines keyboard focus navigation to a group of primitives, such
+// as the fields and buttons of a dialog. While a scope is active (see
+// Application.PushFocusScope()), the keys of Keys.MoveNextField and
+// Keys.MovePreviousField (Tab and Backtab by default) cycle through its
+// primitives and the keys of Keys.Cancel (Escape) exit the scope. The focus
+// cannot move to primitives outside of the scope, e.g. by clicking on them.
+//
+// The primitives of a scope may be containers. The focus then moves from one
+// container to the next, it does not move between the children of a
+// container.
+type FocusScope struct {
+	// The primitives which may receive focus, in focus order.
+	primitives []Primitive
+
+	// The index of the primitive which had focus most recently.
+	focused int
+
+	// The primitive which had focus before the scope was activated.
+	previous Primitive
+
+	// An optional function which is called after the scope was exited with
+	// the Escape key.
+	exit func()
+
+	// Set to true while the focus is moved back into the scope.
+	restoring bool
+
+	sync.RWMutex
+}
+
+// NewFocusScope returns a new focus scope containing the provided primitives.
+// When the scope is activated, the first primitive receives focus.
+func NewFocusScope(primitives ...Primitive) *FocusScope {
+	return &FocusScope{
+		primitives: primitives,
+	}
+}
+
+// SetExitFunc sets a handler which is called when the user exits the scope
+// with the Escape key, after the focus was returned to the primitive which
+// had focus before the scope was activated. This may be used to close a
+// dialog.
+func (s *FocusScope) SetExitFunc(handler func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.exit = handler
+}
+
+// HasFocus returns whether or not one of the primitives of the scope has
+// focus.
+func (s *FocusScope) HasFocus() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return AnyHasFocus(s.primitives...)
+}
+
+// current returns the primitive of the scope which has focus or, if none has
+// it, the one which had focus most recently. Hidden primitives are skipped.
+// It returns nil if there is no visible primitive.
+func (s *FocusScope) current() Primitive {
+	s.Lock()
+	defer s.Unlock()
+
+	for index, p := range s.primitives {
+		if p != nil && p.GetVisible() && AnyHasFocus(p) {
+			s.focused = index
+			return p
+		}
+	}
+	return s.move(0)
+}
+
+// move moves the focus index by the provided offset, wrapping around and
+// skipping hidden primitives, and returns the primitive at the new index. It
+// returns nil if there is no visible primitive. The scope must be locked.
+func (s *FocusScope) move(offset int) Primitive {
+	count := len(s.primitives)
+	for range count {
+		s.focused = ((s.focused+offset)%count + count) % count
+		if p := s.primitives[s.focused]; p != nil && p.GetVisible() {
+			return p
+		}
+		if offset == 0 {
+			offset = 1
+		}
+	}
+	return nil
+}
+
+// next returns the primitive which receives focus when the focus moves
+// forward (offset 1) or backward (offset -1) from the current primitive.
+func (s *FocusScope) next(offset int) Primitive {
+	if s.current() == nil {
+		return nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return s.move(offset)
+}
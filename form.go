@@ -1,6 +1,7 @@
 package nuview
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
 
@@ -75,6 +76,26 @@ type FormItem interface {
 	SetFinishedFunc(func(key tcell.Key))
 }
 
+// preferredFormItemWidth returns the preferred width of a form item whose
+// label and field have the provided widths, plus the border and padding of its
+// box, limited to the maximum width. A field width of 0 means that the field is
+// flexible. It then takes up the maximum width or, if there is none,
+// DefaultFormFieldWidth.
+func preferredFormItemWidth(box *Box, labelWidth, fieldWidth, maxWidth int) int {
+	frameWidth, _ := box.frameSize()
+	if fieldWidth == 0 {
+		if maxWidth > 0 {
+			return maxWidth
+		}
+		fieldWidth = DefaultFormFieldWidth
+	}
+	width := labelWidth + fieldWidth + frameWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
 // Form allows you to combine multiple one-line form elements into a vertical
 // or horizontal layout. Form elements include types such as InputField or
 // CheckBox. These elements can be optionally followed by one or more buttons
@@ -85,6 +106,9 @@ type Form struct {
 	// The items of the form (one row per item).
 	items []FormItem
 
+	// The primitives placed between the items, see InsertPrimitive().
+	attachments []*formAttachment
+
 	// The buttons of the form.
 	buttons []*Button
 
@@ -139,9 +163,76 @@ type Form struct {
 	// An optional function which is called when the user hits Escape.
 	cancel func()
 
+	// The pages of a multi-page form. This is empty for single-page forms.
+	pages []*formPage
+
+	// The index of the page which is shown. Its items are stored in "items".
+	page int
+
+	// The buttons navigating between pages. They are nil until the first page
+	// is added.
+	backButton, nextButton *Button
+
+	// The labels of the navigation buttons.
+	backLabel, nextLabel, finishLabel string
+
+	// An optional function which is called to validate a page before the next
+	// page is shown.
+	validate func(page int, values map[string]interface{}) bool
+
+	// An optional function which is called when the last page was completed.
+	completed func(values map[string]interface{})
+
 	sync.RWMutex
 }
 
+// formPage is a page of a multi-page form.
+type formPage struct {
+	// The title of the page.
+	title string
+
+	// The items of the page.
+	items []FormItem
+
+	// The primitives placed between the items of the page.
+	attachments []*formAttachment
+}
+
+// formAttachment is a primitive which is placed between the items of a form
+// without being one of them, see Form.InsertPrimitive().
+type formAttachment struct {
+	// The primitive.
+	primitive Primitive
+
+	// The index of the item in front of which the primitive is placed.
+	index int
+
+	// The height of the primitive, 0 for its preferred height.
+	height int
+}
+
+// getHeight returns the height of the attached primitive if it is given the
+// provided width.
+func (a *formAttachment) getHeight(width int) int {
+	if a.height > 0 {
+		return a.height
+	}
+	if p, ok := a.primitive.(PreferredSize); ok {
+		return max(p.PreferredHeight(width), 1)
+	}
+	return 1
+}
+
+// getWidth returns the width of the attached primitive in horizontal forms.
+func (a *formAttachment) getWidth() int {
+	if p, ok := a.primitive.(PreferredSize); ok {
+		if width := p.PreferredWidth(0); width > 0 {
+			return width
+		}
+	}
+	return DefaultFormFieldWidth
+}
+
 // NewForm returns a new form.
 func NewForm() *Form {
 	box := NewBox()
@@ -160,6 +251,9 @@ func NewForm() *Form {
 		buttonTextColor:              Styles.PrimaryTextColor,
 		buttonTextColorFocused:       Styles.PrimaryTextColor,
 		labelColorFocused:            ColorUnset,
+		backLabel:                    "Back",
+		nextLabel:                    "Next",
+		finishLabel:                  "Finish",
 	}
 
 	f.focus = f
@@ -461,18 +555,25 @@ func (f *Form) Clear(includeButtons bool) {
 	defer f.Unlock()
 
 	f.items = nil
+	f.attachments = nil
 	if includeButtons {
 		f.buttons = nil
+	} else {
+		_, f.buttons = f.splitButtons()
 	}
 	f.focusedElement = 0
+	f.pages = nil
+	f.page = 0
+	f.backButton, f.nextButton = nil, nil
 }
 
-// ClearButtons removes all buttons from the form.
+// ClearButtons removes all buttons from the form. The buttons navigating
+// between the pages of a multi-page form are kept.
 func (f *Form) ClearButtons() {
 	f.Lock()
 	defer f.Unlock()
 
-	f.buttons = nil
+	f.buttons, _ = f.splitButtons()
 }
 
 // AddFormItem adds a new item to the form. This can be used to add your own
@@ -496,6 +597,66 @@ func (f *Form) AddFormItem(item FormItem) {
 	f.items = append(f.items, item)
 }
 
+// AddPrimitive adds a primitive which is not a form item, e.g. a heading, a
+// separator, a help text or an image, after the items added so far. See
+// InsertPrimitive() for details.
+func (f *Form) AddPrimitive(p Primitive, height int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.insertPrimitive(len(f.items), p, height)
+}
+
+// InsertPrimitive places a primitive which is not a form item, e.g. a heading,
+// a separator, a help text or an image, in front of the form item at the given
+// index. An index equal to or larger than the number of items places it after
+// the last item, in front of the buttons. Several primitives placed in front of
+// the same item appear in the order they were added. Primitives are removed
+// along with the form items with Clear(). For multi-page forms, the primitive
+// is placed on the current page.
+//
+// In vertical layouts, the primitive spans the width of the form. A height of
+// 0 uses its preferred height (see PreferredSize) or a height of 1 if it has
+// none. In horizontal layouts, it is placed in line with the items and is as
+// wide as its preferred width or DefaultFormFieldWidth.
+//
+// Unlike form items, these primitives are not part of the Tab traversal and do
+// not receive focus. They receive mouse events, e.g. to scroll a TextView, and
+// their attributes are left unchanged.
+func (f *Form) InsertPrimitive(index int, p Primitive, height int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.insertPrimitive(index, p, height)
+}
+
+// insertPrimitive places a primitive in front of the item at the given index.
+// The form must be locked.
+func (f *Form) insertPrimitive(index int, p Primitive, height int) {
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		panic("Invalid Primitive")
+	}
+	f.attachments = append(f.attachments, &formAttachment{
+		primitive: p,
+		index:     min(max(index, 0), len(f.items)),
+		height:    height,
+	})
+}
+
+// RemovePrimitive removes a primitive placed between the items of the current
+// page with AddPrimitive() or InsertPrimitive().
+func (f *Form) RemovePrimitive(p Primitive) {
+	f.Lock()
+	defer f.Unlock()
+
+	for index, attachment := range f.attachments {
+		if attachment.primitive == p {
+			f.attachments = append(f.attachments[:index], f.attachments[index+1:]...)
+			return
+		}
+	}
+}
+
 // GetFormItemCount returns the number of items in the form (not including the
 // buttons).
 func (f *Form) GetFormItemCount() int {
@@ -505,6 +666,120 @@ func (f *Form) GetFormItemCount() int {
 	return len(f.items)
 }
 
+// PreferredWidth returns the width needed by the visible form items and
+// buttons plus the border and padding. Fields without a fixed width are
+// assumed to be DefaultFormFieldWidth wide.
+func (f *Form) PreferredWidth(maxWidth int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	var maxLabelWidth int
+	for _, item := range f.items {
+		maxLabelWidth = max(maxLabelWidth, TaggedStringWidth(item.GetLabel())+1)
+	}
+
+	var width int
+	for _, item := range f.items {
+		if !item.GetVisible() {
+			continue
+		}
+		fieldWidth := item.GetFieldWidth()
+		if fieldWidth == 0 {
+			fieldWidth = DefaultFormFieldWidth
+		}
+		if f.horizontal {
+			if width > 0 {
+				width += f.itemPadding
+			}
+			width += TaggedStringWidth(item.GetLabel()) + 1 + fieldWidth
+		} else {
+			width = max(width, maxLabelWidth+fieldWidth)
+		}
+	}
+
+	if f.horizontal {
+		for _, attachment := range f.attachments {
+			if !attachment.primitive.GetVisible() {
+				continue
+			}
+			if width > 0 {
+				width += f.itemPadding
+			}
+			width += attachment.getWidth()
+		}
+	}
+
+	buttonsWidth := -1
+	for _, button := range f.buttons {
+		if button.GetVisible() {
+			buttonsWidth += TaggedStringWidth(button.GetLabel()) + 5
+		}
+	}
+	if f.horizontal && width > 0 && buttonsWidth > 0 {
+		width += f.itemPadding + buttonsWidth
+	} else {
+		width = max(width, buttonsWidth)
+	}
+	if len(f.pages) > 0 {
+		title := fmt.Sprintf("%s (%d/%d)", f.pages[f.page].title, f.page+1, len(f.pages))
+		width = max(width, TaggedStringWidth(title))
+	}
+
+	frameWidth, _ := f.frameSize()
+	width += frameWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// PreferredHeight returns the height needed by the visible form items and
+// buttons plus the border and padding. Horizontal forms are assumed to fit
+// into one line.
+func (f *Form) PreferredHeight(width int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	var height int
+	if f.horizontal {
+		height = 1
+	} else {
+		var items int
+		for _, item := range f.items {
+			if item.GetVisible() {
+				height += item.GetFieldHeight() + f.itemPadding
+				items++
+			}
+		}
+		frameWidth, _ := f.frameSize()
+		for _, attachment := range f.attachments {
+			if attachment.primitive.GetVisible() {
+				height += attachment.getHeight(width-frameWidth) + f.itemPadding
+				items++
+			}
+		}
+		var buttons bool
+		for _, button := range f.buttons {
+			buttons = buttons || button.GetVisible()
+		}
+		if buttons {
+			// Buttons always appear after an empty line.
+			if f.itemPadding == 0 {
+				height++
+			}
+			height++
+		} else if items > 0 {
+			height -= f.itemPadding
+		}
+	}
+	if len(f.pages) > 0 {
+		height += 2 // The page title and an empty line.
+	}
+
+	_, frameHeight := f.frameSize()
+	return height + frameHeight
+}
+
 // IndexOfFormItem returns the index of the given FormItem.
 func (f *Form) IndexOfFormItem(item FormItem) int {
 	f.l.RLock()
@@ -537,6 +812,11 @@ func (f *Form) RemoveFormItem(index int) {
 	defer f.Unlock()
 
 	f.items = append(f.items[:index], f.items[index+1:]...)
+	for _, attachment := range f.attachments {
+		if attachment.index > index {
+			attachment.index--
+		}
+	}
 }
 
 // GetFormItemByLabel returns the first form element with the given label. If
@@ -657,6 +937,15 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 	// Determine the dimensions.
 	x, y, width, height := f.GetInnerRect()
+
+	// Draw the title of the current page, followed by an empty line.
+	if len(f.pages) > 0 && height > 0 {
+		title := fmt.Sprintf("%s (%d/%d)", f.pages[f.page].title, f.page+1, len(f.pages))
+		Print(screen, []byte(title), x, y, width, AlignLeft, f.labelColor)
+		y += 2
+		height = max(height-2, 0)
+	}
+
 	topLimit := y
 	bottomLimit := y + height
 	rightLimit := x + width
@@ -672,10 +961,37 @@ func (f *Form) Draw(screen tcell.Screen) {
 	}
 	maxLabelWidth++ // Add one space.
 
-	// Calculate positions of form items.
+	// Calculate positions of form items and of the primitives between them.
 	positions := make([]struct{ x, y, width, height int }, len(f.items)+len(f.buttons))
+	attachmentPositions := make([]struct{ x, y, width, height int }, len(f.attachments))
+	placeAttachments := func(index int) {
+		for attachmentIndex, attachment := range f.attachments {
+			if attachment.index != index || !attachment.primitive.GetVisible() {
+				continue
+			}
+			attachmentWidth, attachmentHeight := width, attachment.getHeight(width)
+			if f.horizontal {
+				attachmentWidth, attachmentHeight = attachment.getWidth(), 1
+				if x > startX && x+attachmentWidth >= rightLimit {
+					x = startX
+					y += 2
+				}
+				attachmentWidth = min(attachmentWidth, rightLimit-x)
+			}
+			attachmentPositions[attachmentIndex].x = x
+			attachmentPositions[attachmentIndex].y = y
+			attachmentPositions[attachmentIndex].width = attachmentWidth
+			attachmentPositions[attachmentIndex].height = attachmentHeight
+			if f.horizontal {
+				x += attachmentWidth + f.itemPadding
+			} else {
+				y += attachmentHeight + f.itemPadding
+			}
+		}
+	}
 	var focusedPosition struct{ x, y, width, height int }
 	for index, item := range f.items {
+		placeAttachments(index)
 		if !item.GetVisible() {
 			continue
 		}
@@ -728,6 +1044,8 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 	}
 
+	placeAttachments(len(f.items))
+
 	// How wide are the buttons?
 	buttonWidths := make([]int, len(f.buttons))
 	buttonsWidth := 0
@@ -819,12 +1137,31 @@ func (f *Form) Draw(screen tcell.Screen) {
 
 		// Draw items with focus last (in case of overlaps).
 		if item.GetFocusable().HasFocus() {
-			defer item.Draw(screen)
+			defer drawPrimitive(screen, item)
 		} else {
-			item.Draw(screen)
+			drawPrimitive(screen, item)
 		}
 	}
 
+	// Draw the primitives between the items.
+	for index, attachment := range f.attachments {
+		if !attachment.primitive.GetVisible() {
+			continue
+		}
+
+		// Set position.
+		y := attachmentPositions[index].y - offset
+		height := attachmentPositions[index].height
+		attachment.primitive.SetRect(attachmentPositions[index].x, y, attachmentPositions[index].width, height)
+
+		// Is this primitive visible?
+		if y+height <= topLimit || y >= bottomLimit {
+			continue
+		}
+
+		drawPrimitive(screen, attachment.primitive)
+	}
+
 	// Draw buttons.
 	for index, button := range f.buttons {
 		if !button.GetVisible() {
@@ -843,7 +1180,7 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 
 		// Draw button.
-		button.Draw(screen)
+		drawPrimitive(screen, button)
 	}
 }
 
@@ -935,6 +1272,7 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 	if f.focusedElement < 0 || f.focusedElement >= len(f.items)+len(f.buttons) {
 		f.focusedElement = 0
 	}
+	f.updateFocusedElement(false) // Skip hidden items.
 
 	if f.focusedElement < len(f.items) {
 		// We're selecting an item.
@@ -995,18 +1333,35 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 
 		// Determine items to pass mouse events to.
 		for _, item := range f.items {
+			if !item.GetVisible() {
+				continue
+			}
 			consumed, capture = item.MouseHandler()(action, event, setFocus)
 			if consumed {
 				return
 			}
 		}
 		for _, button := range f.buttons {
+			if !button.GetVisible() {
+				continue
+			}
 			consumed, capture = button.MouseHandler()(action, event, setFocus)
 			if consumed {
 				return
 			}
 		}
 
+		// The primitives between the items do not receive focus.
+		for _, attachment := range f.attachments {
+			if !attachment.primitive.GetVisible() {
+				continue
+			}
+			consumed, capture = attachment.primitive.MouseHandler()(action, event, func(Primitive) {})
+			if consumed {
+				return
+			}
+		}
+
 		// A mouse click anywhere else will return the focus to the last selected
 		// element.
 		if action == MouseLeftClick {
@@ -1022,6 +1377,249 @@ func (f *Form) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 	})
 }
 
+// AddPage adds a page with the given title and items to the form, turning it
+// into a multi-page form (a "wizard") which shows one page at a time. Items
+// which were added to the form before its first page are placed on the first
+// page. Items added after that with AddFormItem() and the like are added to
+// the current page.
+//
+// When the first page is added, "Back" and "Next" buttons are inserted in
+// front of the form's buttons. The "Next" button is labeled "Finish" on the
+// last page. See SetPageButtonLabels() to change these labels.
+//
+// Before the next page is shown, the page is validated with the handler set
+// by SetPageValidateFunc(). When the last page is completed, the handler set
+// by SetCompletedFunc() receives the values of the items of all pages.
+func (f *Form) AddPage(title string, items ...FormItem) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, item := range items {
+		if reflect.ValueOf(item).IsNil() {
+			panic("Invalid FormItem")
+		}
+	}
+
+	if len(f.pages) > 0 {
+		f.pages = append(f.pages, &formPage{title: title, items: items})
+		f.updatePageButtons()
+		return
+	}
+
+	f.backButton = NewButton(f.backLabel)
+	f.backButton.SetSelectedFunc(func() {
+		f.PreviousPage()
+	})
+	f.nextButton = NewButton(f.nextLabel)
+	f.nextButton.SetSelectedFunc(func() {
+		f.NextPage()
+	})
+	f.buttons = append([]*Button{f.backButton, f.nextButton}, f.buttons...)
+
+	f.items = append(f.items, items...)
+	f.pages = []*formPage{{title: title, items: f.items, attachments: f.attachments}}
+	f.page = 0
+	f.focusedElement = 0
+	f.updatePageButtons()
+}
+
+// GetPageCount returns the number of pages of a multi-page form. It returns 0
+// if no pages were added.
+func (f *Form) GetPageCount() int {
+	f.RLock()
+	defer f.RUnlock()
+
+	return len(f.pages)
+}
+
+// GetCurrentPage returns the index of the page which is shown, starting with
+// 0 for the first page.
+func (f *Form) GetCurrentPage() int {
+	f.RLock()
+	defer f.RUnlock()
+
+	return f.page
+}
+
+// SetPageButtonLabels sets the labels of the buttons navigating between the
+// pages of a multi-page form. The "finish" label replaces the "next" label on
+// the last page.
+func (f *Form) SetPageButtonLabels(back, next, finish string) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.backLabel, f.nextLabel, f.finishLabel = back, next, finish
+	if len(f.pages) > 0 {
+		f.backButton.SetLabel(back)
+		f.updatePageButtons()
+	}
+}
+
+// SetPageValidateFunc sets a handler which is called with the index of the
+// current page and the values of its items (see GetValues()) before the next
+// page is shown or the form is completed. If it returns false, the current
+// page remains visible.
+func (f *Form) SetPageValidateFunc(handler func(page int, values map[string]interface{}) bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.validate = handler
+}
+
+// SetCompletedFunc sets a handler which is called with the values of the
+// items of all pages (see GetValues()) when the last page of a multi-page form
+// was validated and the user selected the "Finish" button.
+func (f *Form) SetCompletedFunc(handler func(values map[string]interface{})) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.completed = handler
+}
+
+// NextPage validates the current page of a multi-page form and, if it is
+// valid, shows the next page. On the last page, the completed handler is
+// called instead. It returns whether or not the current page was valid. If
+// the form has no pages, nothing happens and false is returned.
+func (f *Form) NextPage() bool {
+	f.Lock()
+	if len(f.pages) == 0 {
+		f.Unlock()
+		return false
+	}
+	page, validate := f.page, f.validate
+	values := formItemValues(f.items)
+	f.Unlock()
+
+	if validate != nil && !validate(page, values) {
+		return false
+	}
+
+	f.Lock()
+	if page < len(f.pages)-1 {
+		if f.page == page {
+			f.showPage(page + 1)
+		}
+		f.Unlock()
+		return true
+	}
+	completed := f.completed
+	f.Unlock()
+
+	if completed != nil {
+		completed(f.GetValues())
+	}
+	return true
+}
+
+// PreviousPage shows the previous page of a multi-page form. The current page
+// is not validated. Nothing happens on the first page.
+func (f *Form) PreviousPage() {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.page > 0 {
+		f.showPage(f.page - 1)
+	}
+}
+
+// GetValues returns the values of the form's items, keyed by their labels. For
+// multi-page forms, the items of all pages are included. The values are:
+//
+//   - InputField: The text (string).
+//   - Checkbox: Whether or not it is checked (bool).
+//   - DropDown: The reference of the selected option or, if it has none, its
+//     text (string). nil if no option is selected.
+//   - Slider: The progress (int).
+//
+// Other items are included with a nil value. Items without a label are not
+// included. If several items have the same label, the value of the last one
+// is returned.
+func (f *Form) GetValues() map[string]interface{} {
+	f.RLock()
+	defer f.RUnlock()
+
+	if len(f.pages) == 0 {
+		return formItemValues(f.items)
+	}
+	values := make(map[string]interface{})
+	for index, page := range f.pages {
+		items := page.items
+		if index == f.page {
+			items = f.items
+		}
+		for label, value := range formItemValues(items) {
+			values[label] = value
+		}
+	}
+	return values
+}
+
+// showPage shows the page with the given index. The form must be locked.
+func (f *Form) showPage(index int) {
+	f.pages[f.page].items = f.items
+	f.pages[f.page].attachments = f.attachments
+	f.page = index
+	f.items = f.pages[index].items
+	f.attachments = f.pages[index].attachments
+	f.focusedElement = 0
+	f.updatePageButtons()
+}
+
+// updatePageButtons updates the navigation buttons for the current page. The
+// form must be locked.
+func (f *Form) updatePageButtons() {
+	f.backButton.SetEnabled(f.page > 0)
+	if f.page == len(f.pages)-1 {
+		f.nextButton.SetLabel(f.finishLabel)
+	} else {
+		f.nextButton.SetLabel(f.nextLabel)
+	}
+}
+
+// splitButtons returns the buttons navigating between pages and the buttons
+// added by the user. The form must be locked.
+func (f *Form) splitButtons() (navigation, user []*Button) {
+	for _, button := range f.buttons {
+		if button != nil && (button == f.backButton || button == f.nextButton) {
+			navigation = append(navigation, button)
+		} else {
+			user = append(user, button)
+		}
+	}
+	return
+}
+
+// formItemValues returns the values of the given items as described in
+// Form.GetValues().
+func formItemValues(items []FormItem) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, item := range items {
+		label := item.GetLabel()
+		if label == "" {
+			continue
+		}
+		switch item := item.(type) {
+		case *InputField:
+			values[label] = item.GetText()
+		case *Checkbox:
+			values[label] = item.IsChecked()
+		case *DropDown:
+			var value interface{}
+			if _, option := item.GetCurrentOption(); option != nil {
+				if value = option.GetReference(); value == nil {
+					value = option.GetText()
+				}
+			}
+			values[label] = value
+		case *Slider:
+			values[label] = item.GetProgress()
+		default:
+			values[label] = nil
+		}
+	}
+	return values
+}
+
 func setFormItemAttributes(item FormItem, attrs *FormItemAttributes) {
 	item.SetLabelWidth(attrs.LabelWidth)
 	item.SetBackgroundColor(attrs.BackgroundColor)
@@ -0,0 +1,164 @@
+package nuview
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFormPages(t *testing.T) {
+	t.Parallel()
+
+	name := NewInputField()
+	name.SetLabel("Name")
+	subscribe := NewCheckbox()
+	subscribe.SetLabel("Subscribe")
+	age := NewSlider()
+	age.SetLabel("Age")
+
+	f := NewForm()
+	f.AddButton("Cancel", nil)
+	f.AddPage("Personal", name)
+	f.AddPage("Preferences", subscribe, age)
+
+	if count := f.GetPageCount(); count != 2 {
+		t.Errorf("failed to add pages: expected 2, got %d", count)
+	}
+	if count := f.GetButtonCount(); count != 3 {
+		t.Errorf("failed to add navigation buttons: expected 3 buttons, got %d", count)
+	}
+	if label := f.GetButton(1).GetLabel(); label != "Next" {
+		t.Errorf("failed to label next button: expected Next, got %s", label)
+	}
+
+	// Validation
+
+	var validated int
+	f.SetPageValidateFunc(func(page int, values map[string]interface{}) bool {
+		validated++
+		return page != 0 || values["Name"] != ""
+	})
+	if f.NextPage() || f.GetCurrentPage() != 0 {
+		t.Error("failed to validate page: expected empty name to be rejected")
+	}
+	name.SetText("Alice")
+	if !f.NextPage() || f.GetCurrentPage() != 1 {
+		t.Errorf("failed to show next page: expected page 1, got %d", f.GetCurrentPage())
+	}
+	if item := f.GetFormItem(0); item != subscribe {
+		t.Errorf("failed to show next page: expected first item Subscribe, got %v", item)
+	}
+	if label := f.GetButton(1).GetLabel(); label != "Finish" {
+		t.Errorf("failed to label finish button: expected Finish, got %s", label)
+	}
+
+	// Completion
+
+	var completed map[string]interface{}
+	f.SetCompletedFunc(func(values map[string]interface{}) {
+		completed = values
+	})
+	subscribe.SetChecked(true)
+	age.SetProgress(42)
+	f.NextPage()
+	expected := map[string]interface{}{"Name": "Alice", "Subscribe": true, "Age": 42}
+	if !reflect.DeepEqual(completed, expected) {
+		t.Errorf("failed to complete form: expected %v, got %v", expected, completed)
+	}
+	if validated != 3 {
+		t.Errorf("failed to validate pages: expected 3 validations, got %d", validated)
+	}
+
+	f.PreviousPage()
+	if page := f.GetCurrentPage(); page != 0 {
+		t.Errorf("failed to show previous page: expected page 0, got %d", page)
+	}
+
+	f.ClearButtons()
+	if count := f.GetButtonCount(); count != 2 {
+		t.Errorf("failed to clear buttons: expected navigation buttons to remain, got %d buttons", count)
+	}
+}
+
+func TestFormHiddenItems(t *testing.T) {
+	t.Parallel()
+
+	name := NewInputField()
+	name.SetLabel("Name")
+	age := NewInputField()
+	age.SetLabel("Age")
+
+	f := NewForm()
+	f.AddFormItem(name)
+	f.AddFormItem(age)
+	name.SetVisible(false)
+
+	var focused Primitive
+	f.Focus(func(p Primitive) { focused = p })
+	if focused != age {
+		t.Errorf("failed to skip hidden item when focusing: expected second field, got %v", focused)
+	}
+}
+
+func TestFormPrimitives(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(30, 12)
+
+	name := NewInputField()
+	name.SetLabel("Name")
+	age := NewInputField()
+	age.SetLabel("Age")
+	heading := NewTextView()
+	heading.SetText("Personal")
+	note := NewTextView()
+	note.SetText("Ages are\nrounded down")
+
+	f := NewForm()
+	f.AddPrimitive(heading, 1)
+	f.AddFormItem(name)
+	f.AddFormItem(age)
+	f.AddPrimitive(note, 2)
+	f.SetRect(0, 0, 30, 12)
+	f.Draw(sc)
+
+	for _, expected := range []struct {
+		p    Primitive
+		y, h int
+	}{{heading, 1, 1}, {name, 3, 1}, {age, 5, 1}, {note, 7, 2}} {
+		if _, y, _, h := expected.p.GetRect(); y != expected.y || h != expected.h {
+			t.Errorf("failed to place primitive: expected y %d height %d, got y %d height %d", expected.y, expected.h, y, h)
+		}
+	}
+	if height := f.PreferredHeight(30); height != 10 {
+		t.Errorf("failed to get preferred height: expected 10, got %d", height)
+	}
+	if count := f.GetFormItemCount(); count != 2 {
+		t.Errorf("failed to keep primitives out of the items: expected 2 items, got %d", count)
+	}
+
+	// Tab only moves between the items.
+	var focused Primitive
+	delegate := func(p Primitive) { focused = p }
+	f.Focus(delegate)
+	if focused != name {
+		t.Errorf("failed to focus first item: expected name field, got %v", focused)
+	}
+	f.formItemInputHandler(delegate)(tcell.KeyTab)
+	if focused != age {
+		t.Errorf("failed to skip primitives: expected age field, got %v", focused)
+	}
+
+	// Primitives move with the items they are placed in front of.
+	f.RemoveFormItem(0)
+	f.RemovePrimitive(heading)
+	f.Draw(sc)
+	if _, y, _, _ := note.GetRect(); y != 3 {
+		t.Errorf("failed to move primitive: expected y 3, got %d", y)
+	}
+}
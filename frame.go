@@ -88,6 +88,59 @@ func (f *Frame) SetBorders(top, bottom, header, footer, left, right int) {
 	f.top, f.bottom, f.header, f.footer, f.left, f.right = top, bottom, header, footer, left, right
 }
 
+// textRows returns the number of rows of text in the header and the footer.
+func (f *Frame) textRows() (header, footer int) {
+	var rows [6]int
+	for _, text := range f.text {
+		index := text.Align
+		if !text.Header {
+			index += 3
+		}
+		rows[index]++
+	}
+	return max(rows[0], rows[1], rows[2]), max(rows[3], rows[4], rows[5])
+}
+
+// PreferredWidth returns the preferred width of the contained primitive or the
+// width of the longest text, whichever is larger, plus the spacing of the
+// frame, the border and the padding.
+func (f *Frame) PreferredWidth(maxWidth int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	frameWidth, _ := f.frameSize()
+	frameWidth += f.left + f.right
+	if maxWidth > 0 {
+		maxWidth = max(maxWidth-frameWidth, 1)
+	}
+	width, _ := preferredWidth(f.primitive, maxWidth)
+	for _, text := range f.text {
+		width = max(width, TaggedStringWidth(text.Text))
+	}
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width + frameWidth
+}
+
+// PreferredHeight returns the preferred height of the contained primitive plus
+// the header, the footer, the spacing of the frame, the border and the padding.
+func (f *Frame) PreferredHeight(width int) int {
+	f.RLock()
+	defer f.RUnlock()
+
+	frameWidth, frameHeight := f.frameSize()
+	height, _ := preferredHeight(f.primitive, max(width-frameWidth-f.left-f.right, 0))
+	header, footer := f.textRows()
+	if header > 0 {
+		height += header + f.header
+	}
+	if footer > 0 {
+		height += footer + f.footer
+	}
+	return height + f.top + f.bottom + frameHeight
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Frame) Draw(screen tcell.Screen) {
 	if !f.GetVisible() {
@@ -154,7 +207,7 @@ func (f *Frame) Draw(screen tcell.Screen) {
 	f.primitive.SetRect(x, top, width, bottom+1-top)
 
 	// Finally, draw the contained primitive.
-	f.primitive.Draw(screen)
+	drawPrimitive(screen, f.primitive)
 }
 
 // Focus is called when this primitive receives focus.
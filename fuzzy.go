@@ -0,0 +1,168 @@
+package nuview
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores of the fuzzy matcher.
+const (
+	fuzzyScoreMatch       = 16 // For each matched rune.
+	fuzzyScoreConsecutive = 8  // For a rune matched right after the previous one.
+	fuzzyScoreWordStart   = 8  // For a rune matched at the start of a word.
+	fuzzyPenaltyGap       = 1  // For each rune skipped between two matched runes.
+)
+
+// FuzzyMatch returns whether the runes of the pattern are found in the text
+// in the same order, though not necessarily next to each other, e.g. "fb"
+// matches "FooBar". The score rates the match, higher scores are better
+// matches. Runes matched consecutively or at the start of a word score higher.
+// The indices are the positions of the matched runes in the text, counted in
+// runes. An empty pattern matches any text with a score of 0.
+//
+// Use FuzzyHighlight() to highlight the matched runes and FuzzyFilter() to
+// rank a number of texts.
+func FuzzyMatch(pattern, text string, caseSensitive bool) (score int, indices []int, ok bool) {
+	patternRunes, original := []rune(pattern), []rune(text)
+	if len(patternRunes) == 0 {
+		return 0, nil, true
+	}
+	textRunes := original
+	if !caseSensitive {
+		// Lowercase rune by rune to keep the indices.
+		textRunes = make([]rune, len(original))
+		for index, r := range original {
+			textRunes[index] = unicode.ToLower(r)
+		}
+		for index, r := range patternRunes {
+			patternRunes[index] = unicode.ToLower(r)
+		}
+	}
+
+	// Try each occurrence of the first rune and keep the best match.
+	for start := range textRunes {
+		if textRunes[start] != patternRunes[0] {
+			continue
+		}
+		matched := make([]int, 0, len(patternRunes))
+		matched = append(matched, start)
+		for index := start + 1; index < len(textRunes) && len(matched) < len(patternRunes); index++ {
+			if textRunes[index] == patternRunes[len(matched)] {
+				matched = append(matched, index)
+			}
+		}
+		if len(matched) < len(patternRunes) {
+			break // No later start can match either.
+		}
+		if s := fuzzyScore(original, matched); !ok || s > score {
+			score, indices, ok = s, matched, true
+		}
+	}
+	return
+}
+
+// fuzzyScore returns the score of the runes of a text matched at the
+// provided indices.
+func fuzzyScore(text []rune, indices []int) (score int) {
+	for number, index := range indices {
+		score += fuzzyScoreMatch
+		if number > 0 {
+			if gap := index - indices[number-1] - 1; gap == 0 {
+				score += fuzzyScoreConsecutive
+			} else {
+				score -= gap * fuzzyPenaltyGap
+			}
+		}
+		if index == 0 ||
+			!unicode.IsLetter(text[index-1]) && !unicode.IsDigit(text[index-1]) ||
+			unicode.IsLower(text[index-1]) && unicode.IsUpper(text[index]) {
+			score += fuzzyScoreWordStart
+		}
+	}
+	return
+}
+
+// FuzzyFilter returns the indices of the texts matching the pattern (see
+// FuzzyMatch()), best matches first. Texts with the same score keep their
+// order.
+func FuzzyFilter(pattern string, texts []string, caseSensitive bool) []int {
+	var (
+		matches []int
+		scores  = make(map[int]int)
+	)
+	for index, text := range texts {
+		if score, _, ok := FuzzyMatch(pattern, text, caseSensitive); ok {
+			matches = append(matches, index)
+			scores[index] = score
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return scores[matches[i]] > scores[matches[j]]
+	})
+	return matches
+}
+
+// FuzzyHighlight returns the text with the runes at the provided indices (as
+// returned by FuzzyMatch()) enclosed in the provided style tag, e.g.
+// "[yellow::b]", and a tag which resets the style. The rest of the text is
+// escaped (see Escape()), so the result may be displayed by any primitive
+// which supports style tags.
+func FuzzyHighlight(text string, indices []int, tag string) string {
+	var (
+		b           strings.Builder
+		run         strings.Builder
+		highlighted bool
+		next        int
+	)
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if highlighted {
+			b.WriteString(tag)
+			b.WriteString(Escape(run.String()))
+			b.WriteString("[-:-:-]")
+		} else {
+			b.WriteString(Escape(run.String()))
+		}
+		run.Reset()
+	}
+	for index, r := range []rune(text) {
+		matched := next < len(indices) && indices[next] == index
+		if matched {
+			next++
+		}
+		if matched != highlighted {
+			flush()
+			highlighted = matched
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
+
+// FuzzyAutocomplete returns an autocomplete function for an input field (see
+// InputField.SetAutocompleteFunc()) which offers the provided entries matching
+// the current text (see FuzzyMatch()), best matches first. The matched runes
+// are highlighted with the provided style tag (see FuzzyHighlight()). At most
+// limit entries are offered, all matches if limit is 0 or less.
+func FuzzyAutocomplete(entries []string, caseSensitive bool, tag string, limit int) func(currentText string) []*ListItem {
+	return func(currentText string) []*ListItem {
+		if currentText == "" {
+			return nil
+		}
+		var items []*ListItem
+		for _, index := range FuzzyFilter(currentText, entries, caseSensitive) {
+			if limit > 0 && len(items) >= limit {
+				break
+			}
+			_, indices, _ := FuzzyMatch(currentText, entries[index], caseSensitive)
+			item := NewListItem(FuzzyHighlight(entries[index], indices, tag))
+			item.SetSecondaryText(entries[index]) // The text inserted when selected.
+			items = append(items, item)
+		}
+		return items
+	}
+}
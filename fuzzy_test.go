@@ -0,0 +1,50 @@
+package nuview
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	t.Parallel()
+
+	score, indices, ok := FuzzyMatch("fb", "FooBar", false)
+	if !ok || !reflect.DeepEqual(indices, []int{0, 3}) {
+		t.Errorf("failed to match: expected [0 3], got %v (%t)", indices, ok)
+	}
+	if _, _, ok := FuzzyMatch("fb", "FooBar", true); ok {
+		t.Error("failed to match case-sensitively: expected no match")
+	}
+	if _, _, ok := FuzzyMatch("bf", "FooBar", false); ok {
+		t.Error("failed to match in order: expected no match")
+	}
+	if other, _, _ := FuzzyMatch("fb", "xfoxxxb", false); other >= score {
+		t.Errorf("failed to rank word starts higher: %d is not less than %d", other, score)
+	}
+
+	// The best occurrence is found, not the first one.
+	if _, indices, _ := FuzzyMatch("ab", "a-x-ab", false); !reflect.DeepEqual(indices, []int{4, 5}) {
+		t.Errorf("failed to find best match: expected [4 5], got %v", indices)
+	}
+
+	if matches := FuzzyFilter("cfg", []string{"config", "readme", "cf-gen", "Config"}, true); !reflect.DeepEqual(matches, []int{2, 0}) {
+		t.Errorf("failed to filter: expected [2 0], got %v", matches)
+	}
+
+	if text := FuzzyHighlight("a[b]c", []int{0, 4}, "[red]"); text != "[red]a[-:-:-][b[][red]c[-:-:-]" {
+		t.Errorf("failed to highlight: got %q", text)
+	}
+
+	l := NewList()
+	for _, text := range []string{"open file", "[red]quit", "options"} {
+		l.AddItem(NewListItem(text))
+	}
+	if matches := l.FuzzyFindItems("op", false); !reflect.DeepEqual(matches, []int{0, 2}) {
+		t.Errorf("failed to find list items: expected [0 2], got %v", matches)
+	}
+
+	items := FuzzyAutocomplete([]string{"apple", "banana", "grape"}, false, "[::b]", 1)("ape")
+	if len(items) != 1 || items[0].GetSecondaryText() != "grape" {
+		t.Errorf("failed to autocomplete: expected grape, got %v", items)
+	}
+}
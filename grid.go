@@ -14,8 +14,10 @@ type gridItem struct {
 	Width, Height               int       // The number of rows and columns the item occupies.
 	MinGridWidth, MinGridHeight int       // The minimum grid width/height for which this item is visible.
 	Focus                       bool      // Whether or not this item attracts the layout's focus.
+	Auto                        bool      // Whether or not the item's row and column are determined by the grid.
 
 	visible    bool // Whether or not this item was visible the last time the grid was drawn.
+	span       int  // The number of columns the item occupies when drawn, Width narrowed to the grid for auto-placed items.
 	x, y, w, h int  // The last position of the item relative to the top-left corner of the grid. Undefined if visible is false.
 }
 
@@ -40,6 +42,11 @@ type Grid struct {
 	// The minimum sizes for rows and columns.
 	minWidth, minHeight int
 
+	// Whether or not the debug overlay is drawn, and the screen positions of
+	// the rows and columns as of the last call to Draw().
+	debug                   bool
+	debugColumns, debugRows []int
+
 	// The size of the gaps between neighboring primitives. This is automatically
 	// set to 1 if borders is true.
 	gapRows, gapColumns int
@@ -56,6 +63,10 @@ type Grid struct {
 	// The color of the borders around grid items.
 	bordersColor tcell.Color
 
+	// The size of rows which are not defined with SetRows(). See
+	// SetImplicitRowSize() for details.
+	implicitRowSize int
+
 	sync.RWMutex
 }
 
@@ -84,6 +95,9 @@ func NewGrid() *Grid {
 // of -3 will have three times the width of a column with a value of -1 (or 0).
 // The minimum width set with SetMinSize() is always observed.
 //
+// A value of AutoSize sets the width of the column to the largest preferred
+// width (see PreferredSize) of the primitives which occupy only this column.
+//
 // Primitives may extend beyond the columns defined explicitly with this
 // function. A value of 0 is assumed for any undefined column. In fact, if you
 // never call this function, all columns occupied by primitives will have the
@@ -205,7 +219,8 @@ func (g *Grid) SetBordersColor(color tcell.Color) {
 //	  AddItem(p, 1, 1, 3, 2, 300, 0, true)   // Multi-column layout for large grids.
 //
 // To use the same grid layout for all sizes, simply set minGridWidth and
-// minGridHeight to 0.
+// minGridHeight to 0. Hidden primitives (see Box.SetVisible()) take up no
+// space.
 //
 // If the item's focus is set to true, it will receive focus when the grid
 // receives focus. If there are multiple items with a true focus flag, the last
@@ -226,6 +241,102 @@ func (g *Grid) AddItem(p Primitive, row, column, rowSpan, colSpan, minGridHeight
 	})
 }
 
+// AddItemAuto adds a primitive to the grid without an explicit position. The
+// primitive will span "rowSpan" rows and "colSpan" columns and is placed in
+// the first free area found when scanning the grid in reading order (left to
+// right, top to bottom), after all items with explicit positions have been
+// placed. Auto-placed items are positioned in the order they were added.
+//
+// The number of columns used for auto-placement is the number of columns
+// defined with SetColumns() or, if none were defined, the number of columns
+// occupied by items with explicit positions (at least one). Rows are added
+// as needed. Their size may be set with SetImplicitRowSize().
+//
+// If the item's focus is set to true, it will receive focus when the grid
+// receives focus. See AddItem() for details.
+func (g *Grid) AddItemAuto(p Primitive, rowSpan, colSpan int, focus bool) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.items = append(g.items, &gridItem{
+		Item:   p,
+		Height: rowSpan,
+		Width:  colSpan,
+		Focus:  focus,
+		Auto:   true,
+	})
+}
+
+// SetImplicitRowSize sets the size of rows which are not defined with
+// SetRows() but are occupied by primitives, such as the rows created when
+// placing items with AddItemAuto(). See SetColumns() for details on sizes.
+// The default value of 0 distributes the remaining space evenly.
+func (g *Grid) SetImplicitRowSize(size int) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.implicitRowSize = size
+}
+
+// placeAutoItems determines the positions of the auto-placed items among the
+// provided applicable items.
+func (g *Grid) placeAutoItems(items map[Primitive]*gridItem) {
+	// How many columns are available?
+	columns := len(g.columns)
+	occupied := make(map[[2]int]bool)
+	for _, item := range items {
+		if item.Auto {
+			continue
+		}
+		if len(g.columns) == 0 && item.Column+item.Width > columns {
+			columns = item.Column + item.Width
+		}
+		for row := item.Row; row < item.Row+item.Height; row++ {
+			for column := item.Column; column < item.Column+item.Width; column++ {
+				occupied[[2]int{row, column}] = true
+			}
+		}
+	}
+	if columns == 0 {
+		columns = 1
+	}
+
+	fits := func(row, column, height, width int) bool {
+		for r := row; r < row+height; r++ {
+			for c := column; c < column+width; c++ {
+				if occupied[[2]int{r, c}] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	// Place the items in the order they were added.
+	for _, item := range g.items {
+		if !item.Auto || items[item.Item] != item {
+			continue
+		}
+		// Items wider than the grid are narrowed to fit, keeping their width
+		// for when the grid grows again.
+		item.span = min(item.Width, columns)
+	Search:
+		for row := 0; ; row++ {
+			for column := 0; column+item.span <= columns; column++ {
+				if fits(row, column, item.Height, item.span) {
+					item.Row, item.Column = row, column
+					break Search
+				}
+			}
+		}
+		for r := item.Row; r < item.Row+item.Height; r++ {
+			for c := item.Column; c < item.Column+item.span; c++ {
+				occupied[[2]int{r, c}] = true
+			}
+		}
+	}
+}
+
 // RemoveItem removes all items for the given primitive from the grid, keeping
 // the order of the remaining items intact.
 func (g *Grid) RemoveItem(p Primitive) {
@@ -268,6 +379,45 @@ func (g *Grid) GetOffset() (rows, columns int) {
 	return g.rowOffset, g.columnOffset
 }
 
+// resolveAutoSizes replaces AutoSize row and column sizes with the largest
+// preferred size of the primitives which occupy only that row or column. Rows
+// and columns without such primitives become proportional. The width of
+// proportional columns is estimated from the width of the grid.
+func (g *Grid) resolveAutoSizes(items map[Primitive]*gridItem, rowSizes, columnSizes []int, width, columns int) {
+	for index, size := range columnSizes {
+		if size != AutoSize {
+			continue
+		}
+		columnSizes[index] = 0
+		for primitive, item := range items {
+			if item.Column != index || item.span != 1 {
+				continue
+			}
+			if preferred, ok := preferredWidth(primitive, width); ok {
+				columnSizes[index] = max(columnSizes[index], preferred)
+			}
+		}
+	}
+	for index, size := range rowSizes {
+		if size != AutoSize {
+			continue
+		}
+		rowSizes[index] = 0
+		for primitive, item := range items {
+			if item.Row != index || item.Height != 1 {
+				continue
+			}
+			itemWidth := width * item.span / columns
+			if item.span == 1 && item.Column < len(columnSizes) && columnSizes[item.Column] > 0 {
+				itemWidth = columnSizes[item.Column]
+			}
+			if preferred, ok := preferredHeight(primitive, itemWidth); ok {
+				rowSizes[index] = max(rowSizes[index], preferred)
+			}
+		}
+	}
+}
+
 // Focus is called when this primitive receives focus.
 func (g *Grid) Focus(delegate func(p Primitive)) {
 	g.Lock()
@@ -275,7 +425,7 @@ func (g *Grid) Focus(delegate func(p Primitive)) {
 	g.Unlock()
 
 	for _, item := range items {
-		if item.Focus {
+		if item.Focus && item.Item.GetVisible() {
 			delegate(item.Item)
 			return
 		}
@@ -313,22 +463,56 @@ func (g *Grid) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 		g.Lock()
 		defer g.Unlock()
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		if g.hitShortcut(event, g.keys().MoveFirst, g.keys().MoveFirst2) {
 			g.rowOffset, g.columnOffset = 0, 0
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if g.hitShortcut(event, g.keys().MoveLast, g.keys().MoveLast2) {
 			g.rowOffset = math.MaxInt32
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2, Keys.MovePreviousField) {
+		} else if g.hitShortcut(event, g.keys().MoveUp, g.keys().MoveUp2, g.keys().MovePreviousField) {
 			g.rowOffset--
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2, Keys.MoveNextField) {
+		} else if g.hitShortcut(event, g.keys().MoveDown, g.keys().MoveDown2, g.keys().MoveNextField) {
 			g.rowOffset++
-		} else if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+		} else if g.hitShortcut(event, g.keys().MoveLeft, g.keys().MoveLeft2) {
 			g.columnOffset--
-		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+		} else if g.hitShortcut(event, g.keys().MoveRight, g.keys().MoveRight2) {
 			g.columnOffset++
 		}
 	})
 }
 
+// SetDebug sets whether or not a debug overlay is drawn on top of the items.
+// It shows the boundaries of the rows and columns (on empty cells), labels
+// each item with its index and size (e.g. "#2 40x10") and lists items which
+// were not laid out, have a size of 0 or are hidden at the bottom of the grid.
+// This helps finding out why an item did not get the expected size. It may be
+// toggled at any time.
+func (g *Grid) SetDebug(debug bool) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.debug = debug
+}
+
+// GetDebug returns whether or not the debug overlay is drawn.
+func (g *Grid) GetDebug() bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.debug
+}
+
+// drawDebug draws the debug overlay, see SetDebug(). The grid must be locked.
+func (g *Grid) drawDebug(screen tcell.Screen) {
+	items := make([]layoutDebugItem, len(g.items))
+	for index, item := range g.items {
+		items[index] = layoutDebugItem{index: index, hidden: item.Item != nil && !item.Item.GetVisible()}
+		if item.visible {
+			items[index].x, items[index].y, items[index].width, items[index].height = item.x, item.y, item.w, item.h
+		}
+	}
+	x, y, width, height := g.GetInnerRect()
+	drawLayoutDebug(screen, x, y, width, height, g.debugColumns, g.debugRows, items)
+}
+
 // Draw draws this primitive onto the screen.
 func (g *Grid) Draw(screen tcell.Screen) {
 	if !g.GetVisible() {
@@ -339,6 +523,10 @@ func (g *Grid) Draw(screen tcell.Screen) {
 
 	g.Lock()
 	defer g.Unlock()
+	g.debugColumns, g.debugRows = nil, nil
+	if g.debug {
+		defer g.drawDebug(screen) // After the focused item.
+	}
 
 	x, y, width, height := g.GetInnerRect()
 	screenWidth, screenHeight := screen.Size()
@@ -350,12 +538,17 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		if item.Width <= 0 || item.Height <= 0 || width < item.MinGridWidth || height < item.MinGridHeight {
 			continue
 		}
+		if item.Item != nil && !item.Item.GetVisible() {
+			continue // Hidden items take up no space.
+		}
 		previousItem, ok := items[item.Item]
 		if ok && item.MinGridWidth < previousItem.MinGridWidth && item.MinGridHeight < previousItem.MinGridHeight {
 			continue
 		}
+		item.span = item.Width
 		items[item.Item] = item
 	}
+	g.placeAutoItems(items)
 
 	// How many rows and columns do we have?
 	rows := len(g.rows)
@@ -365,7 +558,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		if rowEnd > rows {
 			rows = rowEnd
 		}
-		columnEnd := item.Column + item.Width
+		columnEnd := item.Column + item.span
 		if columnEnd > columns {
 			columns = columnEnd
 		}
@@ -374,6 +567,16 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		return // No content.
 	}
 
+	// Rows not defined with SetRows() use the implicit row size.
+	rowSizes := make([]int, rows)
+	copy(rowSizes, g.rows)
+	for index := len(g.rows); index < rows; index++ {
+		rowSizes[index] = g.implicitRowSize
+	}
+	columnSizes := make([]int, len(g.columns))
+	copy(columnSizes, g.columns)
+	g.resolveAutoSizes(items, rowSizes, columnSizes, width, columns)
+
 	// Where are they located?
 	rowPos := make([]int, rows)
 	rowHeight := make([]int, rows)
@@ -385,7 +588,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	remainingHeight := height
 	proportionalWidth := 0
 	proportionalHeight := 0
-	for index, row := range g.rows {
+	for index, row := range rowSizes {
 		if row > 0 {
 			if row < g.minHeight {
 				row = g.minHeight
@@ -398,7 +601,7 @@ func (g *Grid) Draw(screen tcell.Screen) {
 			proportionalHeight += -row
 		}
 	}
-	for index, column := range g.columns {
+	for index, column := range columnSizes {
 		if column > 0 {
 			if column < g.minWidth {
 				column = g.minWidth
@@ -418,19 +621,13 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		remainingHeight -= (rows - 1) * g.gapRows
 		remainingWidth -= (columns - 1) * g.gapColumns
 	}
-	if rows > len(g.rows) {
-		proportionalHeight += rows - len(g.rows)
-	}
 	if columns > len(g.columns) {
 		proportionalWidth += columns - len(g.columns)
 	}
 
 	// Distribute proportional rows/columns.
 	for index := 0; index < rows; index++ {
-		row := 0
-		if index < len(g.rows) {
-			row = g.rows[index]
-		}
+		row := rowSizes[index]
 		if row > 0 {
 			if row < g.minHeight {
 				row = g.minHeight
@@ -451,8 +648,8 @@ func (g *Grid) Draw(screen tcell.Screen) {
 	}
 	for index := 0; index < columns; index++ {
 		column := 0
-		if index < len(g.columns) {
-			column = g.columns[index]
+		if index < len(columnSizes) {
+			column = columnSizes[index]
 		}
 		if column > 0 {
 			if column < g.minWidth {
@@ -505,14 +702,14 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		for index := 0; index < item.Height; index++ {
 			ph += rowHeight[item.Row+index]
 		}
-		for index := 0; index < item.Width; index++ {
+		for index := 0; index < item.span; index++ {
 			pw += columnWidth[item.Column+index]
 		}
 		if g.borders {
-			pw += item.Width - 1
+			pw += item.span - 1
 			ph += item.Height - 1
 		} else {
-			pw += (item.Width - 1) * g.gapColumns
+			pw += (item.span - 1) * g.gapColumns
 			ph += (item.Height - 1) * g.gapRows
 		}
 		item.x, item.y, item.w, item.h = px, py, pw, ph
@@ -606,6 +803,16 @@ func (g *Grid) Draw(screen tcell.Screen) {
 		g.columnOffset = to
 	}
 
+	// Remember the boundaries for the debug overlay.
+	if g.debug {
+		for _, pos := range columnPos {
+			g.debugColumns = append(g.debugColumns, x+pos-offsetX)
+		}
+		for _, pos := range rowPos {
+			g.debugRows = append(g.debugRows, y+pos-offsetY)
+		}
+	}
+
 	// Draw primitives and borders.
 	for primitive, item := range items {
 		// Final primitive position.
@@ -642,24 +849,25 @@ func (g *Grid) Draw(screen tcell.Screen) {
 
 		// Draw primitive.
 		if item == focus {
-			defer primitive.Draw(screen)
+			defer drawPrimitive(screen, primitive)
 		} else {
-			primitive.Draw(screen)
+			drawPrimitive(screen, primitive)
 		}
 
 		// Draw border around primitive.
 		if g.borders {
+			borders := g.borderSet()
 			for bx := item.x; bx < item.x+item.w; bx++ { // Top/bottom lines.
 				if bx < 0 || bx >= screenWidth {
 					continue
 				}
 				by := item.y - 1
 				if by >= 0 && by < screenHeight {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, g.bordersColor)
+					PrintJoinedSemigraphics(screen, bx, by, borders.Horizontal, g.bordersColor)
 				}
 				by = item.y + item.h
 				if by >= 0 && by < screenHeight {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Horizontal, g.bordersColor)
+					PrintJoinedSemigraphics(screen, bx, by, borders.Horizontal, g.bordersColor)
 				}
 			}
 			for by := item.y; by < item.y+item.h; by++ { // Left/right lines.
@@ -668,28 +876,28 @@ func (g *Grid) Draw(screen tcell.Screen) {
 				}
 				bx := item.x - 1
 				if bx >= 0 && bx < screenWidth {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, g.bordersColor)
+					PrintJoinedSemigraphics(screen, bx, by, borders.Vertical, g.bordersColor)
 				}
 				bx = item.x + item.w
 				if bx >= 0 && bx < screenWidth {
-					PrintJoinedSemigraphics(screen, bx, by, Borders.Vertical, g.bordersColor)
+					PrintJoinedSemigraphics(screen, bx, by, borders.Vertical, g.bordersColor)
 				}
 			}
 			bx, by := item.x-1, item.y-1 // Top-left corner.
 			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.TopLeft, g.bordersColor)
+				PrintJoinedSemigraphics(screen, bx, by, borders.TopLeft, g.bordersColor)
 			}
 			bx, by = item.x+item.w, item.y-1 // Top-right corner.
 			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.TopRight, g.bordersColor)
+				PrintJoinedSemigraphics(screen, bx, by, borders.TopRight, g.bordersColor)
 			}
 			bx, by = item.x-1, item.y+item.h // Bottom-left corner.
 			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.BottomLeft, g.bordersColor)
+				PrintJoinedSemigraphics(screen, bx, by, borders.BottomLeft, g.bordersColor)
 			}
 			bx, by = item.x+item.w, item.y+item.h // Bottom-right corner.
 			if bx >= 0 && bx < screenWidth && by >= 0 && by < screenHeight {
-				PrintJoinedSemigraphics(screen, bx, by, Borders.BottomRight, g.bordersColor)
+				PrintJoinedSemigraphics(screen, bx, by, borders.BottomRight, g.bordersColor)
 			}
 		}
 	}
@@ -705,6 +913,9 @@ func (g *Grid) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		// Pass mouse events along to the first child item under the mouse that consumes it.
 		x, y := event.Position()
 		for _, item := range g.items {
+			if !item.visible {
+				continue
+			}
 			rectX, rectY, width, height := item.Item.GetRect()
 			inRect := x >= rectX && x < rectX+width && y >= rectY && y < rectY+height
 			if !inRect {
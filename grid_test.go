@@ -0,0 +1,183 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestGridAutoPlacement(t *testing.T) {
+	t.Parallel()
+
+	g := NewGrid()
+	g.SetColumns(-1, -1, -1)
+	g.SetImplicitRowSize(2)
+
+	header := NewBox()
+	g.AddItem(header, 0, 0, 1, 2, 0, 0, false)
+
+	var boxes []*Box
+	for i := 0; i < 4; i++ {
+		b := NewBox()
+		boxes = append(boxes, b)
+		colSpan := 1
+		if i == 2 {
+			colSpan = 2
+		}
+		g.AddItemAuto(b, 1, colSpan, false)
+	}
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	g.SetRect(0, 0, 30, 6)
+	g.Draw(app.screen)
+
+	expected := [][4]int{{20, 0, 10, 2}, {0, 2, 10, 2}, {10, 2, 20, 2}, {0, 4, 10, 2}}
+	for i, b := range boxes {
+		x, y, width, height := b.GetRect()
+		e := expected[i]
+		if x != e[0] || y != e[1] || width != e[2] || height != e[3] {
+			t.Errorf("failed to auto-place Grid item %d: expected %d,%d %dx%d, got %d,%d %dx%d", i, e[0], e[1], e[2], e[3], x, y, width, height)
+		}
+	}
+}
+
+func TestGridAutoPlacementWide(t *testing.T) {
+	t.Parallel()
+
+	g := NewGrid()
+	g.SetColumns(-1, -1)
+	g.SetImplicitRowSize(2)
+
+	wide, next := NewBox(), NewBox()
+	g.AddItemAuto(wide, 1, 3, false)
+	g.AddItemAuto(next, 1, 1, false)
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	g.SetRect(0, 0, 20, 4)
+	g.Draw(app.screen)
+
+	expected := [][4]int{{0, 0, 20, 2}, {0, 2, 10, 2}}
+	for i, b := range []*Box{wide, next} {
+		x, y, width, height := b.GetRect()
+		e := expected[i]
+		if x != e[0] || y != e[1] || width != e[2] || height != e[3] {
+			t.Errorf("failed to auto-place Grid item %d: expected %d,%d %dx%d, got %d,%d %dx%d", i, e[0], e[1], e[2], e[3], x, y, width, height)
+		}
+	}
+
+	// The item regains its span when the grid is widened again.
+	g.SetColumns(-1, -1, -1, -1)
+	g.SetRect(0, 0, 20, 2)
+	g.Draw(app.screen)
+
+	expected = [][4]int{{0, 0, 15, 2}, {15, 0, 5, 2}}
+	for i, b := range []*Box{wide, next} {
+		x, y, width, height := b.GetRect()
+		e := expected[i]
+		if x != e[0] || y != e[1] || width != e[2] || height != e[3] {
+			t.Errorf("failed to auto-place Grid item %d in widened grid: expected %d,%d %dx%d, got %d,%d %dx%d", i, e[0], e[1], e[2], e[3], x, y, width, height)
+		}
+	}
+}
+
+func TestGridAutoSize(t *testing.T) {
+	t.Parallel()
+
+	label := NewTextView()
+	label.SetText("Name:")
+	inputField := NewInputField()
+
+	g := NewGrid()
+	g.SetColumns(AutoSize, 0)
+	g.SetRows(AutoSize, 0)
+	g.AddItem(label, 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(inputField, 0, 1, 1, 1, 0, 0, true)
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	g.SetRect(0, 0, 30, 10)
+	g.Draw(app.screen)
+
+	if x, y, width, height := label.GetRect(); x != 0 || y != 0 || width != 5 || height != 1 {
+		t.Errorf("failed to size auto column: expected 0,0 5x1, got %d,%d %dx%d", x, y, width, height)
+	}
+	if x, y, width, height := inputField.GetRect(); x != 5 || y != 0 || width != 25 || height != 1 {
+		t.Errorf("failed to size proportional column: expected 5,0 25x1, got %d,%d %dx%d", x, y, width, height)
+	}
+}
+
+func TestGridHiddenItems(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewBox(), NewBox()
+
+	g := NewGrid()
+	g.AddItem(a, 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(b, 0, 1, 1, 1, 0, 0, false)
+
+	app, err := newTestApp(g)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	g.SetRect(0, 0, 20, 5)
+	b.SetVisible(false)
+	g.Draw(app.screen)
+	if _, _, width, _ := a.GetRect(); width != 20 {
+		t.Errorf("failed to skip hidden item: expected width 20, got %d", width)
+	}
+}
+
+func TestGridDebug(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	g := NewGrid()
+	g.SetColumns(10, 0, 0)
+	g.AddItem(NewBox(), 0, 0, 1, 1, 0, 0, false)
+	g.AddItem(NewBox(), 0, 1, 1, 1, 0, 30, false) // Needs more space than there is.
+	g.SetRect(0, 0, 20, 5)
+	g.SetDebug(true)
+	g.Draw(sc)
+	if text := row(0); !strings.HasPrefix(text, "#0 10x5") {
+		t.Errorf("failed to label item: expected prefix %q, got %q", "#0 10x5", text)
+	}
+	if text := row(4); !strings.HasPrefix(text, "#1 0x0") {
+		t.Errorf("failed to list collapsed item: expected prefix %q, got %q", "#1 0x0", text)
+	}
+	if r, _, _, _ := sc.GetContent(15, 2); r != '┊' {
+		t.Errorf("failed to draw column boundary: expected '┊', got %q", r)
+	}
+
+	g.SetDebug(false)
+	sc.Clear()
+	g.Draw(sc)
+	if text := row(0); strings.Contains(text, "#0") {
+		t.Errorf("failed to disable debug overlay: got %q", text)
+	}
+}
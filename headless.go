@@ -0,0 +1,396 @@
+package nuview
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"codeberg.org/tslocum/cbind"
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// headlessSync is an event which is posted to the queues of an application to
+// wait until all preceding events were processed.
+type headlessSync struct {
+	tcell.EventTime
+
+	// Closed when the event is processed.
+	done chan struct{}
+}
+
+// Headless runs an application without a terminal on a simulation screen,
+// e.g. in a long-lived process for end-to-end tests or for the automation of
+// terminal user interfaces. Events are sent to the application with the Send*
+// functions and the rendered screen is retrieved with GetFrame(). Serve()
+// provides the same functionality to other processes via a text protocol.
+//
+// Events are processed asynchronously by the application's event loop. Call
+// Sync() to wait until all events sent so far were processed and the screen
+// was updated. The Send* functions do this automatically.
+//
+// The following example runs an application which may be controlled through a
+// Unix socket:
+//
+//	h := cview.NewHeadless(app, 80, 24)
+//	if err := h.Start(); err != nil {
+//	    panic(err)
+//	}
+//	listener, err := net.Listen("unix", "/tmp/app.sock")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	go h.Serve(listener)
+//	h.Wait()
+type Headless struct {
+	// The application.
+	app *Application
+
+	// The simulation screen the application is drawn on.
+	screen tcell.SimulationScreen
+
+	// The initial size of the screen.
+	width, height int
+
+	// Closed when Run() returned.
+	stopped chan struct{}
+
+	// The error returned by Run().
+	err error
+
+	sync.RWMutex
+}
+
+// NewHeadless returns a new headless runner for the given application, which
+// is drawn on a simulation screen of the provided size. The application must
+// not have been started yet.
+func NewHeadless(app *Application, width, height int) *Headless {
+	return &Headless{
+		app:     app,
+		screen:  tcell.NewSimulationScreen("UTF-8"),
+		width:   width,
+		height:  height,
+		stopped: make(chan struct{}),
+	}
+}
+
+// GetApplication returns the application run by the headless runner.
+func (h *Headless) GetApplication() *Application {
+	return h.app
+}
+
+// GetScreen returns the simulation screen the application is drawn on.
+func (h *Headless) GetScreen() tcell.SimulationScreen {
+	return h.screen
+}
+
+// Start initializes the simulation screen and runs the application in a new
+// goroutine. It returns when the application was drawn for the first time.
+func (h *Headless) Start() error {
+	if err := h.screen.Init(); err != nil {
+		return err
+	}
+	h.screen.SetSize(h.width, h.height)
+
+	h.app.SetScreen(h.screen)
+	h.app.Lock()
+	h.app.width, h.app.height = h.width, h.height
+	h.app.Unlock()
+
+	go func() {
+		err := h.app.Run()
+
+		h.Lock()
+		h.err = err
+		h.Unlock()
+		close(h.stopped)
+	}()
+
+	h.Sync()
+	return nil
+}
+
+// Stop stops the application and waits until it has stopped.
+func (h *Headless) Stop() error {
+	h.app.Stop()
+	return h.Wait()
+}
+
+// Wait waits until the application has stopped, e.g. because the user pressed
+// Ctrl-C, and returns the error returned by Application.Run().
+func (h *Headless) Wait() error {
+	<-h.stopped
+
+	h.RLock()
+	defer h.RUnlock()
+
+	return h.err
+}
+
+// Sync waits until all events sent to the application so far were processed,
+// including updates queued with Application.QueueUpdate(). It returns
+// immediately if the application has stopped.
+func (h *Headless) Sync() {
+	h.barrier(h.post)
+	h.barrier(h.app.QueueEvent)
+	h.barrier(func(event tcell.Event) {
+		h.app.QueueUpdate(func() {
+			close(event.(*headlessSync).done)
+		})
+	})
+}
+
+// barrier posts a sync event with the provided function and waits until it
+// was processed or the application has stopped.
+func (h *Headless) barrier(post func(event tcell.Event)) {
+	event := &headlessSync{done: make(chan struct{})}
+	event.SetEventNow()
+
+	// Posting may block while the queue is full and the application stops.
+	go func() {
+		select {
+		case <-h.stopped:
+		default:
+			post(event)
+		}
+	}()
+
+	select {
+	case <-event.done:
+	case <-h.stopped:
+	}
+}
+
+// post posts an event to the simulation screen unless the application has
+// stopped.
+func (h *Headless) post(event tcell.Event) {
+	select {
+	case <-h.stopped:
+	default:
+		h.screen.PostEventWait(event)
+	}
+}
+
+// SendKeys sends key events to the application, one for each of the provided
+// keys. Keys are encoded as in Keys, e.g. "Ctrl+S" or "Enter". An error is
+// returned if a key could not be decoded, in which case no key is sent.
+func (h *Headless) SendKeys(keys ...string) error {
+	events := make([]*tcell.EventKey, len(keys))
+	for index, key := range keys {
+		mod, k, ch, err := cbind.Decode(key)
+		if err != nil {
+			return fmt.Errorf("invalid key %q: %s", key, err)
+		}
+		events[index] = tcell.NewEventKey(k, ch, mod)
+	}
+	for _, event := range events {
+		h.post(event)
+	}
+	h.Sync()
+	return nil
+}
+
+// SendText sends a key event for each rune of the provided text to the
+// application, as if the text was typed.
+func (h *Headless) SendText(text string) {
+	for _, r := range text {
+		h.post(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	h.Sync()
+}
+
+// SendMouse sends a mouse event with the provided position, buttons and
+// modifiers to the application. A click consists of an event with the button
+// pressed followed by an event with no buttons pressed (see SendClick()).
+func (h *Headless) SendMouse(x, y int, buttons tcell.ButtonMask, modifiers tcell.ModMask) {
+	h.post(tcell.NewEventMouse(x, y, buttons, modifiers))
+	h.Sync()
+}
+
+// SendClick sends a click of the left mouse button at the provided position
+// to the application.
+func (h *Headless) SendClick(x, y int) {
+	h.post(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	h.post(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	h.Sync()
+}
+
+// Resize changes the size of the simulation screen and waits until the
+// application has processed the resize event.
+func (h *Headless) Resize(width, height int) {
+	h.screen.SetSize(width, height)
+	h.post(tcell.NewEventResize(width, height))
+	for {
+		h.Sync()
+
+		select {
+		case <-h.stopped:
+			return
+		default:
+		}
+		if w, ht := h.app.GetScreenSize(); w == width && ht == height {
+			return
+		}
+
+		// The resize event was throttled. It is processed later.
+		time.Sleep(resizeEventThrottle)
+	}
+}
+
+// GetFrame returns the content of the screen, one string per row. Wide
+// characters occupy a single rune in the string. Trailing spaces are kept.
+func (h *Headless) GetFrame() []string {
+	cells, width, height := h.screen.GetContents()
+
+	frame := make([]string, height)
+	for y := 0; y < height; y++ {
+		var b strings.Builder
+		for x := 0; x < width; x++ {
+			cell := cells[y*width+x]
+			if len(cell.Runes) == 0 {
+				b.WriteRune(' ')
+				continue
+			}
+			for _, r := range cell.Runes {
+				b.WriteRune(r)
+			}
+			x += max(runewidth.RuneWidth(cell.Runes[0]), 1) - 1
+		}
+		frame[y] = b.String()
+	}
+	return frame
+}
+
+// Serve accepts connections on the provided listener (e.g. a Unix socket)
+// and serves each connection in a new goroutine until the listener is closed.
+// Each connection receives commands, one per line, and responds with a line
+// starting with "OK" or "ERR" followed by an error message. The commands are:
+//
+//   - key <key>...: Send key events, see SendKeys().
+//   - text <text>: Send the remainder of the line as typed text.
+//   - click <x> <y>: Click the left mouse button, see SendClick().
+//   - mouse <x> <y> <buttons>: Send a mouse event. Buttons is a comma-separated
+//     list of "left", "middle" and "right", or "none".
+//   - resize <width> <height>: Resize the screen.
+//   - frame: Respond with "OK <rows>" followed by the rows of the screen.
+//   - sync: Wait until all events were processed.
+//   - quit: Close the connection.
+//
+// Serve returns the error returned by the listener.
+func (h *Headless) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			h.serveConn(conn)
+		}()
+	}
+}
+
+// serveConn processes the commands received on a connection until it is
+// closed or the "quit" command is received.
+func (h *Headless) serveConn(conn io.ReadWriter) {
+	scanner := bufio.NewScanner(conn)
+	writer := bufio.NewWriter(conn)
+	for scanner.Scan() {
+		command, arguments, _ := strings.Cut(scanner.Text(), " ")
+		if command == "quit" {
+			return
+		}
+
+		response, err := h.execute(command, arguments)
+		if err != nil {
+			fmt.Fprintf(writer, "ERR %s\n", err)
+		} else if response != nil {
+			fmt.Fprintf(writer, "OK %d\n", len(response))
+			for _, line := range response {
+				fmt.Fprintln(writer, line)
+			}
+		} else {
+			fmt.Fprintln(writer, "OK")
+		}
+		if writer.Flush() != nil {
+			return
+		}
+	}
+}
+
+// execute executes a command received by Serve(). It returns the lines of the
+// response, if any.
+func (h *Headless) execute(command, arguments string) ([]string, error) {
+	fields := strings.Fields(arguments)
+	switch command {
+	case "key":
+		return nil, h.SendKeys(fields...)
+	case "text":
+		h.SendText(arguments)
+		return nil, nil
+	case "click", "mouse":
+		if command == "click" && len(fields) != 2 || command == "mouse" && len(fields) != 3 {
+			return nil, fmt.Errorf("invalid arguments for %s", command)
+		}
+		x, errX := strconv.Atoi(fields[0])
+		y, errY := strconv.Atoi(fields[1])
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid position %s,%s", fields[0], fields[1])
+		}
+		if command == "click" {
+			h.SendClick(x, y)
+			return nil, nil
+		}
+		buttons, err := parseHeadlessButtons(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		h.SendMouse(x, y, buttons, tcell.ModNone)
+		return nil, nil
+	case "resize":
+		if len(fields) != 2 {
+			return nil, errors.New("invalid arguments for resize")
+		}
+		width, errWidth := strconv.Atoi(fields[0])
+		height, errHeight := strconv.Atoi(fields[1])
+		if errWidth != nil || errHeight != nil || width < 0 || height < 0 {
+			return nil, fmt.Errorf("invalid size %sx%s", fields[0], fields[1])
+		}
+		h.Resize(width, height)
+		return nil, nil
+	case "frame":
+		return h.GetFrame(), nil
+	case "sync":
+		h.Sync()
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown command %q", command)
+}
+
+// parseHeadlessButtons parses a comma-separated list of mouse buttons.
+func parseHeadlessButtons(text string) (tcell.ButtonMask, error) {
+	var buttons tcell.ButtonMask
+	for _, name := range strings.Split(text, ",") {
+		switch name {
+		case "none":
+		case "left":
+			buttons |= tcell.Button1
+		case "middle":
+			buttons |= tcell.Button3
+		case "right":
+			buttons |= tcell.Button2
+		default:
+			return 0, fmt.Errorf("invalid mouse button %q", name)
+		}
+	}
+	return buttons, nil
+}
@@ -0,0 +1,83 @@
+package nuview
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestHeadless(t *testing.T) {
+	t.Parallel()
+
+	inputField := NewInputField()
+	inputField.SetLabel("Name: ")
+
+	app := NewApplication()
+	app.SetRoot(inputField, true)
+	app.EnableMouse(true)
+
+	h := NewHeadless(app, 20, 2)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	h.SendText("Bob")
+	if err := h.SendKeys("Backspace", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if text := inputField.GetText(); text != "Box" {
+		t.Errorf("failed to send keys: expected Box, got %s", text)
+	}
+	if frame := h.GetFrame(); len(frame) != 2 || !strings.HasPrefix(frame[0], "Name: Box") {
+		t.Errorf("failed to get frame: got %q", frame)
+	}
+	if err := h.SendKeys("Nonsense+Key"); err == nil {
+		t.Error("failed to reject invalid key")
+	}
+
+	h.Resize(30, 3)
+	if width, height := app.GetScreenSize(); width != 30 || height != 3 {
+		t.Errorf("failed to resize screen: expected 30x3, got %dx%d", width, height)
+	}
+
+	// Text protocol.
+
+	client, server := net.Pipe()
+	go h.serveConn(server)
+	reader := bufio.NewReader(client)
+	command := func(line string) string {
+		fmt.Fprintln(client, line)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(response)
+	}
+
+	if response := command("text !"); response != "OK" {
+		t.Errorf("failed to send text: expected OK, got %s", response)
+	}
+	if response := command("frame"); response != "OK 3" {
+		t.Errorf("failed to get frame: expected OK 3, got %s", response)
+	}
+	row, _ := reader.ReadString('\n')
+	if !strings.HasPrefix(row, "Name: Box!") {
+		t.Errorf("failed to get frame: incorrect first row %q", row)
+	}
+	reader.ReadString('\n')
+	reader.ReadString('\n')
+	if response := command("mouse 1 2 left,sideways"); !strings.HasPrefix(response, "ERR") {
+		t.Errorf("failed to reject invalid button: got %s", response)
+	}
+	if response := command("launch"); !strings.HasPrefix(response, "ERR") {
+		t.Errorf("failed to reject unknown command: got %s", response)
+	}
+	fmt.Fprintln(client, "quit")
+	client.Close()
+
+	if err := h.Stop(); err != nil {
+		t.Errorf("failed to stop application: %s", err)
+	}
+}
@@ -0,0 +1,179 @@
+package nuview
+
+import (
+	"image"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ImageCell is a styled cell of a grid of cells, e.g. an image converted by
+// ImageToHalfBlocks() or a region of a table rendered by Table.RenderRegion().
+type ImageCell struct {
+	// The character of the cell.
+	Rune rune
+
+	// The style of the cell.
+	Style tcell.Style
+}
+
+// ImageToHalfBlocks converts an image into a grid of cells (rows first) which
+// is width cells wide and height cells high. The image is scaled to the size
+// of the grid by averaging pixels. Each cell shows two vertically adjacent
+// pixels with the upper half block character "▀", using the foreground color
+// for the upper pixel and the background color for the lower pixel. Colors
+// are true colors. Transparent pixels show the terminal's default background.
+//
+// Note that cells are usually about twice as high as they are wide, so a grid
+// which is twice as wide as it is high preserves the aspect ratio of a square
+// image.
+func ImageToHalfBlocks(img image.Image, width, height int) [][]ImageCell {
+	if img == nil || width <= 0 || height <= 0 {
+		return nil
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil
+	}
+
+	cells := make([][]ImageCell, height)
+	for y := range cells {
+		cells[y] = make([]ImageCell, width)
+		for x := range cells[y] {
+			upper, upperOpaque := averagePixels(img, bounds, x, 2*y, width, 2*height)
+			lower, lowerOpaque := averagePixels(img, bounds, x, 2*y+1, width, 2*height)
+			cell := &cells[y][x]
+			switch {
+			case upperOpaque && lowerOpaque:
+				cell.Rune = '▀'
+				cell.Style = tcell.StyleDefault.Foreground(upper).Background(lower)
+			case upperOpaque:
+				cell.Rune = '▀'
+				cell.Style = tcell.StyleDefault.Foreground(upper)
+			case lowerOpaque:
+				cell.Rune = '▄'
+				cell.Style = tcell.StyleDefault.Foreground(lower)
+			default:
+				cell.Rune = ' '
+				cell.Style = tcell.StyleDefault
+			}
+		}
+	}
+	return cells
+}
+
+// averagePixels returns the average color of the pixels of an image which are
+// covered by the pixel at the given position of a scaled version of the image
+// with the provided size. It also returns whether or not the pixels are
+// mostly opaque.
+func averagePixels(img image.Image, bounds image.Rectangle, x, y, width, height int) (tcell.Color, bool) {
+	fromX := bounds.Min.X + x*bounds.Dx()/width
+	toX := max(bounds.Min.X+(x+1)*bounds.Dx()/width, fromX+1)
+	fromY := bounds.Min.Y + y*bounds.Dy()/height
+	toY := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, fromY+1)
+
+	var r, g, b, a, count uint64
+	for sy := fromY; sy < toY; sy++ {
+		for sx := fromX; sx < toX; sx++ {
+			pr, pg, pb, pa := img.At(sx, sy).RGBA()
+			r += uint64(pr)
+			g += uint64(pg)
+			b += uint64(pb)
+			a += uint64(pa)
+			count++
+		}
+	}
+	if a < count*0x8000 {
+		return tcell.ColorDefault, false
+	}
+
+	// The color components are premultiplied by alpha.
+	return tcell.NewRGBColor(int32(r*0xff/a), int32(g*0xff/a), int32(b*0xff/a)), true
+}
+
+// Image is a box which displays an image. The image is scaled to fit into the
+// box, preserving its aspect ratio, and centered. It is drawn with half block
+// characters and true colors (see ImageToHalfBlocks), which works on any
+// terminal supporting true colors.
+type Image struct {
+	*Box
+
+	// The image to display.
+	image image.Image
+
+	// The converted image and the size it was converted to.
+	cells                   [][]ImageCell
+	cellsWidth, cellsHeight int
+
+	sync.RWMutex
+}
+
+// NewImage returns a new image primitive.
+func NewImage() *Image {
+	return &Image{
+		Box: NewBox(),
+	}
+}
+
+// SetImage sets the image to display. Provide nil to display no image.
+func (i *Image) SetImage(img image.Image) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.image = img
+	i.cells = nil
+}
+
+// GetImage returns the displayed image.
+func (i *Image) GetImage() image.Image {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.image
+}
+
+// Draw draws this primitive onto the screen.
+func (i *Image) Draw(screen tcell.Screen) {
+	if !i.GetVisible() {
+		return
+	}
+
+	i.Box.Draw(screen)
+
+	i.Lock()
+	defer i.Unlock()
+
+	if i.image == nil || i.image.Bounds().Empty() {
+		return
+	}
+	x, y, width, height := i.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Fit the image into the box. Each cell holds two pixels vertically.
+	bounds := i.image.Bounds()
+	cellsWidth, cellsHeight := width, (bounds.Dy()*width/bounds.Dx()+1)/2
+	if cellsHeight > height {
+		cellsWidth, cellsHeight = max(2*height*bounds.Dx()/bounds.Dy(), 1), height
+	}
+	cellsHeight = max(cellsHeight, 1)
+	if i.cells == nil || cellsWidth != i.cellsWidth || cellsHeight != i.cellsHeight {
+		i.cells = ImageToHalfBlocks(i.image, cellsWidth, cellsHeight)
+		i.cellsWidth, i.cellsHeight = cellsWidth, cellsHeight
+	}
+
+	// Draw the cells, centered. Transparent pixels show the box background.
+	background := i.backgroundFill()
+	x += (width - cellsWidth) / 2
+	y += (height - cellsHeight) / 2
+	for row, cells := range i.cells {
+		for column, cell := range cells {
+			style := cell.Style
+			if _, bg, _ := style.Decompose(); bg == tcell.ColorDefault {
+				style = style.Background(background)
+			}
+			screen.SetContent(x+column, y+row, cell.Rune, nil, style)
+		}
+	}
+}
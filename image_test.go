@@ -0,0 +1,77 @@
+package nuview
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestImageToHalfBlocks(t *testing.T) {
+	t.Parallel()
+
+	// A 2x4 image: red over blue in the left column, green over transparent
+	// in the right column, scaled down to 2x1 cells.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 4))
+	for y := 0; y < 4; y++ {
+		if y < 2 {
+			img.Set(0, y, color.RGBA{255, 0, 0, 255})
+			img.Set(1, y, color.RGBA{0, 255, 0, 255})
+		} else {
+			img.Set(0, y, color.RGBA{0, 0, 255, 255})
+		}
+	}
+
+	cells := ImageToHalfBlocks(img, 2, 1)
+	if len(cells) != 1 || len(cells[0]) != 2 {
+		t.Fatalf("failed to convert image: expected 1x2 cells, got %v", cells)
+	}
+	if cell := cells[0][0]; cell.Rune != '▀' || cell.Style != tcell.StyleDefault.Foreground(tcell.NewRGBColor(255, 0, 0)).Background(tcell.NewRGBColor(0, 0, 255)) {
+		t.Errorf("failed to convert opaque pixels: got %q %v", cell.Rune, cell.Style)
+	}
+	if cell := cells[0][1]; cell.Rune != '▀' || cell.Style != tcell.StyleDefault.Foreground(tcell.NewRGBColor(0, 255, 0)) {
+		t.Errorf("failed to convert transparent pixel: got %q %v", cell.Rune, cell.Style)
+	}
+
+	if cells := ImageToHalfBlocks(img, 0, 1); cells != nil {
+		t.Errorf("failed to convert image: expected no cells for empty grid, got %v", cells)
+	}
+}
+
+func TestImage(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	i := NewImage()
+	i.SetRect(0, 0, 10, 2)
+	i.SetImage(img)
+	i.Draw(sc)
+
+	// The square image is drawn 4 cells wide and 2 cells high, centered.
+	for x := 0; x < 10; x++ {
+		r, _, _, _ := sc.GetContent(x, 0)
+		expected := ' '
+		if x >= 3 && x < 7 {
+			expected = '▀'
+		}
+		if r != expected {
+			t.Errorf("failed to draw image: expected %q at column %d, got %q", expected, x, r)
+		}
+	}
+	if r, _, _, _ := sc.GetContent(3, 1); r != '▀' {
+		t.Errorf("failed to draw image: expected image to be 2 rows high, got %q in row 1", r)
+	}
+}
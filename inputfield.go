@@ -2,6 +2,7 @@ package nuview
 
 import (
 	"bytes"
+	"fmt"
 	"math"
 	"regexp"
 	"sync"
@@ -13,8 +14,9 @@ import (
 
 // InputField is a one-line box (three lines if there is a title) where the
 // user can enter text. Use SetAcceptanceFunc() to accept or reject input,
-// SetChangedFunc() to listen for changes, and SetMaskCharacter() to hide input
-// from onlookers (e.g. for password input).
+// SetNumberFormat() to enter numbers, SetChangedFunc() to listen for changes,
+// and SetMaskCharacter() to hide input from onlookers (e.g. for password
+// input).
 //
 // The following keys can be used for navigation and editing:
 //
@@ -41,47 +43,8 @@ type InputField struct {
 	// The text to be displayed in the input area when "text" is empty.
 	placeholder []byte
 
-	// The label color.
-	labelColor tcell.Color
-
-	// The label color when focused.
-	labelFocusedColor tcell.Color
-
-	// The background color of the input area.
-	fieldBackgroundColor tcell.Color
-
-	// The background color of the input area when focused.
-	fieldBackgroundFocusedColor tcell.Color
-
-	// The text color of the input area.
-	fieldTextColor tcell.Color
-
-	// The text color of the input area when focused.
-	fieldTextFocusedColor tcell.Color
-
-	// The text color of the placeholder.
-	placeholderTextColor tcell.Color
-
-	// The text color of the placeholder when focused.
-	placeholderTextFocusedColor tcell.Color
-
-	// The text color of the list items.
-	autocompleteListTextColor tcell.Color
-
-	// The background color of the autocomplete list.
-	autocompleteListBackgroundColor tcell.Color
-
-	// The text color of the selected ListItem.
-	autocompleteListSelectedTextColor tcell.Color
-
-	// The background color of the selected ListItem.
-	autocompleteListSelectedBackgroundColor tcell.Color
-
-	// The text color of the suggestion.
-	autocompleteSuggestionTextColor tcell.Color
-
-	// The text color of the note below the input field.
-	fieldNoteTextColor tcell.Color
+	// The styles of the input field.
+	styles InputFieldStyles
 
 	// The note to show below the input field.
 	fieldNote []byte
@@ -118,6 +81,14 @@ type InputField struct {
 	// An optional function which may reject the last character that was entered.
 	accept func(text string, ch rune) bool
 
+	// An optional function which formats the text when the input field loses
+	// focus.
+	format func(text string) string
+
+	// The format of the number entered into this input field, see
+	// SetNumberFormat(). Nil if none was set.
+	numberFormat *NumberFormat
+
 	// An optional function which is called when the input has changed.
 	changed func(text string)
 
@@ -142,24 +113,41 @@ type InputField struct {
 // NewInputField returns a new input field.
 func NewInputField() *InputField {
 	return &InputField{
-		Box:                                     NewBox(),
-		labelColor:                              Styles.InputFieldLabelColor,
-		fieldBackgroundColor:                    Styles.InputFieldFieldBackgroundColor,
-		fieldBackgroundFocusedColor:             Styles.InputFieldFieldBackgroundFocusedColor,
-		fieldTextColor:                          Styles.InputFieldFieldTextColor,
-		fieldTextFocusedColor:                   Styles.InputFieldFieldTextFocusedColor,
-		placeholderTextColor:                    Styles.InputFieldPlaceholderTextColor,
-		autocompleteListTextColor:               Styles.InputFieldAutocompleteListTextColor,
-		autocompleteListBackgroundColor:         Styles.InputFieldAutocompleteListBackgroundColor,
-		autocompleteListSelectedTextColor:       Styles.InputFieldAutocompleteListSelectedTextColor,
-		autocompleteListSelectedBackgroundColor: Styles.InputFieldAutocompleteListSelectedBackgroundColor,
-		autocompleteSuggestionTextColor:         Styles.InputFieldAutocompleteSuggestionTextColor,
-		fieldNoteTextColor:                      Styles.InputFieldFieldNoteTextColor,
-		labelFocusedColor:                       Styles.InputFieldLabelFocusedColor,
-		placeholderTextFocusedColor:             Styles.InputFieldPlaceholderTextFocusedColor,
+		Box:    NewBox(),
+		styles: Styles.InputField,
+	}
+}
+
+// SetStyles sets the styles of the input field, overriding
+// Styles.InputField.
+func (i *InputField) SetStyles(styles InputFieldStyles) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.styles = styles
+	if i.autocompleteList != nil {
+		i.setAutocompleteListStyles(i.autocompleteList)
 	}
 }
 
+// GetStyles returns the styles of the input field.
+func (i *InputField) GetStyles() InputFieldStyles {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.styles
+}
+
+// setAutocompleteListStyles applies the autocomplete list styles to the
+// provided list.
+func (i *InputField) setAutocompleteListStyles(l *List) {
+	listStyles := l.GetStyles()
+	listStyles.MainText = i.styles.AutocompleteList
+	l.SetStyles(listStyles)
+	_, background, _ := i.styles.AutocompleteList.Normal.Decompose()
+	l.SetBackgroundColor(background)
+}
+
 // SetText sets the current text of the input field.
 func (i *InputField) SetText(text string) {
 	i.Lock()
@@ -220,7 +208,7 @@ func (i *InputField) SetLabelColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.labelColor = color
+	i.styles.Label.Normal = i.styles.Label.Normal.Foreground(color)
 }
 
 // SetLabelFocusedColor sets the color of the label when focused.
@@ -228,7 +216,7 @@ func (i *InputField) SetLabelFocusedColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.labelFocusedColor = color
+	i.styles.Label.Focused = i.styles.Label.Style(true, false, false).Foreground(color)
 }
 
 // SetFieldBackgroundColor sets the background color of the input area.
@@ -236,7 +224,7 @@ func (i *InputField) SetFieldBackgroundColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldBackgroundColor = color
+	i.styles.Field.Normal = i.styles.Field.Normal.Background(color)
 }
 
 // SetFieldBackgroundFocusedColor sets the background color of the input area
@@ -245,7 +233,7 @@ func (i *InputField) SetFieldBackgroundFocusedColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldBackgroundFocusedColor = color
+	i.styles.Field.Focused = i.styles.Field.Style(true, false, false).Background(color)
 }
 
 // SetFieldTextColor sets the text color of the input area.
@@ -253,7 +241,7 @@ func (i *InputField) SetFieldTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldTextColor = color
+	i.styles.Field.Normal = i.styles.Field.Normal.Foreground(color)
 }
 
 // SetFieldTextFocusedColor sets the text color of the input area when focused.
@@ -261,7 +249,7 @@ func (i *InputField) SetFieldTextFocusedColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldTextFocusedColor = color
+	i.styles.Field.Focused = i.styles.Field.Style(true, false, false).Foreground(color)
 }
 
 // SetPlaceholderTextColor sets the text color of placeholder text.
@@ -269,7 +257,7 @@ func (i *InputField) SetPlaceholderTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.placeholderTextColor = color
+	i.styles.Placeholder.Normal = i.styles.Placeholder.Normal.Foreground(color)
 }
 
 // SetPlaceholderTextFocusedColor sets the text color of placeholder text when
@@ -278,7 +266,7 @@ func (i *InputField) SetPlaceholderTextFocusedColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.placeholderTextFocusedColor = color
+	i.styles.Placeholder.Focused = i.styles.Placeholder.Style(true, false, false).Foreground(color)
 }
 
 // SetAutocompleteListTextColor sets the text color of the ListItems.
@@ -286,7 +274,7 @@ func (i *InputField) SetAutocompleteListTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListTextColor = color
+	i.styles.AutocompleteList.Normal = i.styles.AutocompleteList.Normal.Foreground(color)
 }
 
 // SetAutocompleteListBackgroundColor sets the background color of the
@@ -295,7 +283,7 @@ func (i *InputField) SetAutocompleteListBackgroundColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListBackgroundColor = color
+	i.styles.AutocompleteList.Normal = i.styles.AutocompleteList.Normal.Background(color)
 }
 
 // SetAutocompleteListSelectedTextColor sets the text color of the selected
@@ -304,7 +292,7 @@ func (i *InputField) SetAutocompleteListSelectedTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListSelectedTextColor = color
+	i.styles.AutocompleteList.Selected = i.styles.AutocompleteList.Style(false, false, true).Foreground(color)
 }
 
 // SetAutocompleteListSelectedBackgroundColor sets the background of the
@@ -313,7 +301,7 @@ func (i *InputField) SetAutocompleteListSelectedBackgroundColor(color tcell.Colo
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteListSelectedBackgroundColor = color
+	i.styles.AutocompleteList.Selected = i.styles.AutocompleteList.Style(false, false, true).Background(color)
 }
 
 // SetAutocompleteSuggestionTextColor sets the text color of the autocomplete
@@ -322,7 +310,7 @@ func (i *InputField) SetAutocompleteSuggestionTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.autocompleteSuggestionTextColor = color
+	i.styles.AutocompleteSuggestion = i.styles.AutocompleteSuggestion.Foreground(color)
 }
 
 // SetFieldNoteTextColor sets the text color of the note.
@@ -330,7 +318,7 @@ func (i *InputField) SetFieldNoteTextColor(color tcell.Color) {
 	i.Lock()
 	defer i.Unlock()
 
-	i.fieldNoteTextColor = color
+	i.styles.FieldNote = i.styles.FieldNote.Foreground(color)
 }
 
 // SetFieldNote sets the text to show below the input field, e.g. when the
@@ -377,6 +365,32 @@ func (i *InputField) GetFieldHeight() int {
 	return 2
 }
 
+// PreferredWidth returns the width of the label and the field plus the border
+// and padding. A field without a fixed width takes up the maximum width.
+func (i *InputField) PreferredWidth(maxWidth int) int {
+	i.RLock()
+	defer i.RUnlock()
+
+	labelWidth := i.labelWidth
+	if labelWidth <= 0 {
+		labelWidth = TaggedTextWidth(i.label)
+	}
+	return preferredFormItemWidth(i.Box, labelWidth, i.fieldWidth, maxWidth)
+}
+
+// PreferredHeight returns the height of the field, including the field note,
+// plus the border and padding.
+func (i *InputField) PreferredHeight(width int) int {
+	i.RLock()
+	defer i.RUnlock()
+
+	_, frameHeight := i.frameSize()
+	if len(i.fieldNote) == 0 {
+		return 1 + frameHeight
+	}
+	return 2 + frameHeight
+}
+
 // GetCursorPosition returns the cursor position.
 func (i *InputField) GetCursorPosition() int {
 	i.RLock()
@@ -450,11 +464,8 @@ func (i *InputField) Autocomplete() {
 		l := NewList()
 		l.SetChangedFunc(i.autocompleteChanged)
 		l.ShowSecondaryText(false)
-		l.SetMainTextColor(i.autocompleteListTextColor)
-		l.SetSelectedTextColor(i.autocompleteListSelectedTextColor)
-		l.SetSelectedBackgroundColor(i.autocompleteListSelectedBackgroundColor)
 		l.SetHighlightFullLine(true)
-		l.SetBackgroundColor(i.autocompleteListBackgroundColor)
+		i.setAutocompleteListStyles(l)
 
 		i.autocompleteList = l
 	}
@@ -482,9 +493,9 @@ func (i *InputField) Autocomplete() {
 func (i *InputField) autocompleteChanged(_ int, item *ListItem) {
 	mainText := item.GetMainBytes()
 	secondaryText := item.GetSecondaryBytes()
-	if len(i.text) < len(secondaryText) {
+	if len(i.text) < len(secondaryText) && bytes.HasPrefix(secondaryText, i.text) {
 		i.autocompleteListSuggestion = secondaryText[len(i.text):]
-	} else if len(i.text) < len(mainText) {
+	} else if len(i.text) < len(mainText) && bytes.HasPrefix(mainText, i.text) {
 		i.autocompleteListSuggestion = mainText[len(i.text):]
 	} else {
 		i.autocompleteListSuggestion = nil
@@ -494,8 +505,7 @@ func (i *InputField) autocompleteChanged(_ int, item *ListItem) {
 // SetAcceptanceFunc sets a handler which may reject the last character that was
 // entered (by returning false).
 //
-// This package defines a number of variables prefixed with InputField which may
-// be used for common input (e.g. numbers, maximum text length).
+// See SetNumberFormat() for the input of numbers.
 func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar rune) bool) {
 	i.Lock()
 	defer i.Unlock()
@@ -503,6 +513,75 @@ func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar
 	i.accept = handler
 }
 
+// SetFormatFunc sets a handler which formats the text when the input field
+// loses focus. The text is replaced with the handler's result. Provide nil to
+// leave the text as it is, which is the default.
+func (i *InputField) SetFormatFunc(handler func(text string) string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.format = handler
+}
+
+// SetNumberFormat restricts the input to numbers of the provided format (see
+// DecimalFormat(), CurrencyFormat() and PercentFormat()) and formats them when
+// the input field loses focus, e.g. "1234.5" becomes "$1,234.50". This sets
+// the acceptance function (see SetAcceptanceFunc()) and the format function
+// (see SetFormatFunc()), and it determines how GetFloat() and GetInt() parse
+// the text.
+func (i *InputField) SetNumberFormat(format NumberFormat) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.numberFormat = &format
+	i.accept = format.Accept
+	i.format = format.Format
+}
+
+// GetFloat returns the number entered into the input field, parsed according
+// to its number format (see SetNumberFormat()) or, if there is none, as a
+// decimal number with a "." as the decimal separator.
+func (i *InputField) GetFloat() (float64, error) {
+	i.RLock()
+	defer i.RUnlock()
+
+	format := NumberFormat{Decimals: -1, Negative: true}
+	if i.numberFormat != nil {
+		format = *i.numberFormat
+	}
+	return format.Parse(string(i.text))
+}
+
+// GetInt returns the integer entered into the input field, see GetFloat(). An
+// error is returned if the number has a fractional part or does not fit into
+// an int.
+func (i *InputField) GetInt() (int, error) {
+	value, err := i.GetFloat()
+	if err != nil {
+		return 0, err
+	}
+	if value != math.Trunc(value) || value < math.MinInt || value > math.MaxInt {
+		return 0, fmt.Errorf("not an integer: %v", value)
+	}
+	return int(value), nil
+}
+
+// Blur is called when this primitive loses focus. The text is formatted if a
+// format function was set, see SetFormatFunc().
+func (i *InputField) Blur() {
+	i.Box.Blur()
+
+	i.RLock()
+	format, text := i.format, string(i.text)
+	i.RUnlock()
+
+	if format != nil {
+		if formatted := format(text); formatted != text {
+			i.SetText(formatted)
+		}
+	}
+}
+
 // SetChangedFunc sets a handler which is called whenever the text of the input
 // field has changed. It receives the current text (after the change).
 func (i *InputField) SetChangedFunc(handler func(text string)) {
@@ -546,21 +625,10 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	i.Lock()
 	defer i.Unlock()
 
-	// Select colors
-	labelColor := i.labelColor
-	fieldBackgroundColor := i.fieldBackgroundColor
-	fieldTextColor := i.fieldTextColor
-	if i.GetFocusable().HasFocus() {
-		if i.labelFocusedColor != ColorUnset {
-			labelColor = i.labelFocusedColor
-		}
-		if i.fieldBackgroundFocusedColor != ColorUnset {
-			fieldBackgroundColor = i.fieldBackgroundFocusedColor
-		}
-		if i.fieldTextFocusedColor != ColorUnset {
-			fieldTextColor = i.fieldTextFocusedColor
-		}
-	}
+	// Select styles.
+	hasFocus := i.GetFocusable().HasFocus()
+	labelStyle := i.styles.Label.Style(hasFocus, false, false)
+	fieldStyle := i.styles.Field.Style(hasFocus, false, false)
 
 	// Prepare
 	x, y, width, height := i.GetInnerRect()
@@ -575,10 +643,10 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		if labelWidth > rightLimit-x {
 			labelWidth = rightLimit - x
 		}
-		Print(screen, i.label, x, y, labelWidth, AlignLeft, labelColor)
+		PrintStyle(screen, i.label, x, y, labelWidth, AlignLeft, labelStyle)
 		x += labelWidth
 	} else {
-		_, drawnWidth := Print(screen, i.label, x, y, rightLimit-x, AlignLeft, labelColor)
+		_, drawnWidth := PrintStyle(screen, i.label, x, y, rightLimit-x, AlignLeft, labelStyle)
 		x += drawnWidth
 	}
 
@@ -591,21 +659,15 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	if rightLimit-x < fieldWidth {
 		fieldWidth = rightLimit - x
 	}
-	fieldStyle := tcell.StyleDefault.Background(fieldBackgroundColor)
-	for index := 0; index < fieldWidth; index++ {
-		screen.SetContent(x+index, y, ' ', nil, fieldStyle)
-	}
+	FillRect(screen, x, y, fieldWidth, 1, ' ', fieldStyle)
 
 	// Text.
 	var cursorScreenPos int
 	text := i.text
 	if len(text) == 0 && len(i.placeholder) > 0 {
 		// Draw placeholder text.
-		placeholderTextColor := i.placeholderTextColor
-		if i.GetFocusable().HasFocus() && i.placeholderTextFocusedColor != ColorUnset {
-			placeholderTextColor = i.placeholderTextFocusedColor
-		}
-		Print(screen, EscapeBytes(i.placeholder), x, y, fieldWidth, AlignLeft, placeholderTextColor)
+		placeholderStyle := i.styles.Placeholder.Style(hasFocus, false, false)
+		PrintStyle(screen, EscapeBytes(i.placeholder), x, y, fieldWidth, AlignLeft, placeholderStyle)
 		i.offset = 0
 	} else {
 		// Draw entered text.
@@ -616,7 +678,7 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		if fieldWidth > runewidth.StringWidth(string(text)) {
 			// We have enough space for the full text.
 			drawnText = EscapeBytes(text)
-			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
+			PrintStyle(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldStyle)
 			i.offset = 0
 			iterateString(string(text), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
 				if textPos >= i.cursorPos {
@@ -655,17 +717,17 @@ func (i *InputField) Draw(screen tcell.Screen) {
 				return false
 			})
 			drawnText = EscapeBytes(text[i.offset:])
-			Print(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldTextColor)
+			PrintStyle(screen, drawnText, x, y, fieldWidth, AlignLeft, fieldStyle)
 		}
 		// Draw suggestion
 		if i.maskCharacter == 0 && len(i.autocompleteListSuggestion) > 0 {
-			Print(screen, i.autocompleteListSuggestion, x+runewidth.StringWidth(string(drawnText)), y, fieldWidth-runewidth.StringWidth(string(drawnText)), AlignLeft, i.autocompleteSuggestionTextColor)
+			PrintStyle(screen, i.autocompleteListSuggestion, x+runewidth.StringWidth(string(drawnText)), y, fieldWidth-runewidth.StringWidth(string(drawnText)), AlignLeft, i.styles.AutocompleteSuggestion)
 		}
 	}
 
 	// Draw field note
 	if len(i.fieldNote) > 0 {
-		Print(screen, i.fieldNote, x, y+1, fieldWidth, AlignLeft, i.fieldNoteTextColor)
+		PrintStyle(screen, i.fieldNote, x, y+1, fieldWidth, AlignLeft, i.styles.FieldNote)
 	}
 
 	// Draw autocomplete list.
@@ -694,11 +756,11 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		if ly+lheight >= sheight {
 			lheight = sheight - ly
 		}
-		if i.autocompleteList.scrollBarVisibility == ScrollBarAlways || (i.autocompleteList.scrollBarVisibility == ScrollBarAuto && i.autocompleteList.GetItemCount() > lheight) {
+		if i.autocompleteList.scrollBar.IsVisible(i.autocompleteList.GetItemCount(), lheight) {
 			lwidth++ // Add space for scroll bar
 		}
 		i.autocompleteList.SetRect(lx, ly, lwidth, lheight)
-		i.autocompleteList.Draw(screen)
+		i.autocompleteList.Draw(UnclippedScreen(screen)) // The list may extend beyond the input field.
 	}
 
 	// Set cursor.
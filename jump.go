@@ -0,0 +1,168 @@
+package nuview
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// JumpHintsAlphabet is the default alphabet of jump hint labels, the keys of
+// the home row.
+const JumpHintsAlphabet = "asdfghjkl"
+
+// Jumpable is implemented by primitives which support the application's jump
+// hints (see Application.EnableJumpHints). List and Table implement this
+// interface.
+type Jumpable interface {
+	// JumpTargets returns the visible items which may be jumped to.
+	JumpTargets() []JumpTarget
+
+	// Jump selects the provided target, one of those returned by
+	// JumpTargets().
+	Jump(target JumpTarget)
+}
+
+// JumpTarget is an item which may be jumped to, see Jumpable.
+type JumpTarget struct {
+	// The screen position at which the target's label is drawn.
+	X, Y int
+
+	// The position of the item within its primitive, e.g. the index of a list
+	// item (Row) or the row and column of a table cell.
+	Row, Column int
+}
+
+// JumpHints assigns short labels to jump targets, the way avy or easymotion
+// do in text editors. The labels consist of the characters of an alphabet and
+// are drawn on top of the targets. Typing a label selects its target. This is
+// used by the application (see Application.EnableJumpHints) but may also be
+// used by primitives to offer label-based jumping on their own.
+type JumpHints struct {
+	// The characters labels consist of.
+	alphabet []rune
+
+	// The targets and their labels, nil if jump hints are not shown.
+	targets []JumpTarget
+	labels  []string
+
+	// The characters of a label typed so far.
+	typed string
+
+	sync.RWMutex
+}
+
+// NewJumpHints returns new jump hints using JumpHintsAlphabet.
+func NewJumpHints() *JumpHints {
+	return &JumpHints{
+		alphabet: []rune(JumpHintsAlphabet),
+	}
+}
+
+// SetAlphabet sets the characters labels consist of, which must be at least
+// two. Labels are as short as possible, the first characters of the alphabet
+// are used first.
+func (j *JumpHints) SetAlphabet(alphabet string) {
+	j.Lock()
+	defer j.Unlock()
+
+	if len([]rune(alphabet)) < 2 {
+		return
+	}
+	j.alphabet = []rune(alphabet)
+}
+
+// Start assigns labels to the provided targets and shows them until a label
+// is typed or Stop() is called.
+func (j *JumpHints) Start(targets []JumpTarget) {
+	j.Lock()
+	defer j.Unlock()
+
+	j.targets = targets
+	j.labels = jumpLabels(j.alphabet, len(targets))
+	j.typed = ""
+}
+
+// Stop hides the labels.
+func (j *JumpHints) Stop() {
+	j.Lock()
+	defer j.Unlock()
+
+	j.targets, j.labels, j.typed = nil, nil, ""
+}
+
+// IsActive returns whether or not labels are shown.
+func (j *JumpHints) IsActive() bool {
+	j.RLock()
+	defer j.RUnlock()
+
+	return j.targets != nil
+}
+
+// Type adds the provided character to the label typed so far. If this
+// completes a label, the labels are hidden and its target is returned with
+// ok set to true. If no label starts with the typed characters, the labels are
+// hidden, too.
+func (j *JumpHints) Type(r rune) (target JumpTarget, ok bool) {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.targets == nil {
+		return
+	}
+	typed := j.typed + string(r)
+	var matched bool
+	for index, label := range j.labels {
+		if label == typed {
+			target, ok = j.targets[index], true
+			break
+		}
+		matched = matched || strings.HasPrefix(label, typed)
+	}
+	if ok || !matched {
+		j.targets, j.labels, j.typed = nil, nil, ""
+		return
+	}
+	j.typed = typed
+	return
+}
+
+// Draw draws the labels with the provided style on top of their targets,
+// without the characters typed so far. Labels which don't start with the
+// typed characters are not drawn.
+func (j *JumpHints) Draw(screen ScreenWriter, style tcell.Style) {
+	j.RLock()
+	defer j.RUnlock()
+
+	for index, target := range j.targets {
+		label := j.labels[index]
+		if !strings.HasPrefix(label, j.typed) {
+			continue
+		}
+		for offset, r := range []rune(label[len(j.typed):]) {
+			screen.SetContent(target.X+offset, target.Y, r, nil, style)
+		}
+	}
+}
+
+// jumpLabels returns count labels of equal length consisting of the provided
+// characters.
+func jumpLabels(alphabet []rune, count int) []string {
+	length, combinations := 1, len(alphabet)
+	for combinations < count {
+		length++
+		combinations *= len(alphabet)
+	}
+
+	labels := make([]string, count)
+	label := make([]rune, length)
+	for index := range labels {
+		number := index
+		for position := length - 1; position >= 0; position-- {
+			label[position] = alphabet[number%len(alphabet)]
+			number /= len(alphabet)
+		}
+		labels[index] = string(label)
+	}
+	return labels
+}
@@ -0,0 +1,87 @@
+package nuview
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestJumpHints(t *testing.T) {
+	t.Parallel()
+
+	list := NewList()
+	for index := 0; index < 12; index++ {
+		list.AddItem(NewListItem(fmt.Sprintf("Item %d", index)))
+	}
+	app, err := newTestApp(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := app.GetScreen()
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetRoot(list, false)
+	list.SetRect(0, 0, 20, 24)
+	app.EnableJumpHints(true)
+	app.draw()
+	app.SetFocus(list)
+
+	key := func(k tcell.Key, ch rune, mod tcell.ModMask) {
+		app.dispatchKey(tcell.NewEventKey(k, ch, mod), "")
+	}
+	label := func(y int) string {
+		first, _, _, _ := sc.GetContent(0, y)
+		second, _, _, _ := sc.GetContent(1, y)
+		return string([]rune{first, second})
+	}
+
+	// Show the labels, narrow them down, and jump.
+	key(tcell.KeyRune, 'j', tcell.ModAlt)
+	if got := label(0) + " " + label(20); got != "aa ss" {
+		t.Errorf("failed to draw labels: expected \"aa ss\", got %q", got)
+	}
+	key(tcell.KeyRune, 's', tcell.ModNone)
+	if got := label(0); got != "It" {
+		t.Errorf("failed to hide other labels: got %q", got)
+	}
+	key(tcell.KeyRune, 's', tcell.ModNone)
+	if index := list.GetCurrentItemIndex(); index != 10 {
+		t.Errorf("failed to jump to item: expected 10, got %d", index)
+	}
+	if app.GetJumpHints().IsActive() {
+		t.Error("failed to hide labels after jumping")
+	}
+
+	// Cancel.
+	key(tcell.KeyRune, 'j', tcell.ModAlt)
+	key(tcell.KeyEscape, 0, tcell.ModNone)
+	key(tcell.KeyRune, 'a', tcell.ModNone)
+	if index := list.GetCurrentItemIndex(); index != 10 || app.GetJumpHints().IsActive() {
+		t.Errorf("failed to cancel jump hints: expected item 10, got %d", index)
+	}
+
+	// Table cells.
+	tb := NewTable()
+	tb.SetBorders(true)
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(1, 0, "c")
+	tb.SetCellSimple(1, 1, "d")
+	tb.SetRect(0, 0, 10, 5)
+	tb.SetSelectable(true, false)
+	tb.Draw(sc)
+	if got := fmt.Sprint(tb.JumpTargets()); got != "[{1 1 0 0} {1 3 1 0}]" {
+		t.Errorf("failed to get row targets: got %s", got)
+	}
+	tb.SetSelectable(false, true)
+	if got := fmt.Sprint(tb.JumpTargets()); got != "[{1 1 0 0} {3 1 0 1}]" {
+		t.Errorf("failed to get column targets: got %s", got)
+	}
+	tb.SetSelectable(true, true)
+	tb.Jump(tb.JumpTargets()[3])
+	if row, column := tb.GetSelection(); row != 1 || column != 1 {
+		t.Errorf("failed to jump to cell: expected 1,1, got %d,%d", row, column)
+	}
+}
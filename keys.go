@@ -1,12 +1,28 @@
 package nuview
 
 import (
+	"reflect"
+	"strings"
+	"time"
+
 	"codeberg.org/tslocum/cbind"
 	"github.com/gdamore/tcell/v2"
 )
 
+// StandardChordTimeout is a commonly used maximum time between the keystrokes
+// of a key chord.
+const StandardChordTimeout = time.Second
+
+// StandardKeyRepeatInterval is a commonly used minimum time between repeated
+// navigation keys, about one frame at 60 frames per second.
+const StandardKeyRepeatInterval = 16 * time.Millisecond
+
 // Key defines the keyboard shortcuts of an application.
 // Secondary shortcuts apply when not focusing a text input.
+//
+// A shortcut may also be a key chord, i.e. a sequence of keystrokes separated
+// by spaces such as "g g" or "Ctrl+X Ctrl+S". Key chords are recognized by the
+// Application, see Application.AddKeyChords().
 type Key struct {
 	Cancel []string
 
@@ -32,11 +48,52 @@ type Key struct {
 	MovePreviousPage  []string
 	MoveNextPage      []string
 
+	MoveWordLeft          []string
+	MoveWordRight         []string
+	MovePreviousParagraph []string
+	MoveNextParagraph     []string
+
+	ScrollViewTop    []string
+	ScrollViewCenter []string
+	ScrollViewBottom []string
+
 	ShowContextMenu []string
+
+	ToggleSelection      []string
+	ExtendSelectionUp    []string
+	ExtendSelectionDown  []string
+	ExtendSelectionLeft  []string
+	ExtendSelectionRight []string
+
+	ExtendSelectionFirst    []string
+	ExtendSelectionLast     []string
+	ExtendSelectionPageUp   []string
+	ExtendSelectionPageDown []string
+
+	MoveRowUp       []string
+	MoveRowDown     []string
+	MoveColumnLeft  []string
+	MoveColumnRight []string
+
+	Find []string
+
+	Arrange []string
+
+	CancelTask []string
+
+	NextAnnotation     []string
+	PreviousAnnotation []string
+
+	ActivateCommandBar []string
+
+	CloseTab []string
+
+	Jump []string
 }
 
 // Keys defines the keyboard shortcuts of an application.
-// Secondary shortcuts apply when not focusing a text input.
+// Secondary shortcuts apply when not focusing a text input. An application may
+// use its own shortcuts instead, see Application.SetKeys().
 var Keys = Key{
 	Cancel: []string{"Escape"},
 
@@ -62,15 +119,68 @@ var Keys = Key{
 	MovePreviousPage:  []string{"PageUp", "Ctrl+B"},
 	MoveNextPage:      []string{"PageDown", "Ctrl+F"},
 
-	ShowContextMenu: []string{"Alt+Enter"},
+	MoveWordLeft:          []string{"b"},
+	MoveWordRight:         []string{"w"},
+	MovePreviousParagraph: []string{"{", "["},
+	MoveNextParagraph:     []string{"}", "]"},
+
+	ScrollViewTop:    []string{"z t"},
+	ScrollViewCenter: []string{"z z"},
+	ScrollViewBottom: []string{"z b"},
+
+	ShowContextMenu: []string{"Alt+Enter", "Shift+F10"},
+
+	ToggleSelection:      []string{"Insert"},
+	ExtendSelectionUp:    []string{"Shift+Up"},
+	ExtendSelectionDown:  []string{"Shift+Down"},
+	ExtendSelectionLeft:  []string{"Shift+Left"},
+	ExtendSelectionRight: []string{"Shift+Right"},
+
+	ExtendSelectionFirst:    []string{"Shift+Home"},
+	ExtendSelectionLast:     []string{"Shift+End"},
+	ExtendSelectionPageUp:   []string{"Shift+PageUp"},
+	ExtendSelectionPageDown: []string{"Shift+PageDown"},
+
+	MoveRowUp:       []string{"Alt+Up"},
+	MoveRowDown:     []string{"Alt+Down"},
+	MoveColumnLeft:  []string{"Alt+Left"},
+	MoveColumnRight: []string{"Alt+Right"},
+
+	Find: []string{"F3"},
+
+	Arrange: []string{"Ctrl+W"},
+
+	CancelTask: []string{"Delete"},
+
+	NextAnnotation:     []string{"F8"},
+	PreviousAnnotation: []string{"Shift+F8"},
+
+	ActivateCommandBar: []string{":"},
+
+	CloseTab: []string{"Ctrl+F4"},
+
+	Jump: []string{"Alt+j"},
 }
 
 // HitShortcut returns whether the EventKey provided is present in one or more
-// sets of keybindings.
+// sets of keybindings. Key chords are not matched, use
+// Application.HitShortcut() for those.
 func HitShortcut(event *tcell.EventKey, keybindings ...[]string) bool {
-	enc, err := cbind.Encode(event.Modifiers(), event.Key(), event.Rune())
-	if err != nil {
-		return false
+	return hitShortcut(event, "", keybindings)
+}
+
+// hitShortcut returns whether the EventKey provided is present in one or more
+// sets of keybindings. If the event completed a key chord, the chord's
+// keybinding is provided and only that keybinding matches, single keybindings
+// of the event's key do not.
+func hitShortcut(event *tcell.EventKey, chord string, keybindings [][]string) bool {
+	enc := chord
+	if enc == "" {
+		var err error
+		enc, err = cbind.Encode(event.Modifiers(), event.Key(), event.Rune())
+		if err != nil {
+			return false
+		}
 	}
 
 	for _, binds := range keybindings {
@@ -83,3 +193,34 @@ func HitShortcut(event *tcell.EventKey, keybindings ...[]string) bool {
 
 	return false
 }
+
+// keyChords returns all key chords (keybindings consisting of more than one
+// keystroke) of the provided shortcuts.
+func keyChords(k *Key) (chords []string) {
+	value := reflect.ValueOf(k).Elem()
+	for index := 0; index < value.NumField(); index++ {
+		binds, ok := value.Field(index).Interface().([]string)
+		if !ok {
+			continue
+		}
+		for _, key := range binds {
+			if strings.Contains(key, " ") {
+				chords = append(chords, key)
+			}
+		}
+	}
+	return
+}
+
+// keyBound returns whether or not the key event matches any of the provided
+// shortcuts.
+func keyBound(k *Key, event *tcell.EventKey) bool {
+	value := reflect.ValueOf(k).Elem()
+	for index := 0; index < value.NumField(); index++ {
+		binds, ok := value.Field(index).Interface().([]string)
+		if ok && HitShortcut(event, binds) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestKeyChords(t *testing.T) {
+	t.Parallel()
+
+	app := NewApplication()
+	app.AddKeyChords("Ctrl+X Ctrl+S")
+	app.SetChordTimeout(0)
+
+	var pending []string
+	app.SetPendingChordFunc(func(chord string) {
+		pending = append(pending, chord)
+	})
+
+	// Complete a chord
+
+	ctrlX := tcell.NewEventKey(tcell.KeyCtrlX, 0, tcell.ModCtrl)
+	if keys := app.resolveChord(ctrlX); len(keys) != 0 {
+		t.Fatalf("failed to hold back chord start: expected 0 keys, got %d", len(keys))
+	} else if app.GetPendingChord() != "Ctrl+X" {
+		t.Errorf("failed to set pending chord: expected Ctrl+X, got %q", app.GetPendingChord())
+	}
+
+	ctrlS := tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)
+	keys := app.resolveChord(ctrlS)
+	if len(keys) != 1 || keys[0].event != ctrlS || keys[0].chord != "Ctrl+X Ctrl+S" {
+		t.Fatalf("failed to complete chord: got %v", keys)
+	} else if app.GetPendingChord() != "" {
+		t.Errorf("failed to clear pending chord: got %q", app.GetPendingChord())
+	}
+
+	app.setCompletedChord(ctrlS, keys[0].chord)
+	if !app.HitShortcut(ctrlS, []string{"Ctrl+X Ctrl+S"}) {
+		t.Error("failed to hit chord shortcut")
+	} else if app.HitShortcut(ctrlS, []string{"Ctrl+S"}) {
+		t.Error("failed to suppress single key shortcut of completed chord")
+	} else if HitShortcut(ctrlS, []string{"Ctrl+X Ctrl+S"}) {
+		t.Error("failed to ignore completed chord of application")
+	}
+	app.setCompletedChord(ctrlS, "")
+	if !app.HitShortcut(ctrlS, []string{"Ctrl+S"}) {
+		t.Error("failed to hit single key shortcut")
+	}
+
+	// Break a chord
+
+	app.resolveChord(ctrlX)
+	a := tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone)
+	keys = app.resolveChord(a)
+	if len(keys) != 2 || keys[0].event != ctrlX || keys[1].event != a || keys[0].chord != "" || keys[1].chord != "" {
+		t.Errorf("failed to dispatch broken chord: got %v", keys)
+	}
+
+	expected := []string{"Ctrl+X", "", "Ctrl+X", ""}
+	if len(pending) != len(expected) {
+		t.Fatalf("failed to notify pending chord changes: expected %v, got %v", expected, pending)
+	}
+	for index := range expected {
+		if pending[index] != expected[index] {
+			t.Errorf("failed to notify pending chord changes: expected %v, got %v", expected, pending)
+			break
+		}
+	}
+}
+
+func TestKeyChordsRun(t *testing.T) {
+	t.Parallel()
+
+	app := NewApplication()
+	app.AddKeyChords("Ctrl+X Ctrl+S")
+	app.SetRoot(NewBox(), true)
+	var received []string
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		received = append(received, event.Name())
+		if app.HitShortcut(event, []string{"Ctrl+X Ctrl+S"}) {
+			received = append(received, "chord")
+		}
+		return nil
+	})
+
+	h := NewHeadless(app, 10, 4)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	app.QueueEvent(tcell.NewEventKey(tcell.KeyCtrlX, 0, tcell.ModCtrl))
+	app.QueueEvent(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl))
+	h.Sync()
+	if got := strings.Join(received, " "); got != "Ctrl+S chord" {
+		t.Errorf("failed to complete chord while running: expected \"Ctrl+S chord\", got %q", got)
+	}
+}
+
+func TestKeyChordsInputField(t *testing.T) {
+	t.Parallel()
+
+	field := NewInputField()
+	app := NewApplication()
+	app.SetRoot(field, true)
+
+	h := NewHeadless(app, 10, 1)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	// "z t" scrolls text views but must not swallow typed characters.
+	if err := h.SendKeys("z", "t"); err != nil {
+		t.Fatal(err)
+	}
+	h.Sync()
+	if text := field.GetText(); text != "zt" {
+		t.Errorf("failed to type key chord into input field: expected \"zt\", got %q", text)
+	}
+}
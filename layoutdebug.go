@@ -0,0 +1,79 @@
+package nuview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// LayoutDebugColor is the color of the debug overlay of Flex and Grid, see
+// Flex.SetDebug() and Grid.SetDebug().
+var LayoutDebugColor = tcell.ColorYellow
+
+// layoutDebugItem is an item of a layout as shown by the debug overlay.
+type layoutDebugItem struct {
+	index               int  // The index of the item in the layout.
+	x, y, width, height int  // The screen rect of the item.
+	hidden              bool // Whether or not the item is hidden and takes up no space.
+}
+
+// drawLayoutDebug draws the debug overlay of a layout in the provided area:
+// the boundaries of its columns and rows (screen positions, may be nil) on
+// empty cells and the index and size of each item at its top left corner. Items
+// which were not laid out or have a size of 0 are listed at the bottom of the
+// area instead.
+func drawLayoutDebug(screen tcell.Screen, x, y, width, height int, columns, rows []int, items []layoutDebugItem) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the boundaries on empty cells only, to keep the content readable.
+	line := func(cx, cy int, r rune) {
+		if primary, _, style, _ := screen.GetContent(cx, cy); primary == ' ' {
+			screen.SetContent(cx, cy, r, nil, style.Foreground(LayoutDebugColor))
+		}
+	}
+	for _, column := range columns {
+		if column >= x && column < x+width {
+			for cy := y; cy < y+height; cy++ {
+				line(column, cy, '┊')
+			}
+		}
+	}
+	for _, row := range rows {
+		if row >= y && row < y+height {
+			for cx := x; cx < x+width; cx++ {
+				line(cx, row, '┄')
+			}
+		}
+	}
+
+	// Label the items.
+	style := tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(LayoutDebugColor)
+	label := func(lx, ly, maxX int, text string) {
+		for _, r := range text {
+			if lx >= maxX {
+				break
+			}
+			screen.SetContent(lx, ly, r, nil, style)
+			lx++
+		}
+	}
+	var collapsed []string
+	for _, item := range items {
+		switch {
+		case item.hidden:
+			collapsed = append(collapsed, fmt.Sprintf("#%d hidden", item.index))
+		case item.width <= 0 || item.height <= 0:
+			collapsed = append(collapsed, fmt.Sprintf("#%d %dx%d", item.index, max(item.width, 0), max(item.height, 0)))
+		default:
+			if item.y >= y && item.y < y+height && item.x >= x {
+				label(item.x, item.y, min(item.x+item.width, x+width), fmt.Sprintf("#%d %dx%d", item.index, item.width, item.height))
+			}
+		}
+	}
+	if len(collapsed) > 0 {
+		label(x, y+height-1, x+width, strings.Join(collapsed, " "))
+	}
+}
@@ -0,0 +1,231 @@
+package nuview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Link is a line of underlined text which triggers an action when the user
+// selects it with the keyboard or clicks it, e.g. a reference to a help page or
+// to a related item. If a URL is set, the text is also emitted as an OSC 8
+// hyperlink which terminals supporting it let the user open.
+type Link struct {
+	*Box
+
+	// Whether or not the link is enabled.
+	enabled bool
+
+	// The text of the link.
+	text []byte
+
+	// The URL emitted as a hyperlink, empty for none.
+	url string
+
+	// The alignment of the text.
+	align int
+
+	// The styles of the text.
+	styles WidgetStyle
+
+	// An optional function which is called when the link was selected.
+	selected func()
+
+	// An optional function which is called when the user leaves the link. A
+	// key is provided indicating which key was pressed to leave (tab or
+	// backtab).
+	blur func(tcell.Key)
+
+	sync.RWMutex
+}
+
+// NewLink returns a new link with the provided text.
+func NewLink(text string) *Link {
+	box := NewBox()
+	box.SetRect(0, 0, TaggedStringWidth(text), 1)
+	return &Link{
+		Box:     box,
+		enabled: true,
+		text:    []byte(text),
+		align:   AlignLeft,
+		styles:  Styles.Link,
+	}
+}
+
+// SetText sets the text of the link.
+func (l *Link) SetText(text string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.text = []byte(text)
+}
+
+// GetText returns the text of the link.
+func (l *Link) GetText() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return string(l.text)
+}
+
+// SetURL sets the URL the text links to. Terminals supporting OSC 8
+// hyperlinks let the user open it, e.g. with Ctrl+click, independently of the
+// link's action (see SetSelectedFunc()). Provide an empty string, the default,
+// to emit no hyperlink.
+func (l *Link) SetURL(url string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.url = url
+}
+
+// GetURL returns the URL the text links to.
+func (l *Link) GetURL() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.url
+}
+
+// SetAlign sets the alignment of the text, AlignLeft (the default),
+// AlignCenter or AlignRight.
+func (l *Link) SetAlign(align int) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.align = align
+}
+
+// SetStyles sets the styles of the link, overriding Styles.Link. The Selected
+// state is not used.
+func (l *Link) SetStyles(styles WidgetStyle) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.styles = styles
+}
+
+// GetStyles returns the styles of the link.
+func (l *Link) GetStyles() WidgetStyle {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.styles
+}
+
+// SetEnabled sets whether or not the link can be selected.
+func (l *Link) SetEnabled(enabled bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.enabled = enabled
+}
+
+// SetSelectedFunc sets a handler which is called when the user selects the
+// link with the keyboard or clicks it.
+func (l *Link) SetSelectedFunc(handler func()) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.selected = handler
+}
+
+// SetBlurFunc sets a handler which is called when the user leaves the link.
+// The callback function is provided with the key that was pressed, which is one
+// of the following:
+//
+//   - KeyEscape: Leaving the link with no specific direction.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (l *Link) SetBlurFunc(handler func(key tcell.Key)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.blur = handler
+}
+
+// PreferredWidth returns the width of the text plus the border and padding.
+func (l *Link) PreferredWidth(maxWidth int) int {
+	l.RLock()
+	defer l.RUnlock()
+
+	frameWidth, _ := l.frameSize()
+	width := TaggedTextWidth(l.text) + frameWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// PreferredHeight returns the height of the text plus the border and padding.
+func (l *Link) PreferredHeight(width int) int {
+	_, frameHeight := l.frameSize()
+	return 1 + frameHeight
+}
+
+// Draw draws this primitive onto the screen.
+func (l *Link) Draw(screen tcell.Screen) {
+	if !l.GetVisible() {
+		return
+	}
+
+	l.Box.Draw(screen)
+
+	l.Lock()
+	defer l.Unlock()
+
+	x, y, width, height := l.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	style := l.styles.Style(l.focus.HasFocus(), !l.enabled, false)
+	if l.url != "" {
+		style = style.Url(l.url)
+	}
+	PrintStyle(screen, l.text, x, y+height/2, width, l.align, style)
+}
+
+// InputHandler returns the handler for this primitive.
+func (l *Link) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		l.RLock()
+		enabled, selected, blur := l.enabled, l.selected, l.blur
+		l.RUnlock()
+
+		switch {
+		case l.hitShortcut(event, l.keys().Select, l.keys().Select2):
+			if enabled && selected != nil {
+				selected()
+			}
+		case l.hitShortcut(event, l.keys().Cancel, l.keys().MovePreviousField, l.keys().MoveNextField):
+			if blur != nil {
+				blur(event.Key())
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (l *Link) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !l.InRect(event.Position()) {
+			return false, nil
+		}
+
+		if action == MouseLeftClick {
+			l.RLock()
+			enabled, selected := l.enabled, l.selected
+			l.RUnlock()
+			if !enabled {
+				return false, nil
+			}
+			setFocus(l)
+			if selected != nil {
+				selected()
+			}
+			consumed = true
+		}
+
+		return
+	})
+}
@@ -0,0 +1,67 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLink(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 1)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	var selected int
+	l := NewLink("Help")
+	l.SetURL("https://example.com/help")
+	l.SetRect(0, 0, 12, 1)
+	l.SetSelectedFunc(func() {
+		selected++
+	})
+	if width := l.PreferredWidth(0); width != 4 {
+		t.Errorf("failed to get preferred width: expected 4, got %d", width)
+	}
+
+	l.Draw(sc)
+	if got := row(0); got != "Help        " {
+		t.Errorf("failed to draw link: expected %q, got %q", "Help        ", got)
+	}
+	if _, _, style, _ := sc.GetContent(0, 0); style != Styles.Link.Normal.Background(l.GetBackgroundColor()).Url("https://example.com/help") {
+		t.Errorf("failed to draw link: expected link style with URL, got %#v", style)
+	}
+
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	l.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if selected != 2 {
+		t.Errorf("failed to activate link: expected 2 selections, got %d", selected)
+	}
+
+	l.SetEnabled(false)
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	l.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if selected != 2 {
+		t.Errorf("failed to disable link: expected 2 selections, got %d", selected)
+	}
+
+	var blurred tcell.Key
+	l.SetBlurFunc(func(key tcell.Key) {
+		blurred = key
+	})
+	l.InputHandler()(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), func(Primitive) {})
+	if blurred != tcell.KeyTab {
+		t.Errorf("failed to leave link: expected %v, got %v", tcell.KeyTab, blurred)
+	}
+}
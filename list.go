@@ -11,12 +11,13 @@ import (
 
 // ListItem represents an item in a List.
 type ListItem struct {
-	disabled      bool        // Whether or not the list item is selectable.
-	mainText      []byte      // The main text of the list item.
-	secondaryText []byte      // A secondary text to be shown underneath the main text.
-	shortcut      rune        // The key to select the list item directly, 0 if there is no shortcut.
-	selected      func()      // The optional function which is called when the item is selected.
-	reference     interface{} // An optional reference object.
+	disabled      bool         // Whether or not the list item is selectable.
+	mainText      []byte       // The main text of the list item.
+	secondaryText []byte       // A secondary text to be shown underneath the main text.
+	shortcut      rune         // The key to select the list item directly, 0 if there is no shortcut.
+	selected      func()       // The optional function which is called when the item is selected.
+	reference     interface{}  // An optional reference object.
+	suffix        CellRenderer // An optional renderer drawn at the right edge of the main text row.
 
 	sync.RWMutex
 }
@@ -120,6 +121,25 @@ func (l *ListItem) GetReference() interface{} {
 	return l.reference
 }
 
+// SetSuffixRenderer sets a renderer which is drawn at the right edge of the
+// row of the main text, e.g. a Sparkline. It occupies as many cells as its
+// preferred width, at most the width of the list. Set it to nil to remove it.
+func (l *ListItem) SetSuffixRenderer(renderer CellRenderer) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.suffix = renderer
+}
+
+// GetSuffixRenderer returns the renderer drawn at the right edge of the row of
+// the main text, if any.
+func (l *ListItem) GetSuffixRenderer() CellRenderer {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.suffix
+}
+
 // List displays rows of items, each of which can be selected.
 type List struct {
 	*Box
@@ -134,29 +154,20 @@ type List struct {
 	// Whether or not to show the secondary item texts.
 	showSecondaryText bool
 
-	// The item main text color.
-	mainTextColor tcell.Color
-
-	// The item secondary text color.
-	secondaryTextColor tcell.Color
-
-	// The item shortcut text color.
-	shortcutColor tcell.Color
+	// The styles of the items.
+	styles ListStyles
 
-	// The text color for selected items.
-	selectedTextColor tcell.Color
+	// The vertical scroll bar.
+	scrollBar *ScrollBar
 
-	// The style attributes for selected items.
-	selectedTextAttributes tcell.AttrMask
+	// Animates scrolling with the mouse wheel and by pages.
+	smoothScroll smoothScroll
 
-	// Visibility of the scroll bar.
-	scrollBarVisibility ScrollBarVisibility
+	// Reports changes of the item offset, see SetScrollChangedFunc().
+	scrollChanged scrollNotifier
 
-	// The scroll bar color.
-	scrollBarColor tcell.Color
-
-	// The background color for selected items.
-	selectedBackgroundColor tcell.Color
+	// The placeholder and the loading spinner.
+	contentState contentState
 
 	// If true, the selection is only shown when the list has focus.
 	selectedFocusOnly bool
@@ -176,6 +187,9 @@ type List struct {
 	// Whether or not hovering over an item will highlight it.
 	hover bool
 
+	// Whether or not the item shortcuts apply while the list is not focused.
+	globalShortcuts bool
+
 	// The number of list items and columns by which the list is scrolled
 	// down/to the right.
 	itemOffset, columnOffset int
@@ -188,6 +202,9 @@ type List struct {
 	// function will be called even if the list item defines its own callback.
 	selected func(index int, item *ListItem)
 
+	// An optional function which is called when a list item was double-clicked.
+	doubleClick func(index int, item *ListItem)
+
 	// An optional function which is called when the user presses the Escape key.
 	done func()
 
@@ -203,23 +220,22 @@ type List struct {
 	// Maximum prefix and suffix width.
 	prefixWidth, suffixWidth int
 
+	// An optional model of the selected items, see SetSelectionModel().
+	selection *SelectionModel
+
 	sync.RWMutex
 }
 
 // NewList returns a new form.
 func NewList() *List {
 	l := &List{
-		Box:                     NewBox(),
-		showSecondaryText:       true,
-		scrollBarVisibility:     ScrollBarAuto,
-		mainTextColor:           Styles.ListMainTextColor,
-		secondaryTextColor:      Styles.ListSecondaryTextColor,
-		shortcutColor:           Styles.ListShortcutColor,
-		selectedTextColor:       Styles.ListSelectedTextColor,
-		scrollBarColor:          Styles.ListScrollBarColor,
-		selectedBackgroundColor: Styles.ListSelectedBackgroundColor,
+		Box:               NewBox(),
+		showSecondaryText: true,
+		styles:            Styles.List,
+		scrollBar:         NewScrollBar(),
 	}
 
+	l.scrollBar.SetColor(Styles.List.ScrollBarColor)
 	l.ContextMenu = NewContextMenu(l)
 	l.focus = l
 
@@ -250,6 +266,10 @@ func (l *List) SetCurrentItem(index int) {
 
 	l.updateOffset()
 
+	if index != previousItem && l.selection != nil {
+		defer l.selection.follow(previousItem, index, false)
+	}
+
 	if index != previousItem && index < len(l.items) && l.changed != nil {
 		item := l.items[index]
 		l.Unlock()
@@ -286,6 +306,22 @@ func (l *List) GetItems() []*ListItem {
 	return l.items
 }
 
+// ForEachItem calls the provided callback for each item of the list in order.
+// Iteration stops when the callback returns false. The items are those of the
+// list when iteration started, and the list is not locked while the callback
+// runs so it may modify the list.
+func (l *List) ForEachItem(callback func(index int, item *ListItem) bool) {
+	l.RLock()
+	items := append([]*ListItem(nil), l.items...)
+	l.RUnlock()
+
+	for index, item := range items {
+		if !callback(index, item) {
+			return
+		}
+	}
+}
+
 // RemoveItem removes the item with the given index (starting at 0) from the
 // list. If a negative index is provided, items are referred to from the back
 // (-1 = last item, -2 = second-to-last item, and so on). Out of range indices
@@ -315,6 +351,9 @@ func (l *List) RemoveItem(index int) {
 
 	// Remove item.
 	l.items = append(l.items[:index], l.items[index+1:]...)
+	if l.selection != nil {
+		defer l.selection.remove(index)
+	}
 
 	// If there is nothing left, we're done.
 	if len(l.items) == 0 {
@@ -363,12 +402,29 @@ func (l *List) GetOffset() (int, int) {
 	return l.itemOffset, l.columnOffset
 }
 
+// SetStyles sets the styles of the list items, overriding Styles.List.
+func (l *List) SetStyles(styles ListStyles) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.styles = styles
+	l.scrollBar.SetColor(styles.ScrollBarColor)
+}
+
+// GetStyles returns the styles of the list items.
+func (l *List) GetStyles() ListStyles {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.styles
+}
+
 // SetMainTextColor sets the color of the items' main text.
 func (l *List) SetMainTextColor(color tcell.Color) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.mainTextColor = color
+	l.styles.MainText.Normal = l.styles.MainText.Normal.Foreground(color)
 }
 
 // SetSecondaryTextColor sets the color of the items' secondary text.
@@ -376,7 +432,7 @@ func (l *List) SetSecondaryTextColor(color tcell.Color) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.secondaryTextColor = color
+	l.styles.SecondaryText = l.styles.SecondaryText.Foreground(color)
 }
 
 // SetShortcutColor sets the color of the items' shortcut.
@@ -384,7 +440,7 @@ func (l *List) SetShortcutColor(color tcell.Color) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.shortcutColor = color
+	l.styles.Shortcut = l.styles.Shortcut.Foreground(color)
 }
 
 // SetSelectedTextColor sets the text color of selected items.
@@ -392,7 +448,7 @@ func (l *List) SetSelectedTextColor(color tcell.Color) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.selectedTextColor = color
+	l.styles.MainText.Selected = l.styles.MainText.Style(false, false, true).Foreground(color)
 }
 
 // SetSelectedTextAttributes sets the style attributes of selected items.
@@ -400,7 +456,7 @@ func (l *List) SetSelectedTextAttributes(attr tcell.AttrMask) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.selectedTextAttributes = attr
+	l.styles.MainText.Selected = l.styles.MainText.Style(false, false, true).Attributes(attr)
 }
 
 // SetSelectedBackgroundColor sets the background color of selected items.
@@ -408,7 +464,27 @@ func (l *List) SetSelectedBackgroundColor(color tcell.Color) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.selectedBackgroundColor = color
+	l.styles.MainText.Selected = l.styles.MainText.Style(false, false, true).Background(color)
+}
+
+// SetSelectionModel sets a model of the selected items which allows more
+// than the current item to be selected, see SelectionModel for details. The
+// model's items are the indices of the list items. Set to nil (the default) to
+// only select the current item.
+func (l *List) SetSelectionModel(model *SelectionModel) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.selection = model
+}
+
+// GetSelectionModel returns the model of the selected items or nil if there
+// is none.
+func (l *List) GetSelectionModel() *SelectionModel {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.selection
 }
 
 // SetSelectedFocusOnly sets a flag which determines when the currently selected
@@ -461,18 +537,103 @@ func (l *List) ShowSecondaryText(show bool) {
 
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (l *List) SetScrollBarVisibility(visibility ScrollBarVisibility) {
+	l.scrollBar.SetVisibility(visibility)
+}
+
+// SetScrollBarColor sets the color of the scroll bar.
+func (l *List) SetScrollBarColor(color tcell.Color) {
+	l.scrollBar.SetColor(color)
+}
+
+// GetScrollBar returns the vertical scroll bar of the list.
+func (l *List) GetScrollBar() *ScrollBar {
+	return l.scrollBar
+}
+
+// SetSmoothScrolling sets whether or not scrolling with the mouse wheel and
+// by pages is animated over a few frames instead of jumping to the new
+// position. See also DisableSmoothScrolling.
+func (l *List) SetSmoothScrolling(enable bool) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.scrollBarVisibility = visibility
+	l.smoothScroll.enabled = enable
 }
 
-// SetScrollBarColor sets the color of the scroll bar.
-func (l *List) SetScrollBarColor(color tcell.Color) {
+// GetSmoothScrolling returns whether or not smooth scrolling is enabled.
+func (l *List) GetSmoothScrolling() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.smoothScroll.enabled
+}
+
+// SetPlaceholder sets a primitive which is drawn in place of the items when
+// the list has none, e.g. a text view saying "No results" or describing an
+// error. It is drawn only and doesn't receive any input. Provide nil to draw
+// no placeholder, which is the default.
+func (l *List) SetPlaceholder(placeholder Primitive) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.contentState.setPlaceholder(placeholder)
+}
+
+// SetPlaceholderText sets a text which is drawn centered in place of the
+// items when the list has none (see SetPlaceholder()). The text may contain
+// color tags. Provide an empty string to draw no placeholder.
+func (l *List) SetPlaceholderText(text string) {
 	l.Lock()
 	defer l.Unlock()
 
-	l.scrollBarColor = color
+	l.contentState.setPlaceholderText(text)
+}
+
+// SetLoading sets whether or not the content of the list is being loaded.
+// While it is, a spinner (see SpinnerFrames) is drawn in the center of the
+// list in place of its items.
+func (l *List) SetLoading(loading bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.contentState.setLoading(loading)
+}
+
+// GetLoading returns whether or not the content of the list is being loaded.
+func (l *List) GetLoading() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.contentState.loading
+}
+
+// SetError sets an error message which is drawn centered in place of the items
+// of the list, e.g. when loading them failed. Provide an empty string to draw
+// the items again, which is the default. The text is not interpreted for style
+// tags.
+func (l *List) SetError(text string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.contentState.setError(text)
+}
+
+// GetError returns the error message drawn in place of the items of the list, see
+// SetError().
+func (l *List) GetError() string {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.contentState.err
+}
+
+// pageSize returns the number of items which fit into the list, given its
+// height.
+func (l *List) pageSize(height int) int {
+	if l.showSecondaryText {
+		return height / 2
+	}
+	return height
 }
 
 // SetHover sets the flag that determines whether hovering over an item will
@@ -496,6 +657,31 @@ func (l *List) SetWrapAround(wrapAround bool) {
 	l.wrapAround = wrapAround
 }
 
+// SetGlobalShortcuts sets whether or not the shortcuts of the list items (see
+// ListItem.SetShortcut()) select them even when the list does not have focus,
+// as long as the list is visible, e.g. for a main menu. While the list is not
+// focused, a shortcut is pressed together with the Alt key (e.g. Alt+F for the
+// shortcut "f") so it does not interfere with the keys of the focused
+// primitive. Keys the focused primitive has a use for, e.g. all keys typed
+// into an input field, are left to it. If several visible lists have the same
+// shortcut, the one closest to the root primitive wins. This is disabled by
+// default.
+func (l *List) SetGlobalShortcuts(global bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.globalShortcuts = global
+}
+
+// GetGlobalShortcuts returns whether or not the shortcuts of the list items
+// apply while the list is not focused.
+func (l *List) GetGlobalShortcuts() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.globalShortcuts
+}
+
 // SetChangedFunc sets the function which is called when the user navigates to
 // a list item. The function receives the item's index in the list of items
 // (starting with 0) and the list item.
@@ -519,6 +705,16 @@ func (l *List) SetSelectedFunc(handler func(int, *ListItem)) {
 	l.selected = handler
 }
 
+// SetDoubleClickFunc sets a function which is called when the user
+// double-clicks a list item. The item is selected by the first click. See
+// Application.SetDoubleClickInterval for how double clicks are detected.
+func (l *List) SetDoubleClickFunc(handler func(index int, item *ListItem)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.doubleClick = handler
+}
+
 // SetDoneFunc sets a function which is called when the user presses the Escape
 // key.
 func (l *List) SetDoneFunc(handler func()) {
@@ -528,6 +724,20 @@ func (l *List) SetDoneFunc(handler func()) {
 	l.done = handler
 }
 
+// SetScrollChangedFunc sets a handler which is called when the list is drawn
+// scrolled to a different item or with a different number of items, e.g. to
+// keep a scroll indicator in sync. It receives the index of the first visible
+// item and the number of items. It is called during drawing, after the list
+// was unlocked, so changes to other primitives are drawn when the screen is
+// drawn next, see Application.QueueUpdateDraw(). Provide nil to remove the
+// handler.
+func (l *List) SetScrollChangedFunc(handler func(firstVisible, total int)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.scrollChanged.set(handler)
+}
+
 // AddItem calls InsertItem() with an index of -1.
 func (l *List) AddItem(item *ListItem) {
 	l.InsertItem(-1, item)
@@ -579,6 +789,9 @@ func (l *List) InsertItem(index int, item *ListItem) {
 		copy(l.items[index+1:], l.items[index:])
 	}
 	l.items[index] = item
+	if l.selection != nil {
+		defer l.selection.insert(index)
+	}
 
 	// Fire a "change" event for the first item in the list.
 	if len(l.items) == 1 && l.changed != nil {
@@ -607,6 +820,52 @@ func (l *List) GetItemCount() int {
 	return len(l.items)
 }
 
+// PreferredWidth returns the width of the longest item text, including any
+// shortcuts, indicators and suffixes, plus the border and padding.
+func (l *List) PreferredWidth(maxWidth int) int {
+	l.RLock()
+	defer l.RUnlock()
+
+	var width, shortcutWidth int
+	for _, item := range l.items {
+		itemWidth := TaggedTextWidth(item.mainText) + l.prefixWidth + l.suffixWidth
+		if item.suffix != nil {
+			itemWidth += item.suffix.Width()
+		}
+		if l.showSecondaryText {
+			itemWidth = max(itemWidth, TaggedTextWidth(item.secondaryText))
+		}
+		width = max(width, itemWidth)
+		if item.shortcut != 0 {
+			shortcutWidth = 4
+		}
+	}
+	width += shortcutWidth
+	if l.scrollBar.GetVisibility() == ScrollBarAlways {
+		width++
+	}
+	frameWidth, _ := l.frameSize()
+	width += frameWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// PreferredHeight returns the height needed to show all items plus the border
+// and padding.
+func (l *List) PreferredHeight(width int) int {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, frameHeight := l.frameSize()
+	height := len(l.items)
+	if l.showSecondaryText {
+		height *= 2
+	}
+	return height + frameHeight
+}
+
 // GetItemText returns an item's texts (main and secondary). Panics if the index
 // is out of range.
 func (l *List) GetItemText(index int) (main, secondary string) {
@@ -700,6 +959,66 @@ func (l *List) FindItems(mainSearch, secondarySearch string, mustContainBoth, ig
 	return
 }
 
+// FuzzyFindItems returns the indices of the items whose main text matches the
+// pattern (see FuzzyMatch()), best matches first. Style tags are stripped from
+// the main text before matching. This may be used to filter a list as the user
+// types.
+func (l *List) FuzzyFindItems(pattern string, caseSensitive bool) []int {
+	l.RLock()
+	texts := make([]string, len(l.items))
+	for index, item := range l.items {
+		texts[index] = string(StripTags(item.mainText, true, false))
+	}
+	l.RUnlock()
+
+	return FuzzyFilter(pattern, texts, caseSensitive)
+}
+
+// Search selects the next item after the current item (or the previous item
+// if backwards is true) whose main or secondary text contains the query,
+// ignoring case. Disabled items are skipped. Returns whether such an item was
+// found. This implements the Searchable interface.
+func (l *List) Search(query string, backwards bool) bool {
+	l.RLock()
+	index := searchIndex(len(l.items), l.currentItem, backwards, func(index int) bool {
+		item := l.items[index]
+		return !item.disabled && (searchMatches(StripTags(item.mainText, true, false), query) || searchMatches(StripTags(item.secondaryText, true, false), query))
+	})
+	l.RUnlock()
+
+	if index < 0 {
+		return false
+	}
+	l.SetCurrentItem(index)
+	return true
+}
+
+// JumpTargets returns the visible items which are enabled, with their labels
+// at the beginning of their main text. It implements the Jumpable interface.
+func (l *List) JumpTargets() (targets []JumpTarget) {
+	l.RLock()
+	defer l.RUnlock()
+
+	x, y, _, height := l.GetInnerRect()
+	lines := 1
+	if l.showSecondaryText {
+		lines = 2
+	}
+	for index := max(l.itemOffset, 0); index < len(l.items) && (index-l.itemOffset)*lines < height; index++ {
+		if l.items[index].disabled {
+			continue
+		}
+		targets = append(targets, JumpTarget{X: x, Y: y + (index-l.itemOffset)*lines, Row: index})
+	}
+	return
+}
+
+// Jump makes the item of the provided target the current item. It implements
+// the Jumpable interface.
+func (l *List) Jump(target JumpTarget) {
+	l.SetCurrentItem(target.Row)
+}
+
 // Clear removes all items from the list.
 func (l *List) Clear() {
 	l.Lock()
@@ -709,6 +1028,23 @@ func (l *List) Clear() {
 	l.currentItem = 0
 	l.itemOffset = 0
 	l.columnOffset = 0
+	if l.selection != nil {
+		l.selection.Clear()
+	}
+}
+
+// ShowContextMenuForSelection shows the context menu (see ContextMenuList())
+// next to the current item. The index provided to the menu's callbacks is the
+// index of the current item. This is what Keys.ShowContextMenu does.
+func (l *List) ShowContextMenuForSelection(setFocus func(Primitive)) {
+	l.openContextMenu(-1, -1, setFocus)
+}
+
+// openContextMenu shows the context menu for the current item at the provided
+// screen position, see Application.OpenContextMenu().
+func (l *List) openContextMenu(x, y int, setFocus func(Primitive)) bool {
+	l.ShowContextMenu(l.GetCurrentItemIndex(), x, y, setFocus)
+	return l.ContextMenuVisible()
 }
 
 // Focus is called by the application when the primitive receives focus.
@@ -863,10 +1199,7 @@ func (l *List) updateOffset() {
 
 	// Additional width for scroll bar
 	addWidth := 0
-	if l.scrollBarVisibility == ScrollBarAlways ||
-		(l.scrollBarVisibility == ScrollBarAuto &&
-			((!l.showSecondaryText && len(l.items) > l.innerHeight) ||
-				(l.showSecondaryText && len(l.items) > l.innerHeight/2))) {
+	if l.scrollBar.IsVisible(len(l.items), l.pageSize(l.innerHeight)) {
 		addWidth = 1
 	}
 
@@ -887,6 +1220,13 @@ func (l *List) Draw(screen tcell.Screen) {
 	l.Box.Draw(screen)
 	hasFocus := l.GetFocusable().HasFocus()
 
+	var notifyScroll func()
+	defer func() {
+		if notifyScroll != nil {
+			notifyScroll()
+		}
+	}()
+
 	l.Lock()
 	defer l.Unlock()
 
@@ -898,18 +1238,17 @@ func (l *List) Draw(screen tcell.Screen) {
 
 	l.height = height
 
+	// Draw the placeholder or the loading spinner instead of the items.
+	if l.contentState.draw(screen, x, y, width, height, len(l.items) == 0, l.backgroundFill()) {
+		return
+	}
+
 	screenWidth, _ := screen.Size()
-	scrollBarHeight := height
 	scrollBarX := x + (width - 1) + l.paddingLeft + l.paddingRight
 	if scrollBarX > screenWidth-1 {
 		scrollBarX = screenWidth - 1
 	}
 
-	// Halve scroll bar height when drawing two lines per list item.
-	if l.showSecondaryText {
-		scrollBarHeight /= 2
-	}
-
 	// Do we show any shortcuts?
 	var showShortcuts bool
 	for _, item := range l.items {
@@ -926,11 +1265,10 @@ func (l *List) Draw(screen tcell.Screen) {
 		l.updateOffset()
 	}
 
-	scrollBarCursor := int(float64(len(l.items)) * (float64(l.itemOffset) / float64(len(l.items)-height)))
-
-	// Draw the list items.
+	// Draw the list items, starting at the (possibly animated) item offset.
+	itemOffset := l.smoothScroll.offset(l.itemOffset)
 	for index, item := range l.items {
-		if index < l.itemOffset {
+		if index < itemOffset {
 			continue
 		}
 
@@ -954,16 +1292,15 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 
 		if len(item.mainText) == 0 && len(item.secondaryText) == 0 && item.shortcut == 0 { // Divider
-			Print(screen, []byte(string(tcell.RuneLTee)), leftEdge-2, y, 1, AlignLeft, l.mainTextColor)
-			Print(screen, bytes.Repeat([]byte(string(tcell.RuneHLine)), fullWidth), leftEdge-1, y, fullWidth, AlignLeft, l.mainTextColor)
-			Print(screen, []byte(string(tcell.RuneRTee)), leftEdge+fullWidth-1, y, 1, AlignLeft, l.mainTextColor)
-
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+			PrintStyle(screen, []byte(string(tcell.RuneLTee)), leftEdge-2, y, 1, AlignLeft, l.styles.MainText.Normal)
+			PrintStyle(screen, bytes.Repeat([]byte(string(tcell.RuneHLine)), fullWidth), leftEdge-1, y, fullWidth, AlignLeft, l.styles.MainText.Normal)
+			PrintStyle(screen, []byte(string(tcell.RuneRTee)), leftEdge+fullWidth-1, y, 1, AlignLeft, l.styles.MainText.Normal)
 			y++
 			continue
 		}
 
-		if index == l.currentItem {
+		marked := l.selection != nil && l.selection.IsSelected(index)
+		if l.selection == nil && index == l.currentItem || marked {
 			if len(l.selectedPrefix) > 0 {
 				mainText = append(l.selectedPrefix, mainText...)
 			}
@@ -987,22 +1324,28 @@ func (l *List) Draw(screen tcell.Screen) {
 
 			// Main text.
 			Print(screen, mainText, x, y, width, AlignLeft, tcell.ColorGray.TrueColor())
-
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
 			y++
 			continue
 		}
 
 		// Shortcuts.
 		if showShortcuts && item.shortcut != 0 {
-			Print(screen, []byte(fmt.Sprintf("(%c)", item.shortcut)), x-5, y, 4, AlignRight, l.shortcutColor)
+			PrintStyle(screen, []byte(fmt.Sprintf("(%c)", item.shortcut)), x-5, y, 4, AlignRight, l.styles.Shortcut)
+		}
+
+		// Suffix.
+		mainWidth := width
+		if item.suffix != nil {
+			suffixWidth := min(item.suffix.Width(), width)
+			mainWidth -= suffixWidth
+			item.suffix.Draw(NewClippingScreenWriter(screen, x+mainWidth, y, suffixWidth, 1), suffixWidth, l.styles.MainText.Normal)
 		}
 
 		// Main text.
-		Print(screen, mainText, x, y, width, AlignLeft, l.mainTextColor)
+		PrintStyle(screen, mainText, x, y, mainWidth, AlignLeft, l.styles.MainText.Normal)
 
 		// Background color of selected text.
-		if index == l.currentItem && (!l.selectedFocusOnly || hasFocus) {
+		if index == l.currentItem && (!l.selectedFocusOnly || hasFocus) || marked {
 			textWidth := width
 			if !l.highlightFullLine {
 				if w := TaggedTextWidth(mainText); w < textWidth {
@@ -1010,19 +1353,19 @@ func (l *List) Draw(screen tcell.Screen) {
 				}
 			}
 
+			mainTextColor, _, _ := l.styles.MainText.Normal.Decompose()
+			selectedTextColor, selectedBackgroundColor, selectedAttributes := l.styles.MainText.Style(false, false, true).Decompose()
 			for bx := 0; bx < textWidth; bx++ {
 				m, c, style, _ := screen.GetContent(x+bx, y)
 				fg, _, _ := style.Decompose()
-				if fg == l.mainTextColor {
-					fg = l.selectedTextColor
+				if fg == mainTextColor {
+					fg = selectedTextColor
 				}
-				style = SetAttributes(style.Background(l.selectedBackgroundColor).Foreground(fg), l.selectedTextAttributes)
+				style = SetAttributes(style.Background(selectedBackgroundColor).Foreground(fg), selectedAttributes)
 				screen.SetContent(x+bx, y, m, c, style)
 			}
 		}
 
-		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
-
 		y++
 
 		if y >= bottomLimit {
@@ -1031,86 +1374,53 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		// Secondary text.
 		if l.showSecondaryText {
-			Print(screen, secondaryText, x, y, width, AlignLeft, l.secondaryTextColor)
-
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
-
+			PrintStyle(screen, secondaryText, x, y, width, AlignLeft, l.styles.SecondaryText)
 			y++
 		}
 	}
 
-	// Overdraw scroll bar when necessary.
-	for y < bottomLimit {
-		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, bottomLimit-y, l.hasFocus, l.scrollBarColor)
-
-		y++
-	}
+	// Draw the scroll bar.
+	_, scrollBarY, _, _ := l.GetInnerRect()
+	l.scrollBar.Draw(screen, scrollBarX, scrollBarY, height, len(l.items), l.pageSize(height), l.itemOffset, l.hasFocus)
+	notifyScroll = l.scrollChanged.update(l.itemOffset, len(l.items))
 
 	// Draw context menu.
 	if hasFocus && l.ContextMenu.open {
-		ctx := l.ContextMenuList()
-
-		x, y, width, height = l.GetInnerRect()
-
-		// What's the longest option text?
-		maxWidth := 0
-		for _, option := range ctx.items {
-			strWidth := TaggedTextWidth(option.mainText)
-			if option.shortcut != 0 {
-				strWidth += 4
-			}
-			if strWidth > maxWidth {
-				maxWidth = strWidth
-			}
+		offsetX := 7
+		if showShortcuts {
+			offsetX += 4
 		}
-
-		lheight := len(ctx.items)
-		lwidth := maxWidth
-
-		// Add space for borders
-		lwidth += 2
-		lheight += 2
-
-		lwidth += ctx.paddingLeft + ctx.paddingRight
-		lheight += ctx.paddingTop + ctx.paddingBottom
-
-		cx, cy := l.ContextMenu.x, l.ContextMenu.y
-		if cx < 0 || cy < 0 {
-			offsetX := 7
-			if showShortcuts {
-				offsetX += 4
-			}
-			offsetY := l.currentItem
-			if l.showSecondaryText {
-				offsetY *= 2
-			}
-			x, y, _, _ := l.GetInnerRect()
-			cx, cy = x+offsetX, y+offsetY
+		offsetY := l.currentItem
+		if l.showSecondaryText {
+			offsetY *= 2
 		}
+		x, y, _, _ := l.GetInnerRect()
+		l.ContextMenu.drawMenu(screen, x+offsetX, y+offsetY)
+	}
+}
 
-		_, sheight := screen.Size()
-		if cy+lheight >= sheight && cy-2 > lheight-cy {
-			for i := (cy + lheight) - sheight; i > 0; i-- {
-				cy--
-				if cy+lheight < sheight {
-					break
-				}
-			}
-			if cy < 0 {
-				cy = 0
-			}
+// activateShortcut makes the enabled item with the provided shortcut the
+// current item and calls the "selected" callbacks. It returns whether or not
+// there was such an item. The list must be locked.
+func (l *List) activateShortcut(ch rune) bool {
+	for index, item := range l.items {
+		if item.disabled || item.shortcut != ch {
+			continue
 		}
-		if cy+lheight >= sheight {
-			lheight = sheight - cy
+		l.currentItem = index
+		if item.selected != nil {
+			l.Unlock()
+			item.selected()
+			l.Lock()
 		}
-
-		if ctx.scrollBarVisibility == ScrollBarAlways || (ctx.scrollBarVisibility == ScrollBarAuto && len(ctx.items) > lheight) {
-			lwidth++ // Add space for scroll bar
+		if l.selected != nil {
+			l.Unlock()
+			l.selected(index, item)
+			l.Lock()
 		}
-
-		ctx.SetRect(cx, cy, lwidth, lheight)
-		ctx.Draw(screen)
+		return true
 	}
+	return false
 }
 
 // InputHandler returns the handler for this primitive.
@@ -1118,7 +1428,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		l.Lock()
 
-		if HitShortcut(event, Keys.Cancel) {
+		if l.hitShortcut(event, l.keys().Cancel) {
 			if l.ContextMenu.open {
 				l.Unlock()
 
@@ -1133,7 +1443,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 				l.Unlock()
 			}
 			return
-		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
+		} else if l.hitShortcut(event, l.keys().Select, l.keys().Select2) {
 			if l.currentItem >= 0 && l.currentItem < len(l.items) {
 				item := l.items[l.currentItem]
 				if !item.disabled {
@@ -1149,7 +1459,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 					}
 				}
 			}
-		} else if HitShortcut(event, Keys.ShowContextMenu) {
+		} else if l.hitShortcut(event, l.keys().ShowContextMenu) {
 			defer l.ContextMenu.show(l.currentItem, -1, -1, setFocus)
 		} else if len(l.items) == 0 {
 			l.Unlock()
@@ -1157,53 +1467,48 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 		}
 
 		if event.Key() == tcell.KeyRune {
-			ch := event.Rune()
-			if ch != ' ' {
-				// It's not a space bar. Is it a shortcut?
-				for index, item := range l.items {
-					if !item.disabled && item.shortcut == ch {
-						// We have a shortcut.
-						l.currentItem = index
-
-						item := l.items[l.currentItem]
-						if item.selected != nil {
-							l.Unlock()
-							item.selected()
-							l.Lock()
-						}
-						if l.selected != nil {
-							l.Unlock()
-							l.selected(l.currentItem, item)
-							l.Lock()
-						}
-
-						l.Unlock()
-						return
-					}
-				}
+			// It's not a space bar. Is it a shortcut?
+			if ch := event.Rune(); ch != ' ' && l.activateShortcut(ch) {
+				l.Unlock()
+				return
 			}
 		}
 
 		previousItem := l.currentItem
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		extendSelection := false
+		if l.selection != nil && l.hitShortcut(event, l.keys().ToggleSelection) {
+			defer l.selection.Toggle(l.currentItem)
+		} else if l.selection != nil && l.hitShortcut(event, l.keys().ExtendSelectionUp) {
+			l.transform(TransformPreviousItem)
+			extendSelection = true
+		} else if l.selection != nil && l.hitShortcut(event, l.keys().ExtendSelectionDown) {
+			l.transform(TransformNextItem)
+			extendSelection = true
+		} else if l.hitShortcut(event, l.keys().MoveFirst, l.keys().MoveFirst2) {
 			l.transform(TransformFirstItem)
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if l.hitShortcut(event, l.keys().MoveLast, l.keys().MoveLast2) {
 			l.transform(TransformLastItem)
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+		} else if l.hitShortcut(event, l.keys().MoveUp, l.keys().MoveUp2) {
 			l.transform(TransformPreviousItem)
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+		} else if l.hitShortcut(event, l.keys().MoveDown, l.keys().MoveDown2) {
 			l.transform(TransformNextItem)
-		} else if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+		} else if l.hitShortcut(event, l.keys().MoveLeft, l.keys().MoveLeft2) {
 			l.columnOffset--
 			l.updateOffset()
-		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+		} else if l.hitShortcut(event, l.keys().MoveRight, l.keys().MoveRight2) {
 			l.columnOffset++
 			l.updateOffset()
-		} else if HitShortcut(event, Keys.MovePreviousPage) {
+		} else if l.hitShortcut(event, l.keys().MovePreviousPage) {
 			l.transform(TransformPreviousPage)
-		} else if HitShortcut(event, Keys.MoveNextPage) {
+			l.smoothScroll.animate()
+		} else if l.hitShortcut(event, l.keys().MoveNextPage) {
 			l.transform(TransformNextPage)
+			l.smoothScroll.animate()
+		}
+
+		if l.currentItem != previousItem && l.selection != nil {
+			defer l.selection.follow(previousItem, l.currentItem, extendSelection)
 		}
 
 		if l.currentItem != previousItem && l.currentItem < len(l.items) && l.changed != nil {
@@ -1269,6 +1574,16 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			return
 		}
 
+		// Pass events to the scroll bar.
+		if offset, ok, dragging := l.scrollBar.HandleMouse(action, event); ok {
+			l.itemOffset = offset
+			l.Unlock()
+			if dragging {
+				capture = l
+			}
+			return true, capture
+		}
+
 		if !l.InRect(event.Position()) {
 			l.Unlock()
 			return false, nil
@@ -1291,7 +1606,15 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			index := l.indexAtPoint(event.Position())
 			if index != -1 {
 				item := l.items[index]
-				if !item.disabled {
+				if !item.disabled && l.selection != nil {
+					defer l.selection.click(index, event.Modifiers())
+					if event.Modifiers()&(tcell.ModCtrl|tcell.ModShift) != 0 {
+						// Only change the selection.
+						l.currentItem = index
+						item = nil
+					}
+				}
+				if item != nil && !item.disabled {
 					l.currentItem = index
 					if item.selected != nil {
 						l.Unlock()
@@ -1311,6 +1634,15 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				}
 			}
 			consumed = true
+		case MouseLeftDoubleClick:
+			index := l.indexAtPoint(event.Position())
+			if index != -1 && !l.items[index].disabled && l.doubleClick != nil {
+				item := l.items[index]
+				l.Unlock()
+				l.doubleClick(index, item)
+				l.Lock()
+			}
+			consumed = true
 		case MouseMiddleClick:
 			if l.ContextMenu.open {
 				defer l.ContextMenu.hide(setFocus)
@@ -1358,6 +1690,7 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 		case MouseScrollUp:
 			if l.itemOffset > 0 {
 				l.itemOffset--
+				l.smoothScroll.animate()
 			}
 			consumed = true
 		case MouseScrollDown:
@@ -1367,6 +1700,7 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 			}
 			if _, _, _, height := l.GetInnerRect(); lines > height {
 				l.itemOffset++
+				l.smoothScroll.animate()
 			}
 			consumed = true
 		}
@@ -1,7 +1,10 @@
 package nuview
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -73,3 +76,102 @@ func TestList(t *testing.T) {
 
 	l.Draw(app.screen)
 }
+
+func TestListGlobalShortcuts(t *testing.T) {
+	t.Parallel()
+
+	var opened int
+	menu := NewList()
+	item := NewListItem("File")
+	item.SetShortcut('f')
+	item.SetSelectedFunc(func() {
+		opened++
+	})
+	menu.AddItem(item)
+	field := NewInputField()
+	view := NewTextView()
+
+	flex := NewFlex()
+	flex.AddItem(menu, 10, 0, false)
+	flex.AddItem(field, 1, 0, true)
+	flex.AddItem(view, 0, 1, false)
+	app, err := newTestApp(flex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetFocus(field)
+
+	altF := tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModAlt)
+	app.dispatchKey(altF, "")
+	if opened != 0 {
+		t.Errorf("failed to restrict shortcuts to focused list: expected 0 selections, got %d", opened)
+	}
+
+	menu.SetGlobalShortcuts(true)
+	app.dispatchKey(tcell.NewEventKey(tcell.KeyRune, 'f', tcell.ModNone), "")
+	app.dispatchKey(altF, "")
+	if opened != 0 {
+		t.Errorf("failed to leave Alt keys to focused field: expected 0 selections, got %d", opened)
+	}
+	if text := field.GetText(); text != "f" {
+		t.Errorf("failed to pass plain rune to focused field: expected \"f\", got %q", text)
+	}
+
+	app.SetFocus(view)
+	app.dispatchKey(altF, "")
+	if opened != 1 {
+		t.Errorf("failed to select item by global shortcut: expected 1 selection, got %d", opened)
+	}
+
+	var bound int
+	view.AddKeyBinding("Alt+f", func(event *tcell.EventKey) *tcell.EventKey {
+		bound++
+		return nil
+	})
+	app.dispatchKey(altF, "")
+	if opened != 1 || bound != 1 {
+		t.Errorf("failed to leave bound key to focused view: expected 1 selection and 1 binding call, got %d and %d", opened, bound)
+	}
+	view.RemoveKeyBinding("Alt+f")
+
+	keys := app.GetKeys()
+	keys.MoveWordRight = []string{"Alt+f"}
+	app.SetKeys(keys)
+	app.dispatchKey(altF, "")
+	if opened != 1 {
+		t.Errorf("failed to leave shortcut of Keys to focused view: expected 1 selection, got %d", opened)
+	}
+	app.SetKeys(Keys)
+
+	menu.SetVisible(false)
+	app.dispatchKey(altF, "")
+	if opened != 1 {
+		t.Errorf("failed to ignore hidden list: expected 1 selection, got %d", opened)
+	}
+}
+
+func TestListForEachItem(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	for _, text := range []string{"a", "b", "c"} {
+		l.AddItem(NewListItem(text))
+	}
+
+	// The list may be modified while iterating.
+	var visited []string
+	l.ForEachItem(func(index int, item *ListItem) bool {
+		visited = append(visited, item.GetMainText())
+		l.RemoveItem(0)
+		return index < 1
+	})
+	if text := strings.Join(visited, " "); text != "a b" {
+		t.Errorf("failed to iterate items: got %q", text)
+	}
+	if count := l.GetItemCount(); count != 1 {
+		t.Errorf("failed to modify list while iterating: expected 1 item, got %d", count)
+	}
+}
@@ -6,12 +6,13 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
-// Modal is a centered message window used to inform the user or prompt them
+// Modal is a message window used to inform the user or prompt them
 // for an immediate decision. It needs to have at least one button (added via
 // AddButtons) or it will never disappear. You may change the title and
 // appearance of the window by modifying the Frame returned by GetFrame. You
 // may include additional elements within the window by modifying the Form
-// returned by GetForm.
+// returned by GetForm. The window is centered on the screen by default. See
+// SetSize and SetPlacement to change its size and position.
 type Modal struct {
 	*Box
 
@@ -30,6 +31,19 @@ type Modal struct {
 	// The text alignment.
 	textAlign int
 
+	// The requested width and height of the window. Positive values are
+	// absolute sizes, negative values are percentages of the screen size and
+	// 0 means the size is calculated from the content.
+	width, height int
+
+	// The maximum width of the message text before it is wrapped. A value of 0
+	// means a third of the screen width.
+	maxTextWidth int
+
+	// The placement of the window on the screen.
+	align  int
+	valign VerticalAlignment
+
 	// The optional callback for when the user clicked one of the buttons. It
 	// receives the index of the clicked button and the button's label.
 	done func(buttonIndex int, buttonLabel string)
@@ -43,6 +57,8 @@ func NewModal() *Modal {
 		Box:       NewBox(),
 		textColor: Styles.PrimaryTextColor,
 		textAlign: AlignCenter,
+		align:     AlignCenter,
+		valign:    AlignMiddle,
 	}
 
 	m.form = NewForm()
@@ -135,6 +151,42 @@ func (m *Modal) SetTextAlign(align int) {
 	m.textAlign = align
 }
 
+// SetSize sets the size of the window including its border. Positive values
+// are absolute sizes in cells, negative values are percentages of the screen
+// size (e.g. -50 for half of the screen) and a value of 0 (the default) means
+// the size is calculated from the text and the buttons. The window never
+// exceeds the screen.
+func (m *Modal) SetSize(width, height int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.width, m.height = width, height
+}
+
+// SetMaxTextWidth sets the maximum width of the message text. Longer lines
+// are word-wrapped. When set, the window is only as wide as the wrapped text
+// (or the buttons) requires. A value of 0 (the default) wraps the text at a
+// third of the screen width.
+func (m *Modal) SetMaxTextWidth(width int) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.maxTextWidth = width
+}
+
+// SetPlacement sets where the window is placed on the screen. "align" must be
+// one of AlignLeft, AlignCenter (the default), or AlignRight. "valign" must be
+// one of AlignTop, AlignMiddle (the default), or AlignBottom. For example, to
+// place the window in the bottom-right corner of the screen:
+//
+//	modal.SetPlacement(AlignRight, AlignBottom)
+func (m *Modal) SetPlacement(align int, valign VerticalAlignment) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.align, m.valign = align, valign
+}
+
 // GetForm returns the Form embedded in the window. The returned Form may be
 // modified to include additional elements (e.g. AddInputField, AddFormItem).
 func (m *Modal) GetForm() *Form {
@@ -224,9 +276,25 @@ func (m *Modal) Draw(screen tcell.Screen) {
 	buttonsWidth -= 2
 	screenWidth, screenHeight := screen.Size()
 	width := screenWidth / 3
-	if width < buttonsWidth {
+	if m.maxTextWidth > 0 {
+		width = 0
+		for _, line := range WordWrap(m.text, m.maxTextWidth) {
+			if lineWidth := TaggedStringWidth(line); lineWidth > width {
+				width = lineWidth
+			}
+		}
+	}
+	if m.width != 0 {
+		width = modalSize(m.width, screenWidth) - 4
+	} else if width < buttonsWidth {
 		width = buttonsWidth
 	}
+	if width > screenWidth-4 {
+		width = screenWidth - 4
+	}
+	if width < 1 {
+		width = 1
+	}
 	// width is now without the box border.
 
 	// Reset the text and find out how wide it is.
@@ -238,9 +306,27 @@ func (m *Modal) Draw(screen tcell.Screen) {
 
 	// Set the Modal's position and size.
 	height := len(lines) + (formItemCount * 2) + 6
+	if m.height != 0 {
+		height = modalSize(m.height, screenHeight)
+	}
+	if height > screenHeight {
+		height = screenHeight
+	}
 	width += 4
-	x := (screenWidth - width) / 2
-	y := (screenHeight - height) / 2
+
+	var x, y int
+	switch m.align {
+	case AlignCenter:
+		x = (screenWidth - width) / 2
+	case AlignRight:
+		x = screenWidth - width
+	}
+	switch m.valign {
+	case AlignMiddle:
+		y = (screenHeight - height) / 2
+	case AlignBottom:
+		y = screenHeight - height
+	}
 	m.SetRect(x, y, width, height)
 
 	// Draw the frame.
@@ -248,6 +334,14 @@ func (m *Modal) Draw(screen tcell.Screen) {
 	m.frame.Draw(screen)
 }
 
+// modalSize resolves a size set with SetSize() against the screen size.
+func modalSize(size, screenSize int) int {
+	if size < 0 {
+		return screenSize * -size / 100
+	}
+	return size
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (m *Modal) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return m.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
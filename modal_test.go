@@ -0,0 +1,92 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestModal(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(40, 20)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 40; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	draw := func(m *Modal) (x, y, width, height int) {
+		sc.Clear()
+		m.Draw(sc)
+		fx, fy, fw, fh := m.GetFrame().GetRect()
+		x, y, width, height = m.GetRect()
+		if fx != x || fy != y || fw != width || fh != height {
+			t.Errorf("failed to place frame: expected %d,%d %dx%d, got %d,%d %dx%d", x, y, width, height, fx, fy, fw, fh)
+		}
+		return
+	}
+
+	m := NewModal()
+	m.SetText("one two three four five six")
+	m.AddButtons([]string{"OK"})
+
+	// Text wrapped at the maximum width.
+
+	m.SetMaxTextWidth(10)
+	x, y, width, height := draw(m)
+	if x != 13 || y != 5 || width != 14 || height != 10 {
+		t.Errorf("failed to size window to wrapped text: expected 13,5 14x10, got %d,%d %dx%d", x, y, width, height)
+	}
+	for index, line := range []string{"one two", "three", "four five", "six"} {
+		if text := row(y + 2 + index); !strings.Contains(text, line) {
+			t.Errorf("failed to wrap text: expected %q in row %d, got %q", line, index, text)
+		}
+	}
+
+	// Placements.
+
+	for _, placement := range []struct {
+		align  int
+		valign VerticalAlignment
+		x, y   int
+	}{
+		{AlignLeft, AlignTop, 0, 0},
+		{AlignCenter, AlignMiddle, 13, 5},
+		{AlignRight, AlignBottom, 26, 10},
+		{AlignRight, AlignTop, 26, 0},
+		{AlignLeft, AlignBottom, 0, 10},
+	} {
+		m.SetPlacement(placement.align, placement.valign)
+		x, y, _, _ := draw(m)
+		if x != placement.x || y != placement.y {
+			t.Errorf("failed to place window at %d/%d: expected %d,%d, got %d,%d", placement.align, placement.valign, placement.x, placement.y, x, y)
+		}
+		if r, _, _, _ := sc.GetContent(x, y); r == ' ' {
+			t.Errorf("failed to draw border at %d/%d: expected border at %d,%d", placement.align, placement.valign, x, y)
+		}
+	}
+	m.SetPlacement(AlignCenter, AlignMiddle)
+
+	// Sizes.
+
+	m.SetSize(20, -50)
+	if x, y, width, height := draw(m); x != 10 || y != 5 || width != 20 || height != 10 {
+		t.Errorf("failed to set size: expected 10,5 20x10, got %d,%d %dx%d", x, y, width, height)
+	}
+	m.SetSize(-50, 100)
+	if x, y, width, height := draw(m); x != 10 || y != 0 || width != 20 || height != 20 {
+		t.Errorf("failed to set relative size: expected 10,0 20x20, got %d,%d %dx%d", x, y, width, height)
+	}
+	m.SetSize(60, 30)
+	if x, y, width, height := draw(m); x != 0 || y != 0 || width != 40 || height != 20 {
+		t.Errorf("failed to limit size to screen: expected 0,0 40x20, got %d,%d %dx%d", x, y, width, height)
+	}
+}
@@ -1,6 +1,9 @@
 package nuview
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // MouseAction indicates one of the actions the mouse is logically doing.
 type MouseAction int16
@@ -24,7 +27,70 @@ const (
 	MouseScrollDown
 	MouseScrollLeft
 	MouseScrollRight
+	MouseEnter
+	MouseLeave
+	MouseLeftTripleClick
+	MouseMiddleTripleClick
+	MouseRightTripleClick
 )
 
 // StandardDoubleClick is a commonly used double click interval.
 const StandardDoubleClick = 500 * time.Millisecond
+
+// mouseHoverTracker tracks the innermost primitive containing the mouse while
+// a MouseMove action is dispatched (see Application.EnableMouseHover). Once
+// the handler of that primitive returns, no other primitive may take its
+// place.
+type mouseHoverTracker struct {
+	primitive Primitive
+	active    bool
+	closed    bool
+
+	sync.Mutex
+}
+
+// start starts tracking the primitive under the mouse.
+func (h *mouseHoverTracker) start() {
+	h.Lock()
+	defer h.Unlock()
+
+	h.primitive = nil
+	h.active = true
+	h.closed = false
+}
+
+// enter records that the mouse is within the provided primitive. Returns
+// whether it is now the innermost primitive containing the mouse.
+func (h *mouseHoverTracker) enter(p Primitive) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	if !h.active || h.closed {
+		return false
+	}
+	h.primitive = p
+	return true
+}
+
+// leave is called when the handler of a primitive for which enter() returned
+// true returns.
+func (h *mouseHoverTracker) leave(p Primitive) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.primitive == p {
+		h.closed = true
+	}
+}
+
+// finish stops tracking the primitive under the mouse and returns the
+// innermost primitive containing the mouse.
+func (h *mouseHoverTracker) finish() Primitive {
+	h.Lock()
+	defer h.Unlock()
+
+	p := h.primitive
+	h.primitive = nil
+	h.active = false
+	return p
+}
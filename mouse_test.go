@@ -0,0 +1,115 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMouseHover(t *testing.T) {
+	t.Parallel()
+
+	left, right := NewBox(), NewBox()
+	f := NewFlex()
+	f.AddItem(left, 0, 1, false)
+	f.AddItem(right, 0, 1, false)
+
+	app, err := newTestApp(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.EnableMouseHover(true)
+	// Primitives track the mouse in the application which drew them.
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.draw()
+	f.SetRect(0, 0, 80, 24)
+	left.SetRect(0, 0, 40, 24)
+	right.SetRect(40, 0, 40, 24)
+
+	var actions []string
+	record := func(name string) func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+		return func(action MouseAction, event *tcell.EventMouse) (MouseAction, *tcell.EventMouse) {
+			switch action {
+			case MouseEnter:
+				actions = append(actions, "enter "+name)
+			case MouseLeave:
+				actions = append(actions, "leave "+name)
+			}
+			return action, event
+		}
+	}
+	left.SetMouseCapture(record("left"))
+	right.SetMouseCapture(record("right"))
+
+	app.fireMouseActions(tcell.NewEventMouse(5, 5, tcell.ButtonNone, tcell.ModNone))
+	app.fireMouseActions(tcell.NewEventMouse(6, 5, tcell.ButtonNone, tcell.ModNone))
+	app.fireMouseActions(tcell.NewEventMouse(60, 5, tcell.ButtonNone, tcell.ModNone))
+
+	expected := []string{"enter left", "leave left", "enter right"}
+	if len(actions) != len(expected) {
+		t.Fatalf("failed to deliver hover actions: expected %v, got %v", expected, actions)
+	}
+	for index := range expected {
+		if actions[index] != expected[index] {
+			t.Fatalf("failed to deliver hover actions: expected %v, got %v", expected, actions)
+		}
+	}
+}
+
+func TestMouseClicks(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.AddItem(NewListItem(listTextA))
+	l.AddItem(NewListItem(listTextB))
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetRect(0, 0, 80, 24)
+
+	var doubleClicked []int
+	l.SetDoubleClickFunc(func(index int, item *ListItem) {
+		doubleClicked = append(doubleClicked, index)
+	})
+
+	var clicks, doubleClicks, tripleClicks int
+	app.SetMouseCapture(func(event *tcell.EventMouse, action MouseAction) (*tcell.EventMouse, MouseAction) {
+		switch action {
+		case MouseLeftClick:
+			clicks++
+		case MouseLeftDoubleClick:
+			doubleClicks++
+		case MouseLeftTripleClick:
+			tripleClicks++
+		}
+		return event, action
+	})
+
+	click := func(x, y int) {
+		app.fireMouseActions(tcell.NewEventMouse(x, y, tcell.ButtonPrimary, tcell.ModNone))
+		app.lastMouseButtons = tcell.ButtonPrimary
+		app.mouseDownX, app.mouseDownY = x, y
+		app.fireMouseActions(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+		app.lastMouseButtons = tcell.ButtonNone
+	}
+
+	click(1, 2)
+	click(1, 2)
+	click(1, 2)
+	if clicks != 3 || doubleClicks != 1 || tripleClicks != 1 {
+		t.Errorf("failed to detect clicks: expected 3/1/1, got %d/%d/%d", clicks, doubleClicks, tripleClicks)
+	}
+	if len(doubleClicked) != 1 || doubleClicked[0] != 1 {
+		t.Errorf("failed to notify List of double click: expected [1], got %v", doubleClicked)
+	}
+
+	// A click elsewhere starts over.
+	click(1, 0)
+	if clicks != 4 || doubleClicks != 1 {
+		t.Errorf("failed to reset clicks: expected 4/1, got %d/%d", clicks, doubleClicks)
+	}
+}
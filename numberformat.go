@@ -0,0 +1,221 @@
+package nuview
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat describes how a number is entered into an input field and how
+// it is shown once the input field loses focus, see
+// InputField.SetNumberFormat(). Use DecimalFormat(), CurrencyFormat() or
+// PercentFormat() to create one for a locale.
+type NumberFormat struct {
+	// The character separating the integer part from the decimals.
+	DecimalSeparator rune
+
+	// The character separating groups of thousands, 0 for none.
+	GroupSeparator rune
+
+	// The number of decimals. Input with more decimals is rejected, formatted
+	// numbers are padded to this number of decimals. A negative value allows
+	// any number of decimals, formatted numbers use as many as needed.
+	Decimals int
+
+	// Whether or not negative numbers may be entered.
+	Negative bool
+
+	// The text shown before and after formatted numbers, e.g. a currency
+	// symbol. It is optional when entering the number.
+	Prefix, Suffix string
+}
+
+// localeSeparators maps languages (and some language_TERRITORY locales) to
+// their decimal and group separators. Languages not found here use "." and ",".
+// Groups separated by spaces use a no-break space.
+var localeSeparators = map[string][2]rune{
+	"de_CH": {'.', '\''},
+	"de":    {',', '.'},
+	"es":    {',', '.'},
+	"it":    {',', '.'},
+	"nl":    {',', '.'},
+	"pt":    {',', '.'},
+	"da":    {',', '.'},
+	"tr":    {',', '.'},
+	"id":    {',', '.'},
+	"fr":    {',', '\u00a0'},
+	"ru":    {',', '\u00a0'},
+	"uk":    {',', '\u00a0'},
+	"pl":    {',', '\u00a0'},
+	"cs":    {',', '\u00a0'},
+	"sk":    {',', '\u00a0'},
+	"sv":    {',', '\u00a0'},
+	"nb":    {',', '\u00a0'},
+	"fi":    {',', '\u00a0'},
+}
+
+// localeSuffixSymbols are the languages which put currency symbols and percent
+// signs after the number, separated by a space.
+var localeSuffixSymbols = map[string]bool{
+	"de": true, "es": true, "it": true, "pt": true, "da": true, "fr": true,
+	"ru": true, "uk": true, "pl": true, "cs": true, "sk": true, "sv": true,
+	"nb": true, "fi": true,
+}
+
+// parseLocale returns the language and the language_TERRITORY part of a
+// locale such as "de_DE.UTF-8" or "en-US". If the locale is empty, it is taken
+// from the LC_ALL, LC_NUMERIC and LANG environment variables.
+func parseLocale(locale string) (language, territory string) {
+	if locale == "" {
+		for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+			if locale = os.Getenv(name); locale != "" {
+				break
+			}
+		}
+	}
+	if index := strings.IndexAny(locale, ".@"); index >= 0 {
+		locale = locale[:index]
+	}
+	territory = strings.ReplaceAll(locale, "-", "_")
+	language, _, _ = strings.Cut(territory, "_")
+	return strings.ToLower(language), territory
+}
+
+// DecimalFormat returns the format of decimal numbers with the provided number
+// of decimals (negative for any number) in a locale such as "de_DE.UTF-8" or
+// "en-US". An empty locale is taken from the environment (LC_ALL, LC_NUMERIC,
+// LANG). Negative numbers are allowed.
+func DecimalFormat(locale string, decimals int) NumberFormat {
+	language, territory := parseLocale(locale)
+	separators, ok := localeSeparators[territory]
+	if !ok {
+		separators, ok = localeSeparators[language]
+	}
+	if !ok {
+		separators = [2]rune{'.', ','}
+	}
+	return NumberFormat{
+		DecimalSeparator: separators[0],
+		GroupSeparator:   separators[1],
+		Decimals:         decimals,
+		Negative:         true,
+	}
+}
+
+// CurrencyFormat returns the format of amounts with two decimals in the
+// provided currency symbol (e.g. "$" or "€") in a locale, see DecimalFormat().
+func CurrencyFormat(locale, symbol string) NumberFormat {
+	format := DecimalFormat(locale, 2)
+	if language, _ := parseLocale(locale); localeSuffixSymbols[language] {
+		format.Suffix = " " + symbol
+	} else {
+		format.Prefix = symbol
+	}
+	return format
+}
+
+// PercentFormat returns the format of percentages with the provided number of
+// decimals (negative for any number) in a locale, see DecimalFormat(). The
+// numbers are not scaled, "15 %" is the number 15.
+func PercentFormat(locale string, decimals int) NumberFormat {
+	format := DecimalFormat(locale, decimals)
+	if language, _ := parseLocale(locale); localeSuffixSymbols[language] {
+		format.Suffix = " %"
+	} else {
+		format.Suffix = "%"
+	}
+	return format
+}
+
+// decimalSeparator returns the decimal separator, "." if none was set.
+func (f NumberFormat) decimalSeparator() rune {
+	if f.DecimalSeparator == 0 {
+		return '.'
+	}
+	return f.DecimalSeparator
+}
+
+// strip returns the number contained in the text without the prefix, the
+// suffix and group separators, and with a "." as the decimal separator.
+func (f NumberFormat) strip(text string) string {
+	text = strings.TrimSpace(text)
+	if f.Prefix != "" {
+		text = strings.TrimPrefix(text, strings.TrimSpace(f.Prefix))
+	}
+	if f.Suffix != "" {
+		text = strings.TrimSuffix(text, strings.TrimSpace(f.Suffix))
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == f.decimalSeparator():
+			return '.'
+		case r == f.GroupSeparator, f.GroupSeparator != 0 && r == ' ':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(text))
+}
+
+// Accept returns whether or not the provided text is a valid, possibly
+// incomplete number, e.g. "", "-" or "12.". It may be used as an acceptance
+// function, see InputField.SetAcceptanceFunc().
+func (f NumberFormat) Accept(text string, lastChar rune) bool {
+	number := f.strip(text)
+	if f.Negative {
+		number = strings.TrimPrefix(number, "-")
+	}
+	integer, decimals, hasDecimals := strings.Cut(number, ".")
+	if hasDecimals && (f.Decimals == 0 || f.Decimals > 0 && len(decimals) > f.Decimals) {
+		return false
+	}
+	for _, r := range integer + decimals {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse returns the number contained in the provided text.
+func (f NumberFormat) Parse(text string) (float64, error) {
+	number := f.strip(text)
+	if number == "" {
+		return 0, errors.New("no number")
+	}
+	return strconv.ParseFloat(number, 64)
+}
+
+// Format returns the provided text formatted as a number with the configured
+// separators, decimals, prefix and suffix. Text which does not contain a valid
+// number is returned unchanged. It may be used as a format function, see
+// InputField.SetFormatFunc().
+func (f NumberFormat) Format(text string) string {
+	value, err := f.Parse(text)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return text
+	}
+
+	number := strconv.FormatFloat(value, 'f', f.Decimals, 64)
+	negative := strings.HasPrefix(number, "-")
+	integer, decimals, hasDecimals := strings.Cut(strings.TrimPrefix(number, "-"), ".")
+
+	var b strings.Builder
+	b.WriteString(f.Prefix)
+	if negative {
+		b.WriteByte('-')
+	}
+	for index, r := range integer {
+		if index > 0 && (len(integer)-index)%3 == 0 && f.GroupSeparator != 0 {
+			b.WriteRune(f.GroupSeparator)
+		}
+		b.WriteRune(r)
+	}
+	if hasDecimals {
+		b.WriteRune(f.decimalSeparator())
+		b.WriteString(decimals)
+	}
+	b.WriteString(f.Suffix)
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package nuview
+
+import (
+	"testing"
+)
+
+func TestNumberFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		format         NumberFormat
+		input, output  string
+		accept, reject string
+	}{
+		{DecimalFormat("en_US.UTF-8", -1), "1234567.25", "1,234,567.25", "-12.", "1.2.3"},
+		{DecimalFormat("de-DE", 0), "-1234", "-1.234", "1.234", "12,5"},
+		{DecimalFormat("fr_FR", 2), "1234,5", "1\u00a0234,50", "1 234,5", "1,234"},
+		{CurrencyFormat("en_US", "$"), "1234.5", "$1,234.50", "$12.3", "12a"},
+		{CurrencyFormat("de_DE", "€"), "1234,5", "1.234,50 €", "12,34 €", "12,345"},
+		{PercentFormat("en_US", 1), "12.5", "12.5%", "12.5%", "12.55"},
+	} {
+		if output := c.format.Format(c.input); output != c.output {
+			t.Errorf("failed to format %q: expected %q, got %q", c.input, c.output, output)
+		}
+		if !c.format.Accept(c.accept, 0) {
+			t.Errorf("failed to accept %q", c.accept)
+		}
+		if c.format.Accept(c.reject, 0) {
+			t.Errorf("failed to reject %q", c.reject)
+		}
+	}
+
+	i := NewInputField()
+	i.SetNumberFormat(CurrencyFormat("en_US", "$"))
+	i.SetText("1234.5")
+	i.Blur()
+	if text := i.GetText(); text != "$1,234.50" {
+		t.Errorf("failed to format on blur: expected %q, got %q", "$1,234.50", text)
+	}
+	if value, err := i.GetFloat(); err != nil || value != 1234.5 {
+		t.Errorf("failed to get float: expected 1234.5, got %v (%v)", value, err)
+	}
+	if _, err := i.GetInt(); err == nil {
+		t.Error("failed to reject fractional number")
+	}
+	i.SetText("$1,000.00")
+	if value, err := i.GetInt(); err != nil || value != 1000 {
+		t.Errorf("failed to get int: expected 1000, got %d (%v)", value, err)
+	}
+}
@@ -0,0 +1,101 @@
+package nuview
+
+import (
+	"io"
+	"log"
+	"os"
+)
+
+// outputGuard redirects standard output and standard error to a writer.
+type outputGuard struct {
+	// The original streams.
+	stdout, stderr *os.File
+
+	// Whether or not the output of the standard logger was redirected.
+	logRedirected bool
+
+	// The write end of the pipe which replaces the streams.
+	writer *os.File
+
+	// Closed when all output was copied to the writer.
+	done chan struct{}
+}
+
+// SetOutputGuard redirects writes to standard output and standard error (e.g.
+// by fmt.Println() or by a library logging to the standard logger) to the
+// provided writer while the application's screen is active. Such writes would
+// otherwise corrupt the screen. The writer may be a TextView, e.g. shown in an
+// overlay panel (see Panels.AddOverlayPanel()) or in a window, or a log file.
+// Note that the writer is called from a separate goroutine, see
+// TextView.SetChangedFunc() for how to redraw a text view.
+//
+// The streams are restored when the application is stopped or suspended (see
+// Suspend()) and when a panic is handled (see HandlePanic()). Only writes
+// through os.Stdout, os.Stderr and the standard logger (if it writes to
+// os.Stderr) are redirected. Writes to the underlying file descriptors, e.g.
+// by C code or by the Go runtime, are not.
+//
+// The streams are replaced by assigning os.Stdout and os.Stderr, which is not
+// synchronized with other goroutines. Writes by goroutines running while the
+// guard is started or stopped may still reach the terminal or, if they keep a
+// reference to the replaced stream, fail. Enable the guard before starting
+// such goroutines where possible.
+//
+// Provide nil to disable the guard, which is the default.
+func (a *Application) SetOutputGuard(sink io.Writer) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.outputSink = sink
+	if a.screen != nil {
+		// The screen is active. Restart the guard.
+		a.stopOutputGuard()
+		a.startOutputGuard()
+	}
+}
+
+// startOutputGuard redirects standard output and standard error to the output
+// sink, if one was set. The application must be locked.
+func (a *Application) startOutputGuard() {
+	if a.outputSink == nil || a.outputGuard != nil {
+		return
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return // Leave the streams alone.
+	}
+	g := &outputGuard{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		writer: writer,
+		done:   make(chan struct{}),
+	}
+	if log.Writer() == os.Stderr {
+		log.SetOutput(writer)
+		g.logRedirected = true
+	}
+	os.Stdout, os.Stderr = writer, writer
+	a.outputGuard = g
+
+	go func(sink io.Writer) {
+		defer close(g.done)
+		defer reader.Close()
+		io.Copy(sink, reader)
+	}(a.outputSink)
+}
+
+// stopOutputGuard restores standard output and standard error. Output which
+// was written before is still copied to the output sink. The application must
+// be locked.
+func (a *Application) stopOutputGuard() {
+	g := a.outputGuard
+	if g == nil {
+		return
+	}
+	os.Stdout, os.Stderr = g.stdout, g.stderr
+	if g.logRedirected && log.Writer() == g.writer {
+		log.SetOutput(g.stderr)
+	}
+	g.writer.Close()
+	a.outputGuard = nil
+}
@@ -1,17 +1,44 @@
 package nuview
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// OverlayModality specifies how an overlay panel treats input to the panels
+// below it (see Panels.AddOverlayPanel).
+type OverlayModality int
+
+// Overlay modalities.
+const (
+	// OverlayModal blocks all mouse and key events to the panels below the
+	// overlay.
+	OverlayModal OverlayModality = iota
+
+	// OverlayPassThrough passes mouse events outside the overlay's rect on to
+	// the panels below the overlay.
+	OverlayPassThrough
+)
+
 // panel represents a single panel of a Panels object.
 type panel struct {
-	Name    string    // The panel's name.
-	Item    Primitive // The panel's primitive.
-	Resize  bool      // Whether or not to resize the panel when it is drawn.
-	Visible bool      // Whether or not this panel is visible.
+	Name     string          // The panel's name.
+	Item     Primitive       // The panel's primitive.
+	Resize   bool            // Whether or not to resize the panel when it is drawn.
+	Visible  bool            // Whether or not this panel is visible.
+	Overlay  bool            // Whether or not this panel is an overlay.
+	Modality OverlayModality // How an overlay treats input to lower panels.
+
+	Shortcuts []string // Keybindings which make this panel the current panel.
+}
+
+// inRect returns whether or not the given position is inside the panel's
+// primitive.
+func (pg *panel) inRect(x, y int) bool {
+	rectX, rectY, width, height := pg.Item.GetRect()
+	return x >= rectX && x < rectX+width && y >= rectY && y < rectY+height
 }
 
 // Panels is a container for other primitives often used as the application's
@@ -31,6 +58,17 @@ type Panels struct {
 	// panels changes.
 	changed func()
 
+	// An optional handler which is called whenever a panel is shown or hidden.
+	visibility func(name string, item Primitive, visible bool)
+
+	// Whether or not the panels below the front-most visible overlay panel are
+	// dimmed.
+	dimOverlaid bool
+
+	// Whether or not Alt+1 to Alt+9 make the first nine panels (which are not
+	// overlays) the current panel.
+	numberShortcuts bool
+
 	sync.RWMutex
 }
 
@@ -52,6 +90,28 @@ func (p *Panels) SetChangedFunc(handler func()) {
 	p.changed = handler
 }
 
+// SetVisibilityFunc sets a handler which is called whenever a panel becomes
+// visible or hidden, including when a visible panel is added or removed. It
+// receives the panel's name, its primitive and its new visibility. This can be
+// used to pause work done for a panel while it is hidden, see Refresher.
+func (p *Panels) SetVisibilityFunc(handler func(name string, item Primitive, visible bool)) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.visibility = handler
+}
+
+// notifyVisibility calls the visibility handler for a panel which became
+// visible or hidden. The panels must be locked.
+func (p *Panels) notifyVisibility(pg *panel, visible bool) {
+	if p.visibility != nil {
+		handler := p.visibility
+		p.Unlock()
+		handler(pg.Name, pg.Item, visible)
+		p.Lock()
+	}
+}
+
 // GetPanelCount returns the number of panels currently stored in this object.
 func (p *Panels) GetPanelCount() int {
 	p.RLock()
@@ -70,27 +130,54 @@ func (p *Panels) GetPanelCount() int {
 // primitive will be set to the size available to the Panels primitive whenever
 // the panels are drawn.
 func (p *Panels) AddPanel(name string, item Primitive, resize, visible bool) {
+	p.addPanel(&panel{Item: item, Name: name, Resize: resize, Visible: visible})
+}
+
+// AddOverlayPanel adds a new overlay panel with the given name and primitive,
+// e.g. a dialog. Like a panel added with AddPanel() and "resize" set to false,
+// an overlay keeps the position and size set with its primitive's SetRect().
+// In addition, the modality specifies the input the panels below the overlay
+// receive while the overlay is visible: none at all (OverlayModal) or mouse
+// events outside the overlay's rect (OverlayPassThrough). Key events are
+// always delivered to the focused primitive, unless it is below a visible
+// modal overlay.
+//
+// See SetDimOverlaid() to dim the panels below an overlay.
+func (p *Panels) AddOverlayPanel(name string, item Primitive, modality OverlayModality, visible bool) {
+	p.addPanel(&panel{Item: item, Name: name, Visible: visible, Overlay: true, Modality: modality})
+}
+
+// addPanel adds the provided panel, replacing any panel with the same name.
+func (p *Panels) addPanel(newPanel *panel) {
 	hasFocus := p.HasFocus()
 
 	p.Lock()
 	defer p.Unlock()
 
-	var added bool
+	var replaced *panel
 	for i, pg := range p.panels {
-		if pg.Name == name {
-			p.panels[i] = &panel{Item: item, Name: name, Resize: resize, Visible: visible}
-			added = true
+		if pg.Name == newPanel.Name {
+			newPanel.Shortcuts = pg.Shortcuts
+			p.panels[i] = newPanel
+			replaced = pg
 			break
 		}
 	}
-	if !added {
-		p.panels = append(p.panels, &panel{Item: item, Name: name, Resize: resize, Visible: visible})
+	if replaced == nil {
+		p.panels = append(p.panels, newPanel)
 	}
 	if p.changed != nil {
 		p.Unlock()
 		p.changed()
 		p.Lock()
 	}
+	sameItem := replaced != nil && replaced.Visible && replaced.Item == newPanel.Item
+	if replaced != nil && replaced.Visible && (!newPanel.Visible || !sameItem) {
+		p.notifyVisibility(replaced, false)
+	}
+	if newPanel.Visible && !sameItem {
+		p.notifyVisibility(newPanel, true)
+	}
 	if hasFocus {
 		p.Unlock()
 		p.Focus(p.setFocus)
@@ -98,6 +185,37 @@ func (p *Panels) AddPanel(name string, item Primitive, resize, visible bool) {
 	}
 }
 
+// SetDimOverlaid sets whether or not the panels below the front-most visible
+// overlay panel (see AddOverlayPanel()) are drawn dimmed.
+func (p *Panels) SetDimOverlaid(dim bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.dimOverlaid = dim
+}
+
+// GetDimOverlaid returns whether or not the panels below the front-most
+// visible overlay panel are drawn dimmed.
+func (p *Panels) GetDimOverlaid() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.dimOverlaid
+}
+
+// topOverlay returns the index of the front-most visible overlay panel which
+// has the given modality, or of any modality if modal is false. -1 is
+// returned if there is no such panel.
+func (p *Panels) topOverlay(modal bool) int {
+	for index := len(p.panels) - 1; index >= 0; index-- {
+		pg := p.panels[index]
+		if pg.Visible && pg.Overlay && (!modal || pg.Modality == OverlayModal) {
+			return index
+		}
+	}
+	return -1
+}
+
 // RemovePanel removes the panel with the given name. If that panel was the only
 // visible panel, visibility is assigned to the last panel.
 func (p *Panels) RemovePanel(name string) {
@@ -106,29 +224,37 @@ func (p *Panels) RemovePanel(name string) {
 	p.Lock()
 	defer p.Unlock()
 
-	var isVisible bool
+	var removed *panel
 	for index, panel := range p.panels {
 		if panel.Name == name {
-			isVisible = panel.Visible
 			p.panels = append(p.panels[:index], p.panels[index+1:]...)
-			if panel.Visible && p.changed != nil {
-				p.Unlock()
-				p.changed()
-				p.Lock()
+			if panel.Visible {
+				removed = panel
+				if p.changed != nil {
+					p.Unlock()
+					p.changed()
+					p.Lock()
+				}
 			}
 			break
 		}
 	}
-	if isVisible {
+	var shown *panel
+	if removed != nil {
 		for index, panel := range p.panels {
 			if index < len(p.panels)-1 {
 				if panel.Visible {
 					break // There is a remaining visible panel.
 				}
-			} else {
+			} else if !panel.Visible {
 				panel.Visible = true // We need at least one visible panel.
+				shown = panel
 			}
 		}
+		p.notifyVisibility(removed, false)
+	}
+	if shown != nil {
+		p.notifyVisibility(shown, true)
 	}
 	if hasFocus {
 		p.Unlock()
@@ -137,6 +263,62 @@ func (p *Panels) RemovePanel(name string) {
 	}
 }
 
+// SetPanelShortcut sets the keybindings (e.g. "Alt+M" or "F2") which make the
+// panel with the given name the current panel (see SetCurrentPanel()) while
+// the panels have focus. Calling it without keybindings removes them. The
+// keybindings are kept when the panel is replaced by AddPanel().
+func (p *Panels) SetPanelShortcut(name string, shortcuts ...string) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, panel := range p.panels {
+		if panel.Name == name {
+			panel.Shortcuts = shortcuts
+			break
+		}
+	}
+}
+
+// SetNumberShortcuts sets whether or not Alt+1 to Alt+9 make the first nine
+// panels which are not overlays the current panel while the panels have
+// focus. Keybindings set with SetPanelShortcut() take precedence.
+//
+// This is disabled by default.
+func (p *Panels) SetNumberShortcuts(enabled bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.numberShortcuts = enabled
+}
+
+// shortcutPanel returns the name of the panel whose shortcut matches the
+// provided key event and whether or not there is such a panel. The panels
+// must be locked.
+func (p *Panels) shortcutPanel(event *tcell.EventKey) (name string, ok bool) {
+	for _, panel := range p.panels {
+		if len(panel.Shortcuts) > 0 && p.hitShortcut(event, panel.Shortcuts) {
+			return panel.Name, true
+		}
+	}
+	if !p.numberShortcuts {
+		return "", false
+	}
+	var number int
+	for _, panel := range p.panels {
+		if panel.Overlay {
+			continue
+		}
+		number++
+		if number > 9 {
+			break
+		}
+		if p.hitShortcut(event, []string{"Alt+" + strconv.Itoa(number)}) {
+			return panel.Name, true
+		}
+	}
+	return "", false
+}
+
 // HasPanel returns true if a panel with the given name exists in this object.
 func (p *Panels) HasPanel(name string) bool {
 	p.RLock()
@@ -160,12 +342,16 @@ func (p *Panels) ShowPanel(name string) {
 
 	for _, panel := range p.panels {
 		if panel.Name == name {
+			wasVisible := panel.Visible
 			panel.Visible = true
 			if p.changed != nil {
 				p.Unlock()
 				p.changed()
 				p.Lock()
 			}
+			if !wasVisible {
+				p.notifyVisibility(panel, true)
+			}
 			break
 		}
 	}
@@ -185,12 +371,16 @@ func (p *Panels) HidePanel(name string) {
 
 	for _, panel := range p.panels {
 		if panel.Name == name {
+			wasVisible := panel.Visible
 			panel.Visible = false
 			if p.changed != nil {
 				p.Unlock()
 				p.changed()
 				p.Lock()
 			}
+			if wasVisible {
+				p.notifyVisibility(panel, false)
+			}
 			break
 		}
 	}
@@ -209,18 +399,27 @@ func (p *Panels) SetCurrentPanel(name string) {
 	p.Lock()
 	defer p.Unlock()
 
+	var hidden, shown []*panel
 	for _, panel := range p.panels {
-		if panel.Name == name {
-			panel.Visible = true
-		} else {
-			panel.Visible = false
+		visible := panel.Name == name
+		if visible && !panel.Visible {
+			shown = append(shown, panel)
+		} else if !visible && panel.Visible {
+			hidden = append(hidden, panel)
 		}
+		panel.Visible = visible
 	}
 	if p.changed != nil {
 		p.Unlock()
 		p.changed()
 		p.Lock()
 	}
+	for _, panel := range hidden {
+		p.notifyVisibility(panel, false)
+	}
+	for _, panel := range shown {
+		p.notifyVisibility(panel, true)
+	}
 	if hasFocus {
 		p.Unlock()
 		p.Focus(p.setFocus)
@@ -348,14 +547,36 @@ func (p *Panels) Draw(screen tcell.Screen) {
 
 	x, y, width, height := p.GetInnerRect()
 
-	for _, panel := range p.panels {
+	dimBelow := -1
+	if p.dimOverlaid {
+		dimBelow = p.topOverlay(false)
+	}
+	for index, panel := range p.panels {
+		if index == dimBelow {
+			dimRect(screen, x, y, width, height)
+		}
 		if !panel.Visible {
 			continue
 		}
 		if panel.Resize {
 			panel.Item.SetRect(x, y, width, height)
 		}
-		panel.Item.Draw(screen)
+		drawPrimitive(screen, panel.Item)
+	}
+}
+
+// dimRect adds the dim attribute to the cells of the screen in the given
+// rect.
+func dimRect(screen tcell.Screen, x, y, width, height int) {
+	screenWidth, screenHeight := screen.Size()
+	for row := max(y, 0); row < min(y+height, screenHeight); row++ {
+		for column := max(x, 0); column < min(x+width, screenWidth); column++ {
+			mainc, combc, style, w := screen.GetContent(column, row)
+			screen.SetContent(column, row, mainc, combc, style.Dim(true))
+			if w > 1 {
+				column += w - 1 // Skip the rest of a wide character.
+			}
+		}
 	}
 }
 
@@ -367,13 +588,22 @@ func (p *Panels) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 		}
 
 		// Pass mouse events along to the last visible panel item that takes it.
+		// Overlays keep them from the panels below, except for events outside
+		// of pass-through overlays.
 		for index := len(p.panels) - 1; index >= 0; index-- {
 			panel := p.panels[index]
-			if panel.Visible {
-				consumed, capture = panel.Item.MouseHandler()(action, event, setFocus)
-				if consumed {
-					return
-				}
+			if !panel.Visible {
+				continue
+			}
+			if panel.Overlay && panel.Modality == OverlayPassThrough && !panel.inRect(event.Position()) {
+				continue
+			}
+			consumed, capture = panel.Item.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+			if panel.Overlay {
+				return true, nil
 			}
 		}
 
@@ -384,7 +614,16 @@ func (p *Panels) MouseHandler() func(action MouseAction, event *tcell.EventMouse
 // InputHandler returns the handler for this primitive.
 func (p *Panels) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return p.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		for _, page := range p.panels {
+		p.RLock()
+		name, ok := p.shortcutPanel(event)
+		p.RUnlock()
+		if ok {
+			p.SetCurrentPanel(name)
+			return
+		}
+
+		// Panels below a modal overlay don't receive key events.
+		for _, page := range p.panels[max(p.topOverlay(true), 0):] {
 			if page.Item.GetFocusable().HasFocus() {
 				if handler := page.Item.InputHandler(); handler != nil {
 					handler(event, setFocus)
@@ -0,0 +1,95 @@
+package nuview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPanelsOverlay(t *testing.T) {
+	t.Parallel()
+
+	var selected int
+	lower := NewButton("lower")
+	lower.SetSelectedFunc(func() {
+		selected++
+	})
+	overlay := NewBox()
+
+	p := NewPanels()
+	p.SetRect(0, 0, 20, 10)
+	p.AddPanel("lower", lower, true, true)
+	p.AddOverlayPanel("overlay", overlay, OverlayModal, true)
+	lower.SetRect(0, 0, 20, 10)
+	overlay.SetRect(5, 3, 10, 4)
+	lower.Focus(nil)
+
+	click := func(x, y int) {
+		p.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+	}
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	// A modal overlay blocks all input to lower panels.
+	click(1, 1)
+	p.InputHandler()(enter, func(p Primitive) {})
+	if selected != 0 {
+		t.Errorf("failed to block input to lower panel: expected 0 selections, got %d", selected)
+	}
+
+	// A pass-through overlay only blocks mouse events inside its rect.
+	p.AddOverlayPanel("overlay", overlay, OverlayPassThrough, true)
+	click(6, 4)
+	if selected != 0 {
+		t.Errorf("failed to block click inside overlay: expected 0 selections, got %d", selected)
+	}
+	click(1, 1)
+	p.InputHandler()(enter, func(p Primitive) {})
+	if selected != 2 {
+		t.Errorf("failed to pass input through overlay: expected 2 selections, got %d", selected)
+	}
+
+	// Lower panels are dimmed, the overlay is not.
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 10)
+	p.SetDimOverlaid(true)
+	p.Draw(sc)
+	if _, _, style, _ := sc.GetContent(1, 1); !hasAttr(style, tcell.AttrDim) {
+		t.Errorf("failed to dim lower panel: got style %v", style)
+	}
+	if _, _, style, _ := sc.GetContent(6, 4); hasAttr(style, tcell.AttrDim) {
+		t.Errorf("failed to draw overlay undimmed: got style %v", style)
+	}
+}
+
+func TestPanelsVisibility(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+	p := NewPanels()
+	p.SetVisibilityFunc(func(name string, item Primitive, visible bool) {
+		events = append(events, fmt.Sprintf("%s:%t", name, visible))
+	})
+	p.AddPanel("a", NewBox(), true, true)
+	p.AddPanel("b", NewBox(), true, false)
+	p.ShowPanel("b")
+	p.ShowPanel("b")
+	p.SetCurrentPanel("a")
+	p.HidePanel("b")
+	p.RemovePanel("a")
+
+	expected := "a:true b:true b:false a:false b:true"
+	if text := strings.Join(events, " "); text != expected {
+		t.Errorf("failed to notify visibility: expected %q, got %q", expected, text)
+	}
+}
+
+// hasAttr returns whether or not the style has the given attribute.
+func hasAttr(style tcell.Style, attr tcell.AttrMask) bool {
+	_, _, attrs := style.Decompose()
+	return attrs&attr != 0
+}
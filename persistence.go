@@ -0,0 +1,414 @@
+package nuview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Persistent is implemented by primitives whose view state (e.g. the scroll
+// position or the selection, but not the content) can be saved and restored.
+// The state is encoded as JSON. List, Table, TreeView, TextView, Panels,
+// TabbedPanels and Window implement it.
+//
+// Register persistent primitives with Application.SetPersistenceID() to save
+// and restore the state of all of them with Application.SaveSession() and
+// Application.RestoreSession().
+type Persistent interface {
+	Primitive
+
+	// SaveState returns the current view state.
+	SaveState() ([]byte, error)
+
+	// RestoreState restores a view state returned by SaveState(). The content
+	// of the primitive should be set before. State referring to content which
+	// no longer exists is ignored.
+	RestoreState(state []byte) error
+}
+
+// SetPersistenceID registers a primitive whose state is saved by SaveSession()
+// and restored by RestoreSession() under the provided ID. IDs must be unique
+// and should not change between runs of the application. Provide a nil
+// primitive to unregister an ID.
+func (a *Application) SetPersistenceID(id string, p Persistent) {
+	a.Lock()
+	defer a.Unlock()
+
+	if p == nil {
+		delete(a.persistent, id)
+		return
+	}
+	if a.persistent == nil {
+		a.persistent = make(map[string]Persistent)
+	}
+	a.persistent[id] = p
+}
+
+// SaveSession writes the state of all primitives registered with
+// SetPersistenceID() to the provided writer as a JSON object, e.g. to
+// reopen the application where the user left off:
+//
+//	app.SaveSession(file) // Before exiting.
+//	app.RestoreSession(file) // After building the interface.
+//
+// Like all functions modifying primitives, it must be called from the main
+// goroutine or via QueueUpdate() while the application is running.
+func (a *Application) SaveSession(w io.Writer) error {
+	session := make(map[string]json.RawMessage)
+	for _, id := range a.persistenceIDs() {
+		a.RLock()
+		p := a.persistent[id]
+		a.RUnlock()
+		state, err := p.SaveState()
+		if err != nil {
+			return fmt.Errorf("failed to save state of %q: %w", id, err)
+		}
+		session[id] = state
+	}
+	return json.NewEncoder(w).Encode(session)
+}
+
+// RestoreSession reads a session written by SaveSession() and restores the
+// state of the registered primitives. Primitives not found in the session keep
+// their state, IDs not registered are ignored.
+func (a *Application) RestoreSession(r io.Reader) error {
+	var session map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&session); err != nil {
+		return fmt.Errorf("invalid session: %w", err)
+	}
+	for _, id := range a.persistenceIDs() {
+		state, ok := session[id]
+		if !ok {
+			continue
+		}
+		a.RLock()
+		p := a.persistent[id]
+		a.RUnlock()
+		if err := p.RestoreState(state); err != nil {
+			return fmt.Errorf("failed to restore state of %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// persistenceIDs returns the sorted IDs of the registered primitives.
+func (a *Application) persistenceIDs() []string {
+	a.RLock()
+	defer a.RUnlock()
+
+	ids := make([]string, 0, len(a.persistent))
+	for id := range a.persistent {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// listState is the persistent state of a List.
+type listState struct {
+	Current   int             `json:"current"`
+	Offset    [2]int          `json:"offset"`
+	Selection *SelectionModel `json:"selection,omitempty"`
+}
+
+// SaveState returns the current item, the scroll offset and the selection of
+// the list. It implements the Persistent interface.
+func (l *List) SaveState() ([]byte, error) {
+	items, columns := l.GetOffset()
+	return json.Marshal(listState{
+		Current:   l.GetCurrentItemIndex(),
+		Offset:    [2]int{items, columns},
+		Selection: l.GetSelectionModel(),
+	})
+}
+
+// RestoreState restores a state returned by SaveState(). It implements the
+// Persistent interface.
+func (l *List) RestoreState(state []byte) error {
+	s := listState{Selection: l.GetSelectionModel()}
+	if s.Selection == nil {
+		s.Selection = NewSelectionModel(SelectionMultiple) // Discarded.
+	}
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	if s.Current < l.GetItemCount() {
+		l.SetCurrentItem(s.Current)
+	}
+	l.SetOffset(s.Offset[0], s.Offset[1])
+	return nil
+}
+
+// tableState is the persistent state of a Table.
+type tableState struct {
+	Selected  [2]int          `json:"selected"`
+	Offset    [2]int          `json:"offset"`
+	Selection *SelectionModel `json:"selection,omitempty"`
+}
+
+// SaveState returns the selected cell, the scroll offset and the selection of
+// the table. It implements the Persistent interface.
+func (t *Table) SaveState() ([]byte, error) {
+	row, column := t.GetSelection()
+	rowOffset, columnOffset := t.GetOffset()
+	return json.Marshal(tableState{
+		Selected:  [2]int{row, column},
+		Offset:    [2]int{rowOffset, columnOffset},
+		Selection: t.GetSelectionModel(),
+	})
+}
+
+// RestoreState restores a state returned by SaveState(). It implements the
+// Persistent interface.
+func (t *Table) RestoreState(state []byte) error {
+	s := tableState{Selection: t.GetSelectionModel()}
+	if s.Selection == nil {
+		s.Selection = NewSelectionModel(SelectionMultiple) // Discarded.
+	}
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	if s.Selected[0] < t.GetRowCount() && s.Selected[1] < t.GetColumnCount() {
+		t.Select(s.Selected[0], s.Selected[1])
+	}
+	t.SetOffset(s.Offset[0], s.Offset[1])
+	return nil
+}
+
+// treeViewState is the persistent state of a TreeView. Nodes are identified
+// by the texts of the nodes on the path from the root to them.
+type treeViewState struct {
+	Expanded [][]string `json:"expanded,omitempty"`
+	Current  []string   `json:"current,omitempty"`
+	Offset   int        `json:"offset"`
+}
+
+// walkPaths calls the callback for each node of the tree below and including
+// the root, with the texts of the nodes on the path from the root to it.
+func walkPaths(root *TreeNode, callback func(node *TreeNode, path []string)) {
+	// Collect the nodes first, the root is locked during the walk.
+	var nodes, parents []*TreeNode
+	root.Walk(func(node, parent *TreeNode) bool {
+		nodes, parents = append(nodes, node), append(parents, parent)
+		return true
+	})
+	paths := make(map[*TreeNode][]string)
+	for index, node := range nodes {
+		path := []string{node.GetText()}
+		if parent := parents[index]; parent != nil {
+			path = append(append([]string{}, paths[parent]...), path...)
+		}
+		paths[node] = path
+		callback(node, path)
+	}
+}
+
+// SaveState returns the expanded nodes, the current node and the scroll
+// offset of the tree view. It implements the Persistent interface.
+func (t *TreeView) SaveState() ([]byte, error) {
+	var s treeViewState
+	root, current := t.GetRoot(), t.GetCurrentNode()
+	if root != nil {
+		walkPaths(root, func(node *TreeNode, path []string) {
+			if node.IsExpanded() && len(node.GetChildren()) > 0 {
+				s.Expanded = append(s.Expanded, path)
+			}
+			if node == current {
+				s.Current = path
+			}
+		})
+	}
+	s.Offset = t.GetScrollOffset()
+	return json.Marshal(s)
+}
+
+// RestoreState restores a state returned by SaveState(). Nodes with children
+// which were not expanded are collapsed. It implements the Persistent
+// interface.
+func (t *TreeView) RestoreState(state []byte) error {
+	var s treeViewState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	root := t.GetRoot()
+	if root == nil {
+		return nil
+	}
+	key := func(path []string) string {
+		return strings.Join(path, "\x00")
+	}
+	expanded := make(map[string]bool)
+	for _, path := range s.Expanded {
+		expanded[key(path)] = true
+	}
+	var current *TreeNode
+	walkPaths(root, func(node *TreeNode, path []string) {
+		if len(node.GetChildren()) > 0 {
+			node.SetExpanded(expanded[key(path)])
+		}
+		if s.Current != nil && current == nil && key(path) == key(s.Current) {
+			current = node
+		}
+	})
+	if current != nil {
+		t.SetCurrentNode(current)
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.offsetY = max(s.Offset, 0)
+	return nil
+}
+
+// textViewState is the persistent state of a TextView.
+type textViewState struct {
+	Offset [2]int `json:"offset"`
+	End    bool   `json:"end,omitempty"`
+}
+
+// SaveState returns the scroll offset of the text view and whether it follows
+// the end of the text. It implements the Persistent interface.
+func (t *TextView) SaveState() ([]byte, error) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return json.Marshal(textViewState{
+		Offset: [2]int{t.lineOffset, t.columnOffset},
+		End:    t.trackEnd,
+	})
+}
+
+// RestoreState restores a state returned by SaveState(). It implements the
+// Persistent interface.
+func (t *TextView) RestoreState(state []byte) error {
+	var s textViewState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	if s.End {
+		t.ScrollToEnd()
+	} else {
+		t.ScrollTo(s.Offset[0], s.Offset[1])
+	}
+	return nil
+}
+
+// panelsState is the persistent state of Panels.
+type panelsState struct {
+	Visible []string `json:"visible"`
+	Front   string   `json:"front,omitempty"`
+}
+
+// SaveState returns the names of the visible panels and of the front panel.
+// It implements the Persistent interface.
+func (p *Panels) SaveState() ([]byte, error) {
+	s := panelsState{Visible: []string{}}
+	p.RLock()
+	for _, panel := range p.panels {
+		if panel.Visible {
+			s.Visible = append(s.Visible, panel.Name)
+		}
+	}
+	p.RUnlock()
+	s.Front, _ = p.GetFrontPanel()
+	return json.Marshal(s)
+}
+
+// RestoreState restores a state returned by SaveState(). It implements the
+// Persistent interface.
+func (p *Panels) RestoreState(state []byte) error {
+	var s panelsState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	visible := make(map[string]bool)
+	for _, name := range s.Visible {
+		visible[name] = true
+	}
+	p.RLock()
+	names := make([]string, len(p.panels))
+	for index, panel := range p.panels {
+		names[index] = panel.Name
+	}
+	p.RUnlock()
+	for _, name := range names {
+		if visible[name] {
+			p.ShowPanel(name)
+		} else {
+			p.HidePanel(name)
+		}
+	}
+	if s.Front != "" && p.HasPanel(s.Front) {
+		p.SendToFront(s.Front)
+	}
+	return nil
+}
+
+// tabbedPanelsState is the persistent state of TabbedPanels.
+type tabbedPanelsState struct {
+	Current string `json:"current"`
+}
+
+// SaveState returns the name of the current tab. It implements the Persistent
+// interface.
+func (t *TabbedPanels) SaveState() ([]byte, error) {
+	return json.Marshal(tabbedPanelsState{Current: t.GetCurrentTab()})
+}
+
+// RestoreState restores a state returned by SaveState(). It implements the
+// Persistent interface.
+func (t *TabbedPanels) RestoreState(state []byte) error {
+	var s tabbedPanelsState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	if t.HasTab(s.Current) {
+		t.SetCurrentTab(s.Current)
+	}
+	return nil
+}
+
+// windowState is the persistent state of a Window.
+type windowState struct {
+	Rect       [4]int `json:"rect"`
+	Fullscreen bool   `json:"fullscreen,omitempty"`
+}
+
+// SaveState returns the position and size of the window and whether it is
+// fullscreen. The size of a fullscreen window is the size it is restored to.
+// It implements the Persistent interface.
+func (w *Window) SaveState() ([]byte, error) {
+	w.RLock()
+	defer w.RUnlock()
+
+	s := windowState{Fullscreen: w.fullscreen}
+	if w.fullscreen {
+		s.Rect = [4]int{w.normalX, w.normalY, w.normalW, w.normalH}
+	} else {
+		x, y, width, height := w.GetRect()
+		s.Rect = [4]int{x, y, width, height}
+	}
+	return json.Marshal(s)
+}
+
+// RestoreState restores a state returned by SaveState(). The window is
+// neither sized to its content nor centered afterwards. It implements the
+// Persistent interface.
+func (w *Window) RestoreState(state []byte) error {
+	var s windowState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	w.SetFullscreen(false)
+	w.SetRect(s.Rect[0], s.Rect[1], s.Rect[2], s.Rect[3])
+	w.SetFullscreen(s.Fullscreen)
+
+	w.Lock()
+	defer w.Unlock()
+
+	w.sizeToContent, w.center = false, false
+	return nil
+}
@@ -0,0 +1,86 @@
+package nuview
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	newInterface := func() (*List, *TreeView, *TabbedPanels, *Window) {
+		l := NewList()
+		for _, text := range []string{"a", "b", "c", "d"} {
+			l.AddItem(NewListItem(text))
+		}
+		l.SetSelectionModel(NewSelectionModel(SelectionMultiple))
+
+		root := NewTreeNode("root")
+		child := NewTreeNode("child")
+		child.AddChild(NewTreeNode("leaf"))
+		root.AddChild(child)
+		tv := NewTreeView()
+		tv.SetRoot(root)
+		tv.SetCurrentNode(root)
+
+		tp := NewTabbedPanels()
+		tp.AddTab("one", "One", NewBox())
+		tp.AddTab("two", "Two", NewBox())
+
+		w := NewWindow(NewBox())
+		w.SetRect(1, 2, 30, 10)
+		return l, tv, tp, w
+	}
+	register := func(app *Application, l *List, tv *TreeView, tp *TabbedPanels, w *Window) {
+		app.SetPersistenceID("list", l)
+		app.SetPersistenceID("tree", tv)
+		app.SetPersistenceID("tabs", tp)
+		app.SetPersistenceID("window", w)
+	}
+
+	app := NewApplication()
+	l, tv, tp, w := newInterface()
+	register(app, l, tv, tp, w)
+	l.SetCurrentItem(2)
+	l.GetSelectionModel().Select(1)
+	child := tv.GetRoot().GetChildren()[0]
+	child.Collapse()
+	tv.SetCurrentNode(child)
+	tp.SetCurrentTab("two")
+	w.SetRect(5, 6, 40, 12)
+	w.SetFullscreen(true)
+
+	var session bytes.Buffer
+	if err := app.SaveSession(&session); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewApplication()
+	l, tv, tp, w = newInterface()
+	register(restored, l, tv, tp, w)
+	restored.SetPersistenceID("unknown", NewTextView())
+	if err := restored.RestoreSession(bytes.NewReader(session.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if current := l.GetCurrentItemIndex(); current != 2 {
+		t.Errorf("failed to restore list: expected current item 2, got %d", current)
+	}
+	if selection := l.GetSelectionModel(); !selection.IsSelected(1) {
+		t.Errorf("failed to restore list selection: expected item 1 to be selected")
+	}
+	if node := tv.GetCurrentNode(); node.GetText() != "child" || node.IsExpanded() {
+		t.Errorf("failed to restore tree view: expected collapsed current node \"child\", got %q", node.GetText())
+	}
+	if tab := tp.GetCurrentTab(); tab != "two" {
+		t.Errorf("failed to restore tabbed panels: expected tab \"two\", got %q", tab)
+	}
+	w.SetFullscreen(false)
+	if x, y, width, height := w.GetRect(); x != 5 || y != 6 || width != 40 || height != 12 {
+		t.Errorf("failed to restore window: expected rect 5,6,40,12, got %d,%d,%d,%d", x, y, width, height)
+	}
+
+	if err := restored.RestoreSession(bytes.NewReader([]byte("{"))); err == nil {
+		t.Error("failed to restore session: expected error for invalid session")
+	}
+}
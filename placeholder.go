@@ -0,0 +1,127 @@
+package nuview
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var (
+	// SpinnerFrames are the frames of the spinner which List, Table and
+	// TreeView show while their content is loading (see List.SetLoading()) and
+	// which Button shows while its action is running (see Button.SetLoading()).
+	SpinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+	// SpinnerFrameDuration is the time each frame of the spinner is shown.
+	SpinnerFrameDuration = 100 * time.Millisecond
+)
+
+// contentState shows a placeholder or a loading spinner in place of the
+// content of a primitive, e.g. "No results" when a list has no items. It is
+// not a primitive but a component of List, Table, TreeView and TextView.
+type contentState struct {
+	// The primitive shown when there is no content, nil for none.
+	placeholder Primitive
+
+	// The text view created by setPlaceholderText(), nil if the placeholder
+	// was provided by the application.
+	text *TextView
+
+	// Whether or not the content is loading, and since when.
+	loading      bool
+	loadingSince time.Time
+
+	// An error message shown in place of the content, empty for none.
+	err string
+}
+
+// setPlaceholder sets the primitive shown when there is no content.
+func (c *contentState) setPlaceholder(placeholder Primitive) {
+	c.placeholder = placeholder
+	c.text = nil
+}
+
+// setPlaceholderText sets a text shown centered when there is no content.
+func (c *contentState) setPlaceholderText(text string) {
+	if text == "" {
+		c.setPlaceholder(nil)
+		return
+	}
+	t := NewTextView()
+	t.SetDynamicColors(true)
+	t.SetTextAlign(AlignCenter)
+	t.SetVerticalAlign(AlignMiddle)
+	t.SetTextColor(Styles.TertiaryTextColor)
+	t.SetScrollBarVisibility(ScrollBarNever)
+	t.SetText(text)
+	c.placeholder = t
+	c.text = t
+}
+
+// setLoading sets whether or not the content is loading.
+func (c *contentState) setLoading(loading bool) {
+	if loading && !c.loading {
+		c.loadingSince = time.Now()
+		requestAnimationFrame()
+	}
+	c.loading = loading
+}
+
+// setError sets an error message shown in place of the content. Provide an
+// empty string to show the content again.
+func (c *contentState) setError(text string) {
+	c.err = text
+}
+
+// active returns whether or not the placeholder, the spinner or an error is
+// shown in place of the content, given whether or not there is no content.
+func (c *contentState) active(empty bool) bool {
+	return c.loading || c.err != "" || empty && c.placeholder != nil
+}
+
+// draw draws the spinner (if the content is loading), the error message (if
+// one was set) or the placeholder (if there is no content) into the given rect.
+// It returns false if none was drawn, the content is to be drawn as usual
+// then.
+func (c *contentState) draw(screen tcell.Screen, x, y, width, height int, empty bool, background tcell.Color) bool {
+	if !c.active(empty) {
+		return false
+	}
+	if width <= 0 || height <= 0 {
+		return true
+	}
+
+	theme := applicationOf(screen).theme()
+	if c.loading {
+		style := tcell.StyleDefault.Foreground(theme.TertiaryTextColor).Background(background)
+		screen.SetContent(x+width/2, y+height/2, spinnerFrame(c.loadingSince), nil, style)
+		return true
+	}
+
+	if c.err != "" {
+		style := tcell.StyleDefault.Foreground(theme.ErrorTextColor).Background(background)
+		lines := WordWrap(Escape(c.err), width)
+		top := y + max(height-len(lines), 0)/2
+		for index, line := range lines {
+			if index >= height {
+				break
+			}
+			PrintStyle(screen, []byte(line), x, top+index, width, AlignCenter, style)
+		}
+		return true
+	}
+
+	if c.text != nil {
+		c.text.SetBackgroundColor(background)
+	}
+	c.placeholder.SetRect(x, y, width, height)
+	c.placeholder.Draw(screen)
+	return true
+}
+
+// spinnerFrame returns the frame of a spinner started at the provided time and
+// requests the next animation frame.
+func spinnerFrame(since time.Time) rune {
+	requestAnimationFrame()
+	return SpinnerFrames[int(time.Since(since)/SpinnerFrameDuration)%len(SpinnerFrames)]
+}
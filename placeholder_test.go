@@ -0,0 +1,63 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	l := NewList()
+	l.SetRect(0, 0, 20, 5)
+	l.SetPlaceholderText("No results")
+	l.Draw(sc)
+	if text := row(2); strings.TrimSpace(text) != "No results" {
+		t.Errorf("failed to draw placeholder: expected centered text, got %q", text)
+	}
+
+	l.AddItem(NewListItem("item"))
+	sc.Clear()
+	l.Draw(sc)
+	if text := row(0); !strings.HasPrefix(text, "item") || strings.Contains(row(2), "No results") {
+		t.Errorf("failed to draw items: got %q", text)
+	}
+
+	l.SetLoading(true)
+	sc.Clear()
+	l.Draw(sc)
+	if r, _, _, _ := sc.GetContent(10, 2); r != SpinnerFrames[0] || strings.Contains(row(0), "item") {
+		t.Errorf("failed to draw loading spinner: expected %q instead of items, got %q", SpinnerFrames[0], r)
+	}
+
+	// Tables keep their fixed rows.
+	tb := NewTable()
+	tb.SetRect(0, 0, 20, 5)
+	tb.SetFixed(1, 0)
+	tb.SetCellSimple(0, 0, "Name")
+	tb.SetPlaceholderText("Empty")
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(0); !strings.HasPrefix(text, "Name") {
+		t.Errorf("failed to draw fixed row: got %q", text)
+	}
+	if text := row(2); strings.TrimSpace(text) != "Empty" {
+		t.Errorf("failed to draw table placeholder: got %q", text)
+	}
+}
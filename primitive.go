@@ -1,6 +1,10 @@
 package nuview
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Primitive is the top-most interface for all graphical primitives.
 type Primitive interface {
@@ -64,3 +68,88 @@ type Primitive interface {
 	// Box.WrapMouseHandler() so you inherit that functionality.
 	MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive)
 }
+
+// PreferredSize is an optional interface implemented by primitives which can
+// calculate the size they need to show their content. It is used to size
+// primitives automatically, e.g. by Window.SizeToContent(). The returned sizes
+// include borders and padding.
+type PreferredSize interface {
+	// PreferredWidth returns the width the primitive prefers. A maximum width
+	// of 0 or less means there is no limit.
+	PreferredWidth(maxWidth int) int
+
+	// PreferredHeight returns the height the primitive prefers if it is given
+	// the provided width.
+	PreferredHeight(width int) int
+}
+
+// AutoSize may be provided as the fixed size of a Flex item or as the size of
+// a Grid row or column. The size is then determined by the preferred size of
+// the primitives (see PreferredSize). Primitives which do not implement
+// PreferredSize are given a proportional size instead.
+const AutoSize = math.MinInt32
+
+// preferredWidth returns the preferred width of the primitive and whether or
+// not it implements PreferredSize.
+func preferredWidth(p Primitive, maxWidth int) (int, bool) {
+	preferred, ok := p.(PreferredSize)
+	if !ok {
+		return 0, false
+	}
+	return preferred.PreferredWidth(maxWidth), true
+}
+
+// preferredHeight returns the preferred height of the primitive and whether
+// or not it implements PreferredSize.
+func preferredHeight(p Primitive, width int) (int, bool) {
+	preferred, ok := p.(PreferredSize)
+	if !ok {
+		return 0, false
+	}
+	return preferred.PreferredHeight(width), true
+}
+
+// childPrimitives returns the primitives contained in the provided container
+// primitive, nil if it is not a container known to this package. Hidden panels
+// of Panels are not included.
+func childPrimitives(p Primitive) (children []Primitive) {
+	switch c := p.(type) {
+	case *Flex:
+		c.RLock()
+		defer c.RUnlock()
+		for _, item := range c.items {
+			children = append(children, item.Item)
+		}
+	case *Grid:
+		c.RLock()
+		defer c.RUnlock()
+		for _, item := range c.items {
+			children = append(children, item.Item)
+		}
+	case *Panels:
+		c.RLock()
+		defer c.RUnlock()
+		for _, panel := range c.panels {
+			if panel.Visible {
+				children = append(children, panel.Item)
+			}
+		}
+	case *TabbedPanels:
+		children = append(children, c.panels)
+	case *Frame:
+		c.RLock()
+		defer c.RUnlock()
+		children = append(children, c.primitive)
+	case *WindowManager:
+		c.RLock()
+		defer c.RUnlock()
+		for _, w := range c.windows {
+			children = append(children, w)
+		}
+	case *Window:
+		c.RLock()
+		defer c.RUnlock()
+		children = append(children, c.primitive)
+	}
+	return
+}
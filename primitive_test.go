@@ -0,0 +1,164 @@
+package nuview
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// counter is a custom primitive built like the template in demos/primitive. It
+// embeds Box, guards its state with a sync.RWMutex, draws through the helpers
+// for custom primitives and wraps its input and mouse handlers.
+type counter struct {
+	*Box
+
+	// The current value.
+	value int
+
+	// The styles of the value.
+	style WidgetStyle
+
+	sync.RWMutex
+}
+
+// newCounter returns a new counter primitive.
+func newCounter() *counter {
+	c := &counter{
+		Box: NewBox(),
+		style: WidgetStyle{
+			Normal:  tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+			Focused: tcell.StyleDefault.Foreground(Styles.InverseTextColor).Background(Styles.PrimaryTextColor),
+		},
+	}
+	return c
+}
+
+// GetValue returns the current value.
+func (c *counter) GetValue() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.value
+}
+
+// Draw draws this primitive onto the screen.
+func (c *counter) Draw(screen tcell.Screen) {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.Box.Draw(screen)
+	hasFocus := c.GetFocusable().HasFocus()
+
+	c.RLock()
+	defer c.RUnlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if height <= 0 {
+		return
+	}
+	style := c.style.Style(hasFocus, false, false)
+	FillRect(screen, x, y, width, 1, ' ', style)
+	PrintWithStyle(screen, "[::b]"+strconv.Itoa(c.value), x, y, 0, width, AlignCenter, style, false, 0)
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *counter) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		c.Lock()
+		defer c.Unlock()
+
+		if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+			c.value++
+		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+			c.value--
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *counter) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !c.InRect(event.Position()) {
+			return false, nil
+		}
+
+		c.Lock()
+		defer c.Unlock()
+
+		switch action {
+		case MouseLeftClick:
+			setFocus(c)
+			consumed = true
+		case MouseScrollUp:
+			c.value++
+			consumed = true
+		case MouseScrollDown:
+			c.value--
+			consumed = true
+		}
+		return
+	})
+}
+
+func TestCustomPrimitive(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 10)
+
+	c := newCounter()
+	c.SetBorder(true)
+	c.SetRect(0, 0, 10, 3)
+
+	// Input
+
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), func(p Primitive) {})
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), func(p Primitive) {})
+	c.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(p Primitive) {})
+	if value := c.GetValue(); value != 1 {
+		t.Errorf("failed to handle keys: expected 1, got %d", value)
+	}
+
+	var focused Primitive
+	consumed, _ := c.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(4, 1, tcell.Button1, tcell.ModNone), func(p Primitive) { focused = p })
+	if !consumed || focused != c {
+		t.Error("failed to handle mouse: expected click to focus the primitive")
+	}
+
+	// Draw
+
+	c.Draw(sc)
+	if r, _, style, _ := sc.GetContent(5, 1); r != '1' {
+		t.Errorf("failed to draw value: expected '1', got %q", r)
+	} else if _, _, attributes := style.Decompose(); attributes&tcell.AttrBold == 0 {
+		t.Error("failed to draw value: expected color tags to be rendered")
+	} else if style.Underline(false) != c.style.Normal.Bold(true) {
+		t.Error("failed to draw value: expected normal style when not focused")
+	}
+	if r, _, _, _ := sc.GetContent(0, 0); r != Borders.TopLeft {
+		t.Errorf("failed to draw border: expected %q, got %q", Borders.TopLeft, r)
+	}
+
+	c.Focus(nil)
+	if !AnyHasFocus(nil, NewBox(), c) {
+		t.Error("failed to report focus: expected primitive to have focus")
+	}
+	c.Draw(sc)
+	if _, _, style, _ := sc.GetContent(5, 1); style.Underline(false) != c.style.Focused.Bold(true) {
+		t.Error("failed to draw value: expected focused style")
+	}
+
+	// Clipping
+
+	c.SetRect(10, 0, 10, 3)
+	c.Draw(NewClippedScreen(sc, 10, 0, 10, 1))
+	if r, _, _, _ := sc.GetContent(15, 1); r == '1' {
+		t.Error("failed to clip primitive: expected value to be discarded")
+	}
+}
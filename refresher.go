@@ -0,0 +1,297 @@
+package nuview
+
+import (
+	"sync"
+	"time"
+)
+
+// loadingView is a primitive which shows a loading spinner or an error message
+// in place of its content, i.e. a List, Table, TreeView or TextView.
+type loadingView interface {
+	SetLoading(loading bool)
+	SetError(text string)
+}
+
+// Refresher keeps the content of a primitive up to date by calling a load
+// function periodically, e.g. to poll a server. The load function runs in its
+// own goroutine. Its result is delivered to an apply function which runs in
+// the application's event loop (see Application.QueueUpdateDraw()) and may
+// therefore modify primitives directly:
+//
+//	refresher := NewRefresher(app, fetchJobs, func(jobs []Job) {
+//		table.Clear()
+//		for row, job := range jobs {
+//			table.SetCellSimple(row, 0, job.Name)
+//		}
+//	})
+//	refresher.SetView(table)
+//	refresher.SetInterval(5 * time.Second)
+//	refresher.Start()
+//
+// Only one load runs at a time. The interval is measured from the end of one
+// load to the start of the next.
+//
+// A List, Table, TreeView or TextView provided with SetView() shows a loading
+// spinner until the first result is delivered (see Table.SetLoading()) and the
+// error message in place of its content while the most recent load failed (see
+// Table.SetError()), unless an error handler was set with SetErrorFunc().
+//
+// A refresher does not need to load while its primitive is hidden. It may be
+// paused with SetPaused(), e.g. when the primitive's panel is hidden:
+//
+//	panels.SetVisibilityFunc(func(name string, item Primitive, visible bool) {
+//		if name == "jobs" {
+//			refresher.SetPaused(!visible)
+//		}
+//	})
+type Refresher[T any] struct {
+	// The application whose event loop receives the results.
+	app *Application
+
+	// The function which loads the content.
+	load func() (T, error)
+
+	// The function which displays the content.
+	apply func(result T)
+
+	// An optional function which is called when loading failed.
+	errorFunc func(err error)
+
+	// An optional primitive which shows the loading and error states.
+	view loadingView
+
+	// The time between the end of a load and the start of the next one, 0 to
+	// load on demand only.
+	interval time.Duration
+
+	// The timer which starts the next load.
+	timer *time.Timer
+
+	// Whether or not the refresher was started and is paused.
+	running, paused bool
+
+	// Whether or not a load is in progress.
+	loading bool
+
+	// Whether or not another load is due, because it was requested while
+	// loading or because the interval passed while paused.
+	due bool
+
+	// Whether or not a result was delivered since the refresher was started.
+	loaded bool
+
+	// Incremented when the refresher is stopped, to discard the results of
+	// loads which were started before.
+	generation int
+
+	sync.RWMutex
+}
+
+// NewRefresher returns a new refresher which delivers the results of the
+// provided load function to the apply function in the event loop of the
+// application. The refresher loads when Refresh() is called and, once it was
+// started with Start(), periodically (see SetInterval()).
+func NewRefresher[T any](app *Application, load func() (T, error), apply func(result T)) *Refresher[T] {
+	return &Refresher[T]{
+		app:   app,
+		load:  load,
+		apply: apply,
+	}
+}
+
+// SetInterval sets the time between the end of one load and the start of the
+// next one while the refresher is running. A value of 0, the default, loads
+// only when Refresh() is called.
+func (r *Refresher[T]) SetInterval(interval time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.interval = interval
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.schedule()
+}
+
+// SetView sets the primitive which shows the loading and error states, a List,
+// Table, TreeView or TextView. Other primitives are ignored. Provide nil for
+// none, which is the default.
+func (r *Refresher[T]) SetView(view Primitive) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.view, _ = view.(loadingView)
+}
+
+// SetErrorFunc sets a handler which is called in the application's event loop
+// when loading failed. It is called instead of showing the error in the
+// primitive set with SetView(). Provide nil to show the error again.
+func (r *Refresher[T]) SetErrorFunc(handler func(err error)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.errorFunc = handler
+}
+
+// Start starts loading periodically, beginning with a load right away.
+func (r *Refresher[T]) Start() {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.running {
+		return
+	}
+	r.running, r.loaded = true, false
+	r.startLoad()
+}
+
+// Stop stops loading periodically. The result of a load which is in progress
+// is discarded.
+func (r *Refresher[T]) Stop() {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.running {
+		return
+	}
+	r.running, r.due = false, false
+	r.generation++
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// IsRunning returns whether or not the refresher was started and not stopped
+// since.
+func (r *Refresher[T]) IsRunning() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.running
+}
+
+// Refresh loads right away, even when the refresher is paused or not running.
+// If a load is in progress, another one follows it.
+func (r *Refresher[T]) Refresh() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.startLoad()
+}
+
+// SetPaused sets whether or not periodic loading is paused. When it is resumed
+// and a load became due in the meantime, it loads right away.
+func (r *Refresher[T]) SetPaused(paused bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.paused = paused
+	if !paused && r.due && !r.loading {
+		r.startLoad()
+	}
+}
+
+// IsPaused returns whether or not periodic loading is paused.
+func (r *Refresher[T]) IsPaused() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.paused
+}
+
+// IsLoading returns whether or not a load is in progress.
+func (r *Refresher[T]) IsLoading() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.loading
+}
+
+// schedule starts the timer for the next load if the refresher is running
+// periodically. The refresher must be locked.
+func (r *Refresher[T]) schedule() {
+	if !r.running || r.interval <= 0 || r.loading {
+		return
+	}
+	r.timer = time.AfterFunc(r.interval, func() {
+		r.Lock()
+		defer r.Unlock()
+
+		r.timer = nil
+		if !r.running {
+			return
+		}
+		if r.paused {
+			r.due = true
+			return
+		}
+		r.startLoad()
+	})
+}
+
+// startLoad starts a load in a new goroutine. If a load is in progress, it
+// marks another load as due instead. The refresher must be locked.
+func (r *Refresher[T]) startLoad() {
+	if r.loading {
+		r.due = true
+		return
+	}
+	r.loading, r.due = true, false
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	go r.run(r.generation, !r.loaded)
+}
+
+// run calls the load function and delivers its result in the event loop. The
+// spinner of the view is shown while loading if showLoading is true.
+func (r *Refresher[T]) run(generation int, showLoading bool) {
+	r.RLock()
+	view := r.view
+	r.RUnlock()
+	if showLoading && view != nil {
+		r.app.QueueUpdateDraw(func() {
+			view.SetLoading(true)
+		})
+	}
+
+	result, err := r.load()
+
+	r.app.QueueUpdateDraw(func() {
+		r.Lock()
+		r.loading = false
+		current := generation == r.generation
+		if current && err == nil {
+			r.loaded = true
+		}
+		view, errorFunc, apply := r.view, r.errorFunc, r.apply
+		if r.due {
+			r.startLoad()
+		} else {
+			r.schedule()
+		}
+		r.Unlock()
+
+		if view != nil {
+			view.SetLoading(false)
+		}
+		if !current {
+			return
+		}
+		if err != nil {
+			if errorFunc != nil {
+				errorFunc(err)
+			} else if view != nil {
+				view.SetError(err.Error())
+			}
+			return
+		}
+		if view != nil {
+			view.SetError("")
+		}
+		apply(result)
+	})
+}
@@ -0,0 +1,97 @@
+package nuview
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefresher(t *testing.T) {
+	t.Parallel()
+
+	textView := NewTextView()
+	app := NewApplication()
+	app.SetRoot(textView, true)
+	h := NewHeadless(app, 20, 3)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	type result struct {
+		text string
+		err  error
+	}
+	results := make(chan result)
+	var loads atomic.Int32
+	r := NewRefresher(app, func() (string, error) {
+		loads.Add(1)
+		res := <-results
+		return res.text, res.err
+	}, func(text string) {
+		textView.SetText(text)
+	})
+	r.SetView(textView)
+
+	waitFor := func(what string, condition func() bool) {
+		t.Helper()
+		for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(time.Millisecond) {
+			h.Sync()
+			if condition() {
+				return
+			}
+		}
+		t.Fatalf("failed to %s", what)
+	}
+
+	r.Start()
+	waitFor("show loading spinner", textView.GetLoading)
+	results <- result{text: "one"}
+	waitFor("apply result", func() bool {
+		return textView.GetText(false) == "one" && !textView.GetLoading() && !r.IsLoading()
+	})
+
+	r.Refresh()
+	results <- result{err: errors.New("failed")}
+	waitFor("show error", func() bool {
+		return textView.GetError() == "failed"
+	})
+	if textView.GetLoading() {
+		t.Error("failed to refresh without spinner: spinner shown after first result")
+	}
+
+	r.Refresh()
+	results <- result{text: "two"}
+	waitFor("clear error", func() bool {
+		return textView.GetText(false) == "two" && textView.GetError() == ""
+	})
+
+	// No loads while paused, a due load follows when resumed.
+	r.SetPaused(true)
+	r.SetInterval(time.Millisecond)
+	count := loads.Load()
+	time.Sleep(20 * time.Millisecond)
+	if n := loads.Load(); n != count {
+		t.Errorf("failed to pause: expected %d loads, got %d", count, n)
+	}
+	r.SetPaused(false)
+	waitFor("resume", r.IsLoading)
+	r.SetInterval(0)
+	results <- result{text: "three"}
+	waitFor("apply result after resuming", func() bool {
+		return textView.GetText(false) == "three"
+	})
+
+	// The result of a load in progress is discarded when stopped.
+	r.Refresh()
+	waitFor("start loading", r.IsLoading)
+	r.Stop()
+	results <- result{text: "four"}
+	waitFor("finish loading", func() bool {
+		return !r.IsLoading()
+	})
+	if text := textView.GetText(false); text != "three" {
+		t.Errorf("failed to discard result: expected three, got %s", text)
+	}
+}
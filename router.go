@@ -0,0 +1,445 @@
+package nuview
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// RouteParams holds the parameters of a route. These are the values of
+// placeholder segments (e.g. "id" for the pattern "users/:id") as well as any
+// query parameters (e.g. "tab" for the route "settings?tab=network").
+type RouteParams map[string]string
+
+// route represents a single route of a Router.
+type route struct {
+	Pattern  []string                           // The segments of the route pattern.
+	Factory  func(params RouteParams) Primitive // Creates the route's primitive.
+	Wildcard bool                               // Whether or not the last segment matches the rest of the path.
+}
+
+// Router is a container which maps string routes such as "settings/network"
+// to functions creating the primitive to be shown for that route. Only the
+// primitive of the current route is shown. The router keeps a history of
+// visited routes which may be navigated with Back() and Forward().
+//
+// Route patterns consist of segments separated by "/". A segment starting
+// with ":" is a placeholder matching any single segment. A final segment "*"
+// matches the remainder of the path, which is provided as the parameter "*".
+// For example:
+//
+//	router.AddRoute("users/:id", func(params RouteParams) Primitive {
+//		return newUserView(params["id"])
+//	})
+//	router.Navigate("users/42?tab=history")
+type Router struct {
+	*Box
+
+	// The registered routes in the order they were added.
+	routes []*route
+
+	// The visited paths and the index of the current one.
+	history      []string
+	historyIndex int
+
+	// The primitive of the current route.
+	current Primitive
+
+	// The maximum number of history entries. A value of 0 means unlimited.
+	maxHistory int
+
+	// We keep a reference to the function which allows us to set the focus to
+	// the primitive of a newly visited route.
+	setFocus func(p Primitive)
+
+	// An optional handler which is called before the route changes. Returning
+	// false cancels the navigation.
+	beforeChange func(from, to string) bool
+
+	// An optional handler which is called after the route changed.
+	changed func(path string, params RouteParams, item Primitive)
+
+	// An optional handler which is called when no route matches a path.
+	notFound func(path string) Primitive
+
+	sync.RWMutex
+}
+
+// NewRouter returns a new Router without any routes.
+func NewRouter() *Router {
+	r := &Router{
+		Box:          NewBox(),
+		historyIndex: -1,
+	}
+	r.focus = r
+	return r
+}
+
+// AddRoute registers a function which creates the primitive shown for paths
+// matching the provided pattern. See Router for the pattern syntax. If a path
+// matches multiple routes, the route added first is used. The factory is
+// called each time the route is visited.
+func (r *Router) AddRoute(pattern string, factory func(params RouteParams) Primitive) {
+	r.Lock()
+	defer r.Unlock()
+
+	segments := splitRoutePath(pattern)
+	rt := &route{Pattern: segments, Factory: factory}
+	if len(segments) > 0 && segments[len(segments)-1] == "*" {
+		rt.Pattern = segments[:len(segments)-1]
+		rt.Wildcard = true
+	}
+	r.routes = append(r.routes, rt)
+}
+
+// SetNotFoundFunc sets a handler which creates the primitive shown when
+// navigating to a path which matches no route. If no handler is set, such
+// navigation fails.
+func (r *Router) SetNotFoundFunc(handler func(path string) Primitive) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.notFound = handler
+}
+
+// SetBeforeChangeFunc sets a handler which is called before the current route
+// changes. It receives the current path (empty if there is none) and the path
+// being navigated to. Returning false cancels the navigation.
+func (r *Router) SetBeforeChangeFunc(handler func(from, to string) bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.beforeChange = handler
+}
+
+// SetChangedFunc sets a handler which is called after the current route
+// changed. It receives the new path, its parameters and the primitive created
+// for it. This can be used to redraw the application.
+func (r *Router) SetChangedFunc(handler func(path string, params RouteParams, item Primitive)) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.changed = handler
+}
+
+// SetMaxHistory sets the maximum number of history entries. The oldest
+// entries are discarded first. A value of 0 (the default) means unlimited.
+func (r *Router) SetMaxHistory(max int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.maxHistory = max
+	r.trimHistory()
+}
+
+// Navigate shows the primitive for the provided path, which may contain query
+// parameters. Any forward history is discarded. Returns false if no route
+// matches the path (and no "not found" handler is set) or the navigation was
+// cancelled.
+func (r *Router) Navigate(path string) bool {
+	if !r.show(path) {
+		return false
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.history = append(r.history[:r.historyIndex+1], path)
+	r.historyIndex = len(r.history) - 1
+	r.trimHistory()
+	return true
+}
+
+// Replace works like Navigate() but replaces the current history entry
+// instead of adding a new one.
+func (r *Router) Replace(path string) bool {
+	if !r.show(path) {
+		return false
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if r.historyIndex < 0 {
+		r.history = []string{path}
+		r.historyIndex = 0
+	} else {
+		r.history[r.historyIndex] = path
+	}
+	return true
+}
+
+// Back navigates to the previous entry of the history. Returns false if there
+// is no previous entry or the navigation was cancelled.
+func (r *Router) Back() bool {
+	return r.step(-1)
+}
+
+// Forward navigates to the next entry of the history. Returns false if there
+// is no next entry or the navigation was cancelled.
+func (r *Router) Forward() bool {
+	return r.step(1)
+}
+
+// CanGoBack returns whether or not there is a previous history entry.
+func (r *Router) CanGoBack() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.historyIndex > 0
+}
+
+// CanGoForward returns whether or not there is a next history entry.
+func (r *Router) CanGoForward() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.historyIndex < len(r.history)-1
+}
+
+// GetHistory returns the visited paths, oldest first, and the index of the
+// current path. The index is -1 if no path was visited yet.
+func (r *Router) GetHistory() (history []string, index int) {
+	r.RLock()
+	defer r.RUnlock()
+
+	history = make([]string, len(r.history))
+	copy(history, r.history)
+	return history, r.historyIndex
+}
+
+// GetCurrentRoute returns the current path and its primitive. If no path was
+// visited yet, ("", nil) is returned.
+func (r *Router) GetCurrentRoute() (path string, item Primitive) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.historyIndex < 0 {
+		return "", nil
+	}
+	return r.history[r.historyIndex], r.current
+}
+
+// step moves through the history by the provided number of entries.
+func (r *Router) step(delta int) bool {
+	r.RLock()
+	index := r.historyIndex + delta
+	if index < 0 || index >= len(r.history) {
+		r.RUnlock()
+		return false
+	}
+	path := r.history[index]
+	r.RUnlock()
+
+	if !r.show(path) {
+		return false
+	}
+
+	r.Lock()
+	r.historyIndex = index
+	r.Unlock()
+	return true
+}
+
+// trimHistory discards the oldest history entries exceeding the maximum.
+func (r *Router) trimHistory() {
+	if r.maxHistory <= 0 || len(r.history) <= r.maxHistory {
+		return
+	}
+	excess := len(r.history) - r.maxHistory
+	r.history = r.history[excess:]
+	r.historyIndex -= excess
+	if r.historyIndex < 0 {
+		r.historyIndex = 0
+	}
+}
+
+// show creates and shows the primitive for the provided path without
+// modifying the history.
+func (r *Router) show(path string) bool {
+	hasFocus := r.HasFocus()
+
+	r.RLock()
+	from := ""
+	if r.historyIndex >= 0 {
+		from = r.history[r.historyIndex]
+	}
+	beforeChange := r.beforeChange
+	r.RUnlock()
+
+	if beforeChange != nil && !beforeChange(from, path) {
+		return false
+	}
+
+	r.RLock()
+	factory, params := r.match(path)
+	notFound := r.notFound
+	r.RUnlock()
+
+	var item Primitive
+	if factory != nil {
+		item = factory(params)
+	} else if notFound != nil {
+		item = notFound(path)
+	}
+	if item == nil {
+		return false
+	}
+
+	r.Lock()
+	r.current = item
+	changed := r.changed
+	setFocus := r.setFocus
+	r.Unlock()
+
+	if hasFocus && setFocus != nil {
+		setFocus(item)
+	}
+	if changed != nil {
+		changed(path, params, item)
+	}
+	return true
+}
+
+// match returns the factory and parameters of the first route matching the
+// provided path.
+func (r *Router) match(path string) (func(params RouteParams) Primitive, RouteParams) {
+	params := make(RouteParams)
+	if index := strings.IndexRune(path, '?'); index >= 0 {
+		if query, err := url.ParseQuery(path[index+1:]); err == nil {
+			for key, values := range query {
+				if len(values) > 0 {
+					params[key] = values[0]
+				}
+			}
+		}
+		path = path[:index]
+	}
+	segments := splitRoutePath(path)
+
+RouteLoop:
+	for _, rt := range r.routes {
+		if len(segments) < len(rt.Pattern) || (!rt.Wildcard && len(segments) != len(rt.Pattern)) {
+			continue
+		}
+		matched := make(RouteParams)
+		for index, pattern := range rt.Pattern {
+			if strings.HasPrefix(pattern, ":") {
+				matched[pattern[1:]] = segments[index]
+			} else if pattern != segments[index] {
+				continue RouteLoop
+			}
+		}
+		if rt.Wildcard {
+			matched["*"] = strings.Join(segments[len(rt.Pattern):], "/")
+		}
+		for key, value := range matched {
+			params[key] = value
+		}
+		return rt.Factory, params
+	}
+	return nil, params
+}
+
+// splitRoutePath splits a path into its non-empty segments.
+func splitRoutePath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (r *Router) HasFocus() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.current == nil {
+		return r.hasFocus
+	}
+	return r.current.GetFocusable().HasFocus()
+}
+
+// Focus is called by the application when the primitive receives focus.
+func (r *Router) Focus(delegate func(p Primitive)) {
+	r.Lock()
+	defer r.Unlock()
+
+	if delegate == nil {
+		return // We cannot delegate so we cannot focus.
+	}
+	r.setFocus = delegate
+	if r.current != nil {
+		current := r.current
+		r.Unlock()
+		delegate(current)
+		r.Lock()
+	} else {
+		r.hasFocus = true
+	}
+}
+
+// Blur is called by the application when the primitive loses focus.
+func (r *Router) Blur() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.hasFocus = false
+}
+
+// Draw draws this primitive onto the screen.
+func (r *Router) Draw(screen tcell.Screen) {
+	if !r.GetVisible() {
+		return
+	}
+
+	r.Box.Draw(screen)
+
+	r.Lock()
+	current := r.current
+	r.Unlock()
+
+	if current == nil {
+		return
+	}
+	current.SetRect(r.GetInnerRect())
+	drawPrimitive(screen, current)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (r *Router) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return r.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !r.InRect(event.Position()) {
+			return false, nil
+		}
+
+		r.RLock()
+		current := r.current
+		r.RUnlock()
+
+		if current != nil {
+			return current.MouseHandler()(action, event, setFocus)
+		}
+		return
+	})
+}
+
+// InputHandler returns the handler for this primitive.
+func (r *Router) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return r.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		r.RLock()
+		current := r.current
+		r.RUnlock()
+
+		if current != nil && current.GetFocusable().HasFocus() {
+			if handler := current.InputHandler(); handler != nil {
+				handler(event, setFocus)
+			}
+		}
+	})
+}
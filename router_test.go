@@ -0,0 +1,81 @@
+package nuview
+
+import (
+	"testing"
+)
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	r := NewRouter()
+
+	var lastParams RouteParams
+	r.AddRoute("settings/network", func(params RouteParams) Primitive {
+		lastParams = params
+		return NewBox()
+	})
+	r.AddRoute("users/:id", func(params RouteParams) Primitive {
+		lastParams = params
+		return NewBox()
+	})
+	r.AddRoute("files/*", func(params RouteParams) Primitive {
+		lastParams = params
+		return NewBox()
+	})
+
+	if r.Navigate("unknown") {
+		t.Errorf("failed to navigate Router: unknown route matched")
+	}
+
+	if !r.Navigate("settings/network") {
+		t.Errorf("failed to navigate Router: settings/network did not match")
+	}
+
+	if !r.Navigate("users/42?tab=history") {
+		t.Errorf("failed to navigate Router: users/42 did not match")
+	} else if lastParams["id"] != "42" || lastParams["tab"] != "history" {
+		t.Errorf("failed to navigate Router: unexpected parameters %v", lastParams)
+	}
+
+	if !r.Navigate("files/a/b.txt") {
+		t.Errorf("failed to navigate Router: files/a/b.txt did not match")
+	} else if lastParams["*"] != "a/b.txt" {
+		t.Errorf("failed to navigate Router: expected wildcard a/b.txt, got %s", lastParams["*"])
+	}
+
+	// Back and forward
+
+	if !r.Back() || !r.Back() {
+		t.Errorf("failed to go back in Router history")
+	}
+	if path, _ := r.GetCurrentRoute(); path != "settings/network" {
+		t.Errorf("failed to go back in Router history: expected settings/network, got %s", path)
+	}
+	if r.Back() {
+		t.Errorf("failed to go back in Router history: went past first entry")
+	}
+	if !r.Forward() {
+		t.Errorf("failed to go forward in Router history")
+	} else if lastParams["id"] != "42" {
+		t.Errorf("failed to go forward in Router history: unexpected parameters %v", lastParams)
+	}
+
+	// Navigating discards the forward history
+
+	r.Navigate("settings/network")
+	if r.CanGoForward() {
+		t.Errorf("failed to navigate Router: forward history was not discarded")
+	}
+	if history, index := r.GetHistory(); len(history) != 3 || index != 2 {
+		t.Errorf("failed to navigate Router: unexpected history %v at %d", history, index)
+	}
+
+	// Cancel navigation
+
+	r.SetBeforeChangeFunc(func(from, to string) bool {
+		return to != "users/1"
+	})
+	if r.Navigate("users/1") {
+		t.Errorf("failed to cancel Router navigation")
+	}
+}
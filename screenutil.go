@@ -1,7 +1,15 @@
 package nuview
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
 
+// ScreenWriter is the drawing surface used by the print functions of this
+// package (e.g. Print, PrintStyle) and by components such as ScrollBar. A
+// tcell.Screen is a ScreenWriter, as are the clipping and translating writers
+// below, so the same drawing code works on the screen as well as inside a
+// clipped region.
 type ScreenWriter interface {
 	GetContent(x, y int) (primary rune, combining []rune, style tcell.Style, width int)
 	SetContent(x int, y int, primary rune, combining []rune, style tcell.Style)
@@ -9,6 +17,8 @@ type ScreenWriter interface {
 	Fill(rune, tcell.Style)
 }
 
+// TranslateScreenWriter is a ScreenWriter which can derive further writers
+// which are clipped or translated relative to it.
 type TranslateScreenWriter interface {
 	ScreenWriter
 	AbsolutePosition(x int, y int) (absX int, absY int)
@@ -16,12 +26,60 @@ type TranslateScreenWriter interface {
 	NewTranslate(tx int, ty int) TranslateScreenWriter
 }
 
+// FillRect fills the provided rectangle with the provided rune and style.
+func FillRect(screen ScreenWriter, x, y, width, height int, r rune, style tcell.Style) {
+	for row := y; row < y+height; row++ {
+		for column := x; column < x+width; column++ {
+			screen.SetContent(column, row, r, nil, style)
+		}
+	}
+}
+
+// DrawBorder draws a single line border along the edges of the provided
+// rectangle using the runes of the Borders variable, or of the application
+// drawing on the screen (see Application.SetBorders()). If focused is true, the
+// runes for focused borders are used. Nothing is drawn if the rectangle is
+// smaller than 2x2 cells.
+func DrawBorder(screen ScreenWriter, x, y, width, height int, style tcell.Style, focused bool) {
+	if width < 2 || height < 2 {
+		return
+	}
+
+	borders := applicationOf(screen).borderSet()
+	horizontal, vertical := borders.Horizontal, borders.Vertical
+	topLeft, topRight := borders.TopLeft, borders.TopRight
+	bottomLeft, bottomRight := borders.BottomLeft, borders.BottomRight
+	if focused {
+		horizontal, vertical = borders.HorizontalFocus, borders.VerticalFocus
+		topLeft, topRight = borders.TopLeftFocus, borders.TopRightFocus
+		bottomLeft, bottomRight = borders.BottomLeftFocus, borders.BottomRightFocus
+	}
+
+	for column := x + 1; column < x+width-1; column++ {
+		screen.SetContent(column, y, horizontal, nil, style)
+		screen.SetContent(column, y+height-1, horizontal, nil, style)
+	}
+	for row := y + 1; row < y+height-1; row++ {
+		screen.SetContent(x, row, vertical, nil, style)
+		screen.SetContent(x+width-1, row, vertical, nil, style)
+	}
+	screen.SetContent(x, y, topLeft, nil, style)
+	screen.SetContent(x+width-1, y, topRight, nil, style)
+	screen.SetContent(x, y+height-1, bottomLeft, nil, style)
+	screen.SetContent(x+width-1, y+height-1, bottomRight, nil, style)
+}
+
 // -------------------------------------------------------------------------
+
+// TranslateScreenWriterAdapter turns a ScreenWriter (e.g. a tcell.Screen) into
+// a TranslateScreenWriter using absolute coordinates.
 type TranslateScreenWriterAdapter struct {
-	screen tcell.Screen
+	screen ScreenWriter
 }
 
-func NewTranslateScreenWriterAdapter(screen tcell.Screen) *TranslateScreenWriterAdapter {
+// NewTranslateScreenWriterAdapter returns a new adapter for the provided
+// screen.
+func NewTranslateScreenWriterAdapter(screen ScreenWriter) *TranslateScreenWriterAdapter {
 	return &TranslateScreenWriterAdapter{screen: screen}
 }
 
@@ -90,10 +148,27 @@ func (c *ClippingScreenWriter) GetContent(x, y int) (primary rune, combining []r
 	return c.writer.GetContent(c.x+x+c.tx, c.y+y+c.ty)
 }
 
+// SetContent sets the content of a cell inside the clipping area. Content
+// outside of the area is discarded. A wide character (e.g. a CJK character)
+// which is cut in half by the edge of the area is not drawn, its half inside
+// the area is left blank instead.
 func (c *ClippingScreenWriter) SetContent(x int, y int, primary rune, combining []rune, style tcell.Style) {
 	transX := x + c.tx
 	transY := y + c.ty
-	if transX < 0 || transY < 0 || transX >= c.width || transY >= c.height {
+	if transY < 0 || transY >= c.height || c.width <= 0 {
+		return
+	}
+	if runewidth.RuneWidth(primary) == 2 {
+		switch transX {
+		case -1: // The right half is inside.
+			c.writer.SetContent(c.x, transY+c.y, ' ', nil, style)
+			return
+		case c.width - 1: // The left half is inside.
+			c.writer.SetContent(transX+c.x, transY+c.y, ' ', nil, style)
+			return
+		}
+	}
+	if transX < 0 || transX >= c.width {
 		return
 	}
 	c.writer.SetContent(transX+c.x, transY+c.y, primary, combining, style)
@@ -112,7 +187,7 @@ func (c *ClippingScreenWriter) Fill(r rune, style tcell.Style) {
 }
 
 func (c *ClippingScreenWriter) AbsolutePosition(x int, y int) (absX int, absY int) {
-	return x + c.x, y + c.y
+	return x + c.tx + c.x, y + c.ty + c.y
 }
 
 func (c *ClippingScreenWriter) NewClipXY(x int, y int) TranslateScreenWriter {
@@ -140,3 +215,173 @@ func (c *ClippingScreenWriter) NewTranslate(tx int, ty int) TranslateScreenWrite
 	}
 	return r
 }
+
+//-------------------------------------------------------------------------
+
+// ClippedScreen is a tcell.Screen which discards any content drawn outside of
+// a rectangle. Coordinates remain absolute. Container primitives pass it to
+// the Draw() method of their children so that children, including custom
+// primitives which draw onto the screen directly, cannot draw outside of the
+// area assigned to them. The cursor is hidden if it is placed outside of the
+// rectangle.
+type ClippedScreen struct {
+	tcell.Screen
+
+	// The clipping rectangle.
+	x, y, width, height int
+}
+
+// NewClippedScreen returns a screen which only draws inside the provided
+// rectangle. If the provided screen is itself a ClippedScreen, the resulting
+// rectangle is the intersection of both rectangles.
+func NewClippedScreen(screen tcell.Screen, x, y, width, height int) *ClippedScreen {
+	if clipped, ok := screen.(*ClippedScreen); ok {
+		right, bottom := min(x+width, clipped.x+clipped.width), min(y+height, clipped.y+clipped.height)
+		x, y = max(x, clipped.x), max(y, clipped.y)
+		width, height = right-x, bottom-y
+		screen = clipped.Screen
+	}
+	return &ClippedScreen{
+		Screen: screen,
+		x:      x,
+		y:      y,
+		width:  max(width, 0),
+		height: max(height, 0),
+	}
+}
+
+// UnclippedScreen returns the screen underlying the provided screen if it is a
+// ClippedScreen, or the provided screen itself otherwise. Primitives use it to
+// draw popups, such as the list of a DropDown, which may extend beyond the
+// area assigned to them.
+func UnclippedScreen(screen tcell.Screen) tcell.Screen {
+	if clipped, ok := screen.(*ClippedScreen); ok {
+		return clipped.Screen
+	}
+	return screen
+}
+
+// GetClipRect returns the clipping rectangle.
+func (c *ClippedScreen) GetClipRect() (x, y, width, height int) {
+	return c.x, c.y, c.width, c.height
+}
+
+// inside returns whether or not the provided position is inside the clipping
+// rectangle.
+func (c *ClippedScreen) inside(x, y int) bool {
+	return x >= c.x && x < c.x+c.width && y >= c.y && y < c.y+c.height
+}
+
+// clipWide returns the cell of a wide character (e.g. a CJK character) drawn
+// at the provided position which is cut in half by the edge of the clipping
+// rectangle. Its half inside the rectangle is to be left blank. It returns
+// false if the character is not cut in half.
+func (c *ClippedScreen) clipWide(x, y int, primary rune) (int, bool) {
+	if runewidth.RuneWidth(primary) != 2 || y < c.y || y >= c.y+c.height || c.width <= 0 {
+		return 0, false
+	}
+	switch x {
+	case c.x - 1: // The right half is inside.
+		return c.x, true
+	case c.x + c.width - 1: // The left half is inside.
+		return x, true
+	}
+	return 0, false
+}
+
+// SetContent sets the content of a cell inside the clipping rectangle.
+// Content outside of the rectangle is discarded. A wide character which is cut
+// in half by the edge of the rectangle is not drawn, its half inside the
+// rectangle is left blank instead.
+func (c *ClippedScreen) SetContent(x int, y int, primary rune, combining []rune, style tcell.Style) {
+	if blank, ok := c.clipWide(x, y, primary); ok {
+		c.Screen.SetContent(blank, y, ' ', nil, style)
+	} else if c.inside(x, y) {
+		c.Screen.SetContent(x, y, primary, combining, style)
+	}
+}
+
+// SetCell is an older API for SetContent.
+func (c *ClippedScreen) SetCell(x int, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		if blank, ok := c.clipWide(x, y, ch[0]); ok {
+			c.Screen.SetContent(blank, y, ' ', nil, style)
+			return
+		}
+	}
+	if c.inside(x, y) {
+		c.Screen.SetCell(x, y, style, ch...)
+	}
+}
+
+// Fill fills the clipping rectangle with the provided rune and style.
+func (c *ClippedScreen) Fill(r rune, style tcell.Style) {
+	for y := c.y; y < c.y+c.height; y++ {
+		for x := c.x; x < c.x+c.width; x++ {
+			c.Screen.SetContent(x, y, r, nil, style)
+		}
+	}
+}
+
+// Clear clears the clipping rectangle.
+func (c *ClippedScreen) Clear() {
+	c.Fill(' ', tcell.StyleDefault)
+}
+
+// ShowCursor shows the cursor at the provided position if it is inside the
+// clipping rectangle. Otherwise, the cursor is hidden.
+func (c *ClippedScreen) ShowCursor(x int, y int) {
+	if c.inside(x, y) {
+		c.Screen.ShowCursor(x, y)
+	} else {
+		c.Screen.HideCursor()
+	}
+}
+
+//-------------------------------------------------------------------------
+
+// cellBuffer is a ScreenWriter which draws into a grid of cells in memory.
+type cellBuffer struct {
+	cells [][]ImageCell
+}
+
+// newCellBuffer returns a new cell buffer of the provided size, filled with
+// spaces in the provided style.
+func newCellBuffer(width, height int, style tcell.Style) *cellBuffer {
+	b := &cellBuffer{cells: make([][]ImageCell, height)}
+	for y := range b.cells {
+		b.cells[y] = make([]ImageCell, width)
+	}
+	b.Fill(' ', style)
+	return b
+}
+
+func (b *cellBuffer) GetContent(x, y int) (primary rune, combining []rune, style tcell.Style, width int) {
+	if y < 0 || y >= len(b.cells) || x < 0 || x >= len(b.cells[y]) {
+		return ' ', nil, tcell.StyleDefault, 1
+	}
+	cell := b.cells[y][x]
+	return cell.Rune, nil, cell.Style, runewidth.RuneWidth(cell.Rune)
+}
+
+func (b *cellBuffer) SetContent(x int, y int, primary rune, combining []rune, style tcell.Style) {
+	if y < 0 || y >= len(b.cells) || x < 0 || x >= len(b.cells[y]) {
+		return
+	}
+	b.cells[y][x] = ImageCell{Rune: primary, Style: style}
+}
+
+func (b *cellBuffer) Size() (width, height int) {
+	if len(b.cells) == 0 {
+		return 0, 0
+	}
+	return len(b.cells[0]), len(b.cells)
+}
+
+func (b *cellBuffer) Fill(r rune, style tcell.Style) {
+	for _, row := range b.cells {
+		for x := range row {
+			row[x] = ImageCell{Rune: r, Style: style}
+		}
+	}
+}
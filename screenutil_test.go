@@ -0,0 +1,88 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestClippedScreen(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 10)
+
+	clipped := NewClippedScreen(NewClippedScreen(sc, 2, 2, 10, 5), 5, 0, 20, 20)
+	if x, y, width, height := clipped.GetClipRect(); x != 5 || y != 2 || width != 7 || height != 5 {
+		t.Errorf("failed to intersect clipping rectangles: expected 5,2,7,5, got %d,%d,%d,%d", x, y, width, height)
+	}
+	if UnclippedScreen(clipped) != sc {
+		t.Error("failed to return the unclipped screen")
+	}
+
+	Print(clipped, []byte("0123456789"), 0, 3, 20, AlignLeft, tcell.ColorWhite)
+	for x, expected := range "     56789          " {
+		if r, _, _, _ := sc.GetContent(x, 3); r != expected {
+			t.Errorf("failed to clip content at column %d: expected %q, got %q", x, expected, r)
+		}
+	}
+
+	b := NewBox()
+	b.SetRect(0, 0, 20, 10)
+	b.SetBorder(true)
+	b.Draw(NewClippedScreen(sc, 0, 0, 20, 1))
+	if r, _, _, _ := sc.GetContent(0, 9); r == Borders.BottomLeft {
+		t.Error("failed to clip box: expected bottom border to be discarded")
+	}
+}
+
+func TestWideCharacterClipping(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+	sc.Fill('x', tcell.StyleDefault)
+
+	// Wide characters cut by the edges of the clipping area leave a blank.
+	w := NewClippingScreenWriter(sc, 2, 0, 4, 1).NewTranslate(-1, 0)
+	for x := 0; x < 8; x += 2 {
+		w.SetContent(x, 0, '世', nil, tcell.StyleDefault)
+	}
+	for x, expected := range map[int]rune{1: 'x', 2: ' ', 3: '世', 5: ' ', 6: 'x'} {
+		if r, _, _, _ := sc.GetContent(x, 0); r != expected {
+			t.Errorf("failed to clip wide character at column %d: expected %q, got %q", x, expected, r)
+		}
+	}
+
+	// The same applies to clipped screens.
+	clipped := NewClippedScreen(sc, 1, 3, 3, 1)
+	Print(clipped, []byte("ab世"), 1, 3, 10, AlignLeft, tcell.ColorWhite)
+	for x, expected := range map[int]rune{0: 'x', 1: 'a', 2: 'b', 3: ' ', 4: 'x'} {
+		if r, _, _, _ := sc.GetContent(x, 3); r != expected {
+			t.Errorf("failed to clip wide character on clipped screen at column %d: expected %q, got %q", x, expected, r)
+		}
+	}
+	clipped.SetCell(0, 3, tcell.StyleDefault, '世')
+	if r, _, _, _ := sc.GetContent(1, 3); r != ' ' {
+		t.Errorf("failed to clip wide character at left edge of clipped screen: expected ' ', got %q", r)
+	}
+
+	// Printing never cuts a wide character in half.
+	PrintWithStyle(sc, "世界", 0, 1, 1, 10, AlignLeft, tcell.StyleDefault, false, 0)
+	if r, _, _, _ := sc.GetContent(0, 1); r != ' ' {
+		t.Errorf("failed to blank skipped half: got %q", r)
+	}
+	if r, _, _, _ := sc.GetContent(1, 1); r != '界' {
+		t.Errorf("failed to keep alignment after skipped half: expected '界', got %q", r)
+	}
+	PrintWithStyle(sc, "世界", 0, 2, 0, 3, AlignLeft, tcell.StyleDefault, false, 0)
+	if r, _, _, _ := sc.GetContent(2, 2); r != 'x' {
+		t.Errorf("failed to stop before wide character at right border: got %q", r)
+	}
+}
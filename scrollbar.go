@@ -0,0 +1,280 @@
+package nuview
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ScrollBar draws a vertical or horizontal scroll bar and handles mouse
+// interaction with it. It is not a primitive but a component of primitives
+// which scroll their content, such as List, TextView, Table and TreeView.
+//
+// Content is measured in items (e.g. lines, rows or columns of cells). The
+// scroll bar shows the position of the first visible item (the offset)
+// relative to the last possible offset. Clicking the scroll bar before or
+// after its handle scrolls by one page, dragging the handle scrolls to the
+// corresponding position.
+//
+// The scroll bar is drawn using ScrollBarArea, ScrollBarAreaFocused,
+// ScrollBarHandle and ScrollBarHandleFocused.
+type ScrollBar struct {
+	// Visibility of the scroll bar.
+	visibility ScrollBarVisibility
+
+	// The scroll bar color.
+	color tcell.Color
+
+	// Whether the scroll bar is horizontal rather than vertical.
+	horizontal bool
+
+	// The position and length of the scroll bar as of the last call to Draw().
+	// The length is 0 if the scroll bar was not drawn.
+	x, y, length int
+
+	// The content dimensions as of the last call to Draw().
+	items, pageSize, offset int
+
+	// Whether or not the handle is being dragged.
+	dragging bool
+
+	sync.RWMutex
+}
+
+// NewScrollBar returns a new scroll bar which is shown when there are items
+// offscreen.
+func NewScrollBar() *ScrollBar {
+	return &ScrollBar{
+		visibility: ScrollBarAuto,
+		color:      Styles.ScrollBarColor,
+	}
+}
+
+// NewHorizontalScrollBar returns a new horizontal scroll bar which is shown
+// when there are items offscreen.
+func NewHorizontalScrollBar() *ScrollBar {
+	s := NewScrollBar()
+	s.horizontal = true
+	return s
+}
+
+// SetVisibility specifies the display of the scroll bar.
+func (s *ScrollBar) SetVisibility(visibility ScrollBarVisibility) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.visibility = visibility
+}
+
+// GetVisibility returns the display setting of the scroll bar.
+func (s *ScrollBar) GetVisibility() ScrollBarVisibility {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.visibility
+}
+
+// SetColor sets the color of the scroll bar.
+func (s *ScrollBar) SetColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.color = color
+}
+
+// GetColor returns the color of the scroll bar.
+func (s *ScrollBar) GetColor() tcell.Color {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.color
+}
+
+// IsVisible returns whether or not the scroll bar is shown for content of the
+// provided number of items of which pageSize items fit on the screen.
+func (s *ScrollBar) IsVisible(items, pageSize int) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.isVisible(items, pageSize)
+}
+
+func (s *ScrollBar) isVisible(items, pageSize int) bool {
+	return s.visibility == ScrollBarAlways || (s.visibility == ScrollBarAuto && items > pageSize)
+}
+
+// Draw draws the scroll bar at the provided position if it is visible. A
+// vertical scroll bar extends downwards, a horizontal one to the right, over
+// the provided length. The content consists of the provided number of items
+// of which pageSize items fit on the screen, starting with the item at the
+// provided offset.
+func (s *ScrollBar) Draw(screen ScreenWriter, x, y, length, items, pageSize, offset int, focused bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.x, s.y, s.items, s.pageSize, s.offset = x, y, items, pageSize, offset
+	if length <= 0 || !s.isVisible(items, pageSize) {
+		s.length = 0
+		return
+	}
+	s.length = length
+
+	area, handle := ScrollBarArea, ScrollBarHandle
+	if focused {
+		area, handle = ScrollBarAreaFocused, ScrollBarHandleFocused
+	}
+	handlePosition := s.handlePosition()
+	for position := 0; position < length; position++ {
+		text := area
+		if position == handlePosition {
+			text = handle
+		}
+		if s.horizontal {
+			Print(screen, text, x+position, y, 1, AlignLeft, s.color)
+		} else {
+			Print(screen, text, x, y+position, 1, AlignLeft, s.color)
+		}
+	}
+}
+
+// handlePosition returns the position of the handle, relative to the start of
+// the scroll bar.
+func (s *ScrollBar) handlePosition() int {
+	maxOffset := s.items - s.pageSize
+	if maxOffset <= 0 || s.length <= 1 {
+		return 0
+	}
+	offset := s.offset
+	if offset < 0 {
+		offset = 0
+	} else if offset > maxOffset {
+		offset = maxOffset
+	}
+	return (s.length - 1) * offset / maxOffset
+}
+
+// offsetAt returns the offset corresponding to the handle being at the
+// provided position, relative to the start of the scroll bar.
+func (s *ScrollBar) offsetAt(position int) int {
+	maxOffset := s.items - s.pageSize
+	if maxOffset <= 0 || s.length <= 1 {
+		return 0
+	}
+	if position < 0 {
+		position = 0
+	} else if position > s.length-1 {
+		position = s.length - 1
+	}
+	return (position*maxOffset + (s.length-1)/2) / (s.length - 1)
+}
+
+// position returns the position of the provided screen coordinates along the
+// scroll bar, relative to its start, and whether or not they are on the
+// scroll bar as of the last call to Draw().
+func (s *ScrollBar) position(x, y int) (position int, inRect bool) {
+	along, across := y-s.y, x-s.x
+	if s.horizontal {
+		along, across = x-s.x, y-s.y
+	}
+	return along, s.length > 0 && across == 0 && along >= 0 && along < s.length
+}
+
+// clampOffset limits the provided offset to the valid range.
+func (s *ScrollBar) clampOffset(offset int) int {
+	if offset > s.items-s.pageSize {
+		offset = s.items - s.pageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// InRect returns whether or not the provided screen position is on the scroll
+// bar as of the last call to Draw().
+func (s *ScrollBar) InRect(x, y int) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	_, inRect := s.position(x, y)
+	return inRect
+}
+
+// HandleMouse processes a mouse action. If the action was consumed by the
+// scroll bar, the new offset of the content is returned along with
+// consumed=true. If capture is true, the primitive containing the scroll bar
+// should capture subsequent mouse events (see Primitive.MouseHandler()) so the
+// handle can be dragged outside of the scroll bar.
+func (s *ScrollBar) HandleMouse(action MouseAction, event *tcell.EventMouse) (offset int, consumed, capture bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	position, inRect := s.position(event.Position())
+	offset = s.offset
+	switch action {
+	case MouseLeftDown:
+		if !inRect {
+			return offset, false, false
+		}
+		switch handlePosition := s.handlePosition(); {
+		case position < handlePosition:
+			offset = s.clampOffset(s.offset - s.pageSize)
+		case position > handlePosition:
+			offset = s.clampOffset(s.offset + s.pageSize)
+		default:
+			s.dragging = true
+			capture = true
+		}
+		s.offset = offset
+		return offset, true, capture
+	case MouseMove:
+		if !s.dragging {
+			return offset, false, false
+		}
+		s.offset = s.offsetAt(position)
+		return s.offset, true, true
+	case MouseLeftUp:
+		if !s.dragging {
+			return offset, false, false
+		}
+		s.dragging = false
+		return offset, true, false
+	case MouseLeftClick, MouseLeftDoubleClick, MouseLeftTripleClick:
+		// Clicks on the scroll bar are handled by MouseLeftDown.
+		if inRect {
+			return offset, true, false
+		}
+	}
+	return offset, false, false
+}
+
+// scrollNotifier reports changes of the scroll position of a primitive to a
+// handler, see e.g. TextView.SetScrollChangedFunc().
+type scrollNotifier struct {
+	// The handler, nil for none.
+	handler func(firstVisible, total int)
+
+	// The position last reported, -1 if none was reported yet.
+	firstVisible, total int
+}
+
+// set sets the handler. It is called with the current position the next time
+// the primitive is drawn.
+func (n *scrollNotifier) set(handler func(firstVisible, total int)) {
+	n.handler = handler
+	n.firstVisible, n.total = -1, -1
+}
+
+// update records the scroll position of the primitive being drawn. If it
+// changed since it was last reported, a function calling the handler is
+// returned, nil otherwise. Primitives call it once they are unlocked.
+func (n *scrollNotifier) update(firstVisible, total int) func() {
+	if n.handler == nil || firstVisible == n.firstVisible && total == n.total {
+		return nil
+	}
+	n.firstVisible, n.total = firstVisible, total
+	handler := n.handler
+	return func() {
+		handler(firstVisible, total)
+	}
+}
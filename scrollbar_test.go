@@ -0,0 +1,52 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScrollBar(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 10)
+
+	s := NewScrollBar()
+	if s.IsVisible(10, 10) {
+		t.Error("failed to hide scroll bar: expected auto-hide when all items fit")
+	} else if !s.IsVisible(30, 10) {
+		t.Error("failed to show scroll bar: expected scroll bar when items are offscreen")
+	}
+
+	// 30 items, 10 visible, at the top.
+	s.Draw(sc, 9, 0, 10, 30, 10, 0, false)
+	if !s.InRect(9, 5) || s.InRect(8, 5) {
+		t.Error("failed to draw scroll bar at the expected position")
+	}
+
+	// Click below the handle to page down.
+	offset, consumed, capture := s.HandleMouse(MouseLeftDown, tcell.NewEventMouse(9, 5, tcell.ButtonPrimary, tcell.ModNone))
+	if !consumed || capture || offset != 10 {
+		t.Errorf("failed to page down: expected offset 10, got %d (consumed %v, capture %v)", offset, consumed, capture)
+	}
+
+	// Drag the handle to the bottom.
+	s.Draw(sc, 9, 0, 10, 30, 10, 0, false)
+	if _, consumed, capture = s.HandleMouse(MouseLeftDown, tcell.NewEventMouse(9, 0, tcell.ButtonPrimary, tcell.ModNone)); !consumed || !capture {
+		t.Fatal("failed to grab handle")
+	}
+	offset, consumed, _ = s.HandleMouse(MouseMove, tcell.NewEventMouse(3, 20, tcell.ButtonPrimary, tcell.ModNone))
+	if !consumed || offset != 20 {
+		t.Errorf("failed to drag handle: expected offset 20, got %d", offset)
+	}
+	if _, consumed, capture = s.HandleMouse(MouseLeftUp, tcell.NewEventMouse(3, 20, tcell.ButtonNone, tcell.ModNone)); !consumed || capture {
+		t.Error("failed to release handle")
+	}
+	if _, consumed, _ = s.HandleMouse(MouseMove, tcell.NewEventMouse(3, 5, tcell.ButtonNone, tcell.ModNone)); consumed {
+		t.Error("failed to stop dragging")
+	}
+}
@@ -0,0 +1,458 @@
+package nuview
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SelectionMode determines which items of a SelectionModel may be selected
+// at the same time.
+type SelectionMode int
+
+// Selection modes.
+const (
+	// At most one item is selected. The selection follows the current item of
+	// the primitive.
+	SelectionSingle SelectionMode = iota
+
+	// Any number of items are selected. Items are toggled individually and
+	// ranges of items are added by extending the selection.
+	SelectionMultiple
+
+	// A single contiguous range of items is selected.
+	SelectionRange
+)
+
+// SelectionModel holds the selected items of a List, Table or TreeView. The
+// same model type is used by all of these primitives so application code
+// handling selections works the same across them. Items are identified by
+// their index:
+//
+//   - List: the index of the list item.
+//   - Table: the index of the row. Rows must be selectable.
+//   - TreeView: the index of the node in a depth-first traversal of all nodes
+//     starting at the root (index 0), including the children of collapsed
+//     nodes.
+//
+// When a model is set on a primitive, Keys.ToggleSelection toggles the
+// current item and Keys.ExtendSelectionUp and Keys.ExtendSelectionDown move
+// the current item while extending the selection from its anchor. Clicking an
+// item with the Ctrl key held down toggles it, clicking it with the Shift key
+// held down extends the selection to it. The selected items are highlighted
+// like the current item.
+//
+// The selection may be saved and restored with MarshalText() and
+// UnmarshalText(), e.g. to persist it between sessions.
+type SelectionModel struct {
+	// The selection mode.
+	mode SelectionMode
+
+	// The selected items.
+	selected map[int]bool
+
+	// The item from which the selection is extended, -1 if there is none.
+	anchor int
+
+	// An optional function which is called when the selection changed.
+	changed func(selected []int)
+
+	sync.RWMutex
+}
+
+// NewSelectionModel returns a new, empty selection model.
+func NewSelectionModel(mode SelectionMode) *SelectionModel {
+	return &SelectionModel{
+		mode:     mode,
+		selected: make(map[int]bool),
+		anchor:   -1,
+	}
+}
+
+// SetMode sets the selection mode. If the current selection is not valid in
+// the new mode, it is reduced to the anchor item (or the first selected item).
+func (s *SelectionModel) SetMode(mode SelectionMode) {
+	s.Lock()
+	s.mode = mode
+	if s.isValid() {
+		s.Unlock()
+		return
+	}
+	keep := s.anchor
+	if !s.selected[keep] {
+		keep = s.sorted()[0]
+	}
+	s.selected = map[int]bool{keep: true}
+	s.notify()
+}
+
+// GetMode returns the selection mode.
+func (s *SelectionModel) GetMode() SelectionMode {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.mode
+}
+
+// SetChangedFunc sets a handler which is called whenever the selection
+// changes. The handler receives the selected items in ascending order. It is
+// called from the goroutine which changed the selection, e.g. the
+// application's event loop, without the model being locked.
+func (s *SelectionModel) SetChangedFunc(handler func(selected []int)) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.changed = handler
+}
+
+// IsSelected returns whether or not the item with the given index is
+// selected.
+func (s *SelectionModel) IsSelected(index int) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.selected[index]
+}
+
+// GetSelected returns the selected items in ascending order.
+func (s *SelectionModel) GetSelected() []int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.sorted()
+}
+
+// GetSelectedCount returns the number of selected items.
+func (s *SelectionModel) GetSelectedCount() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.selected)
+}
+
+// GetAnchor returns the item from which the selection is extended or -1 if
+// there is none.
+func (s *SelectionModel) GetAnchor() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.anchor
+}
+
+// Select selects the item with the given index and makes it the anchor. In
+// SelectionMultiple mode, the item is added to the selection. In the other
+// modes, it replaces the selection.
+func (s *SelectionModel) Select(index int) {
+	s.Lock()
+	if s.mode != SelectionMultiple {
+		s.selected = make(map[int]bool)
+	}
+	s.selected[index] = true
+	s.anchor = index
+	s.notify()
+}
+
+// Deselect deselects the item with the given index. In SelectionRange mode,
+// deselecting the first or last item of the range shrinks it, deselecting an
+// item inside the range clears the selection.
+func (s *SelectionModel) Deselect(index int) {
+	s.Lock()
+	if !s.selected[index] {
+		s.Unlock()
+		return
+	}
+	delete(s.selected, index)
+	if !s.isValid() {
+		s.selected = make(map[int]bool)
+	}
+	if !s.selected[s.anchor] {
+		s.anchor = -1
+	}
+	s.notify()
+}
+
+// Toggle selects the item with the given index if it is not selected and
+// deselects it otherwise.
+func (s *SelectionModel) Toggle(index int) {
+	if s.IsSelected(index) {
+		s.Deselect(index)
+	} else {
+		s.Select(index)
+	}
+}
+
+// SelectRange selects the items from one index to another (inclusive) and
+// makes the first one the anchor. In SelectionMultiple mode, the items are
+// added to the selection. In SelectionRange mode, they replace it. In
+// SelectionSingle mode, only the item at the "to" index is selected.
+func (s *SelectionModel) SelectRange(from, to int) {
+	s.Lock()
+	if s.mode == SelectionSingle {
+		s.selected = map[int]bool{to: true}
+		s.anchor = to
+		s.notify()
+		return
+	}
+	if s.mode == SelectionRange {
+		s.selected = make(map[int]bool)
+	}
+	s.addRange(from, to)
+	s.anchor = from
+	s.notify()
+}
+
+// ExtendTo replaces the selection with the items from the anchor to the given
+// index (inclusive). If there is no anchor, the given item becomes the anchor.
+// In SelectionSingle mode, only the given item is selected.
+func (s *SelectionModel) ExtendTo(index int) {
+	s.Lock()
+	if s.anchor < 0 || s.mode == SelectionSingle {
+		s.anchor = index
+	}
+	s.selected = make(map[int]bool)
+	s.addRange(s.anchor, index)
+	s.notify()
+}
+
+// SetSelected replaces the selection with the given items, e.g. to restore a
+// previously saved selection. The first item becomes the anchor. In
+// SelectionSingle mode, only the first item is selected. In SelectionRange
+// mode, all items between the smallest and the largest one are selected.
+func (s *SelectionModel) SetSelected(indices []int) {
+	s.Lock()
+	s.selected = make(map[int]bool)
+	s.anchor = -1
+	if len(indices) > 0 {
+		s.anchor = indices[0]
+		switch s.mode {
+		case SelectionSingle:
+			s.selected[indices[0]] = true
+		case SelectionRange:
+			minimum, maximum := indices[0], indices[0]
+			for _, index := range indices {
+				minimum, maximum = min(minimum, index), max(maximum, index)
+			}
+			s.addRange(minimum, maximum)
+		default:
+			for _, index := range indices {
+				s.selected[index] = true
+			}
+		}
+	}
+	s.notify()
+}
+
+// Clear deselects all items.
+func (s *SelectionModel) Clear() {
+	s.Lock()
+	if len(s.selected) == 0 && s.anchor < 0 {
+		s.Unlock()
+		return
+	}
+	s.selected = make(map[int]bool)
+	s.anchor = -1
+	s.notify()
+}
+
+// MarshalText returns the selected items as text, e.g. "1,3,5-9". It
+// implements encoding.TextMarshaler.
+func (s *SelectionModel) MarshalText() ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	var ranges []string
+	indices := s.sorted()
+	for start := 0; start < len(indices); {
+		end := start
+		for end+1 < len(indices) && indices[end+1] == indices[end]+1 {
+			end++
+		}
+		if end > start {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", indices[start], indices[end]))
+		} else {
+			ranges = append(ranges, strconv.Itoa(indices[start]))
+		}
+		start = end + 1
+	}
+	return []byte(strings.Join(ranges, ",")), nil
+}
+
+// UnmarshalText replaces the selection with the items of text returned by
+// MarshalText() (see SetSelected). It implements encoding.TextUnmarshaler.
+func (s *SelectionModel) UnmarshalText(text []byte) error {
+	var indices []int
+	if len(text) > 0 {
+		for _, field := range strings.Split(string(text), ",") {
+			from, to, isRange := strings.Cut(field, "-")
+			start, err := strconv.Atoi(from)
+			if err != nil {
+				return fmt.Errorf("invalid selection %q: %w", text, err)
+			}
+			end := start
+			if isRange {
+				if end, err = strconv.Atoi(to); err != nil {
+					return fmt.Errorf("invalid selection %q: %w", text, err)
+				}
+			}
+			for index := start; index <= end; index++ {
+				indices = append(indices, index)
+			}
+		}
+	}
+	s.SetSelected(indices)
+	return nil
+}
+
+// follow updates the selection after the current item of a primitive moved
+// from one index to another, either by a regular movement (which the
+// selection follows in SelectionSingle mode) or by extending the selection.
+func (s *SelectionModel) follow(previous, current int, extend bool) {
+	if extend {
+		s.Lock()
+		if s.anchor < 0 {
+			s.anchor = previous
+		}
+		s.Unlock()
+		s.ExtendTo(current)
+	} else if s.GetMode() == SelectionSingle && current >= 0 {
+		s.Select(current)
+	}
+}
+
+// click updates the selection after an item was clicked with the provided
+// modifier keys held down.
+func (s *SelectionModel) click(index int, modifiers tcell.ModMask) {
+	switch {
+	case modifiers&tcell.ModCtrl != 0:
+		s.Toggle(index)
+	case modifiers&tcell.ModShift != 0:
+		s.ExtendTo(index)
+	default:
+		s.SetSelected([]int{index})
+	}
+}
+
+// insert shifts the selection after an item was inserted at the given index.
+func (s *SelectionModel) insert(index int) {
+	s.shift(index, 1)
+}
+
+// remove removes an item from the selection and shifts the items after it.
+func (s *SelectionModel) remove(index int) {
+	s.shift(index, -1)
+}
+
+// shift moves the items at and after the given index by delta. If delta is
+// negative, the item at the index is removed.
+func (s *SelectionModel) shift(index, delta int) {
+	s.Lock()
+	selected := make(map[int]bool, len(s.selected))
+	changed := false
+	for item := range s.selected {
+		if item < index {
+			selected[item] = true
+		} else if delta < 0 && item == index {
+			changed = true
+		} else {
+			selected[item+delta] = true
+			changed = true
+		}
+	}
+	s.selected = selected
+	if s.anchor == index && delta < 0 {
+		s.anchor = -1
+	} else if s.anchor >= index {
+		s.anchor += delta
+	}
+	if !changed {
+		s.Unlock()
+		return
+	}
+	s.notify()
+}
+
+// move moves an item from one index to another, shifting the items between
+// them by one.
+func (s *SelectionModel) move(from, to int) {
+	s.Lock()
+	selected := make(map[int]bool, len(s.selected))
+	changed := false
+	for item := range s.selected {
+		moved := movedIndex(item, from, to)
+		selected[moved] = true
+		changed = changed || moved != item
+	}
+	s.selected = selected
+	if s.anchor >= 0 {
+		s.anchor = movedIndex(s.anchor, from, to)
+	}
+	if !changed {
+		s.Unlock()
+		return
+	}
+	s.notify()
+}
+
+// movedIndex returns the new index of an item after the item at one index was
+// moved to another.
+func movedIndex(index, from, to int) int {
+	switch {
+	case index == from:
+		return to
+	case from < to && index > from && index <= to:
+		return index - 1
+	case from > to && index >= to && index < from:
+		return index + 1
+	}
+	return index
+}
+
+// addRange selects the items from one index to another (inclusive). The
+// model must be locked.
+func (s *SelectionModel) addRange(from, to int) {
+	if from > to {
+		from, to = to, from
+	}
+	for index := from; index <= to; index++ {
+		s.selected[index] = true
+	}
+}
+
+// sorted returns the selected items in ascending order. The model must be
+// locked.
+func (s *SelectionModel) sorted() []int {
+	indices := make([]int, 0, len(s.selected))
+	for index := range s.selected {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// isValid returns whether or not the selection is valid in the current mode.
+// The model must be locked.
+func (s *SelectionModel) isValid() bool {
+	switch s.mode {
+	case SelectionSingle:
+		return len(s.selected) <= 1
+	case SelectionRange:
+		indices := s.sorted()
+		return len(indices) == 0 || indices[len(indices)-1]-indices[0] == len(indices)-1
+	}
+	return true
+}
+
+// notify unlocks the model and calls the changed handler, if any. The model
+// must be locked.
+func (s *SelectionModel) notify() {
+	changed, selected := s.changed, s.sorted()
+	s.Unlock()
+
+	if changed != nil {
+		changed(selected)
+	}
+}
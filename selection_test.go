@@ -0,0 +1,190 @@
+package nuview
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSelectionModel(t *testing.T) {
+	t.Parallel()
+
+	var changes int
+	s := NewSelectionModel(SelectionMultiple)
+	s.SetChangedFunc(func(selected []int) {
+		changes++
+	})
+
+	// Multiple
+
+	s.Select(3)
+	s.Select(1)
+	s.SelectRange(5, 7)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{1, 3, 5, 6, 7}) {
+		t.Errorf("failed to select items: expected [1 3 5 6 7], got %v", selected)
+	}
+	s.Toggle(3)
+	if s.IsSelected(3) {
+		t.Error("failed to toggle item: expected item 3 to be deselected")
+	}
+	if changes != 4 {
+		t.Errorf("failed to notify changes: expected 4, got %d", changes)
+	}
+
+	// Persistence
+
+	text, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(text) != "1,5-7" {
+		t.Errorf("failed to marshal selection: expected 1,5-7, got %s", text)
+	}
+	restored := NewSelectionModel(SelectionMultiple)
+	if err := restored.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	} else if selected := restored.GetSelected(); !reflect.DeepEqual(selected, []int{1, 5, 6, 7}) {
+		t.Errorf("failed to unmarshal selection: expected [1 5 6 7], got %v", selected)
+	}
+	if err := restored.UnmarshalText([]byte("1,x")); err == nil {
+		t.Error("failed to unmarshal selection: expected error for invalid text")
+	}
+
+	// Shifting
+
+	s.insert(0)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{2, 6, 7, 8}) {
+		t.Errorf("failed to shift selection: expected [2 6 7 8], got %v", selected)
+	}
+	s.remove(6)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{2, 6, 7}) {
+		t.Errorf("failed to shift selection: expected [2 6 7], got %v", selected)
+	}
+
+	// Range
+
+	s.SetMode(SelectionRange)
+	if selected := s.GetSelected(); len(selected) != 1 {
+		t.Errorf("failed to change mode: expected 1 item, got %v", selected)
+	}
+	s.Select(4)
+	s.ExtendTo(2)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{2, 3, 4}) {
+		t.Errorf("failed to extend selection: expected [2 3 4], got %v", selected)
+	}
+	s.Deselect(3)
+	if count := s.GetSelectedCount(); count != 0 {
+		t.Errorf("failed to deselect item: expected empty selection, got %d items", count)
+	}
+
+	// Single
+
+	s.SetMode(SelectionSingle)
+	s.SelectRange(1, 3)
+	s.Select(5)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{5}) {
+		t.Errorf("failed to select item: expected [5], got %v", selected)
+	}
+}
+
+func TestListSelectionModel(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	for _, text := range []string{"a", "b", "c", "d"} {
+		l.AddItem(NewListItem(text))
+	}
+	s := NewSelectionModel(SelectionMultiple)
+	l.SetSelectionModel(s)
+
+	input := func(key tcell.Key, modifiers tcell.ModMask) {
+		l.InputHandler()(tcell.NewEventKey(key, 0, modifiers), func(p Primitive) {})
+	}
+	input(tcell.KeyInsert, tcell.ModNone)
+	input(tcell.KeyDown, tcell.ModNone)
+	input(tcell.KeyDown, tcell.ModShift)
+	input(tcell.KeyDown, tcell.ModShift)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{0, 1, 2, 3}) {
+		t.Errorf("failed to extend selection: expected [0 1 2 3], got %v", selected)
+	}
+	if current := l.GetCurrentItemIndex(); current != 3 {
+		t.Errorf("failed to move current item: expected 3, got %d", current)
+	}
+
+	l.RemoveItem(0)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{0, 1, 2}) {
+		t.Errorf("failed to shift selection: expected [0 1 2], got %v", selected)
+	}
+
+	s.SetMode(SelectionSingle)
+	l.SetCurrentItem(0)
+	if selected := s.GetSelected(); !reflect.DeepEqual(selected, []int{0}) {
+		t.Errorf("failed to follow current item: expected [0], got %v", selected)
+	}
+}
+
+func TestSelectionModelCallbacks(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 5)
+
+	// Callbacks read the primitive, they must not be called while it is
+	// locked.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		tb := NewTable()
+		for row, text := range []string{"a", "b", "c"} {
+			tb.SetCellSimple(row, 0, text)
+		}
+		tb.SetSelectable(true, false)
+		var rows []int
+		s := NewSelectionModel(SelectionSingle)
+		s.SetChangedFunc(func(selected []int) {
+			row, _ := tb.GetSelection()
+			rows = append(rows, row)
+		})
+		tb.SetSelectionModel(s)
+		tb.SetSelectionChangedFunc(func(row, column int) {
+			tb.GetCell(row, column)
+		})
+		tb.Select(1, 0)
+		tb.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), func(Primitive) {})
+		if got := fmt.Sprint(rows); got != "[1 2]" {
+			t.Errorf("failed to notify table selection: expected [1 2], got %s", got)
+		}
+
+		root := NewTreeNode("root")
+		child := NewTreeNode("child")
+		root.AddChild(child)
+		tr := NewTreeView()
+		tr.SetRoot(root)
+		tr.SetCurrentNode(root)
+		var nodes []string
+		tr.SetSelectionModel(NewSelectionModel(SelectionMultiple))
+		tr.GetSelectionModel().SetChangedFunc(func(selected []int) {
+			nodes = append(nodes, tr.GetCurrentNode().GetText())
+		})
+		tr.SetChangedFunc(func(node *TreeNode) {
+			nodes = append(nodes, tr.GetCurrentNode().GetText())
+		})
+		tr.SetRect(0, 0, 10, 5)
+		tr.Draw(sc)
+		tr.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(2, 1, tcell.Button1, tcell.ModNone), func(Primitive) {})
+		if got := fmt.Sprint(nodes); got != "[root child]" {
+			t.Errorf("failed to notify tree view selection: expected [root child], got %s", got)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed to notify selection: callbacks deadlocked")
+	}
+}
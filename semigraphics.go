@@ -273,7 +273,7 @@ var SemigraphicJoints = map[string]rune{
 // position with the given color, joining it with any existing semigraphics
 // rune. Background colors are preserved. At this point, only regular single
 // line borders are supported.
-func PrintJoinedSemigraphics(screen tcell.Screen, x, y int, ch rune, color tcell.Color) {
+func PrintJoinedSemigraphics(screen ScreenWriter, x, y int, ch rune, color tcell.Color) {
 	previous, _, style, _ := screen.GetContent(x, y)
 	style = style.Foreground(color)
 
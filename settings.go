@@ -0,0 +1,572 @@
+package nuview
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// SettingType is the type of the value of a setting.
+type SettingType int
+
+// Setting types.
+const (
+	SettingString SettingType = iota // A string, edited with an InputField.
+	SettingBool                      // A bool, edited with a Checkbox.
+	SettingInt                       // An int, edited with an InputField.
+	SettingChoice                    // One of the setting's choices (a string), edited with a DropDown.
+)
+
+// Setting declares a setting of an application, see Settings.
+type Setting struct {
+	// The key identifying the setting. Keys must be unique.
+	Key string
+
+	// The type of the setting's value.
+	Type SettingType
+
+	// The label shown in the settings view. The key is shown if it is empty.
+	Label string
+
+	// The group the setting is shown in, e.g. "Appearance". Settings without a
+	// group are shown first.
+	Group string
+
+	// An optional description shown below the setting in the settings view.
+	Description string
+
+	// The default value, whose Go type must match the setting type: string,
+	// bool or int, or one of the choices for SettingChoice. The zero value of
+	// the type (the first choice for SettingChoice) is used if it is nil.
+	Default interface{}
+
+	// The values the user may choose from for SettingChoice.
+	Choices []string
+}
+
+// label returns the label of the setting, its key if it has none.
+func (s *Setting) label() string {
+	if s.Label == "" {
+		return s.Key
+	}
+	return s.Label
+}
+
+// convert returns the provided value as a value of the setting, or an error if
+// it is not valid for the setting. Integers may be provided as any integer
+// type, as integral float64 values or as json.Number.
+func (s *Setting) convert(value interface{}) (interface{}, error) {
+	switch s.Type {
+	case SettingBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case SettingInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			if v >= math.MinInt && v <= math.MaxInt {
+				return int(v), nil
+			}
+		case float64:
+			if v == math.Trunc(v) && v >= math.MinInt && v <= math.MaxInt {
+				return int(v), nil
+			}
+		case json.Number:
+			if i, err := strconv.Atoi(string(v)); err == nil {
+				return i, nil
+			}
+		}
+	case SettingChoice:
+		if c, ok := value.(string); ok && slices.Contains(s.Choices, c) {
+			return c, nil
+		}
+	default:
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid value %v of setting %q", value, s.Key)
+}
+
+// defaultValue returns the default value of the setting, nil if the declared
+// default is invalid.
+func (s *Setting) defaultValue() interface{} {
+	if s.Default != nil {
+		value, _ := s.convert(s.Default)
+		return value
+	}
+	switch s.Type {
+	case SettingBool:
+		return false
+	case SettingInt:
+		return 0
+	case SettingChoice:
+		if len(s.Choices) > 0 {
+			return s.Choices[0]
+		}
+	}
+	return ""
+}
+
+// Settings holds the values of an application's settings, which are declared
+// with their key, type, label, group and default value (see Setting). Values
+// are changed with Set() and reset to their defaults with Reset() and
+// ResetAll(). See SetChangedFunc() to be notified of changes.
+//
+// The values may be saved and loaded as JSON (see WriteJSON() and LoadJSON())
+// or TOML (see WriteTOML() and LoadTOML()). A SettingsView lets the user
+// search and edit the settings, see NewSettingsView().
+//
+// Settings may be accessed from any goroutine but handlers are called on the
+// goroutine which changed the values.
+type Settings struct {
+	// The declared settings in the order they were added.
+	settings []*Setting
+
+	// The settings keyed by their keys.
+	index map[string]*Setting
+
+	// The current values keyed by the settings' keys.
+	values map[string]interface{}
+
+	// An optional function which is called when a value changed.
+	changed func(key string, value interface{})
+
+	// Functions of the settings views which are called when a value changed.
+	observers []func(key string, value interface{})
+
+	sync.RWMutex
+}
+
+// NewSettings returns new settings with the provided declarations, set to their
+// default values. See Add().
+func NewSettings(settings ...*Setting) *Settings {
+	s := &Settings{
+		index:  make(map[string]*Setting),
+		values: make(map[string]interface{}),
+	}
+	s.Add(settings...)
+	return s
+}
+
+// Add declares settings, set to their default values. It panics if a key was
+// declared before or if a default value does not match the type of its
+// setting.
+func (s *Settings) Add(settings ...*Setting) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, setting := range settings {
+		if _, ok := s.index[setting.Key]; ok {
+			panic(fmt.Sprintf("duplicate setting %q", setting.Key))
+		}
+		value, err := setting.convert(setting.defaultValue())
+		if err != nil {
+			panic(fmt.Sprintf("invalid default of setting %q", setting.Key))
+		}
+		s.settings = append(s.settings, setting)
+		s.index[setting.Key] = setting
+		s.values[setting.Key] = value
+	}
+}
+
+// GetSettings returns the declared settings in the order they were added.
+func (s *Settings) GetSettings() []*Setting {
+	s.RLock()
+	defer s.RUnlock()
+
+	return append([]*Setting(nil), s.settings...)
+}
+
+// Get returns the value of the setting with the provided key, nil if there is
+// no such setting.
+func (s *Settings) Get(key string) interface{} {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.values[key]
+}
+
+// GetString returns the value of a SettingString or SettingChoice setting, ""
+// if there is no such setting.
+func (s *Settings) GetString(key string) string {
+	str, _ := s.Get(key).(string)
+	return str
+}
+
+// GetBool returns the value of a SettingBool setting, false if there is no such
+// setting.
+func (s *Settings) GetBool(key string) bool {
+	b, _ := s.Get(key).(bool)
+	return b
+}
+
+// GetInt returns the value of a SettingInt setting, 0 if there is no such
+// setting.
+func (s *Settings) GetInt(key string) int {
+	i, _ := s.Get(key).(int)
+	return i
+}
+
+// Set sets the value of the setting with the provided key. An error is
+// returned if there is no such setting or if the value is not valid for it.
+// The changed handler is called if the value changed.
+func (s *Settings) Set(key string, value interface{}) error {
+	s.Lock()
+	setting, ok := s.index[key]
+	if !ok {
+		s.Unlock()
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	value, err := setting.convert(value)
+	if err != nil {
+		s.Unlock()
+		return err
+	}
+	s.set(key, value)
+	return nil
+}
+
+// set sets a valid value, unlocks the settings and, if the value changed,
+// calls the handlers. The settings must be locked.
+func (s *Settings) set(key string, value interface{}) {
+	if s.values[key] == value {
+		s.Unlock()
+		return
+	}
+	s.values[key] = value
+	changed, observers := s.changed, s.observers
+	s.Unlock()
+
+	for _, observer := range observers {
+		observer(key, value)
+	}
+	if changed != nil {
+		changed(key, value)
+	}
+}
+
+// IsDefault returns whether or not the setting with the provided key has its
+// default value.
+func (s *Settings) IsDefault(key string) bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	setting, ok := s.index[key]
+	return ok && s.values[key] == setting.defaultValue()
+}
+
+// Reset sets the setting with the provided key to its default value.
+func (s *Settings) Reset(key string) {
+	s.Lock()
+	setting, ok := s.index[key]
+	if !ok {
+		s.Unlock()
+		return
+	}
+	s.set(key, setting.defaultValue())
+}
+
+// ResetAll sets all settings to their default values.
+func (s *Settings) ResetAll() {
+	for _, setting := range s.GetSettings() {
+		s.Reset(setting.Key)
+	}
+}
+
+// SetChangedFunc sets a handler which is called with the key and the new value
+// of a setting whenever a value changed, e.g. when the user edited it in a
+// settings view, when it was reset or when values were loaded.
+func (s *Settings) SetChangedFunc(handler func(key string, value interface{})) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.changed = handler
+}
+
+// observe adds a function which is called when a value changed.
+func (s *Settings) observe(observer func(key string, value interface{})) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.observers = append(s.observers, observer)
+}
+
+// groups returns the declared settings grouped by their groups, in the order
+// in which the groups first appear. Settings without a group come first.
+func (s *Settings) groups() (names []string, settings [][]*Setting) {
+	index := make(map[string]int)
+	all := s.GetSettings()
+	if slices.ContainsFunc(all, func(setting *Setting) bool { return setting.Group == "" }) {
+		index[""] = 0
+		names, settings = []string{""}, [][]*Setting{nil}
+	}
+	for _, setting := range all {
+		group, ok := index[setting.Group]
+		if !ok {
+			group = len(names)
+			index[setting.Group] = group
+			names = append(names, setting.Group)
+			settings = append(settings, nil)
+		}
+		settings[group] = append(settings[group], setting)
+	}
+	return
+}
+
+// load validates the provided values and then sets them. Values of unknown
+// settings are ignored. If a value is invalid, no value is set.
+func (s *Settings) load(values map[string]interface{}) error {
+	s.RLock()
+	converted := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		setting, ok := s.index[key]
+		if !ok {
+			continue
+		}
+		value, err := setting.convert(value)
+		if err != nil {
+			s.RUnlock()
+			return err
+		}
+		converted[key] = value
+	}
+	s.RUnlock()
+
+	for _, setting := range s.GetSettings() {
+		if value, ok := converted[setting.Key]; ok {
+			s.Lock()
+			s.set(setting.Key, value)
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the values of all settings to the provided writer as a JSON
+// object keyed by the settings' keys.
+func (s *Settings) WriteJSON(w io.Writer) error {
+	s.RLock()
+	values := make(map[string]interface{}, len(s.values))
+	for key, value := range s.values {
+		values[key] = value
+	}
+	s.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(values)
+}
+
+// LoadJSON reads values written by WriteJSON() and sets them. Values of
+// settings which are not declared are ignored, settings missing from the input
+// keep their values. If a value is invalid, an error is returned and no value
+// is set.
+func (s *Settings) LoadJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+	return s.load(values)
+}
+
+// WriteTOML writes the values of all settings to the provided writer as TOML
+// key/value pairs, preceded by a comment with the name of their group.
+func (s *Settings) WriteTOML(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	names, groups := s.groups()
+	for index, settings := range groups {
+		if index > 0 {
+			writer.WriteString("\n")
+		}
+		if names[index] != "" {
+			fmt.Fprintf(writer, "# %s\n", names[index])
+		}
+		for _, setting := range settings {
+			var value string
+			switch v := s.Get(setting.Key).(type) {
+			case string:
+				value = tomlString(v)
+			default:
+				value = fmt.Sprint(v)
+			}
+			fmt.Fprintf(writer, "%s = %s\n", tomlKey(setting.Key), value)
+		}
+	}
+	return writer.Flush()
+}
+
+// LoadTOML reads values written by WriteTOML() and sets them like LoadJSON().
+// Only the subset of TOML written by WriteTOML() is supported: comments and
+// key/value pairs whose values are strings, integers or booleans.
+func (s *Settings) LoadTOML(r io.Reader) error {
+	values := make(map[string]interface{})
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		key, value, err := parseTOMLLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("invalid settings in line %d: %w", line, err)
+		}
+		if key != "" {
+			values[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return s.load(values)
+}
+
+// tomlKey returns the provided key as a TOML key, quoted unless it is a bare
+// key.
+func tomlKey(key string) string {
+	if key == "" || strings.IndexFunc(key, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	}) >= 0 {
+		return tomlString(key)
+	}
+	return key
+}
+
+// tomlString returns the provided text as a TOML basic string.
+func tomlString(text string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseTOMLLine parses a line of TOML written by Settings.WriteTOML(). It
+// returns an empty key for empty lines and comments.
+func parseTOMLLine(line string) (key string, value interface{}, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return "", nil, nil
+	}
+
+	// The key.
+	if line[0] == '"' {
+		key, line, err = parseTOMLString(line)
+		if err != nil {
+			return "", nil, err
+		}
+	} else {
+		end := strings.IndexAny(line, " \t=")
+		if end <= 0 {
+			return "", nil, fmt.Errorf("expected key/value pair")
+		}
+		key, line = line[:end], line[end:]
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "=") {
+		return "", nil, fmt.Errorf("expected '=' after key %q", key)
+	}
+	line = strings.TrimSpace(line[1:])
+
+	// The value.
+	switch {
+	case strings.HasPrefix(line, `"`):
+		value, line, err = parseTOMLString(line)
+		if err != nil {
+			return "", nil, err
+		}
+	case strings.HasPrefix(line, "true"):
+		value, line = true, line[4:]
+	case strings.HasPrefix(line, "false"):
+		value, line = false, line[5:]
+	default:
+		end := strings.IndexAny(line, " \t#")
+		if end < 0 {
+			end = len(line)
+		}
+		i, err := strconv.ParseInt(strings.ReplaceAll(line[:end], "_", ""), 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("unsupported value of key %q", key)
+		}
+		value, line = i, line[end:]
+	}
+	if line = strings.TrimSpace(line); line != "" && line[0] != '#' {
+		return "", nil, fmt.Errorf("unexpected %q after value of key %q", line, key)
+	}
+	return key, value, nil
+}
+
+// parseTOMLString parses the TOML basic string at the start of the provided
+// text and returns it and the rest of the text.
+func parseTOMLString(text string) (str, rest string, err error) {
+	var b strings.Builder
+	for index := 1; index < len(text); {
+		r, size := utf8.DecodeRuneInString(text[index:])
+		switch r {
+		case '"':
+			return b.String(), text[index+1:], nil
+		case '\\':
+			if index+1 >= len(text) {
+				return "", "", fmt.Errorf("unterminated string")
+			}
+			escape := text[index+1]
+			index += 2
+			switch escape {
+			case 'b':
+				b.WriteByte('\b')
+			case 't':
+				b.WriteByte('\t')
+			case 'n':
+				b.WriteByte('\n')
+			case 'f':
+				b.WriteByte('\f')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(escape)
+			case 'u', 'U':
+				digits := 4
+				if escape == 'U' {
+					digits = 8
+				}
+				if index+digits > len(text) {
+					return "", "", fmt.Errorf("invalid escape sequence")
+				}
+				code, err := strconv.ParseUint(text[index:index+digits], 16, 32)
+				if err != nil {
+					return "", "", fmt.Errorf("invalid escape sequence")
+				}
+				b.WriteRune(rune(code))
+				index += digits
+			default:
+				return "", "", fmt.Errorf("invalid escape sequence")
+			}
+			continue
+		}
+		b.WriteRune(r)
+		index += size
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
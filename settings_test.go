@@ -0,0 +1,148 @@
+package nuview
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newTestSettings() *Settings {
+	return NewSettings(
+		&Setting{Key: "name", Type: SettingString, Label: "Name", Default: "guest"},
+		&Setting{Key: "editor.wrap", Type: SettingBool, Label: "Wrap lines", Group: "Editor", Description: "Wrap long lines at the window edge.", Default: true},
+		&Setting{Key: "editor.tab_width", Type: SettingInt, Label: "Tab width", Group: "Editor", Default: 4},
+		&Setting{Key: "theme", Type: SettingChoice, Label: "Theme", Group: "Appearance", Choices: []string{"dark", "light"}, Default: "dark"},
+	)
+}
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSettings()
+	if got := s.GetString("name"); got != "guest" {
+		t.Errorf("failed to get default: expected %q, got %q", "guest", got)
+	}
+	if got := s.GetInt("editor.tab_width"); got != 4 {
+		t.Errorf("failed to get default: expected 4, got %d", got)
+	}
+
+	var changes []string
+	s.SetChangedFunc(func(key string, value interface{}) {
+		changes = append(changes, key)
+	})
+	if err := s.Set("editor.tab_width", "eight"); err == nil {
+		t.Error("failed to reject value: expected error, got nil")
+	}
+	if err := s.Set("theme", "blue"); err == nil {
+		t.Error("failed to reject choice: expected error, got nil")
+	}
+	if err := s.Set("editor.tab_width", 8); err != nil {
+		t.Fatalf("failed to set value: %v", err)
+	}
+	if err := s.Set("editor.tab_width", 8); err != nil {
+		t.Fatalf("failed to set value: %v", err)
+	}
+	if len(changes) != 1 || changes[0] != "editor.tab_width" {
+		t.Errorf("failed to notify change: expected [editor.tab_width], got %v", changes)
+	}
+	if s.IsDefault("editor.tab_width") {
+		t.Error("failed to change value: expected non-default, got default")
+	}
+
+	s.Set("theme", "light")
+	s.Reset("editor.tab_width")
+	if got := s.GetInt("editor.tab_width"); got != 4 || !s.IsDefault("editor.tab_width") {
+		t.Errorf("failed to reset value: expected 4, got %d", got)
+	}
+	s.ResetAll()
+	if got := s.GetString("theme"); got != "dark" {
+		t.Errorf("failed to reset all values: expected %q, got %q", "dark", got)
+	}
+}
+
+func TestSettingsPersistence(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"JSON", "TOML"} {
+		s := newTestSettings()
+		s.Set("name", "Anne \"Quote\"\n")
+		s.Set("editor.wrap", false)
+		s.Set("editor.tab_width", 2)
+		s.Set("theme", "light")
+
+		var buf bytes.Buffer
+		var err error
+		if format == "JSON" {
+			err = s.WriteJSON(&buf)
+		} else {
+			err = s.WriteTOML(&buf)
+		}
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", format, err)
+		}
+
+		loaded := newTestSettings()
+		if format == "JSON" {
+			err = loaded.LoadJSON(&buf)
+		} else {
+			err = loaded.LoadTOML(&buf)
+		}
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", format, err)
+		}
+		for _, setting := range s.GetSettings() {
+			if expected, got := s.Get(setting.Key), loaded.Get(setting.Key); expected != got {
+				t.Errorf("failed to load %s value of %s: expected %v, got %v", format, setting.Key, expected, got)
+			}
+		}
+	}
+}
+
+func TestSettingsView(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSettings()
+	v := NewSettingsView(s)
+	v.SetRect(0, 0, 60, 20)
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(60, 20)
+	v.Draw(sc)
+
+	v.SetQuery("wrap")
+	for key, item := range v.items {
+		if expected := key == "editor.wrap"; item.GetVisible() != expected {
+			t.Errorf("failed to filter %s: expected visible %v, got %v", key, expected, item.GetVisible())
+		}
+	}
+	if !v.headings["Editor"].GetVisible() || v.headings["Appearance"].GetVisible() {
+		t.Error("failed to filter headings: expected only Editor visible")
+	}
+	v.SetQuery("nothing like this")
+	if !v.noMatches.GetVisible() {
+		t.Error("failed to filter: expected no matches note")
+	}
+	v.SetQuery("")
+	for key, item := range v.items {
+		if !item.GetVisible() {
+			t.Errorf("failed to clear filter: expected %s visible", key)
+		}
+	}
+
+	s.Set("editor.tab_width", 8)
+	if got := v.items["editor.tab_width"].(*InputField).GetText(); got != "8" {
+		t.Errorf("failed to update item: expected %q, got %q", "8", got)
+	}
+	v.items["name"].(*InputField).SetText("admin")
+	if got := s.GetString("name"); got != "admin" {
+		t.Errorf("failed to edit setting: expected %q, got %q", "admin", got)
+	}
+	v.items["theme"].(*DropDown).SetCurrentOption(1)
+	if got := s.GetString("theme"); got != "light" {
+		t.Errorf("failed to edit setting: expected %q, got %q", "light", got)
+	}
+}
@@ -0,0 +1,308 @@
+package nuview
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SettingsView lets the user search and edit settings (see Settings). It
+// consists of a search field above a form which holds an item for each setting,
+// grouped under headings with the names of their groups, and a button
+// resetting all settings to their defaults. The form is updated when the values
+// change elsewhere, e.g. when they are loaded.
+//
+// Typing into the search field shows only the settings whose label, key, group
+// or description contains the query, ignoring case. Enter, Tab and the Down key
+// move from the search field to the form, Escape returns to the search field.
+type SettingsView struct {
+	*Box
+
+	// The settings shown in the view.
+	settings *Settings
+
+	// The field for the search query.
+	search *InputField
+
+	// The form holding the items of the settings.
+	form *Form
+
+	// The form items, the descriptions and the group headings keyed by the
+	// settings' keys and the groups' names, respectively.
+	items        map[string]FormItem
+	descriptions map[string]*TextView
+	headings     map[string]*TextView
+
+	// The text shown if no setting matches the query.
+	noMatches *TextView
+
+	// The key of the setting whose value is changed by its form item, empty
+	// for none. Its item is not updated by the change.
+	editing string
+
+	// The function which sets the focus, as provided to Focus().
+	setFocus func(p Primitive)
+
+	sync.RWMutex
+}
+
+// NewSettingsView returns a new view of the provided settings. Only the
+// settings declared at that time are shown.
+func NewSettingsView(settings *Settings) *SettingsView {
+	v := &SettingsView{
+		Box:          NewBox(),
+		settings:     settings,
+		search:       NewInputField(),
+		form:         NewForm(),
+		items:        make(map[string]FormItem),
+		descriptions: make(map[string]*TextView),
+		headings:     make(map[string]*TextView),
+		noMatches:    NewTextView(),
+	}
+
+	v.search.SetLabel("Search: ")
+	v.search.SetChangedFunc(v.filter)
+	v.search.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter, tcell.KeyTab, tcell.KeyDown:
+			v.focusPrimitive(v.form)
+		case tcell.KeyEscape:
+			v.search.SetText("")
+		}
+	})
+
+	v.form.SetPadding(1, 0, 0, 0)
+	v.form.SetCancelFunc(func() {
+		v.focusPrimitive(v.search)
+	})
+	names, groups := settings.groups()
+	for index, group := range groups {
+		if names[index] != "" {
+			heading := NewTextView()
+			heading.SetText(names[index])
+			heading.SetTextColor(Styles.TitleColor)
+			v.headings[names[index]] = heading
+			v.form.AddPrimitive(heading, 1)
+		}
+		for _, setting := range group {
+			item := v.newItem(setting)
+			v.items[setting.Key] = item
+			v.form.AddFormItem(item)
+			if setting.Description != "" {
+				description := NewTextView()
+				description.SetText(setting.Description)
+				description.SetTextColor(Styles.TertiaryTextColor)
+				v.descriptions[setting.Key] = description
+				v.form.AddPrimitive(description, 0)
+			}
+		}
+	}
+	v.noMatches.SetText("No matching settings")
+	v.noMatches.SetTextColor(Styles.TertiaryTextColor)
+	v.noMatches.SetVisible(false)
+	v.form.AddPrimitive(v.noMatches, 1)
+	v.form.AddButton("Reset to defaults", settings.ResetAll)
+
+	settings.observe(v.update)
+	v.focus = v
+	return v
+}
+
+// newItem returns a form item editing the provided setting.
+func (v *SettingsView) newItem(setting *Setting) FormItem {
+	key := setting.Key
+	switch setting.Type {
+	case SettingBool:
+		checkbox := NewCheckbox()
+		checkbox.SetLabel(setting.label())
+		checkbox.SetChecked(v.settings.GetBool(key))
+		checkbox.SetChangedFunc(func(checked bool) {
+			v.edit(key, checked)
+		})
+		return checkbox
+	case SettingChoice:
+		dropDown := NewDropDown()
+		dropDown.SetLabel(setting.label())
+		dropDown.AddOptionsSimple(setting.Choices...)
+		dropDown.SetCurrentOptionByText(v.settings.GetString(key))
+		dropDown.SetSelectedFunc(func(index int, option *DropDownOption) {
+			if option != nil {
+				v.edit(key, option.GetText())
+			}
+		})
+		return dropDown
+	case SettingInt:
+		inputField := NewInputField()
+		inputField.SetLabel(setting.label())
+		inputField.SetText(strconv.Itoa(v.settings.GetInt(key)))
+		inputField.SetAcceptanceFunc(InputFieldInteger)
+		inputField.SetChangedFunc(func(text string) {
+			if i, err := strconv.Atoi(text); err == nil {
+				v.edit(key, i)
+			}
+		})
+		return inputField
+	default:
+		inputField := NewInputField()
+		inputField.SetLabel(setting.label())
+		inputField.SetText(v.settings.GetString(key))
+		inputField.SetChangedFunc(func(text string) {
+			v.edit(key, text)
+		})
+		return inputField
+	}
+}
+
+// edit sets the value of a setting edited with its form item.
+func (v *SettingsView) edit(key string, value interface{}) {
+	v.Lock()
+	v.editing = key
+	v.Unlock()
+
+	v.settings.Set(key, value)
+
+	v.Lock()
+	v.editing = ""
+	v.Unlock()
+}
+
+// update updates the form item of a setting whose value changed.
+func (v *SettingsView) update(key string, value interface{}) {
+	v.RLock()
+	item, editing := v.items[key], v.editing
+	v.RUnlock()
+	if item == nil || key == editing {
+		return
+	}
+
+	switch item := item.(type) {
+	case *Checkbox:
+		item.SetChecked(value.(bool))
+	case *DropDown:
+		item.SetCurrentOptionByText(value.(string))
+	case *InputField:
+		if i, ok := value.(int); ok {
+			item.SetText(strconv.Itoa(i))
+		} else {
+			item.SetText(value.(string))
+		}
+	}
+}
+
+// SetQuery sets the search query, showing only the matching settings.
+func (v *SettingsView) SetQuery(query string) {
+	v.search.SetText(query)
+}
+
+// GetQuery returns the search query.
+func (v *SettingsView) GetQuery() string {
+	return v.search.GetText()
+}
+
+// filter shows only the settings matching the provided query.
+func (v *SettingsView) filter(query string) {
+	v.RLock()
+	defer v.RUnlock()
+
+	shownGroups := make(map[string]bool)
+	for _, setting := range v.settings.GetSettings() {
+		item := v.items[setting.Key]
+		if item == nil {
+			continue // Declared after the view was created.
+		}
+		shown := query == ""
+		for _, text := range []string{setting.label(), setting.Key, setting.Group, setting.Description} {
+			shown = shown || searchMatches([]byte(text), query)
+		}
+		item.SetVisible(shown)
+		if description := v.descriptions[setting.Key]; description != nil {
+			description.SetVisible(shown)
+		}
+		shownGroups[setting.Group] = shownGroups[setting.Group] || shown
+	}
+	for group, heading := range v.headings {
+		heading.SetVisible(shownGroups[group])
+	}
+	v.noMatches.SetVisible(len(v.items) > 0 && !anyTrue(shownGroups))
+}
+
+// anyTrue returns whether or not any value of the provided map is true.
+func anyTrue(values map[string]bool) bool {
+	for _, value := range values {
+		if value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSearchField returns the field for the search query.
+func (v *SettingsView) GetSearchField() *InputField {
+	return v.search
+}
+
+// GetForm returns the form holding the items of the settings, e.g. to add
+// buttons.
+func (v *SettingsView) GetForm() *Form {
+	return v.form
+}
+
+// focusPrimitive gives the focus to the provided child primitive.
+func (v *SettingsView) focusPrimitive(p Primitive) {
+	v.RLock()
+	setFocus := v.setFocus
+	v.RUnlock()
+
+	if setFocus != nil {
+		setFocus(p)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (v *SettingsView) Focus(delegate func(p Primitive)) {
+	v.Lock()
+	v.setFocus = delegate
+	v.Unlock()
+
+	delegate(v.search)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (v *SettingsView) HasFocus() bool {
+	return v.search.HasFocus() || v.form.HasFocus()
+}
+
+// Draw draws this primitive onto the screen.
+func (v *SettingsView) Draw(screen tcell.Screen) {
+	if !v.GetVisible() {
+		return
+	}
+
+	v.Box.Draw(screen)
+
+	x, y, width, height := v.GetInnerRect()
+	v.search.SetRect(x, y, width, 1)
+	v.form.SetRect(x, y+1, width, max(height-1, 0))
+	v.search.Draw(screen)
+	v.form.Draw(screen)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (v *SettingsView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return v.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !v.InRect(event.Position()) {
+			return false, nil
+		}
+
+		v.Lock()
+		v.setFocus = setFocus
+		v.Unlock()
+
+		consumed, capture = v.search.MouseHandler()(action, event, setFocus)
+		if consumed {
+			return
+		}
+		return v.form.MouseHandler()(action, event, setFocus)
+	})
+}
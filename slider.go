@@ -265,7 +265,7 @@ func (s *Slider) Draw(screen tcell.Screen) {
 // InputHandler returns the handler for this primitive.
 func (s *Slider) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return s.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
-		if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+		if s.hitShortcut(event, s.keys().Cancel, s.keys().MovePreviousField, s.keys().MoveNextField) {
 			if s.done != nil {
 				s.done(event.Key())
 			}
@@ -277,13 +277,13 @@ func (s *Slider) InputHandler() func(event *tcell.EventKey, setFocus func(p Prim
 
 		previous := s.progress
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		if s.hitShortcut(event, s.keys().MoveFirst, s.keys().MoveFirst2) {
 			s.SetProgress(0)
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if s.hitShortcut(event, s.keys().MoveLast, s.keys().MoveLast2) {
 			s.SetProgress(s.max)
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2, Keys.MoveRight, Keys.MoveRight2, Keys.MovePreviousField) {
+		} else if s.hitShortcut(event, s.keys().MoveUp, s.keys().MoveUp2, s.keys().MoveRight, s.keys().MoveRight2, s.keys().MovePreviousField) {
 			s.AddProgress(s.increment)
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2, Keys.MoveLeft, Keys.MoveLeft2, Keys.MoveNextField) {
+		} else if s.hitShortcut(event, s.keys().MoveDown, s.keys().MoveDown2, s.keys().MoveLeft, s.keys().MoveLeft2, s.keys().MoveNextField) {
 			s.AddProgress(s.increment * -1)
 		}
 
@@ -0,0 +1,75 @@
+package nuview
+
+import (
+	"log"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetSlowDrawFunc enables diagnostics which detect primitives that take too
+// long to draw, e.g. because of accidental quadratic drawing in a big table.
+// Whenever drawing a single primitive takes longer than the provided
+// threshold, the handler is called with the primitive and the time it took.
+// The time spent drawing the primitives it contains, e.g. the items of a Flex,
+// is not counted. Use Primitive.GetRect() to locate the primitive. If the
+// handler is nil, the primitive's type, its rect and the time are written
+// with the standard logger instead (see log.SetOutput()).
+//
+// The handler is called while the screen is being drawn. It must therefore not
+// call functions which wait for the event loop, such as QueueUpdate().
+//
+// A threshold of 0 disables the diagnostics, which is the default. They are
+// meant to be used during development.
+func (a *Application) SetSlowDrawFunc(threshold time.Duration, handler func(p Primitive, elapsed time.Duration)) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.slowDrawThreshold = threshold
+	a.slowDrawFunc = handler
+}
+
+// drawPrimitive draws a primitive contained in another primitive (or the root
+// primitive) and measures the time it took if slow draw diagnostics are
+// enabled for the application drawing on the screen.
+func drawPrimitive(screen tcell.Screen, p Primitive) {
+	target := screen
+	for {
+		clipped, ok := target.(*ClippedScreen)
+		if !ok {
+			break
+		}
+		target = clipped.Screen
+	}
+	a := applicationOf(target)
+	if a == nil {
+		p.Draw(screen)
+		return
+	}
+	a.RLock()
+	threshold, handler := a.slowDrawThreshold, a.slowDrawFunc
+	a.RUnlock()
+	if threshold <= 0 {
+		p.Draw(screen)
+		return
+	}
+
+	// Measure the time without the nested primitives.
+	outer := a.drawNested
+	a.drawNested = 0
+	start := time.Now()
+	p.Draw(screen)
+	elapsed := time.Since(start)
+	own := elapsed - a.drawNested
+	a.drawNested = outer + elapsed
+
+	if own <= threshold {
+		return
+	}
+	if handler != nil {
+		handler(p, own)
+		return
+	}
+	x, y, width, height := p.GetRect()
+	log.Printf("drawing %T at (%d,%d %dx%d) took %s", p, x, y, width, height, own)
+}
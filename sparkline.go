@@ -0,0 +1,142 @@
+package nuview
+
+import (
+	"math"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Sparkline is a CellRenderer which draws the most recent samples of a series
+// as a sparkline, one sample per cell. Samples are kept in a ring buffer of
+// fixed capacity so adding a sample never allocates memory, which makes it
+// suitable for values which are updated in place many times per second, e.g.
+// CPU usage. It may be used as the suffix of a list item (see
+// ListItem.SetSuffixRenderer()) or in a table cell (see TableCell.SetRenderer()).
+//
+// Unlike SparklineCellRenderer, a Sparkline may be updated from any goroutine.
+// Call Application.Draw() afterwards to show the new samples.
+type Sparkline struct {
+	// The ring buffer of samples.
+	samples []float64
+
+	// The index of the oldest sample and the number of samples.
+	start, count int
+
+	// The fixed range of the samples. If the minimum is not less than the
+	// maximum, the range is determined from the samples.
+	minimum, maximum float64
+
+	// The color of the sparkline. If this is tcell.ColorDefault, the style
+	// provided to Draw() is used.
+	color tcell.Color
+
+	sync.RWMutex
+}
+
+// NewSparkline returns a new sparkline which holds up to the provided number
+// of samples. This is also the width the sparkline prefers.
+func NewSparkline(capacity int) *Sparkline {
+	return &Sparkline{
+		samples: make([]float64, max(capacity, 1)),
+		color:   tcell.ColorDefault,
+	}
+}
+
+// Add adds samples to the sparkline. If it is full, the oldest samples are
+// discarded.
+func (s *Sparkline) Add(samples ...float64) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, sample := range samples {
+		if s.count < len(s.samples) {
+			s.samples[(s.start+s.count)%len(s.samples)] = sample
+			s.count++
+		} else {
+			s.samples[s.start] = sample
+			s.start = (s.start + 1) % len(s.samples)
+		}
+	}
+}
+
+// GetSamples returns the samples of the sparkline, starting with the oldest.
+func (s *Sparkline) GetSamples() []float64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	samples := make([]float64, s.count)
+	for index := range samples {
+		samples[index] = s.samples[(s.start+index)%len(s.samples)]
+	}
+	return samples
+}
+
+// Clear removes all samples.
+func (s *Sparkline) Clear() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.start, s.count = 0, 0
+}
+
+// SetRange sets a fixed range for the samples, e.g. 0 to 100 for percentages.
+// Samples outside of the range are clamped. If the minimum is not less than
+// the maximum, the range is determined from the visible samples, which is the
+// default.
+func (s *Sparkline) SetRange(minimum, maximum float64) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.minimum, s.maximum = minimum, maximum
+}
+
+// SetColor sets the color of the sparkline. If this is tcell.ColorDefault
+// (the default), the text color of the list item or table cell is used.
+func (s *Sparkline) SetColor(color tcell.Color) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.color = color
+}
+
+// Draw draws the most recent samples which fit into the provided width,
+// right-aligned.
+func (s *Sparkline) Draw(screen ScreenWriter, width int, style tcell.Style) {
+	s.RLock()
+	defer s.RUnlock()
+
+	visible := min(s.count, width)
+	if visible <= 0 {
+		return
+	}
+	if s.color != tcell.ColorDefault {
+		style = style.Foreground(s.color)
+	}
+	first := s.count - visible
+
+	minimum, maximum := s.minimum, s.maximum
+	if minimum >= maximum {
+		minimum, maximum = math.Inf(1), math.Inf(-1)
+		for index := first; index < s.count; index++ {
+			sample := s.samples[(s.start+index)%len(s.samples)]
+			minimum = math.Min(minimum, sample)
+			maximum = math.Max(maximum, sample)
+		}
+	}
+
+	x := width - visible
+	for index := first; index < s.count; index++ {
+		sample := s.samples[(s.start+index)%len(s.samples)]
+		screen.SetContent(x, 0, sparklineRune(sample, minimum, maximum), nil, style)
+		x++
+	}
+}
+
+// Width returns the capacity of the sparkline.
+func (s *Sparkline) Width() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.samples)
+}
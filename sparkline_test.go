@@ -0,0 +1,45 @@
+package nuview
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	s := NewSparkline(4)
+	s.Add(1, 2, 3)
+	s.Add(4, 5)
+	if samples := s.GetSamples(); !reflect.DeepEqual(samples, []float64{2, 3, 4, 5}) {
+		t.Errorf("failed to add samples: expected [2 3 4 5], got %v", samples)
+	}
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 1)
+
+	l := NewList()
+	l.SetRect(0, 0, 12, 1)
+	l.SetScrollBarVisibility(ScrollBarNever)
+	item := NewListItem("cpu")
+	item.SetSuffixRenderer(s)
+	l.AddItem(item)
+
+	// Samples are clamped to a fixed range.
+	s.SetRange(0, 7)
+	s.Add(-1, 9)
+	l.Draw(sc)
+	for x, expected := range []rune("▅▆▁█") {
+		if r, _, _, _ := sc.GetContent(8+x, 0); r != expected {
+			t.Errorf("failed to draw sparkline suffix: expected %q at column %d, got %q", expected, x, r)
+		}
+	}
+	if r, _, _, _ := sc.GetContent(0, 0); r != 'c' {
+		t.Errorf("failed to draw main text: expected 'c', got %q", r)
+	}
+}
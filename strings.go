@@ -524,6 +524,115 @@ func TaggedStringWidth(text string) (width int) {
 	return
 }
 
+// MeasureTagged returns the width of the widest line of the given text and
+// the number of lines needed to print it on screen. Style tags have no width,
+// grapheme clusters are measured as a whole and wide characters (e.g. CJK) take
+// up two cells. The text is split into lines at newlines only.
+func MeasureTagged(text string) (width, height int) {
+	var (
+		state     *stepState
+		lineWidth int
+	)
+	height = 1
+	for len(text) > 0 {
+		_, text, state = step(text, state, stepOptionsStyle)
+		if lineBreak, optional := state.LineBreak(); lineBreak && !optional {
+			width = max(width, lineWidth)
+			lineWidth = 0
+			if len(text) > 0 {
+				height++
+			}
+			continue
+		}
+		lineWidth += state.Width()
+	}
+	return max(width, lineWidth), height
+}
+
+// TruncatePosition determines where TruncateTagged() shortens a text.
+type TruncatePosition int
+
+// Positions of the ellipsis in truncated texts.
+const (
+	TruncateEnd    TruncatePosition = iota // "Hello, w…"
+	TruncateStart                          // "…, world!"
+	TruncateMiddle                         // "Hell…rld!"
+)
+
+// TruncateTagged shortens a single line of text such that it does not exceed
+// the given screen width. If the text is too wide, clusters are removed at the
+// given position and replaced with an ellipsis (…). Style tags are kept, so
+// the remaining text retains its styles. Grapheme clusters are never split and
+// wide characters which do not fit are removed, so the result may be narrower
+// than the given width. Use MeasureTagged() to determine the width of a text.
+func TruncateTagged(text string, width int, position TruncatePosition) string {
+	if width <= 0 {
+		return ""
+	}
+
+	// Split the text into grapheme clusters, each with its preceding tags.
+	type segment struct {
+		raw, tags string
+		width     int
+	}
+	var (
+		segments   []segment
+		state      *stepState
+		totalWidth int
+	)
+	for str := text; len(str) > 0; {
+		var cluster string
+		cluster, str, state = step(str, state, stepOptionsStyle)
+		raw := text[len(text)-len(str)-state.GrossLength() : len(text)-len(str)]
+		tags, found := strings.CutSuffix(raw, cluster)
+		if !found {
+			tags = "" // An escaped tag.
+		}
+		segments = append(segments, segment{raw: raw, tags: tags, width: state.Width()})
+		totalWidth += state.Width()
+	}
+	if totalWidth <= width {
+		return text
+	}
+
+	// Determine the clusters to keep at the start and at the end.
+	available := width - 1 // For the ellipsis.
+	var headWidth, tailWidth int
+	switch position {
+	case TruncateEnd:
+		headWidth = available
+	case TruncateStart:
+		tailWidth = available
+	default:
+		headWidth = (available + 1) / 2
+		tailWidth = available - headWidth
+	}
+	head := 0
+	for head < len(segments) && segments[head].width <= headWidth {
+		headWidth -= segments[head].width
+		head++
+	}
+	tail := len(segments)
+	for tail > head && segments[tail-1].width <= tailWidth {
+		tailWidth -= segments[tail-1].width
+		tail--
+	}
+
+	var b strings.Builder
+	for index, segment := range segments {
+		switch {
+		case index < head || index >= tail:
+			b.WriteString(segment.raw)
+		default:
+			if index == head {
+				b.WriteRune(SemigraphicsHorizontalEllipsis)
+			}
+			b.WriteString(segment.tags)
+		}
+	}
+	return b.String()
+}
+
 // WordWrap splits a text such that each resulting line does not exceed the
 // given screen width. Split points are determined using the algorithm described
 // in [Unicode Standard Annex #14].
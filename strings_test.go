@@ -0,0 +1,34 @@
+package nuview
+
+import "testing"
+
+func TestTruncateTagged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text     string
+		width    int
+		position TruncatePosition
+		expected string
+	}{
+		{"Hello, world!", 20, TruncateEnd, "Hello, world!"},
+		{"Hello, world!", 9, TruncateEnd, "Hello, w…"},
+		{"Hello, world!", 9, TruncateStart, "…, world!"},
+		{"Hello, world!", 9, TruncateMiddle, "Hell…rld!"},
+		{"[red]Hello[-], [blue]world!", 7, TruncateEnd, "[red]Hello[-],…[blue]"},
+		{"[red]Hello[-], [blue]world!", 7, TruncateStart, "…[red][-][blue]world!"},
+		{"日本語のテキスト", 7, TruncateEnd, "日本語…"},
+		{"日本語のテキスト", 6, TruncateEnd, "日本…"},
+		{"Hello", 1, TruncateMiddle, "…"},
+		{"Hello", 0, TruncateEnd, ""},
+	}
+	for _, test := range tests {
+		if truncated := TruncateTagged(test.text, test.width, test.position); truncated != test.expected {
+			t.Errorf("failed to truncate %q to width %d: expected %q, got %q", test.text, test.width, test.expected, truncated)
+		}
+	}
+
+	if width, height := MeasureTagged("[red]日本[-]\nHello"); width != 5 || height != 2 {
+		t.Errorf("failed to measure text: expected 5x2, got %dx%d", width, height)
+	}
+}
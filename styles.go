@@ -2,7 +2,10 @@ package nuview
 
 import "github.com/gdamore/tcell/v2"
 
-// Theme defines the colors used when primitives are initialized.
+// Theme defines the colors and styles used when primitives are initialized.
+// The general colors are shared by all primitives. Widgets with more specific
+// needs have their own nested style structs, which may also be overridden per
+// instance (e.g. Button.SetStyles).
 type Theme struct {
 	// Title, border and other lines
 	TitleColor    tcell.Color // Box titles.
@@ -16,53 +19,20 @@ type Theme struct {
 	InverseTextColor           tcell.Color // Text on primary-colored backgrounds.
 	ContrastPrimaryTextColor   tcell.Color // Primary text for contrasting elements.
 	ContrastSecondaryTextColor tcell.Color // Secondary text on ContrastBackgroundColor-colored backgrounds.
+	ErrorTextColor             tcell.Color // Error messages.
 
 	// Background
 	PrimitiveBackgroundColor    tcell.Color // Main background color for primitives.
 	ContrastBackgroundColor     tcell.Color // Background color for contrasting elements.
 	MoreContrastBackgroundColor tcell.Color // Background color for even more contrasting elements.
 
-	// Button
-	ButtonCursorRune              rune // The symbol to draw at the end of button labels when focused.
-	ButtonLabelColor              tcell.Color
-	ButtonLabelFocusedColor       tcell.Color
-	ButtonBackgroundColor         tcell.Color
-	ButtonBackgroundFocusedColor  tcell.Color
-	ButtonBackgroundDisabledColor tcell.Color
-	ButtonLabelDisabledColor      tcell.Color
-
-	// Check box
-	CheckboxLabelStyle            tcell.Style
-	CheckboxUncheckedStyle        tcell.Style
-	CheckboxCheckedStyle          tcell.Style
-	CheckboxFocusStyle            tcell.Style
-	CheckboxCheckedString         string
-	CheckboxUncheckedString       string
-	CheckboxCursorCheckedString   string
-	CheckboxCursorUncheckedString string
-
-	// Input field
-	InputFieldLabelColor                              tcell.Color
-	InputFieldFieldBackgroundColor                    tcell.Color
-	InputFieldFieldBackgroundFocusedColor             tcell.Color
-	InputFieldFieldTextColor                          tcell.Color
-	InputFieldFieldTextFocusedColor                   tcell.Color
-	InputFieldPlaceholderTextColor                    tcell.Color
-	InputFieldAutocompleteListTextColor               tcell.Color
-	InputFieldAutocompleteListBackgroundColor         tcell.Color
-	InputFieldAutocompleteListSelectedTextColor       tcell.Color
-	InputFieldAutocompleteListSelectedBackgroundColor tcell.Color
-	InputFieldAutocompleteSuggestionTextColor         tcell.Color
-	InputFieldFieldNoteTextColor                      tcell.Color
-	InputFieldLabelFocusedColor                       tcell.Color
-	InputFieldPlaceholderTextFocusedColor             tcell.Color
-
-	ListMainTextColor           tcell.Color
-	ListSecondaryTextColor      tcell.Color
-	ListShortcutColor           tcell.Color
-	ListSelectedTextColor       tcell.Color
-	ListScrollBarColor          tcell.Color
-	ListSelectedBackgroundColor tcell.Color
+	// Widgets
+	Button     ButtonStyles
+	Checkbox   CheckboxStyles
+	InputField InputFieldStyles
+	Link       WidgetStyle
+	List       ListStyles
+	Table      TableStyles
 
 	// Context menu
 	ContextMenuPaddingTop    int
@@ -86,7 +56,8 @@ type Theme struct {
 
 // Styles defines the appearance of an application. The default is for a black
 // background and some basic colors: black, white, yellow, green, cyan, and
-// blue.
+// blue. An application may use its own theme instead, see
+// Application.SetStyles().
 var Styles = Theme{
 	TitleColor:    tcell.ColorWhite.TrueColor(),
 	BorderColor:   tcell.ColorWhite.TrueColor(),
@@ -98,49 +69,86 @@ var Styles = Theme{
 	InverseTextColor:           tcell.ColorBlack.TrueColor(),
 	ContrastPrimaryTextColor:   tcell.ColorBlack.TrueColor(),
 	ContrastSecondaryTextColor: tcell.ColorLightSlateGray.TrueColor(),
+	ErrorTextColor:             tcell.ColorRed.TrueColor(),
 
 	PrimitiveBackgroundColor:    tcell.ColorBlack.TrueColor(),
 	ContrastBackgroundColor:     tcell.ColorGreen.TrueColor(),
 	MoreContrastBackgroundColor: tcell.ColorDarkGreen.TrueColor(),
 
-	ButtonCursorRune:              '◀',
-	ButtonLabelColor:              tcell.ColorWhite.TrueColor(),
-	ButtonLabelFocusedColor:       tcell.ColorWhite.TrueColor(),
-	ButtonBackgroundColor:         tcell.ColorDarkGreen.TrueColor(),
-	ButtonBackgroundFocusedColor:  tcell.ColorGreen.TrueColor(),
-	ButtonBackgroundDisabledColor: tcell.ColorDarkGray.TrueColor(),
-	ButtonLabelDisabledColor:      tcell.ColorBlack.TrueColor(),
-
-	CheckboxLabelStyle:            tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()),
-	CheckboxUncheckedStyle:        tcell.StyleDefault.Background(tcell.ColorGreen.TrueColor()).Foreground(tcell.ColorWhite.TrueColor()),
-	CheckboxCheckedStyle:          tcell.StyleDefault.Background(tcell.ColorGreen.TrueColor()).Foreground(tcell.ColorWhite.TrueColor()),
-	CheckboxFocusStyle:            tcell.StyleDefault.Background(tcell.ColorWhite.TrueColor()).Foreground(tcell.ColorGreen.TrueColor()),
-	CheckboxCheckedString:         "[X]",
-	CheckboxUncheckedString:       "[ ]",
-	CheckboxCursorCheckedString:   ">X<",
-	CheckboxCursorUncheckedString: "> <",
-
-	InputFieldLabelColor:                              tcell.ColorYellow.TrueColor(),
-	InputFieldFieldBackgroundColor:                    tcell.ColorDarkGreen.TrueColor(),
-	InputFieldFieldBackgroundFocusedColor:             tcell.ColorGreen.TrueColor(),
-	InputFieldFieldTextColor:                          tcell.ColorWhite.TrueColor(),
-	InputFieldFieldTextFocusedColor:                   tcell.ColorWhite.TrueColor(),
-	InputFieldPlaceholderTextColor:                    tcell.ColorLightSlateGray.TrueColor(),
-	InputFieldAutocompleteListTextColor:               tcell.ColorBlack.TrueColor(),
-	InputFieldAutocompleteListBackgroundColor:         tcell.ColorDarkGreen.TrueColor(),
-	InputFieldAutocompleteListSelectedTextColor:       tcell.ColorBlack.TrueColor(),
-	InputFieldAutocompleteListSelectedBackgroundColor: tcell.ColorWhite.TrueColor(),
-	InputFieldAutocompleteSuggestionTextColor:         tcell.ColorLightSlateGray.TrueColor(),
-	InputFieldFieldNoteTextColor:                      tcell.ColorYellow.TrueColor(),
-	InputFieldLabelFocusedColor:                       ColorUnset,
-	InputFieldPlaceholderTextFocusedColor:             ColorUnset,
-
-	ListMainTextColor:           tcell.ColorWhite.TrueColor(),
-	ListSecondaryTextColor:      tcell.ColorLimeGreen.TrueColor(),
-	ListShortcutColor:           tcell.ColorYellow.TrueColor(),
-	ListSelectedTextColor:       tcell.ColorBlack.TrueColor(),
-	ListScrollBarColor:          tcell.ColorWhite.TrueColor(),
-	ListSelectedBackgroundColor: tcell.ColorWhite.TrueColor(),
+	Button: ButtonStyles{
+		WidgetStyle: WidgetStyle{
+			Normal:   tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorDarkGreen.TrueColor()),
+			Focused:  tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorGreen.TrueColor()),
+			Disabled: tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorDarkGray.TrueColor()),
+		},
+		Pressed:    tcell.StyleDefault.Foreground(tcell.ColorDarkGreen.TrueColor()).Background(tcell.ColorWhite.TrueColor()),
+		CursorRune: '◀',
+	},
+
+	Checkbox: CheckboxStyles{
+		Label: WidgetStyle{
+			Normal: tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()),
+		},
+		Box: WidgetStyle{
+			Normal:   tcell.StyleDefault.Background(tcell.ColorGreen.TrueColor()).Foreground(tcell.ColorWhite.TrueColor()),
+			Selected: tcell.StyleDefault.Background(tcell.ColorGreen.TrueColor()).Foreground(tcell.ColorWhite.TrueColor()),
+			Focused:  tcell.StyleDefault.Background(tcell.ColorWhite.TrueColor()).Foreground(tcell.ColorGreen.TrueColor()),
+		},
+		Pressed:               tcell.StyleDefault.Background(tcell.ColorYellow.TrueColor()).Foreground(tcell.ColorBlack.TrueColor()),
+		CheckedString:         "[X]",
+		UncheckedString:       "[ ]",
+		CursorCheckedString:   ">X<",
+		CursorUncheckedString: "> <",
+	},
+
+	InputField: InputFieldStyles{
+		Label: WidgetStyle{
+			Normal: tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()),
+		},
+		Field: WidgetStyle{
+			Normal:  tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorDarkGreen.TrueColor()),
+			Focused: tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorGreen.TrueColor()),
+		},
+		Placeholder: WidgetStyle{
+			Normal: tcell.StyleDefault.Foreground(tcell.ColorLightSlateGray.TrueColor()),
+		},
+		AutocompleteList: WidgetStyle{
+			Normal:   tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorDarkGreen.TrueColor()),
+			Selected: tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorWhite.TrueColor()),
+		},
+		AutocompleteSuggestion: tcell.StyleDefault.Foreground(tcell.ColorLightSlateGray.TrueColor()),
+		FieldNote:              tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()),
+	},
+
+	Link: WidgetStyle{
+		Normal:   tcell.StyleDefault.Foreground(tcell.ColorLightSkyBlue.TrueColor()).Underline(true),
+		Focused:  tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorLightSkyBlue.TrueColor()).Underline(true),
+		Disabled: tcell.StyleDefault.Foreground(tcell.ColorGray.TrueColor()).Underline(true),
+	},
+
+	List: ListStyles{
+		MainText: WidgetStyle{
+			Normal:   tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()),
+			Selected: tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorWhite.TrueColor()),
+		},
+		SecondaryText:  tcell.StyleDefault.Foreground(tcell.ColorLimeGreen.TrueColor()),
+		Shortcut:       tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()),
+		ScrollBarColor: tcell.ColorWhite.TrueColor(),
+	},
+
+	Table: TableStyles{
+		Cell: WidgetStyle{
+			Normal: tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorBlack.TrueColor()),
+		},
+		Borders: tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()),
+		Range:   tcell.StyleDefault.Foreground(tcell.ColorWhite.TrueColor()).Background(tcell.ColorNavy.TrueColor()),
+		Header:  tcell.StyleDefault.Foreground(tcell.ColorYellow.TrueColor()).Background(tcell.ColorBlack.TrueColor()).Bold(true),
+
+		SortAscending:  " ▲",
+		SortDescending: " ▼",
+		Match:          tcell.StyleDefault.Foreground(tcell.ColorBlack.TrueColor()).Background(tcell.ColorYellow.TrueColor()),
+		Marker:         "●",
+	},
 
 	ContextMenuPaddingTop:    0,
 	ContextMenuPaddingBottom: 0,
@@ -157,3 +165,145 @@ var Styles = Theme{
 	WindowMinWidth:  4,
 	WindowMinHeight: 3,
 }
+
+// WidgetStyle holds the styles of a primitive for its different states. A
+// state style which equals tcell.StyleDefault is considered unset and falls
+// back to the Normal style.
+type WidgetStyle struct {
+	Normal   tcell.Style // The primitive is neither focused, disabled nor selected.
+	Focused  tcell.Style // The primitive has focus.
+	Disabled tcell.Style // The primitive is disabled.
+	Selected tcell.Style // The primitive (or an item of it) is selected.
+}
+
+// Style returns the style for the provided state. Disabled takes precedence
+// over selected, which takes precedence over focused.
+func (s WidgetStyle) Style(focused, disabled, selected bool) tcell.Style {
+	var style tcell.Style
+	switch {
+	case disabled:
+		style = s.Disabled
+	case selected:
+		style = s.Selected
+	case focused:
+		style = s.Focused
+	default:
+		return s.Normal
+	}
+	if style == tcell.StyleDefault {
+		return s.Normal
+	}
+	return style
+}
+
+// ButtonStyles holds the styles of a Button. The foreground of each style is
+// used for the label, the background for the button itself. The Selected
+// state is not used.
+type ButtonStyles struct {
+	WidgetStyle
+
+	// The style shown briefly after the button was activated with the keyboard
+	// (see PressedDuration). If unset, the focused style is shown reversed.
+	Pressed tcell.Style
+
+	// The symbol to draw at the end of the label when focused.
+	CursorRune rune
+}
+
+// CheckboxStyles holds the styles of a Checkbox.
+type CheckboxStyles struct {
+	// The labels. The Selected state is not used.
+	Label WidgetStyle
+
+	// The checkbox itself. Normal is used when unchecked, Selected when
+	// checked. Focused takes precedence over both.
+	Box WidgetStyle
+
+	// The style of the checkbox shown briefly after it was toggled with the
+	// keyboard (see PressedDuration). If unset, the focused style is shown
+	// reversed.
+	Pressed tcell.Style
+
+	// The strings shown for the checked and unchecked states, without and with
+	// the cursor on the checkbox.
+	CheckedString         string
+	UncheckedString       string
+	CursorCheckedString   string
+	CursorUncheckedString string
+}
+
+// InputFieldStyles holds the styles of an InputField.
+type InputFieldStyles struct {
+	// The label. The Selected state is not used.
+	Label WidgetStyle
+
+	// The input area. The Selected state is not used.
+	Field WidgetStyle
+
+	// The placeholder text. The Selected state is not used. An unset
+	// background keeps the background of the input area.
+	Placeholder WidgetStyle
+
+	// The items of the autocomplete list. Selected is used for the current
+	// item.
+	AutocompleteList WidgetStyle
+
+	// The autocomplete suggestion shown after the entered text.
+	AutocompleteSuggestion tcell.Style
+
+	// The note below the input area.
+	FieldNote tcell.Style
+}
+
+// ListStyles holds the styles of a List.
+type ListStyles struct {
+	// The main text of the items. Selected is used for the current item.
+	MainText WidgetStyle
+
+	// The secondary text of the items.
+	SecondaryText tcell.Style
+
+	// The shortcuts of the items.
+	Shortcut tcell.Style
+
+	// The color of the scroll bar.
+	ScrollBarColor tcell.Color
+}
+
+// TableStyles holds the styles of a Table.
+type TableStyles struct {
+	// The cells. Normal is the initial style of new cells (see NewTableCell).
+	// Selected is used for selected cells which don't define their own
+	// selected style. If it is unset, the cell's colors are swapped.
+	Cell WidgetStyle
+
+	// The borders. An unset background keeps the table's background color.
+	Borders tcell.Style
+
+	// The cells of a selected range (see Table.SetRangeSelectable) other than
+	// the selected cell. If it is unset, the selected style is used.
+	Range tcell.Style
+
+	// The cells of the header row (see Table.SetHeader) which don't define
+	// their own style. If it is unset, the cells' colors are used.
+	Header tcell.Style
+
+	// Applied to the cells of every other row, starting with the second one
+	// (zebra striping). See Table.SetAlternateRowStyle for details. It is
+	// unset by default.
+	AlternateRow tcell.Style
+
+	// The texts appended to the header cell of the column the table is sorted
+	// by, see Table.SetSortColumn.
+	SortAscending, SortDescending string
+
+	// The matches of the text searched for with Table.Find.
+	Match tcell.Style
+
+	// The string shown by marked marker cells, see NewMarkerCell.
+	Marker string
+
+	// Applied to the cells of the row under the mouse, see
+	// Table.SetHoverStyle. It is unset by default.
+	Hover tcell.Style
+}
@@ -16,8 +16,16 @@ type TabbedPanels struct {
 	panels   *Panels
 
 	tabLabels  map[string]string
+	tabBadges  map[string]string
 	currentTab string
 
+	badgeColor tcell.Color
+
+	// Whether or not the user may close tabs, and an optional handler which
+	// decides whether or not a tab is closed.
+	closable bool
+	closing  func(name string) bool
+
 	dividerStart string
 	dividerMid   string
 	dividerEnd   string
@@ -42,6 +50,8 @@ func NewTabbedPanels() *TabbedPanels {
 		dividerMid: string(BoxDrawingsDoubleVertical),
 		dividerEnd: string(BoxDrawingsLightVertical),
 		tabLabels:  make(map[string]string),
+		tabBadges:  make(map[string]string),
+		badgeColor: Styles.SecondaryTextColor,
 	}
 
 	s := t.Switcher
@@ -89,6 +99,10 @@ func (t *TabbedPanels) AddTab(name, label string, item Primitive) {
 
 // RemoveTab removes a tab.
 func (t *TabbedPanels) RemoveTab(name string) {
+	t.Lock()
+	delete(t.tabBadges, name)
+	t.Unlock()
+
 	t.panels.RemovePanel(name)
 
 	t.updateAll()
@@ -156,6 +170,108 @@ func (t *TabbedPanels) SetTabLabel(name, label string) {
 	t.updateTabLabels()
 }
 
+// SetTabBadge sets a short text shown after the label of a tab, e.g. the
+// number of unread messages or a dot marking unsaved changes. An empty badge
+// removes it. Color tags are supported.
+func (t *TabbedPanels) SetTabBadge(name, badge string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.tabBadges[name] == badge {
+		return
+	}
+
+	if badge == "" {
+		delete(t.tabBadges, name)
+	} else {
+		t.tabBadges[name] = badge
+	}
+	t.updateTabLabels()
+}
+
+// GetTabBadge returns the badge of a tab, see SetTabBadge().
+func (t *TabbedPanels) GetTabBadge(name string) string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.tabBadges[name]
+}
+
+// SetTabBadgeColor sets the color of tab badges.
+func (t *TabbedPanels) SetTabBadgeColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.badgeColor = color
+	t.updateTabLabels()
+}
+
+// SetTabShortcut sets the keybindings (e.g. "Alt+M" or "F2") which switch to
+// the tab with the given name while the tabbed panels have focus. Calling it
+// without keybindings removes them.
+func (t *TabbedPanels) SetTabShortcut(name string, shortcuts ...string) {
+	t.panels.SetPanelShortcut(name, shortcuts...)
+}
+
+// SetTabNumberShortcuts sets whether or not Alt+1 to Alt+9 switch to the
+// first nine tabs while the tabbed panels have focus. Keybindings set with
+// SetTabShortcut() take precedence.
+//
+// This is disabled by default.
+func (t *TabbedPanels) SetTabNumberShortcuts(enabled bool) {
+	t.panels.SetNumberShortcuts(enabled)
+}
+
+// SetTabsClosable sets whether or not the user may close tabs by pressing
+// Keys.CloseTab (which closes the current tab) or by clicking a tab with the
+// middle mouse button. See SetTabCloseFunc() to keep tabs from being closed.
+//
+// This is disabled by default.
+func (t *TabbedPanels) SetTabsClosable(closable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.closable = closable
+}
+
+// SetTabCloseFunc sets a handler which is called when a tab is about to be
+// closed by the user or by CloseTab(). The tab is only closed if the handler
+// returns true. This can be used to ask the user whether or not to discard
+// unsaved changes, closing the tab later with RemoveTab().
+func (t *TabbedPanels) SetTabCloseFunc(handler func(name string) bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.closing = handler
+}
+
+// CloseTab closes the tab with the given name unless the handler set with
+// SetTabCloseFunc() vetoes it. It returns whether or not the tab was closed.
+func (t *TabbedPanels) CloseTab(name string) bool {
+	if !t.HasTab(name) {
+		return false
+	}
+
+	t.RLock()
+	closing := t.closing
+	t.RUnlock()
+
+	if closing != nil && !closing(name) {
+		return false
+	}
+	t.RemoveTab(name)
+	return true
+}
+
+// tabLabel returns the label of a tab including its badge, if any.
+func (t *TabbedPanels) tabLabel(name string) string {
+	label := t.tabLabels[name]
+	if badge := t.tabBadges[name]; badge != "" {
+		label += fmt.Sprintf(" [%s]%s[-]", ColorHex(t.badgeColor), badge)
+	}
+	return label
+}
+
 // SetTabTextColor sets the color of the tab text.
 func (t *TabbedPanels) SetTabTextColor(color tcell.Color) {
 	t.Switcher.SetTextColor(color)
@@ -252,9 +368,9 @@ func (t *TabbedPanels) updateTabLabels() {
 
 	maxWidth := 0
 	for _, panel := range t.panels.panels {
-		label := t.tabLabels[panel.Name]
-		if len(label) > maxWidth {
-			maxWidth = len(label)
+		width := TaggedStringWidth(t.tabLabel(panel.Name))
+		if width > maxWidth {
+			maxWidth = width
 		}
 	}
 
@@ -274,13 +390,13 @@ func (t *TabbedPanels) updateTabLabels() {
 			b.WriteRune(' ')
 		}
 
-		label := t.tabLabels[panel.Name]
+		label := t.tabLabel(panel.Name)
 		if !t.switcherVertical {
 			label = " " + label
 		}
 
 		if t.switcherVertical {
-			spacer = bytes.Repeat([]byte(" "), maxWidth-len(label)+1)
+			spacer = bytes.Repeat([]byte(" "), maxWidth-TaggedStringWidth(label)+1)
 		}
 
 		b.WriteString(fmt.Sprintf(`["%s"]%s%s[""]`, panel.Name, label, spacer))
@@ -373,6 +489,25 @@ func (t *TabbedPanels) InputHandler() func(event *tcell.EventKey, setFocus func(
 		if t.setFocus == nil {
 			t.setFocus = setFocus
 		}
+
+		t.panels.RLock()
+		name, ok := t.panels.shortcutPanel(event)
+		t.panels.RUnlock()
+		if ok {
+			t.SetCurrentTab(name)
+			setFocus(t.panels)
+			return
+		}
+
+		t.RLock()
+		closable := t.closable
+		t.RUnlock()
+		if closable && t.hitShortcut(event, t.keys().CloseTab) {
+			t.CloseTab(t.GetCurrentTab())
+			setFocus(t.panels)
+			return
+		}
+
 		t.Flex.InputHandler()(event, setFocus)
 	})
 }
@@ -390,6 +525,16 @@ func (t *TabbedPanels) MouseHandler() func(action MouseAction, event *tcell.Even
 		}
 
 		if t.Switcher.InRect(x, y) {
+			t.RLock()
+			closable := t.closable
+			t.RUnlock()
+			if closable && action == MouseMiddleClick {
+				if name, ok := t.Switcher.regionAt(x, y); ok && name != "" {
+					t.CloseTab(name)
+				}
+				return true, nil
+			}
+
 			if t.setFocus != nil {
 				defer t.setFocus(t.panels)
 			}
@@ -0,0 +1,83 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTabbedPanelsShortcuts(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(30, 5)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 30; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tp := NewTabbedPanels()
+	tp.SetTabSwitcherDivider("", "|", "")
+	tp.AddTab("a", "A", NewBox())
+	tp.AddTab("b", "B", NewBox())
+	tp.AddTab("c", "C", NewBox())
+	tp.SetRect(0, 0, 30, 5)
+	key := func(k tcell.Key, ch rune, mod tcell.ModMask) {
+		tp.InputHandler()(tcell.NewEventKey(k, ch, mod), func(Primitive) {})
+	}
+
+	// Switch tabs with shortcuts.
+	key(tcell.KeyRune, '2', tcell.ModAlt)
+	if tab := tp.GetCurrentTab(); tab != "a" {
+		t.Errorf("failed to ignore disabled number shortcuts: expected a, got %s", tab)
+	}
+	tp.SetTabNumberShortcuts(true)
+	key(tcell.KeyRune, '2', tcell.ModAlt)
+	if tab := tp.GetCurrentTab(); tab != "b" {
+		t.Errorf("failed to switch tab with number shortcut: expected b, got %s", tab)
+	}
+	tp.SetTabShortcut("c", "F2")
+	key(tcell.KeyF2, 0, tcell.ModNone)
+	if tab := tp.GetCurrentTab(); tab != "c" {
+		t.Errorf("failed to switch tab with shortcut: expected c, got %s", tab)
+	}
+
+	// Show a badge.
+	tp.SetTabBadge("b", "3")
+	tp.Draw(sc)
+	if got := strings.TrimRight(row(0), " "); got != " A | B 3 | C" {
+		t.Errorf("failed to draw tab badge: got %q", got)
+	}
+	if badge := tp.GetTabBadge("b"); badge != "3" {
+		t.Errorf("failed to get tab badge: expected 3, got %q", badge)
+	}
+
+	// Close tabs, unless the handler vetoes it.
+	var requested []string
+	veto := true
+	tp.SetTabCloseFunc(func(name string) bool {
+		requested = append(requested, name)
+		return !veto
+	})
+	tp.SetTabsClosable(true)
+	key(tcell.KeyF4, 0, tcell.ModCtrl)
+	if !tp.HasTab("c") {
+		t.Error("failed to veto closing tab")
+	}
+	veto = false
+	tp.MouseHandler()(MouseMiddleClick, tcell.NewEventMouse(5, 0, tcell.ButtonMiddle, tcell.ModNone), func(Primitive) {})
+	if tp.HasTab("b") {
+		t.Error("failed to close tab with middle click")
+	}
+	if got := strings.Join(requested, " "); got != "c b" {
+		t.Errorf("failed to request closing tabs: expected \"c b\", got %q", got)
+	}
+}
@@ -1,10 +1,13 @@
 package nuview
 
 import (
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
 )
 
 // TableCell represents one cell inside a Table. You can instantiate this type
@@ -59,6 +62,15 @@ type TableCell struct {
 	// If set to true, this cell cannot be selected.
 	NotSelectable bool
 
+	// If set to true, the text is wrapped onto as many lines as needed instead
+	// of being cut off, increasing the height of the row.
+	Wrap bool
+
+	// An optional renderer which draws the cell's content in place of its
+	// text, e.g. a progress bar. The text is still used for other purposes,
+	// e.g. sorting. See CellRenderer for details.
+	Renderer CellRenderer
+
 	// An optional handler for mouse clicks. This also fires if the cell is not
 	// selectable. If true is returned, no additional "selected" event is fired
 	// on selectable cells.
@@ -81,7 +93,7 @@ func NewTableCell(text string) *TableCell {
 	cell := &TableCell{
 		Text:        text,
 		Align:       AlignLeft,
-		Style:       tcell.StyleDefault.Foreground(Styles.PrimaryTextColor).Background(Styles.PrimitiveBackgroundColor),
+		Style:       Styles.Table.Cell.Normal,
 		Transparent: true,
 	}
 	cell.updateWidth()
@@ -92,6 +104,12 @@ func NewTableCell(text string) *TableCell {
 // the text content and maximum width constraint.
 func (c *TableCell) updateWidth() {
 	textWidth := TaggedStringWidth(c.Text)
+	if c.Wrap {
+		textWidth, _ = MeasureTagged(c.Text)
+	}
+	if c.Renderer != nil {
+		textWidth = c.Renderer.Width()
+	}
 	if c.MaxWidth > 0 && textWidth > c.MaxWidth {
 		c.width = c.MaxWidth
 	} else {
@@ -212,6 +230,17 @@ func (c *TableCell) SetSelectedStyle(style tcell.Style) {
 	c.SelectedStyle = style
 }
 
+// SetWrap sets whether or not the cell's text is wrapped onto multiple lines
+// when it is wider than its column. Rows are as high as their highest cell.
+// Combine this with SetMaxWidth() to limit the width of the column. Lines
+// breaks in the text are kept.
+func (c *TableCell) SetWrap(wrap bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.Wrap = wrap
+	c.updateWidth()
+}
+
 // SetSelectable sets whether or not this cell can be selected by the user.
 func (c *TableCell) SetSelectable(selectable bool) {
 	c.Lock()
@@ -249,6 +278,22 @@ func (c *TableCell) GetLastPosition() (x, y, width int) {
 	return c.x, c.y, c.width
 }
 
+// SetRenderer sets a renderer which draws the cell's content in place of its
+// text. Set to nil to draw the text again.
+func (c *TableCell) SetRenderer(renderer CellRenderer) {
+	c.Lock()
+	defer c.Unlock()
+	c.Renderer = renderer
+	c.updateWidth()
+}
+
+// GetRenderer returns the cell's renderer or nil if its text is drawn.
+func (c *TableCell) GetRenderer() CellRenderer {
+	c.RLock()
+	defer c.RUnlock()
+	return c.Renderer
+}
+
 // SetClickedFunc sets a handler which fires when this cell is clicked. This is
 // independent of whether the cell is selectable or not. But for selectable
 // cells, if the function returns "true", the "selected" event is not fired.
@@ -258,7 +303,12 @@ func (c *TableCell) SetClickedFunc(clicked func() bool) {
 	c.Clicked = clicked
 }
 
-type tableContent interface {
+// TableContent provides the data of a Table. By default, a table stores its
+// cells in memory. Provide your own implementation with Table.SetContent() to
+// back a table with other data, e.g. a database cursor, such that only the
+// cells which are drawn are ever created. GetCell() is called for each visible
+// cell whenever the table is drawn, so it should be fast.
+type TableContent interface {
 	// Return the cell at the given position or nil if there is no cell. The
 	// row and column arguments start at 0 and end at what GetRowCount() and
 	// GetColumnCount() return, minus 1.
@@ -302,6 +352,29 @@ type tableContent interface {
 	Clear()
 }
 
+// TableContentReadOnly is an empty implementation of the modifying functions
+// of TableContent. Embed it in read-only implementations of TableContent to
+// only implement GetCell(), GetRowCount() and GetColumnCount().
+type TableContentReadOnly struct{}
+
+// SetCell does nothing.
+func (t TableContentReadOnly) SetCell(row, column int, cell *TableCell) {}
+
+// RemoveRow does nothing.
+func (t TableContentReadOnly) RemoveRow(row int) {}
+
+// RemoveColumn does nothing.
+func (t TableContentReadOnly) RemoveColumn(column int) {}
+
+// InsertRow does nothing.
+func (t TableContentReadOnly) InsertRow(row int) {}
+
+// InsertColumn does nothing.
+func (t TableContentReadOnly) InsertColumn(column int) {}
+
+// Clear does nothing.
+func (t TableContentReadOnly) Clear() {}
+
 // tableDefaultContent implements the default TableContent interface for the
 // Table class.
 type tableDefaultContent struct {
@@ -453,22 +526,108 @@ func (t *tableDefaultContent) GetColumnCount() int {
 // See https://github.com/rivo/tview/wiki/Table for an example.
 type Table struct {
 	*Box
+	*ContextMenu
 
 	// Whether or not this table has borders around each cell.
 	borders bool
 
-	// The color of the borders or the separator.
-	bordersColor tcell.Color
+	// The styles of the borders (or the separator) and of selected cells.
+	styles TableStyles
 
 	// If there are no borders, the column separator.
 	separator rune
 
-	// The table's data structure.
-	content tableContent
+	// The table's data structure. If a column order is set, this is the view
+	// presenting the content in that order, see SetColumnOrder().
+	content    TableContent
+	columnView *tableColumnView
+
+	// Whether or not only the drawn rows are measured to determine the widths
+	// of the columns, see SetEvaluateVisibleRowsOnly().
+	visibleRowsOnly bool
+
+	// The table whose columns this table follows, see TableSplitView, nil
+	// for none. Its column widths are used.
+	columnsSource *Table
+
+	// The vertical scroll bar. It is never shown by default.
+	scrollBar *ScrollBar
+
+	// The horizontal scroll bar. It is never shown by default.
+	horizontalScrollBar *ScrollBar
+
+	// Animates scrolling with the mouse wheel and by pages.
+	smoothScroll smoothScroll
+
+	// Reports changes of the row offset, see SetScrollChangedFunc().
+	scrollChanged scrollNotifier
+
+	// Reports changes of the row offset and of the horizontal scroll
+	// position, see SetViewportChangedFunc().
+	viewportChanged scrollNotifier
+
+	// The placeholder and the loading spinner, shown below the fixed rows.
+	contentState contentState
+
+	// The cells of the header row drawn above all other rows, nil for none.
+	// See SetHeader().
+	header []*TableCell
+
+	// The column whose header shows the sort indicator (-1 for none) and the
+	// direction of the sort order.
+	sortColumn    int
+	sortAscending bool
+
+	// An optional function which is called when a header cell is clicked.
+	sortFunc func(column int, ascending bool)
+
+	// Functions which return the renderers of the cells of content columns,
+	// see SetColumnRenderer().
+	columnRenderers map[int]func(row int, cell *TableCell) CellRenderer
+
+	// The default attributes of the cells of content columns, see
+	// SetColumnAttributes().
+	columnAttributes map[int]tableColumnAttributes
+
+	// Whether or not columns shrink to fit the table's width, and the
+	// minimum widths and shrink priorities of content columns, see
+	// SetAutoFitColumns().
+	autoFit    bool
+	columnFits map[int]tableColumnFit
+
+	// A function which returns the byte ranges of the matches of the find query
+	// in the text of a cell without style tags, nil if nothing is searched
+	// for. See Find().
+	findMatches func(text string) [][]int
+
+	// An optional function which determines whether or not a row is shown,
+	// see SetFilterFunc().
+	filter func(row int) bool
+
+	// An optional function which returns the style applied to the cells of a
+	// row, see SetRowStyleFunc().
+	rowStyle func(row int) tcell.Style
+
+	// If a filter is set, the rows shown as of the last time the table was
+	// drawn, including the fixed rows. Row offsets count these rows then.
+	shownRows []int
 
 	// The number of fixed rows / columns.
 	fixedRows, fixedColumns int
 
+	// The number of fixed rows at the bottom and of fixed columns on the
+	// right, see SetFixedFooter() and SetFixedRight().
+	fixedFooterRows, fixedRightColumns int
+
+	// The x-coordinate (relative to the inner rect) of the fixed columns on
+	// the right as of the last time the table was drawn.
+	rightColumnsX int
+
+	// The rows being drawn, the y-coordinates (relative to the inner rect) of
+	// their first lines and their heights. Unless RenderRegion() is drawing,
+	// these are the rows drawn by the last call to Draw().
+	drawnRows, rowTops, rowHeights []int
+
 	// Whether or not rows or columns can be selected. If both are set to true,
 	// cells can be selected.
 	rowsSelectable, columnsSelectable bool
@@ -476,6 +635,39 @@ type Table struct {
 	// The currently selected row and column.
 	selectedRow, selectedColumn int
 
+	// Whether or not a range of cells can be selected, see
+	// SetRangeSelectable(). The range spans from the anchor to the selected
+	// cell. A negative anchor row means that there is no range.
+	rangeSelectable                   bool
+	rangeAnchorRow, rangeAnchorColumn int
+
+	// Set to true while a range is selected by dragging the mouse.
+	rangeDragging bool
+
+	// Whether or not the user may move rows, see SetRowsMovable(), and set to
+	// true while the selected row is moved by dragging the mouse.
+	rowsMovable bool
+	rowMoving   bool
+
+	// Whether or not the user may move columns, see SetColumnsMovable(), and
+	// the column whose header cell is dragged with the mouse (-1 for none) as
+	// well as whether it was moved since the mouse button was pressed.
+	columnsMovable     bool
+	draggedColumn      int
+	draggedColumnMoved bool
+
+	// Whether or not the user may hide and show columns, see
+	// SetColumnsHideable(), and the menu which lets them do so, nil until it
+	// is first shown.
+	columnsHideable bool
+	columnChooser   *ContextMenu
+
+	// Whether or not the selection follows the reference of the selected row
+	// when rows are inserted or removed, see SetStableSelection(), and that
+	// reference as of the last time the table was drawn or a row was selected.
+	stableSelection   bool
+	selectedReference interface{}
+
 	// A temporary flag which causes the next call to Draw() to force the
 	// current selection to remain visible. It is set to false afterwards.
 	clampToSelection bool
@@ -494,17 +686,22 @@ type Table struct {
 	// If set to true, the table's last row will always be visible.
 	trackEnd bool
 
+	// The cell to be scrolled into view the next time the table is drawn, if
+	// reveal is set. A negative row or column is not scrolled to.
+	reveal                  bool
+	revealRow, revealColumn int
+
 	// The number of visible rows the last time the table was drawn.
 	visibleRows int
 
+	// Set while RenderRegion() draws cells so their last positions on screen
+	// are left untouched.
+	renderingRegion bool
+
 	// The indices of the visible columns as of the last time the table was
 	// drawn.
 	visibleColumnIndices []int
 
-	// The style of the selected rows. If this value is the empty struct,
-	// selected rows are simply inverted.
-	selectedStyle tcell.Style
-
 	// An optional function which gets called when the user presses Enter on a
 	// selected cell. If entire rows selected, the column value is undefined.
 	// Likewise for entire columns.
@@ -515,234 +712,1473 @@ type Table struct {
 	// Likewise for entire columns.
 	selectionChanged func(row, column int)
 
+	// An optional function which is called when the selected range changes.
+	selectionRangeChanged func(fromRow, fromColumn, toRow, toColumn int)
+
 	// An optional function which gets called when the user double-clicks a
 	// cell. If entire rows are selected, the column index is undefined.
 	doubleClick func(row, column int)
 
+	// An optional function which gets called when the user right-clicks the
+	// table.
+	rightClick func(row, column, x, y int)
+
+	// The cell under the mouse (-1 for none) and an optional function which
+	// gets called when it changes, see SetHoverFunc().
+	hoverRow, hoverColumn int
+	hover                 func(row, column int)
+
+	// An optional function which gets called when the user moved a row.
+	rowMoved func(from, to int)
+
+	// An optional function which gets called when the user moved a column.
+	columnMoved func(from, to int)
+
+	// An optional function which gets called after the content was changed
+	// with ApplyUpdates().
+	contentChanged func()
+
 	// An optional function which gets called when the user presses Escape, Tab,
 	// or Backtab. Also when the user presses Enter if nothing is selectable.
 	done func(key tcell.Key)
 
-	lastMouseDown       time.Time
-	doubleClickDuration time.Duration
+	// An optional model of the selected rows, see SetSelectionModel().
+	selection *SelectionModel
+
 	sync.RWMutex
 }
 
 // NewTable returns a new table.
 func NewTable() *Table {
 	t := &Table{
-		Box:                 NewBox(),
-		bordersColor:        Styles.GraphicsColor,
-		separator:           ' ',
-		doubleClickDuration: StandardDoubleClick,
+		Box:       NewBox(),
+		styles:    Styles.Table,
+		separator: ' ',
 		content: &tableDefaultContent{
 			lastColumn: -1,
 		},
+		scrollBar:           NewScrollBar(),
+		horizontalScrollBar: NewHorizontalScrollBar(),
+		rangeAnchorRow:      -1,
+		sortColumn:          -1,
+		draggedColumn:       -1,
+		hoverRow:            -1,
+		hoverColumn:         -1,
 	}
+	t.ContextMenu = NewContextMenu(t)
+	t.scrollBar.SetVisibility(ScrollBarNever)
+	t.horizontalScrollBar.SetVisibility(ScrollBarNever)
 	return t
 }
 
+// SetContent sets the data of the table, replacing the cells stored in the
+// table itself. All functions of the table which access cells, e.g. SetCell()
+// or GetCell(), then use the provided content. Only the visible cells are
+// requested when the table is drawn, so the widths of the columns are those of
+// their widest visible cells and may change while scrolling. Provide nil to
+// return to an empty table storing its own cells. See TableContent for
+// details.
+func (t *Table) SetContent(content TableContent) {
+	t.Lock()
+	defer t.Unlock()
+
+	if content == nil {
+		content = &tableDefaultContent{lastColumn: -1}
+	}
+	t.content = content
+	if t.columnView != nil {
+		t.columnView.TableContent = content
+		t.content = t.columnView
+	}
+	t.trackEnd = false
+}
+
+// GetContent returns the data of the table, see SetContent().
+func (t *Table) GetContent() TableContent {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.columnView != nil {
+		return t.columnView.TableContent
+	}
+	return t.content
+}
+
 // Clear removes all table data.
 func (t *Table) Clear() {
 	t.Lock()
 	defer t.Unlock()
 	t.content.Clear()
+	if t.selection != nil {
+		t.selection.Clear()
+	}
 }
 
-// SetBorders sets whether or not each cell in the table is surrounded by a
-// border.
-func (t *Table) SetBorders(show bool) {
+// tableHeaderRow is the row index of the header row, see Table.SetHeader().
+const tableHeaderRow = -1
+
+// SetHeader sets the cells of a header row which is drawn above all other rows
+// and stays visible while scrolling. Unlike a fixed row (see SetFixed()), the
+// header is not part of the table's content: it is not counted by
+// GetRowCount(), it cannot be selected, and row 0 is the first row below it.
+// Header cells with the default style are drawn in the header style of the
+// table (see TableStyles). Provide nil to remove the header.
+//
+// The header of the column the table is sorted by shows a sort indicator, see
+// SetSortColumn(). Clicking a header cell sorts by its column, see
+// SetSortFunc().
+func (t *Table) SetHeader(cells []*TableCell) {
 	t.Lock()
 	defer t.Unlock()
-	t.borders = show
+	for _, cell := range cells {
+		if cell != nil {
+			cell.updateWidth()
+		}
+	}
+	t.header = cells
 }
 
-// SetBordersColor sets the color of the cell borders.
-func (t *Table) SetBordersColor(color tcell.Color) {
+// GetHeader returns the cells of the header row, nil if there is none.
+func (t *Table) GetHeader() []*TableCell {
+	t.RLock()
+	defer t.RUnlock()
+	return t.header
+}
+
+// SetSortColumn sets the column whose header cell shows the sort indicator
+// and the direction of the sort order. It does not sort the table. Provide -1
+// to remove the indicator.
+func (t *Table) SetSortColumn(column int, ascending bool) {
 	t.Lock()
 	defer t.Unlock()
-	t.bordersColor = color
+	t.sortColumn, t.sortAscending = column, ascending
 }
 
-// SetSelectedStyle sets a specific style for selected cells. If no such style
-// is set, the cell's background and text color are swapped. If a cell defines
-// its own selected style, that will be used instead.
-//
-// To reset a previous setting to its default, make the following call:
-//
-//	table.SetSelectedStyle(tcell.StyleDefault)
-func (t *Table) SetSelectedStyle(style tcell.Style) {
+// GetSortColumn returns the column whose header cell shows the sort indicator
+// (-1 for none) and the direction of the sort order.
+func (t *Table) GetSortColumn() (column int, ascending bool) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.sortColumn, t.sortAscending
+}
+
+// SetSortFunc sets a handler which is called when the user clicks a header
+// cell. The clicked column becomes the sort column, sorted in ascending order
+// or, if it already was the sort column, in the reverse order. The handler is
+// expected to reorder the table's rows accordingly. Without a handler, header
+// cells cannot be clicked.
+func (t *Table) SetSortFunc(handler func(column int, ascending bool)) {
 	t.Lock()
 	defer t.Unlock()
-	t.selectedStyle = style
+	t.sortFunc = handler
 }
 
-// SetSeparator sets the character used to fill the space between two
-// neighboring cells. This is a space character ' ' per default but you may
-// want to set it to Borders.Vertical (or any other rune) if the column
-// separation should be more visible. If cell borders are activated, this is
-// ignored.
+// tableColumnAttributes holds the default attributes of the cells of a
+// column, see Table.SetColumnAttributes().
+type tableColumnAttributes struct {
+	align     int
+	style     tcell.Style
+	expansion int
+	maxWidth  int
+}
+
+// SetColumnAttributes sets the default alignment, style, expansion (see
+// TableCell.SetExpansion), and maximum width of the cells of a column, so they
+// don't have to be set on every cell, e.g. when rows are appended. They are
+// applied to the column's existing cells and to the cells set with SetCell()
+// or SetCellSimple() afterwards, but only to those properties which still
+// have the values of a new cell (see NewTableCell): AlignLeft, the normal cell
+// style of the table's styles, an expansion of 0, and no maximum width. A style
+// of tcell.StyleDefault leaves the cells' styles unchanged.
 //
-// Separators have the same color as borders.
-func (t *Table) SetSeparator(separator rune) {
+// The attributes stay with the column if it is moved (see SetColumnOrder()).
+// Cells of content provided with SetContent() are not changed.
+func (t *Table) SetColumnAttributes(column int, align int, style tcell.Style, expansion int, maxWidth int) {
 	t.Lock()
 	defer t.Unlock()
-	t.separator = separator
+
+	if t.columnAttributes == nil {
+		t.columnAttributes = make(map[int]tableColumnAttributes)
+	}
+	t.columnAttributes[t.contentColumn(column)] = tableColumnAttributes{
+		align:     align,
+		style:     style,
+		expansion: expansion,
+		maxWidth:  maxWidth,
+	}
+
+	content := t.content
+	if t.columnView != nil {
+		content = t.columnView.TableContent
+	}
+	if _, ok := content.(*tableDefaultContent); !ok {
+		return
+	}
+	for row := 0; row < t.content.GetRowCount(); row++ {
+		if cell := t.content.GetCell(row, column); cell != nil {
+			t.applyColumnAttributes(column, cell)
+		}
+	}
 }
 
-// SetFixed sets the number of fixed rows and columns which are always visible
-// even when the rest of the cells are scrolled out of view. Rows are always the
-// top-most ones. Columns are always the left-most ones.
-func (t *Table) SetFixed(rows int, columns int) {
-	t.Lock()
-	defer t.Unlock()
-	t.fixedRows = rows
-	t.fixedColumns = columns
-	t.columnOffset = 0
+// applyColumnAttributes applies the default attributes of the provided column
+// (see SetColumnAttributes) to the properties of the cell which have the
+// values of a new cell. The table must be locked.
+func (t *Table) applyColumnAttributes(column int, cell *TableCell) {
+	attributes, ok := t.columnAttributes[t.contentColumn(column)]
+	if !ok {
+		return
+	}
+
+	cell.Lock()
+	defer cell.Unlock()
+
+	if cell.Align == AlignLeft {
+		cell.Align = attributes.align
+	}
+	if cell.Style == Styles.Table.Cell.Normal && attributes.style != tcell.StyleDefault {
+		cell.Style = attributes.style
+	}
+	if cell.Expansion == 0 {
+		cell.Expansion = attributes.expansion
+	}
+	if cell.MaxWidth == 0 && attributes.maxWidth > 0 {
+		cell.MaxWidth = attributes.maxWidth
+		cell.updateWidth()
+	}
 }
 
-// SetSelectable sets the flags which determine what can be selected in a table.
-// There are three selection modi:
+// tableColumnFit holds the auto-fit settings of a column, see
+// Table.SetColumnFit().
+type tableColumnFit struct {
+	minWidth int
+	priority int
+}
+
+// SetAutoFitColumns sets whether or not the columns shrink to fit the width of
+// the table if their content is wider, instead of clipping the rightmost
+// columns. Columns shrink in proportion to how much wider they are than their
+// minimum widths, columns with a higher shrink priority first, see
+// SetColumnFit(). Cells whose text does not fit are truncated.
 //
-//   - rows = false, columns = false: Nothing can be selected.
-//   - rows = true, columns = false: Rows can be selected.
-//   - rows = false, columns = true: Columns can be selected.
-//   - rows = true, columns = true: Individual cells can be selected.
-func (t *Table) SetSelectable(rows bool, columns bool) {
+// This is disabled by default.
+func (t *Table) SetAutoFitColumns(autoFit bool) {
 	t.Lock()
 	defer t.Unlock()
-	t.rowsSelectable = rows
-	t.columnsSelectable = columns
-}
 
-// GetSelectable returns what can be selected in a table. Refer to
-// SetSelectable() for details.
-func (t *Table) GetSelectable() (rows bool, columns bool) {
-	t.RLock()
-	defer t.RUnlock()
-	return t.rowsSelectable, t.columnsSelectable
+	t.autoFit = autoFit
 }
 
-// GetSelection returns the position of the current selection.
-// If entire rows are selected, the column index is undefined.
-// Likewise for entire columns.
-func (t *Table) GetSelection() (row int, column int) {
+// GetAutoFitColumns returns whether or not the columns shrink to fit the width
+// of the table, see SetAutoFitColumns().
+func (t *Table) GetAutoFitColumns() bool {
 	t.RLock()
 	defer t.RUnlock()
-	return t.selectedRow, t.selectedColumn
+
+	return t.autoFit
 }
 
-// Select sets the selected cell. Depending on the selection settings
-// specified via SetSelectable(), this may be an entire row or column, or even
-// ignored completely. The "selection changed" event is fired if such a callback
-// is available (even if the selection ends up being the same as before and even
-// if cells are not selectable).
-func (t *Table) Select(row int, column int) {
+// SetColumnFit sets the minimum width of a column and its shrink priority,
+// which are used when the columns shrink to fit the width of the table (see
+// SetAutoFitColumns). Columns with a higher priority shrink first, down to
+// their minimum widths, before columns with a lower priority shrink. By
+// default, columns have a minimum width of 1 and a priority of 0. The settings
+// stay with the column if it is moved (see SetColumnOrder()).
+func (t *Table) SetColumnFit(column, minWidth, priority int) {
 	t.Lock()
 	defer t.Unlock()
-	t.selectedRow = row
-	t.selectedColumn = column
-	t.clampToSelection = true
-	if t.selectionChanged != nil {
-		t.selectionChanged(row, column)
+
+	if t.columnFits == nil {
+		t.columnFits = make(map[int]tableColumnFit)
+	}
+	t.columnFits[t.contentColumn(column)] = tableColumnFit{
+		minWidth: max(minWidth, 1),
+		priority: priority,
 	}
 }
 
-// SetOffset sets how many rows and columns should be skipped when drawing the
-// table. This is useful for large tables that do not fit on the screen.
-// Navigating a selection can change these values.
-//
-// Fixed rows and columns are never skipped.
-func (t *Table) SetOffset(row int, column int) {
+// SetColumnRenderer sets a function which returns the renderer drawing the
+// content of a cell of the provided column in place of its text, e.g. a
+// progress bar derived from the cell's text or reference (see CellRenderer). It
+// is called for each cell of the column which has no renderer of its own (see
+// TableCell.SetRenderer()) whenever the cell is measured or drawn, and may
+// return nil to draw the cell's text. Header cells are not affected. The
+// function stays with the column if it is moved (see SetColumnOrder()). Provide
+// nil to remove it.
+func (t *Table) SetColumnRenderer(column int, renderer func(row int, cell *TableCell) CellRenderer) {
 	t.Lock()
 	defer t.Unlock()
-	t.rowOffset = row
-	t.columnOffset = column
-	t.trackEnd = false
+
+	column = t.contentColumn(column)
+	if renderer == nil {
+		delete(t.columnRenderers, column)
+		return
+	}
+	if t.columnRenderers == nil {
+		t.columnRenderers = make(map[int]func(row int, cell *TableCell) CellRenderer)
+	}
+	t.columnRenderers[column] = renderer
 }
 
-// GetOffset returns the current row and column offset. This indicates how many
-// rows and columns the table is scrolled down and to the right.
-func (t *Table) GetOffset() (row int, column int) {
-	t.RLock()
-	defer t.RUnlock()
-	return t.rowOffset, t.columnOffset
+// cellRenderer returns the renderer of the provided cell, its own or the one
+// of its column (see SetColumnRenderer), or nil if its text is drawn. The table
+// must be locked.
+func (t *Table) cellRenderer(row, column int, cell *TableCell) CellRenderer {
+	if cell.Renderer != nil || row == tableHeaderRow {
+		return cell.Renderer
+	}
+	if renderer := t.columnRenderers[t.contentColumn(column)]; renderer != nil {
+		return renderer(row, cell)
+	}
+	return nil
 }
 
-// SetXScroll sets the horizontal scroll position of the table. This overrides columnOffset.
-func (t *Table) SetXScroll(x int) {
+// cellWidth returns the effective width of the provided cell, taking the
+// renderer of its column into account. The table must be locked.
+func (t *Table) cellWidth(row, column int, cell *TableCell) int {
+	if cell.Renderer != nil || len(t.columnRenderers) == 0 {
+		return cell.width
+	}
+	renderer := t.cellRenderer(row, column, cell)
+	if renderer == nil {
+		return cell.width
+	}
+	if width := renderer.Width(); cell.MaxWidth <= 0 || width < cell.MaxWidth {
+		return width
+	}
+	return cell.MaxWidth
+}
+
+// SetEvaluateVisibleRowsOnly sets whether or not only the rows which are drawn
+// are measured to determine the widths of the columns. By default, all cells
+// of the table are measured each time it is drawn, which becomes slow for
+// tables with hundreds of thousands of rows. If set to true, only the fixed rows
+// and the visible rows are measured, so the widths of the columns are those of
+// their widest visible cells and may change while scrolling. This is always
+// the case for content set with SetContent().
+func (t *Table) SetEvaluateVisibleRowsOnly(visibleOnly bool) {
 	t.Lock()
 	defer t.Unlock()
-	t.xScroll = x
-	t.columnOffset = -1 // This indicates that xScroll is being used.
-	t.trackEnd = false
+	t.visibleRowsOnly = visibleOnly
 }
 
-// GetXScroll returns the current horizontal scroll position of the table.
-func (t *Table) GetXScroll() int {
+// GetEvaluateVisibleRowsOnly returns whether or not only the rows which are
+// drawn are measured to determine the widths of the columns, see
+// SetEvaluateVisibleRowsOnly().
+func (t *Table) GetEvaluateVisibleRowsOnly() bool {
 	t.RLock()
 	defer t.RUnlock()
-	return t.xScroll
+
+	return t.visibleRowsOnly
 }
 
-// SetSelectedFunc sets a handler which is called whenever the user presses the
-// Enter key on a selected cell/row/column. The handler receives the position of
-// the selection and its cell contents. If entire rows are selected, the column
-// index is undefined. Likewise for entire columns.
-func (t *Table) SetSelectedFunc(handler func(row int, column int)) {
+// SetFilterFunc sets a function which determines whether or not a row is shown.
+// Rows for which it returns false are hidden without removing them from the
+// table's content: row indices, e.g. those of the selection or of GetCell(),
+// remain those of the content, and GetRowCount() still counts all rows. Hidden
+// rows cannot be selected, if the selected row is hidden, the next shown row
+// is selected. Fixed rows (see SetFixed() and SetFixedFooter()) are always
+// shown. If all other rows are hidden, the placeholder is shown (see
+// SetPlaceholder()).
+//
+// The function is called for every row each time the table is drawn, so
+// changes to the criteria it uses take effect when the table is drawn next.
+// The table is locked at that time so the function must not call the table's
+// functions. Provide nil to show all rows, which is the default.
+func (t *Table) SetFilterFunc(filter func(row int) bool) {
 	t.Lock()
 	defer t.Unlock()
-	t.selected = handler
+	t.filter = filter
+	t.shownRows = nil
+	t.rowOffset = 0
+	t.clampToSelection = true
 }
 
-// SetSelectionChangedFunc sets a handler which is called whenever the current
-// selection changes. The handler receives the position of the new selection.
-// If entire rows are selected, the column index is undefined. Likewise for
-// entire columns.
-func (t *Table) SetSelectionChangedFunc(handler func(row int, column int)) {
-	t.Lock()
-	defer t.Unlock()
-	t.selectionChanged = handler
+// rowHidden returns whether or not the provided row is hidden by the filter,
+// given the number of rows of the content.
+func (t *Table) rowHidden(row, rowCount int) bool {
+	return t.filter != nil && row >= t.fixedRows && row < rowCount-t.footerRows(rowCount) && !t.filter(row)
 }
 
-// SetDoneFunc sets a handler which is called whenever the user presses the
-// Escape, Tab, or Backtab key. If nothing is selected, it is also called when
-// user presses the Enter key (because pressing Enter on a selection triggers
-// the "selected" handler set via SetSelectedFunc()).
-func (t *Table) SetDoneFunc(handler func(key tcell.Key)) {
-	t.Lock()
-	defer t.Unlock()
-	t.done = handler
+// updateShownRows determines the rows which are shown if a filter is set,
+// given the number of rows of the content.
+func (t *Table) updateShownRows(rowCount int) {
+	if t.filter == nil {
+		t.shownRows = nil
+		return
+	}
+	t.shownRows = t.shownRows[:0]
+	for row := range rowCount {
+		if !t.rowHidden(row, rowCount) {
+			t.shownRows = append(t.shownRows, row)
+		}
+	}
 }
 
-// SetDoubleClickFunc sets a handler which is called whenever the user
-// double-clicks a cell. The handler receives the position of the double-clicked
-// cell. If entire rows are selected, the column index is undefined. Likewise
-// for entire columns.
-func (t *Table) SetDoubleClickFunc(handler func(row int, column int)) {
-	t.Lock()
-	defer t.Unlock()
-	t.doubleClick = handler
+// shownRowCount returns the number of rows which are shown given the number of
+// rows of the content.
+func (t *Table) shownRowCount(rowCount int) int {
+	if t.filter == nil {
+		return rowCount
+	}
+	return len(t.shownRows)
 }
 
-// SetCell sets the content of a cell the specified position. It is ok to
-// directly instantiate a TableCell object. If the cell has content, at least
-// the Text and Color fields should be set.
-//
-// Note that setting cells in previously unknown rows and columns will
-// automatically extend the internal table representation with empty TableCell
-// objects, e.g. starting with a row of 100,000 will immediately create 100,000
-// empty rows.
-//
-// To avoid unnecessary garbage collection, fill columns from left to right.
-func (t *Table) SetCell(row int, column int, cell *TableCell) {
-	t.Lock()
-	defer t.Unlock()
-	t.content.SetCell(row, column, cell)
+// shownRow returns the row shown at the provided index among the shown rows.
+func (t *Table) shownRow(index int) int {
+	if t.filter == nil || index < 0 || index >= len(t.shownRows) {
+		return index
+	}
+	return t.shownRows[index]
 }
 
-// SetCellSimple calls SetCell() with the given text, left-aligned, in white.
-func (t *Table) SetCellSimple(row int, column int, text string) {
+// shownIndex returns the index of the provided row among the shown rows. For
+// hidden rows, this is the index of the next shown row.
+func (t *Table) shownIndex(row int) int {
+	if t.filter == nil {
+		return row
+	}
+	return sort.SearchInts(t.shownRows, row)
+}
+
+// headerRows returns the number of header rows, 0 or 1.
+func (t *Table) headerRows() int {
+	if t.header == nil {
+		return 0
+	}
+	return 1
+}
+
+// cell returns the cell at the provided position, which may be in the header
+// row, or nil if there is none.
+func (t *Table) cell(row, column int) *TableCell {
+	if row == tableHeaderRow {
+		column = t.contentColumn(column)
+		if column < 0 || column >= len(t.header) {
+			return nil
+		}
+		return t.header[column]
+	}
+	return t.content.GetCell(row, column)
+}
+
+// columnCount returns the number of shown columns, including header cells
+// beyond the columns of the content.
+func (t *Table) columnCount() int {
+	if t.columnView != nil {
+		return max(t.content.GetColumnCount(), t.columnView.shownCount(len(t.header)))
+	}
+	return max(t.content.GetColumnCount(), len(t.header))
+}
+
+// contentColumnCount returns the number of columns of the content, including
+// hidden columns and header cells beyond the columns of the content.
+func (t *Table) contentColumnCount() int {
+	if t.columnView != nil {
+		return max(t.columnView.TableContent.GetColumnCount(), len(t.header))
+	}
+	return max(t.content.GetColumnCount(), len(t.header))
+}
+
+// headerColumnAt returns whether or not the provided screen position is in the
+// header row as of the last time the table was drawn and, if so, the column at
+// that position (-1 if there is none).
+func (t *Table) headerColumnAt(x, y int) (column int, ok bool) {
+	t.RLock()
+	_, rectY, _, _ := t.GetInnerRect()
+	inHeader := len(t.drawnRows) > 0 && t.drawnRows[0] == tableHeaderRow &&
+		y-rectY >= t.rowTops[0] && y-rectY < t.rowTops[0]+t.rowHeights[0]
+	t.RUnlock()
+	if !inHeader {
+		return -1, false
+	}
+	_, column = t.CellAt(x, y)
+	return column, true
+}
+
+// SetBorders sets whether or not each cell in the table is surrounded by a
+// border.
+func (t *Table) SetBorders(show bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.borders = show
+}
+
+// SetBordersColor sets the color of the cell borders.
+func (t *Table) SetBordersColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+	t.styles.Borders = t.styles.Borders.Foreground(color)
+}
+
+// SetStyles sets the styles of the table, overriding Styles.Table. The normal
+// cell style only applies to cells created with NewTableCell() and is
+// therefore ignored here.
+func (t *Table) SetStyles(styles TableStyles) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.styles = styles
+}
+
+// GetStyles returns the styles of the table.
+func (t *Table) GetStyles() TableStyles {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.styles
+}
+
+// SetScrollBarVisibility specifies the display of the scroll bar. The scroll
+// bar is never shown by default.
+func (t *Table) SetScrollBarVisibility(visibility ScrollBarVisibility) {
+	t.scrollBar.SetVisibility(visibility)
+}
+
+// SetHorizontalScrollBarVisibility specifies the display of the horizontal
+// scroll bar below the table. It shows the position of the columns which are
+// scrolled horizontally, i.e. those which are not fixed (see SetFixed() and
+// SetFixedRight()). The scroll bar is never shown by default.
+func (t *Table) SetHorizontalScrollBarVisibility(visibility ScrollBarVisibility) {
+	t.horizontalScrollBar.SetVisibility(visibility)
+}
+
+// SetScrollBarColor sets the color of the scroll bars.
+func (t *Table) SetScrollBarColor(color tcell.Color) {
+	t.scrollBar.SetColor(color)
+	t.horizontalScrollBar.SetColor(color)
+}
+
+// GetScrollBar returns the vertical scroll bar of the table.
+func (t *Table) GetScrollBar() *ScrollBar {
+	return t.scrollBar
+}
+
+// GetHorizontalScrollBar returns the horizontal scroll bar of the table.
+func (t *Table) GetHorizontalScrollBar() *ScrollBar {
+	return t.horizontalScrollBar
+}
+
+// SetSmoothScrolling sets whether or not scrolling with the mouse wheel and
+// by pages is animated over a few frames instead of jumping to the new
+// position. See also DisableSmoothScrolling.
+func (t *Table) SetSmoothScrolling(enable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.smoothScroll.enabled = enable
+}
+
+// GetSmoothScrolling returns whether or not smooth scrolling is enabled.
+func (t *Table) GetSmoothScrolling() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.smoothScroll.enabled
+}
+
+// SetPlaceholder sets a primitive which is drawn in place of the rows below the fixed rows when
+// the table has none, e.g. a text view saying "No results" or describing an
+// error. It is drawn only and doesn't receive any input. Provide nil to draw
+// no placeholder, which is the default.
+func (t *Table) SetPlaceholder(placeholder Primitive) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setPlaceholder(placeholder)
+}
+
+// SetPlaceholderText sets a text which is drawn centered in place of the
+// rows below the fixed rows when the table has none (see SetPlaceholder()). The text may contain
+// color tags. Provide an empty string to draw no placeholder.
+func (t *Table) SetPlaceholderText(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setPlaceholderText(text)
+}
+
+// SetLoading sets whether or not the content of the table is being loaded.
+// While it is, a spinner (see SpinnerFrames) is drawn in the center of the
+// table in place of its rows below the fixed rows.
+func (t *Table) SetLoading(loading bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setLoading(loading)
+}
+
+// GetLoading returns whether or not the content of the table is being loaded.
+func (t *Table) GetLoading() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.loading
+}
+
+// SetError sets an error message which is drawn centered in place of the rows
+// of the table, e.g. when loading them failed. Provide an empty string to draw
+// the rows again, which is the default. The text is not interpreted for style
+// tags.
+func (t *Table) SetError(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setError(text)
+}
+
+// GetError returns the error message drawn in place of the rows of the table, see
+// SetError().
+func (t *Table) GetError() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.err
+}
+
+// SetSelectedStyle sets a specific style for selected cells. If no such style
+// is set, the cell's background and text color are swapped. If a cell defines
+// its own selected style, that will be used instead.
+//
+// To reset a previous setting to its default, make the following call:
+//
+//	table.SetSelectedStyle(tcell.StyleDefault)
+func (t *Table) SetSelectedStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+	t.styles.Cell.Selected = style
+}
+
+// SetAlternateRowStyle sets the style applied to the cells of every other row,
+// starting with row 1, to make wide tables easier to read (zebra striping).
+// This overrides Styles.Table.AlternateRow. Rows are counted by their index in
+// the content, the header is not affected.
+//
+// Only the set parts of the style are applied: a foreground color other than
+// tcell.ColorDefault replaces the color of the cells' text, a background color
+// other than tcell.ColorDefault replaces the background of cells which use the
+// table's background (see TableCell.SetTransparency), and attributes are added
+// to those of the cells. Provide tcell.StyleDefault to turn striping off.
+func (t *Table) SetAlternateRowStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+	t.styles.AlternateRow = style
+}
+
+// SetRowStyleFunc sets a function which returns the style applied to the cells
+// of a row, e.g. to color rows depending on their content. It is called for
+// each drawn row other than the header and its result is applied after the
+// alternate row style (see SetAlternateRowStyle) in the same way. Return
+// tcell.StyleDefault to leave a row unchanged. Provide nil to remove the
+// function.
+func (t *Table) SetRowStyleFunc(handler func(row int) tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+	t.rowStyle = handler
+}
+
+// rowCellStyle returns the style of a cell of the provided row with the
+// alternate row style and the row style applied.
+func (t *Table) rowCellStyle(row int, cell *TableCell, style tcell.Style) tcell.Style {
+	if row == tableHeaderRow {
+		return style
+	}
+	apply := func(rowStyle tcell.Style) {
+		foreground, background, attributes := rowStyle.Decompose()
+		if foreground != tcell.ColorDefault {
+			style = style.Foreground(foreground)
+		}
+		if background != tcell.ColorDefault && cell.Transparent {
+			style = style.Background(background)
+		}
+		if _, _, cellAttributes := style.Decompose(); attributes != 0 {
+			style = SetAttributes(style, cellAttributes|attributes)
+		}
+	}
+	if row%2 == 1 && t.styles.AlternateRow != tcell.StyleDefault {
+		apply(t.styles.AlternateRow)
+	}
+	if t.rowStyle != nil {
+		apply(t.rowStyle(row))
+	}
+	if row == t.hoverRow && t.styles.Hover != tcell.StyleDefault {
+		foreground, background, attributes := t.styles.Hover.Decompose()
+		if foreground != tcell.ColorDefault {
+			style = style.Foreground(foreground)
+		}
+		if background != tcell.ColorDefault {
+			style = style.Background(background)
+		}
+		if _, _, cellAttributes := style.Decompose(); attributes != 0 {
+			style = SetAttributes(style, cellAttributes|attributes)
+		}
+	}
+	return style
+}
+
+// SetHoverStyle sets the style applied to the cells of the row under the mouse
+// so users see which row a click affects. This overrides Styles.Table.Hover.
+// Unlike the alternate row style, a set background color replaces the
+// background of all cells of the row. Provide tcell.StyleDefault to turn the
+// highlighting off.
+//
+// The row is tracked while the mouse moves over the table. To remove the
+// highlighting when the mouse leaves the table, enable
+// Application.EnableMouseHover.
+func (t *Table) SetHoverStyle(style tcell.Style) {
+	t.Lock()
+	defer t.Unlock()
+	t.styles.Hover = style
+}
+
+// SetHoverFunc sets a handler which is called when the mouse moves to another
+// cell of the table, e.g. to show details of the row under the mouse in a
+// status bar. The handler receives the position of the cell, -1 for both when
+// the mouse leaves the cells (including the header row). If entire rows are
+// selectable, the column is still the one under the mouse. See SetHoverStyle
+// for when the mouse leaving the table is detected.
+func (t *Table) SetHoverFunc(handler func(row, column int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.hover = handler
+}
+
+// GetHoveredCell returns the position of the cell under the mouse, -1 for both
+// if there is none.
+func (t *Table) GetHoveredCell() (row, column int) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.hoverRow, t.hoverColumn
+}
+
+// setHovered records the cell under the mouse and calls the hover handler if
+// it changed. It returns whether or not the cell changed. The table must not
+// be locked.
+func (t *Table) setHovered(row, column int) bool {
+	t.Lock()
+	if row < 0 || column < 0 {
+		row, column = -1, -1
+	}
+	if row == t.hoverRow && column == t.hoverColumn {
+		t.Unlock()
+		return false
+	}
+	t.hoverRow, t.hoverColumn = row, column
+	hover := t.hover
+	t.Unlock()
+
+	if hover != nil {
+		hover(row, column)
+	}
+	return true
+}
+
+// SetSeparator sets the character used to fill the space between two
+// neighboring cells. This is a space character ' ' per default but you may
+// want to set it to Borders.Vertical (or any other rune) if the column
+// separation should be more visible. If cell borders are activated, this is
+// ignored.
+//
+// Separators have the same color as borders.
+func (t *Table) SetSeparator(separator rune) {
+	t.Lock()
+	defer t.Unlock()
+	t.separator = separator
+}
+
+// SetFixed sets the number of fixed rows and columns which are always visible
+// even when the rest of the cells are scrolled out of view. Rows are always the
+// top-most ones. Columns are always the left-most ones. See SetFixedFooter()
+// and SetFixedRight() for the other sides.
+func (t *Table) SetFixed(rows int, columns int) {
+	t.Lock()
+	defer t.Unlock()
+	t.fixedRows = rows
+	t.fixedColumns = columns
+	t.columnOffset = 0
+}
+
+// SetFixedFooter sets the number of bottom-most rows which are always visible
+// below the scrolled rows, e.g. for totals. If there are fewer rows than fit
+// on screen, they directly follow the other rows.
+func (t *Table) SetFixedFooter(rows int) {
+	t.Lock()
+	defer t.Unlock()
+	t.fixedFooterRows = max(rows, 0)
+}
+
+// SetFixedRight sets the number of right-most columns which are always visible
+// to the right of the horizontally scrolled columns, e.g. for actions. If all
+// columns fit on screen, they directly follow the other columns.
+func (t *Table) SetFixedRight(columns int) {
+	t.Lock()
+	defer t.Unlock()
+	t.fixedRightColumns = max(columns, 0)
+	t.columnOffset = min(t.columnOffset, 0)
+}
+
+// footerRows returns the number of fixed rows at the bottom given the number
+// of rows. These never overlap with the fixed rows at the top.
+func (t *Table) footerRows(rowCount int) int {
+	return max(min(t.fixedFooterRows, rowCount-t.fixedRows), 0)
+}
+
+// rightColumns returns the number of fixed columns on the right given the
+// number of columns. These never overlap with the fixed columns on the left.
+func (t *Table) rightColumns(columnCount int) int {
+	return max(min(t.fixedRightColumns, columnCount-t.fixedColumns), 0)
+}
+
+// SetSelectable sets the flags which determine what can be selected in a table.
+// There are three selection modi:
+//
+//   - rows = false, columns = false: Nothing can be selected.
+//   - rows = true, columns = false: Rows can be selected.
+//   - rows = false, columns = true: Columns can be selected.
+//   - rows = true, columns = true: Individual cells can be selected.
+func (t *Table) SetSelectable(rows bool, columns bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.rowsSelectable = rows
+	t.columnsSelectable = columns
+}
+
+// SetRangeSelectable sets whether or not the user can select a range of cells.
+// With Shift and the arrow keys, Home, End, PageUp, or PageDown (see
+// Keys.ExtendSelectionUp and its siblings) or by dragging the mouse, the
+// selection is extended from the cell where it started to the selected cell.
+// Moving the selection without Shift collapses the range. If only rows (or
+// only columns) are selectable, the range consists of entire rows (or
+// columns). Cells in the range are drawn with the range style (see
+// TableStyles). See SetSelectionRangeChangedFunc() to be notified of changes.
+// This is disabled by default.
+func (t *Table) SetRangeSelectable(selectable bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.rangeSelectable = selectable
+	if !selectable {
+		t.rangeAnchorRow = -1
+	}
+}
+
+// GetRangeSelectable returns whether or not a range of cells can be selected.
+func (t *Table) GetRangeSelectable() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.rangeSelectable
+}
+
+// SelectRange selects the range of cells from one cell to another, the latter
+// becoming the selected cell. See SetRangeSelectable() for details.
+func (t *Table) SelectRange(fromRow, fromColumn, toRow, toColumn int) {
+	t.Lock()
+	t.rangeAnchorRow, t.rangeAnchorColumn = fromRow, fromColumn
+	t.selectedRow, t.selectedColumn = toRow, toColumn
+	t.clampToSelection = true
+	selectionChanged, selectionRangeChanged := t.selectionChanged, t.selectionRangeChanged
+	t.Unlock()
+
+	if selectionChanged != nil {
+		selectionChanged(toRow, toColumn)
+	}
+	if selectionRangeChanged != nil {
+		selectionRangeChanged(t.GetSelectedRange())
+	}
+}
+
+// GetSelectedRange returns the top left and the bottom right cell of the
+// selected range. If no range is selected, both are the selected cell. If only
+// rows are selectable, the range spans all columns, likewise for columns. If
+// nothing is selectable, all values are -1.
+func (t *Table) GetSelectedRange() (fromRow, fromColumn, toRow, toColumn int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.selectedRange()
+}
+
+// GetSelectedCells returns the cells of the selected range (see
+// GetSelectedRange()), one slice per row. Missing cells are nil. Note that if
+// only columns are selectable, this includes the cells of all rows.
+func (t *Table) GetSelectedCells() [][]*TableCell {
+	t.RLock()
+	defer t.RUnlock()
+
+	fromRow, fromColumn, toRow, toColumn := t.selectedRange()
+	if fromRow < 0 {
+		return nil
+	}
+	cells := make([][]*TableCell, 0, toRow-fromRow+1)
+	for row := fromRow; row <= toRow; row++ {
+		line := make([]*TableCell, 0, toColumn-fromColumn+1)
+		for column := fromColumn; column <= toColumn; column++ {
+			line = append(line, t.content.GetCell(row, column))
+		}
+		cells = append(cells, line)
+	}
+	return cells
+}
+
+// selectedRange returns the selected range, see GetSelectedRange(). The table
+// must be locked.
+func (t *Table) selectedRange() (fromRow, fromColumn, toRow, toColumn int) {
+	if !t.rowsSelectable && !t.columnsSelectable {
+		return -1, -1, -1, -1
+	}
+	anchorRow, anchorColumn := t.selectedRow, t.selectedColumn
+	if t.rangeAnchorRow >= 0 {
+		anchorRow, anchorColumn = t.rangeAnchorRow, t.rangeAnchorColumn
+	}
+	fromRow, toRow = min(anchorRow, t.selectedRow), max(anchorRow, t.selectedRow)
+	fromColumn, toColumn = min(anchorColumn, t.selectedColumn), max(anchorColumn, t.selectedColumn)
+	if !t.columnsSelectable {
+		fromColumn, toColumn = 0, t.content.GetColumnCount()-1
+	}
+	if !t.rowsSelectable {
+		fromRow, toRow = 0, t.content.GetRowCount()-1
+	}
+	return
+}
+
+// inSelectedRange returns whether or not the provided cell is part of a
+// selected range. The table must be locked.
+func (t *Table) inSelectedRange(row, column int) bool {
+	if t.rangeAnchorRow < 0 {
+		return false
+	}
+	fromRow, fromColumn, toRow, toColumn := t.selectedRange()
+	return row >= fromRow && row <= toRow && column >= fromColumn && column <= toColumn
+}
+
+// SetSelectionModel sets a model of the selected rows which allows more than
+// the current row to be selected, see SelectionModel for details. The model's
+// items are the indices of the rows. It only has an effect if rows are
+// selectable (see SetSelectable). Set to nil (the default) to only select the
+// current row.
+func (t *Table) SetSelectionModel(model *SelectionModel) {
+	t.Lock()
+	defer t.Unlock()
+	t.selection = model
+}
+
+// GetSelectionModel returns the model of the selected rows or nil if there is
+// none.
+func (t *Table) GetSelectionModel() *SelectionModel {
+	t.RLock()
+	defer t.RUnlock()
+	return t.selection
+}
+
+// ShowContextMenuForSelection shows the context menu (see ContextMenuList())
+// below the selected cell. The index provided to the menu's callbacks is the
+// selected row. This is what Keys.ShowContextMenu does.
+func (t *Table) ShowContextMenuForSelection(setFocus func(Primitive)) {
+	t.openContextMenu(-1, -1, setFocus)
+}
+
+// openContextMenu shows the context menu for the selected row at the provided
+// screen position, see Application.OpenContextMenu().
+func (t *Table) openContextMenu(x, y int, setFocus func(Primitive)) bool {
+	row, _ := t.GetSelection()
+	t.ShowContextMenu(row, x, y, setFocus)
+	return t.ContextMenuVisible()
+}
+
+// Focus is called when this primitive receives focus.
+func (t *Table) Focus(delegate func(p Primitive)) {
+	t.Box.Focus(delegate)
+	if menu := t.activeMenu(); menu != nil {
+		delegate(menu.list)
+	}
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (t *Table) HasFocus() bool {
+	if menu := t.activeMenu(); menu != nil {
+		return menu.list.HasFocus()
+	}
+	return t.Box.HasFocus()
+}
+
+// GetSelectable returns what can be selected in a table. Refer to
+// SetSelectable() for details.
+func (t *Table) GetSelectable() (rows bool, columns bool) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.rowsSelectable, t.columnsSelectable
+}
+
+// GetSelection returns the position of the current selection.
+// If entire rows are selected, the column index is undefined.
+// Likewise for entire columns.
+func (t *Table) GetSelection() (row int, column int) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.selectedRow, t.selectedColumn
+}
+
+// Select sets the selected cell. Depending on the selection settings
+// specified via SetSelectable(), this may be an entire row or column, or even
+// ignored completely. The "selection changed" event is fired if such a callback
+// is available (even if the selection ends up being the same as before and even
+// if cells are not selectable).
+func (t *Table) Select(row int, column int) {
+	t.Lock()
+	var selection *SelectionModel
+	previousRow := t.selectedRow
+	if t.rowsSelectable && row != t.selectedRow {
+		selection = t.selection
+	}
+	t.selectedRow = row
+	t.selectedColumn = column
+	t.rangeAnchorRow = -1
+	t.clampToSelection = true
+	if t.stableSelection {
+		t.selectedReference = t.rowReference(row)
+	}
+	selectionChanged, selectionRangeChanged := t.selectionChanged, t.selectionRangeChanged
+	t.Unlock()
+
+	if selection != nil {
+		selection.follow(previousRow, row, false)
+	}
+	if selectionChanged != nil {
+		selectionChanged(row, column)
+	}
+	if selectionRangeChanged != nil {
+		selectionRangeChanged(t.GetSelectedRange())
+	}
+}
+
+// Search selects the next selectable cell after the current selection (or
+// the previous one if backwards is true), in reading order, whose text
+// contains the query, ignoring case. If only rows (or only columns) are
+// selectable, entire rows (or columns) are searched. Returns whether a match
+// was found. This implements the Searchable interface.
+func (t *Table) Search(query string, backwards bool) bool {
+	t.RLock()
+	row, column := t.findCell(func(text []byte) bool {
+		return searchMatches(text, query)
+	}, backwards, false)
+	t.RUnlock()
+
+	if row < 0 || column < 0 {
+		return false
+	}
+	t.Select(row, column)
+	return true
+}
+
+// JumpTargets returns the visible selectable cells, or the visible rows (or
+// columns) if only rows (or only columns) are selectable, with their labels
+// at their top left corners. It implements the Jumpable interface.
+func (t *Table) JumpTargets() (targets []JumpTarget) {
+	t.RLock()
+	defer t.RUnlock()
+
+	if !t.rowsSelectable && !t.columnsSelectable {
+		return nil
+	}
+	x, y, width, _ := t.GetInnerRect()
+
+	// The left edges of the visible columns. A column ends with its right
+	// border or gap. Columns which are scrolled out partly start at their
+	// first visible cell.
+	var columns, columnXs []int
+	columnWidths := t.calculateColumnWidths()
+	for relX := 0; relX < width; relX++ {
+		column := t.columnAt(relX, columnWidths)
+		if column < 0 {
+			continue
+		}
+		if len(columns) == 0 || columns[len(columns)-1] != column {
+			columns = append(columns, column)
+			columnXs = append(columnXs, x+relX)
+		} else {
+			last := len(columns) - 1
+			columnXs[last] = max(columnXs[last], x+relX-columnWidths[column])
+		}
+	}
+
+	rowCount := t.content.GetRowCount()
+	selectable := func(row, column int) bool {
+		cell := t.content.GetCell(row, column)
+		return cell != nil && !cell.NotSelectable
+	}
+	drawn := func(position int) bool {
+		row := t.drawnRows[position]
+		return row >= 0 && row < rowCount // Not the header row.
+	}
+
+	// One target per column, in its first selectable row.
+	if !t.rowsSelectable {
+		for index, column := range columns {
+			for position, row := range t.drawnRows {
+				if drawn(position) && selectable(row, column) {
+					targets = append(targets, JumpTarget{X: columnXs[index], Y: y + t.rowTops[position], Row: row, Column: column})
+					break
+				}
+			}
+		}
+		return
+	}
+
+	// One target per selectable cell, or per row if only rows are selectable.
+	for position, row := range t.drawnRows {
+		if !drawn(position) {
+			continue
+		}
+		for index, column := range columns {
+			if !selectable(row, column) {
+				continue
+			}
+			targets = append(targets, JumpTarget{X: columnXs[index], Y: y + t.rowTops[position], Row: row, Column: column})
+			if !t.columnsSelectable {
+				break
+			}
+		}
+	}
+	return
+}
+
+// Jump selects the cell (or its row or column) of the provided target. It
+// implements the Jumpable interface.
+func (t *Table) Jump(target JumpTarget) {
+	t.Select(target.Row, target.Column)
+}
+
+// findCell returns the next selectable cell after the selected cell (or before
+// it if backwards is true) whose text without color tags is accepted by the
+// provided function, wrapping around. If inclusive is true, the selected cell
+// is checked first. If only rows (or only columns) are selectable, entire rows
+// (or columns) are checked. Returns -1 values if there is no such cell. The
+// table must be locked.
+func (t *Table) findCell(accept func(text []byte) bool, backwards, inclusive bool) (row, column int) {
+	rowCount, columnCount := t.content.GetRowCount(), t.content.GetColumnCount()
+	rowsSelectable, columnsSelectable := t.rowsSelectable, t.columnsSelectable
+	if !rowsSelectable && !columnsSelectable || rowCount == 0 || columnCount == 0 {
+		return -1, -1
+	}
+	matches := func(row, column int) bool {
+		cell := t.content.GetCell(row, column)
+		return cell != nil && !cell.NotSelectable && !t.rowHidden(row, rowCount) &&
+			accept(StripTags([]byte(cell.Text), true, false))
+	}
+	start := func(current int) int {
+		if !inclusive {
+			return current
+		} else if backwards {
+			return current + 1
+		}
+		return current - 1
+	}
+	row, column = -1, -1
+	switch {
+	case rowsSelectable && columnsSelectable:
+		current := start(t.selectedRow*columnCount + t.selectedColumn)
+		if index := searchIndex(rowCount*columnCount, current, backwards, func(index int) bool {
+			return matches(index/columnCount, index%columnCount)
+		}); index >= 0 {
+			row, column = index/columnCount, index%columnCount
+		}
+	case rowsSelectable:
+		row = searchIndex(rowCount, start(t.selectedRow), backwards, func(index int) bool {
+			for c := 0; c < columnCount; c++ {
+				if matches(index, c) {
+					return true
+				}
+			}
+			return false
+		})
+		column = t.selectedColumn
+	default:
+		column = searchIndex(columnCount, start(t.selectedColumn), backwards, func(index int) bool {
+			for r := 0; r < rowCount; r++ {
+				if matches(r, index) {
+					return true
+				}
+			}
+			return false
+		})
+		row = t.selectedRow
+	}
+	return row, column
+}
+
+// Find searches the cells for the provided text, ignoring case, and highlights
+// the matches with the match style (see TableStyles). The selection moves to
+// the first cell at or after the selected cell which contains a match, so
+// calling Find() while the user types the query keeps the selection on the
+// current cell as long as it matches. Use FindNext() and FindPrev() to move to
+// the other matches and ClearFind() to remove the highlights. Like Search(),
+// only selectable cells of shown rows are found, entire rows (or columns) if
+// only rows (or columns) are selectable. Returns whether a match was found.
+//
+// Matches in cells which wrap their text or have a renderer are not
+// highlighted. An empty query removes the highlights like ClearFind().
+func (t *Table) Find(query string) bool {
+	if query == "" {
+		t.ClearFind()
+		return false
+	}
+	query = strings.ToLower(query)
+	return t.find(func(text string) [][]int {
+		var matches [][]int
+		text = strings.ToLower(text)
+		for offset := 0; ; {
+			index := strings.Index(text[offset:], query)
+			if index < 0 {
+				return matches
+			}
+			matches = append(matches, []int{offset + index, offset + index + len(query)})
+			offset += index + len(query)
+		}
+	})
+}
+
+// FindRegexp works like Find() but searches the cells for matches of the
+// provided regular expression. Provide nil to remove the highlights like
+// ClearFind().
+func (t *Table) FindRegexp(expression *regexp.Regexp) bool {
+	if expression == nil {
+		t.ClearFind()
+		return false
+	}
+	return t.find(func(text string) [][]int {
+		return expression.FindAllStringIndex(text, -1)
+	})
+}
+
+// find sets the function which finds the matches in the text of a cell and
+// selects the first cell at or after the selected cell with a match.
+func (t *Table) find(matches func(text string) [][]int) bool {
+	t.Lock()
+	t.findMatches = matches
+	t.Unlock()
+	return t.findNext(false, true)
+}
+
+// FindNext selects the next cell after the selected cell which contains a
+// match of the query set with Find() or FindRegexp(), wrapping around. Returns
+// whether a match was found.
+func (t *Table) FindNext() bool {
+	return t.findNext(false, false)
+}
+
+// FindPrev selects the previous cell before the selected cell which contains
+// a match of the query set with Find() or FindRegexp(), wrapping around.
+// Returns whether a match was found.
+func (t *Table) FindPrev() bool {
+	return t.findNext(true, false)
+}
+
+// findNext selects the next (or previous) cell with a match, see findCell().
+func (t *Table) findNext(backwards, inclusive bool) bool {
+	t.RLock()
+	matches := t.findMatches
+	row, column := -1, -1
+	if matches != nil {
+		row, column = t.findCell(func(text []byte) bool {
+			return len(matches(string(text))) > 0
+		}, backwards, inclusive)
+	}
+	selectedRow, selectedColumn := t.selectedRow, t.selectedColumn
+	t.RUnlock()
+
+	if row < 0 || column < 0 {
+		return false
+	}
+	if row != selectedRow || column != selectedColumn {
+		t.Select(row, column)
+	} else {
+		t.EnsureCellVisible(row, column)
+	}
+	return true
+}
+
+// ClearFind removes the highlights of the matches of the query set with Find()
+// or FindRegexp().
+func (t *Table) ClearFind() {
+	t.Lock()
+	defer t.Unlock()
+	t.findMatches = nil
+}
+
+// highlightMatches applies the match style to the matches of the find query in
+// the text of a cell drawn on one line at the provided y-coordinate.
+func (t *Table) highlightMatches(screenWriter ScreenWriter, text string, y, width, align int) {
+	text = stripTags(text)
+	matches := t.findMatches(text)
+	if len(matches) == 0 {
+		return
+	}
+	x := 0
+	if textWidth := uniseg.StringWidth(text); align == AlignRight {
+		x = width - textWidth
+	} else if align == AlignCenter {
+		x = (width - textWidth) / 2
+	}
+	for _, match := range matches {
+		from := x + uniseg.StringWidth(text[:match[0]])
+		to := x + uniseg.StringWidth(text[:match[1]])
+		t.drawRectangleColorScreenWriter(screenWriter, from, y, to-from, 1, t.styles.Match)
+	}
+}
+
+// SetOffset sets how many rows and columns should be skipped when drawing the
+// table. This is useful for large tables that do not fit on the screen.
+// Navigating a selection can change these values.
+//
+// Fixed rows and columns are never skipped. Rows hidden by a filter (see
+// SetFilterFunc()) are not counted.
+func (t *Table) SetOffset(row int, column int) {
+	t.Lock()
+	defer t.Unlock()
+	t.rowOffset = row
+	t.columnOffset = column
+	t.trackEnd = false
+}
+
+// GetOffset returns the current row and column offset. This indicates how many
+// rows and columns the table is scrolled down and to the right.
+func (t *Table) GetOffset() (row int, column int) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.rowOffset, t.columnOffset
+}
+
+// SetXScroll sets the horizontal scroll position of the table. This overrides columnOffset.
+func (t *Table) SetXScroll(x int) {
+	t.Lock()
+	defer t.Unlock()
+	t.xScroll = x
+	t.columnOffset = -1 // This indicates that xScroll is being used.
+	t.trackEnd = false
+}
+
+// GetXScroll returns the current horizontal scroll position of the table.
+func (t *Table) GetXScroll() int {
+	t.RLock()
+	defer t.RUnlock()
+	return t.xScroll
+}
+
+// SetSelectedFunc sets a handler which is called whenever the user presses the
+// Enter key on a selected cell/row/column. The handler receives the position of
+// the selection and its cell contents. If entire rows are selected, the column
+// index is undefined. Likewise for entire columns.
+func (t *Table) SetSelectedFunc(handler func(row int, column int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.selected = handler
+}
+
+// SetSelectionChangedFunc sets a handler which is called whenever the current
+// selection changes. The handler receives the position of the new selection.
+// If entire rows are selected, the column index is undefined. Likewise for
+// entire columns.
+func (t *Table) SetSelectionChangedFunc(handler func(row int, column int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.selectionChanged = handler
+}
+
+// SetSelectionRangeChangedFunc sets a handler which is called whenever the
+// selected range changes (see SetRangeSelectable()), including when the
+// selection moves without extending the range. The handler receives the top
+// left and the bottom right cell of the range, see GetSelectedRange().
+func (t *Table) SetSelectionRangeChangedFunc(handler func(fromRow, fromColumn, toRow, toColumn int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.selectionRangeChanged = handler
+}
+
+// SetScrollChangedFunc sets a handler which is called when the table is drawn
+// scrolled to a different row or with a different number of rows, e.g. to keep
+// a scroll indicator in sync. It receives the number of rows the table is
+// scrolled down by (see GetOffset()) and the number of rows which scroll, i.e.
+// the shown rows without the fixed rows. It is called during drawing, so
+// changes to other primitives are drawn when the screen is drawn next, see
+// Application.QueueUpdateDraw(). Provide nil to remove the handler.
+func (t *Table) SetScrollChangedFunc(handler func(firstVisible, total int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.scrollChanged.set(handler)
+}
+
+// SetViewportChangedFunc sets a handler which is called when the table is
+// drawn scrolled to a different position, vertically or horizontally, e.g. to
+// keep a row detail pane or a minimap in sync with the rows shown (see
+// GetVisibleRowRange()). It receives the number of rows the table is scrolled
+// down by (see GetOffset()) and the number of cells the scrolled columns are
+// scrolled to the right by (see GetXScroll()). Like the handler set with
+// SetScrollChangedFunc(), it is called during drawing. Provide nil to remove
+// the handler.
+func (t *Table) SetViewportChangedFunc(handler func(rowOffset, xScroll int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.viewportChanged.set(handler)
+}
+
+// SetDoneFunc sets a handler which is called whenever the user presses the
+// Escape, Tab, or Backtab key. If nothing is selected, it is also called when
+// user presses the Enter key (because pressing Enter on a selection triggers
+// the "selected" handler set via SetSelectedFunc()).
+func (t *Table) SetDoneFunc(handler func(key tcell.Key)) {
+	t.Lock()
+	defer t.Unlock()
+	t.done = handler
+}
+
+// SetDoubleClickFunc sets a handler which is called whenever the user
+// double-clicks a cell. The handler receives the position of the double-clicked
+// cell. If entire rows are selected, the column index is undefined. Likewise
+// for entire columns. See Application.SetDoubleClickInterval for how double
+// clicks are detected.
+func (t *Table) SetDoubleClickFunc(handler func(row int, column int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.doubleClick = handler
+}
+
+// SetRightClickFunc sets a handler which is called whenever the user
+// right-clicks the table. The handler receives the position of the clicked
+// cell, -1 for both if no cell was clicked, and the screen position of the
+// mouse. The clicked cell is selected first if it is selectable.
+//
+// After the handler returns, the table's context menu (see AddContextItem()) is
+// shown below the clicked cell if it has any items. The handler may therefore
+// change the items, e.g. to offer only the actions which apply to the clicked
+// row. The index provided to the items' handlers is the clicked row.
+func (t *Table) SetRightClickFunc(handler func(row, column, x, y int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.rightClick = handler
+}
+
+// SetCell sets the content of a cell the specified position. It is ok to
+// directly instantiate a TableCell object. If the cell has content, at least
+// the Text and Color fields should be set.
+//
+// Note that setting cells in previously unknown rows and columns will
+// automatically extend the internal table representation with empty TableCell
+// objects, e.g. starting with a row of 100,000 will immediately create 100,000
+// empty rows.
+//
+// To avoid unnecessary garbage collection, fill columns from left to right.
+func (t *Table) SetCell(row int, column int, cell *TableCell) {
+	t.Lock()
+	defer t.Unlock()
+	if cell != nil {
+		t.applyColumnAttributes(column, cell)
+	}
+	t.content.SetCell(row, column, cell)
+}
+
+// SetCellSimple calls SetCell() with the given text, left-aligned, in white.
+func (t *Table) SetCellSimple(row int, column int, text string) {
 	t.SetCell(row, column, NewTableCell(text))
 }
 
@@ -767,6 +2203,9 @@ func (t *Table) RemoveRow(row int) {
 	t.Lock()
 	defer t.Unlock()
 	t.content.RemoveRow(row)
+	if t.selection != nil {
+		t.selection.remove(row)
+	}
 }
 
 // RemoveColumn removes the column at the given position from the table. If
@@ -784,6 +2223,9 @@ func (t *Table) InsertRow(row int) {
 	t.Lock()
 	defer t.Unlock()
 	t.content.InsertRow(row)
+	if t.selection != nil {
+		t.selection.insert(row)
+	}
 }
 
 // InsertColumn inserts a column before the column with the given index. Cells
@@ -796,6 +2238,157 @@ func (t *Table) InsertColumn(column int) {
 	t.content.InsertColumn(column)
 }
 
+// ApplyUpdates calls the provided function with the table's content, as
+// accessed by SetCell() and GetCell(), while the table is locked, such that many cells may be changed
+// at once, e.g. by a streaming data source, without locking the table for each
+// change. Afterwards, the handler set with SetContentChangedFunc() is called
+// and all running applications are redrawn once, regardless of the number of
+// changes. The function must not call any of the table's functions.
+//
+// Unlike RemoveRow() and InsertRow(), removing or inserting rows on the content
+// does not update the selection model (see SetSelectionModel).
+func (t *Table) ApplyUpdates(update func(content TableContent)) {
+	t.Lock()
+	update(t.content)
+	changed := t.contentChanged
+	t.Unlock()
+
+	if changed != nil {
+		changed()
+	}
+	requestAnimationFrame()
+}
+
+// SetContentChangedFunc sets a handler which is called after the content of
+// the table was changed with ApplyUpdates(), e.g. to update a summary of the
+// data shown elsewhere. Changes made with other functions such as SetCell()
+// are not reported. Provide nil to remove the handler.
+func (t *Table) SetContentChangedFunc(handler func()) {
+	t.Lock()
+	defer t.Unlock()
+	t.contentChanged = handler
+}
+
+// MoveRow moves the row at one index to another, shifting the rows between
+// them by one. The selected row and the selection model follow the moved rows.
+// If either index is out of range, this function has no effect.
+//
+// The row is moved by removing it from the content, inserting an empty row at
+// the new index and setting its cells again. Content provided via SetContent()
+// must implement these functions for the row to move.
+func (t *Table) MoveRow(from, to int) {
+	t.Lock()
+	defer t.Unlock()
+	t.moveRow(from, to)
+}
+
+// moveRow moves a row, see MoveRow(). The table must be locked.
+func (t *Table) moveRow(from, to int) {
+	rowCount := t.content.GetRowCount()
+	if from == to || from < 0 || to < 0 || from >= rowCount || to >= rowCount {
+		return
+	}
+
+	columnCount := t.content.GetColumnCount()
+	cells := make([]*TableCell, columnCount)
+	for column := range columnCount {
+		cells[column] = t.content.GetCell(from, column)
+	}
+	t.content.RemoveRow(from)
+	if to < t.content.GetRowCount() {
+		t.content.InsertRow(to)
+	} else if len(cells) == 0 || cells[0] == nil {
+		t.content.SetCell(to, 0, &TableCell{}) // Appending an empty row.
+	}
+	for column, cell := range cells {
+		if cell != nil {
+			t.content.SetCell(to, column, cell)
+		}
+	}
+
+	if t.selection != nil {
+		t.selection.move(from, to)
+	}
+	if t.selectedRow >= 0 {
+		t.selectedRow = movedIndex(t.selectedRow, from, to)
+	}
+	if t.rangeAnchorRow >= 0 {
+		t.rangeAnchorRow = movedIndex(t.rangeAnchorRow, from, to)
+	}
+	t.clampToSelection = true
+}
+
+// SetRowsMovable sets whether or not the user may move rows if rows are
+// selectable (see SetSelectable). The selected row is moved with
+// Keys.MoveRowUp and Keys.MoveRowDown or by dragging it with the mouse, e.g. to
+// reorder a priority list or a playlist. Fixed rows (see SetFixed and
+// SetFixedFooter) are not moved and rows are not moved past them. While rows
+// are movable, dragging the mouse moves rows instead of selecting a range (see
+// SetRangeSelectable).
+//
+// The content is updated as with MoveRow(). See SetRowMovedFunc() to be
+// notified of moved rows. The default is false.
+func (t *Table) SetRowsMovable(movable bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.rowsMovable = movable
+}
+
+// GetRowsMovable returns whether or not the user may move rows, see
+// SetRowsMovable().
+func (t *Table) GetRowsMovable() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.rowsMovable
+}
+
+// SetRowMovedFunc sets a handler which is called after the user moved a row
+// from one index to another (see SetRowsMovable). When dragging a row with the
+// mouse, the handler is called each time it moves.
+func (t *Table) SetRowMovedFunc(handler func(from, to int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.rowMoved = handler
+}
+
+// rowMovable returns whether or not the user may move the provided row, or
+// another row to its place. The table must be locked.
+func (t *Table) rowMovable(row int) bool {
+	rowCount := t.content.GetRowCount()
+	return row >= t.fixedRows && row < rowCount-t.footerRows(rowCount) && !t.rowHidden(row, rowCount)
+}
+
+// moveSelectedRow moves the selected row to the provided row if the user may
+// move both and notifies the row moved handler.
+func (t *Table) moveSelectedRow(to int) {
+	t.Lock()
+	from := t.selectedRow
+	if from == to || !t.rowMovable(from) || !t.rowMovable(to) {
+		t.Unlock()
+		return
+	}
+	t.moveRow(from, to)
+	moved := t.rowMoved
+	t.Unlock()
+
+	if moved != nil {
+		moved(from, to)
+	}
+}
+
+// moveSelectedRowBy moves the selected row past the next shown row above it
+// (for a negative direction) or below it.
+func (t *Table) moveSelectedRowBy(direction int) {
+	t.RLock()
+	rowCount := t.content.GetRowCount()
+	to := t.selectedRow + direction
+	for to >= 0 && to < rowCount && t.rowHidden(to, rowCount) {
+		to += direction
+	}
+	t.RUnlock()
+	t.moveSelectedRow(to)
+}
+
 // GetRowCount returns the number of rows in the table.
 func (t *Table) GetRowCount() int {
 	t.RLock()
@@ -810,6 +2403,30 @@ func (t *Table) GetColumnCount() int {
 	return t.content.GetColumnCount()
 }
 
+// ForEachCell calls the provided callback for each cell of the table in reading
+// order, i.e. row by row, skipping missing cells. The header row (see
+// SetHeader()) is not included. Iteration stops when the callback returns
+// false. The table is not locked while the callback runs so it may modify the
+// table, cells added beyond the dimensions the table had when iteration
+// started are not visited.
+func (t *Table) ForEachCell(callback func(row, column int, cell *TableCell) bool) {
+	t.RLock()
+	rowCount, columnCount := t.content.GetRowCount(), t.content.GetColumnCount()
+	t.RUnlock()
+
+	for row := range rowCount {
+		for column := range columnCount {
+			cell := t.GetCell(row, column)
+			if cell == nil {
+				continue
+			}
+			if !callback(row, column, cell) {
+				return
+			}
+		}
+	}
+}
+
 // CellAt returns the row and column located at the given screen coordinates.
 // Each returned value may be negative if there is no row and/or cell. This
 // function will also process coordinates outside the table's inner rectangle so
@@ -822,54 +2439,59 @@ func (t *Table) CellAt(x, y int) (row int, column int) {
 	defer t.RUnlock()
 	rectX, rectY, _, _ := t.GetInnerRect()
 
-	// Determine row as seen on screen.
-	if t.borders {
-		row = (y - rectY - 1) / 2
-	} else {
-		row = y - rectY
-	}
-
-	// Respect fixed rows and row offset.
-	if row >= 0 {
-		if row >= t.fixedRows {
-			row += t.rowOffset
-		}
-		if row >= t.content.GetRowCount() {
-			row = -1
+	// Determine the row as seen on screen.
+	row = -1
+	for position, top := range t.rowTops {
+		if y-rectY >= top && y-rectY < top+t.rowHeights[position] {
+			row = t.drawnRows[position]
+			break
 		}
 	}
+	if row >= t.content.GetRowCount() {
+		row = -1
+	}
 
+	return row, t.columnAt(x-rectX, t.calculateColumnWidths())
+}
+
+// columnAt returns the column drawn at the provided x-coordinate relative to
+// the table's inner rectangle, given the widths of the columns, or -1 if there
+// is none. The table must be locked.
+func (t *Table) columnAt(relX int, columnWidths []int) (column int) {
 	column = -1
-	columnWidths := t.calculateColumnWidths()
-	relX := x - rectX
 	posX := 0
 	for i := 0; i < t.fixedColumns; i++ {
-		posX += columnWidths[i]
-		if t.borders {
-			posX++ // Add space for the borders.
-		}
+		posX += columnWidths[i] + 1 // Add space for the borders or the column gap.
 		if relX < posX {
-			column = i
-			return row, column
+			return i
 		}
 	}
 	if t.borders {
 		posX++ // Add space for the borders.
 	}
 
-	relX += t.effectiveXOffset(columnWidths)
-	for i := t.fixedColumns; i < len(columnWidths); i++ {
-		posX += columnWidths[i]
-		if t.borders {
-			posX++ // Add space for the borders.
+	// The fixed columns on the right.
+	rightColumns := t.rightColumns(len(columnWidths))
+	if rightColumns > 0 && relX >= t.rightColumnsX {
+		rightX := t.rightColumnsX
+		for i := len(columnWidths) - rightColumns; i < len(columnWidths); i++ {
+			rightX += columnWidths[i] + 1
+			if relX < rightX {
+				return i
+			}
 		}
+		return column
+	}
+
+	relX += t.effectiveXOffset(columnWidths)
+	for i := t.fixedColumns; i < len(columnWidths)-rightColumns; i++ {
+		posX += columnWidths[i] + 1 // Add space for the borders or the column gap.
 		if relX < posX {
-			column = i
-			return row, column
+			return i
 		}
 	}
 
-	return row, column
+	return column
 }
 
 // ScrollToBeginning scrolls the table to the beginning to that the top left
@@ -898,11 +2520,63 @@ func (t *Table) ScrollToEnd() {
 	t.rowOffset = t.content.GetRowCount()
 }
 
+// GetVisibleColumnRange returns the indices of the first and the last column
+// which were visible the last time the table was drawn. Fixed columns are
+// included. If no column was visible or the table was not drawn yet, -1 is
+// returned for both.
 func (t *Table) GetVisibleColumnRange() (first int, last int) {
+	t.RLock()
+	defer t.RUnlock()
+
 	totalVisibleColumns := len(t.visibleColumnIndices)
+	if totalVisibleColumns == 0 {
+		return -1, -1
+	}
 	return t.visibleColumnIndices[0], t.visibleColumnIndices[totalVisibleColumns-1]
 }
 
+// GetVisibleRowRange returns the indices of the first and the last row which
+// were drawn the last time the table was drawn, including fixed rows but not
+// the header row. If rows are hidden (see SetFilterFunc()), the rows in
+// between are not necessarily all visible. If no row was drawn, -1 is returned
+// for both.
+func (t *Table) GetVisibleRowRange() (first int, last int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	first, last = -1, -1
+	rowCount := t.content.GetRowCount()
+	for _, row := range t.drawnRows {
+		if row == tableHeaderRow || row >= rowCount {
+			continue
+		}
+		if first < 0 {
+			first = row
+		}
+		last = row
+	}
+	return
+}
+
+// EnsureCellVisible scrolls the table so that the cell at the given position
+// is visible the next time the table is drawn, e.g. a search result. Unlike
+// Select(), the selection is not changed. Provide -1 as the row or the column
+// to only scroll horizontally or vertically. Fixed rows and columns are always
+// visible.
+func (t *Table) EnsureCellVisible(row, column int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.reveal = true
+	t.revealRow, t.revealColumn = row, column
+}
+
+// ScrollColumnIntoView scrolls the table horizontally so that the given column
+// is visible the next time the table is drawn.
+func (t *Table) ScrollColumnIntoView(column int) {
+	t.EnsureCellVisible(-1, column)
+}
+
 // SetWrapSelection determines whether a selection wraps vertically or
 // horizontally when moved. Vertically wrapping selections will jump from the
 // last selectable row to the first selectable row and vice versa. Horizontally
@@ -920,6 +2594,78 @@ func (t *Table) SetWrapSelection(vertical, horizontal bool) {
 	t.wrapVertically = vertical
 }
 
+// SetStableSelection sets whether or not the selection stays with the same
+// logical row when rows are inserted or removed above it, e.g. when a live
+// data feed updates the table. Rows are identified by their references: the
+// reference of a row is that of its first cell which has one (see
+// TableCell.SetReference). If the reference of the selected row has moved to a
+// different row when the table is drawn, that row is selected and scrolled
+// into view. The selection changed handler is not called as the selected row
+// remains the same. If the reference is not found anymore, the selected row
+// index is kept.
+//
+// References of uncomparable types such as slices or maps are never found.
+// With content provided via SetContent(), finding a moved row may request the
+// cells of all rows.
+//
+// The default is false.
+func (t *Table) SetStableSelection(stable bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.stableSelection = stable
+	t.selectedReference = nil
+	if stable {
+		t.selectedReference = t.rowReference(t.selectedRow)
+	}
+}
+
+// GetStableSelection returns whether or not the selection stays with the same
+// logical row when rows are inserted or removed, see SetStableSelection().
+func (t *Table) GetStableSelection() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.stableSelection
+}
+
+// rowReference returns the reference of the first cell of the provided row
+// which has one, nil if there is none.
+func (t *Table) rowReference(row int) interface{} {
+	if row < 0 {
+		return nil
+	}
+	for column := range t.content.GetColumnCount() {
+		if cell := t.content.GetCell(row, column); cell != nil && cell.Reference != nil {
+			return cell.Reference
+		}
+	}
+	return nil
+}
+
+// followSelectedReference selects the row which holds the reference of the
+// selected row if it has moved, see SetStableSelection(). The row offset moves
+// by the same number of rows so the row stays in place on screen if possible.
+func (t *Table) followSelectedReference(rowCount int) {
+	if !t.stableSelection || t.selectedReference == nil ||
+		referencesEqual(t.rowReference(t.selectedRow), t.selectedReference) {
+		return
+	}
+	for row := range rowCount {
+		if !referencesEqual(t.rowReference(row), t.selectedReference) {
+			continue
+		}
+		delta := row - t.selectedRow
+		t.selectedRow = row
+		if t.rangeAnchorRow >= 0 {
+			t.rangeAnchorRow += delta
+		}
+		if !t.trackEnd {
+			t.rowOffset += delta
+		}
+		t.clampToSelection = true
+		return
+	}
+}
+
 func (t *Table) Draw(screen tcell.Screen) {
 	t.Box.Draw(screen)
 	// What's our available screen space?
@@ -932,37 +2678,189 @@ func (t *Table) Draw(screen tcell.Screen) {
 	} else {
 		t.visibleRows = height
 	}
-
-	screenAdapter := NewTranslateScreenWriterAdapter(screen)
-	screenWriter := NewClippingScreenWriter(screenAdapter, x, y, width, height)
+	t.visibleRows -= t.headerRows()
 
 	// Setup selection and get table dimensions
 	rowCount := t.content.GetRowCount()
-	columnCount := t.content.GetColumnCount()
+	columnCount := t.columnCount()
+	t.updateShownRows(rowCount)
+	shownCount := t.shownRowCount(rowCount)
+
+	// Reserve space for the scroll bar.
+	footerRows, rightColumns := t.footerRows(shownCount), t.rightColumns(columnCount)
+	showScrollBar := t.scrollBar.IsVisible(shownCount-t.fixedRows-footerRows, t.visibleRows-t.fixedRows-footerRows)
+	if showScrollBar {
+		width--
+	}
+
+	// Reserve space for the horizontal scroll bar. The scrolled columns may
+	// be scrolled one cell beyond their width, see MaximumXOffset().
+	widths := t.calculateColumnWidths()
+	scrolledWidth := t.effectiveColumnsWidth(widths[t.fixedColumns:columnCount-rightColumns]) + 1
+	viewportWidth := max(width-t.frozenColumnsWidth(widths), 0)
+	if t.horizontalScrollBar.IsVisible(scrolledWidth, viewportWidth) {
+		height--
+		t.visibleRows = height
+		if t.borders {
+			t.visibleRows = height / 2
+		}
+		t.visibleRows -= t.headerRows()
+	}
 
+	screenAdapter := NewTranslateScreenWriterAdapter(screen)
+	screenWriter := NewClippingScreenWriter(screenAdapter, x, y, width, height)
+
+	t.followSelectedReference(rowCount)
 	t.ensureValidSelection(rowCount, columnCount)
-	t.clampOffsets(height, width, rowCount, columnCount)
+	t.clampOffsets(height, width, shownCount, columnCount)
+	if t.stableSelection {
+		t.selectedReference = t.rowReference(t.selectedRow)
+	}
 
-	// Determine visible rows
-	rows, _ := t.calculateVisibleRows(height, rowCount)
+	// Determine visible rows, starting at the (possibly animated) row offset.
+	rowOffset := t.smoothScroll.offset(t.rowOffset)
 	columnWidths := t.calculateColumnWidths()
+	rows, rowHeights := t.calculateVisibleRows(height, shownCount, rowOffset, columnWidths)
+	t.drawnRows, t.rowHeights = rows, rowHeights
+	t.rowTops = t.calculateRowTops(rowHeights)
 
-	normalColumnCount := columnCount - t.fixedColumns
+	normalColumnCount := columnCount - t.fixedColumns - rightColumns
 
 	xOffset := t.effectiveXOffset(columnWidths)
 	fixedColumnsWidth := t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns])
+	normalColumnsWidth := t.effectiveColumnsWidth(columnWidths[t.fixedColumns : columnCount-rightColumns])
+	rightColumnsWidth := t.effectiveColumnsWidth(columnWidths[columnCount-rightColumns:])
+
+	// The fixed columns on the right follow the scrolled columns but don't
+	// leave the screen.
+	t.rightColumnsX = width
+	if rightColumns > 0 {
+		t.rightColumnsX = max(min(width-rightColumnsWidth, fixedColumnsWidth+normalColumnsWidth-xOffset), fixedColumnsWidth)
+	}
+	scrolledWriter := NewClippingScreenWriter(screenAdapter, x+fixedColumnsWidth, y, t.rightColumnsX-fixedColumnsWidth, height).NewTranslate(-xOffset, 0)
+	rightWriter := screenWriter.NewClipXY(t.rightColumnsX, 0)
 
-	t.drawCellColumnRange(screenWriter.NewClipXY(fixedColumnsWidth, 0).NewTranslate(-xOffset, 0), rows, t.fixedColumns,
-		normalColumnCount, columnWidths)
+	t.drawCellColumnRange(scrolledWriter, rows, t.fixedColumns, normalColumnCount, columnWidths)
+	if rightColumns > 0 {
+		t.drawCellColumnRange(rightWriter, rows, columnCount-rightColumns, rightColumns, columnWidths)
+	}
 	if t.fixedColumns > 0 {
 		t.drawCellColumnRange(screenWriter, rows, 0, t.fixedColumns, columnWidths)
 	}
 
-	t.drawCellBackgroundColumnRange(screenWriter.NewClipXY(fixedColumnsWidth, 0).NewTranslate(-xOffset, 0), rows, t.fixedColumns,
-		normalColumnCount, columnWidths)
+	// Remember the visible columns.
+	t.visibleColumnIndices = t.visibleColumnIndices[:0]
+	for column := 0; column < columnCount; column++ {
+		if column >= columnCount-rightColumns {
+			if t.rightColumnsX >= width {
+				continue
+			}
+		} else if column >= t.fixedColumns {
+			left, right := t.normalColumnLeftRightPositions(columnWidths, column)
+			if right <= xOffset || left >= xOffset+t.rightColumnsX-fixedColumnsWidth {
+				continue
+			}
+		}
+		t.visibleColumnIndices = append(t.visibleColumnIndices, column)
+	}
+
+	t.drawCellBackgroundColumnRange(scrolledWriter, rows, t.fixedColumns, normalColumnCount, columnWidths)
+	if rightColumns > 0 {
+		t.drawCellBackgroundColumnRange(rightWriter, rows, columnCount-rightColumns, rightColumns, columnWidths)
+	}
 	if t.fixedColumns > 0 {
 		t.drawCellBackgroundColumnRange(screenWriter, rows, 0, t.fixedColumns, columnWidths)
 	}
+
+	// Draw the placeholder or the loading spinner over the rows below the
+	// fixed rows.
+	if empty := shownCount <= t.fixedRows; t.contentState.active(empty) {
+		top := min(t.fixedRows, shownCount) + t.headerRows()
+		if t.borders && top > 0 {
+			top = 2*top + 1
+		}
+		background := t.backgroundFill()
+		style := tcell.StyleDefault.Background(background)
+		for row := y + top; row < y+height; row++ {
+			for column := x; column < x+width; column++ {
+				screen.SetContent(column, row, ' ', nil, style)
+			}
+		}
+		t.contentState.draw(screen, x, y+top, width, height-top, empty, background)
+	}
+
+	// Draw the scroll bars.
+	t.scrollBar.Draw(screen, x+width, y, height, shownCount-t.fixedRows-footerRows, t.visibleRows-t.fixedRows-footerRows, t.rowOffset, t.hasFocus)
+	t.horizontalScrollBar.Draw(screen, x+fixedColumnsWidth, y+height, viewportWidth, scrolledWidth, viewportWidth, xOffset, t.hasFocus)
+	if notifyScroll := t.scrollChanged.update(t.rowOffset, shownCount-t.fixedRows-footerRows); notifyScroll != nil {
+		notifyScroll()
+	}
+	if notifyViewport := t.viewportChanged.update(t.rowOffset, xOffset); notifyViewport != nil {
+		notifyViewport()
+	}
+
+	// Draw the context menu below the selected cell.
+	if menu := t.activeMenu(); menu != nil && t.HasFocus() {
+		anchorX, anchorY := x, y
+		if cell := t.content.GetCell(t.selectedRow, max(t.selectedColumn, 0)); cell != nil {
+			anchorX, anchorY = cell.x, cell.y+1
+		}
+		menu.drawMenu(screen, anchorX, anchorY)
+	}
+}
+
+// RenderRegion renders the cells from fromRow/fromColumn to toRow/toColumn
+// (inclusive) the way they are drawn on screen, including borders, colors,
+// attributes and the selection, and returns them as a grid of cells (rows
+// first). The range is clamped to the table's content, nil is returned if it
+// is empty. Use GetOffset() and GetVisibleColumnRange() to render the visible
+// viewport and CellsToANSI() to turn the result into text with ANSI escape
+// sequences, e.g. for copying it to the clipboard.
+func (t *Table) RenderRegion(fromRow, fromColumn, toRow, toColumn int) [][]ImageCell {
+	t.Lock()
+	defer t.Unlock()
+
+	fromRow, fromColumn = max(fromRow, 0), max(fromColumn, 0)
+	toRow, toColumn = min(toRow, t.content.GetRowCount()-1), min(toColumn, t.content.GetColumnCount()-1)
+	if fromRow > toRow || fromColumn > toColumn {
+		return nil
+	}
+
+	columnCount := toColumn - fromColumn + 1
+	columnWidths := t.calculateColumnWidths()
+	rowCount := t.content.GetRowCount()
+	rows := make([]int, 0, toRow-fromRow+1)
+	rowHeights := make([]int, 0, toRow-fromRow+1)
+	for row := fromRow; row <= toRow; row++ {
+		if t.rowHidden(row, rowCount) {
+			continue
+		}
+		rows = append(rows, row)
+		rowHeights = append(rowHeights, t.rowHeight(row, columnWidths))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	rowTops := t.calculateRowTops(rowHeights)
+	width := t.effectiveColumnsWidth(columnWidths[fromColumn : toColumn+1])
+	height := rowTops[len(rowTops)-1] + rowHeights[len(rowHeights)-1]
+	if t.borders {
+		width++ // The right edge of the last column.
+	} else {
+		width-- // No space after the last column.
+	}
+
+	// Draw with the layout of the region, then return to that of the screen.
+	buffer := newCellBuffer(width, height, tcell.StyleDefault.Background(t.backgroundFill()))
+	screenWriter := NewTranslateScreenWriterAdapter(buffer)
+	drawnRows, drawnTops, drawnHeights := t.drawnRows, t.rowTops, t.rowHeights
+	t.drawnRows, t.rowTops, t.rowHeights = rows, rowTops, rowHeights
+	t.renderingRegion = true
+	t.drawCellColumnRange(screenWriter, rows, fromColumn, columnCount, columnWidths)
+	t.drawCellBackgroundColumnRange(screenWriter, rows, fromColumn, columnCount, columnWidths)
+	t.renderingRegion = false
+	t.drawnRows, t.rowTops, t.rowHeights = drawnRows, drawnTops, drawnHeights
+	return buffer.cells
 }
 
 func (t *Table) effectiveXOffset(columnWidths []int) int {
@@ -980,33 +2878,42 @@ func (t *Table) effectiveXOffset(columnWidths []int) int {
 func (t *Table) MaximumXOffset() int {
 	_, _, width, _ := t.GetInnerRect()
 	columnWidths := t.calculateColumnWidths()
-	fixedColumnsWidth := t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns])
-	effectiveWidth := width - fixedColumnsWidth
-	normalColumnsWidth := t.effectiveColumnsWidth(columnWidths[t.fixedColumns:])
+	effectiveWidth := width - t.frozenColumnsWidth(columnWidths)
+	rightColumns := t.rightColumns(len(columnWidths))
+	normalColumnsWidth := t.effectiveColumnsWidth(columnWidths[t.fixedColumns : len(columnWidths)-rightColumns])
 	return max(0, normalColumnsWidth-effectiveWidth+1)
 }
 
 func (t *Table) ScrollableWidth() int {
 	columnWidths := t.calculateColumnWidths()
-	normalColumnsWidth := t.effectiveColumnsWidth(columnWidths[t.fixedColumns:])
+	rightColumns := t.rightColumns(len(columnWidths))
+	normalColumnsWidth := t.effectiveColumnsWidth(columnWidths[t.fixedColumns : len(columnWidths)-rightColumns])
 	return normalColumnsWidth
 }
 
 func (t *Table) ScrollableViewportWidth() int {
 	columnWidths := t.calculateColumnWidths()
-	fixedColumnsWidth := t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns])
 	_, _, width, _ := t.GetInnerRect()
-	return max(0, width-fixedColumnsWidth)
+	return max(0, width-t.frozenColumnsWidth(columnWidths))
+}
+
+// frozenColumnsWidth returns the width of the fixed columns on the left and
+// on the right.
+func (t *Table) frozenColumnsWidth(columnWidths []int) int {
+	rightColumns := t.rightColumns(len(columnWidths))
+	return t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns]) +
+		t.effectiveColumnsWidth(columnWidths[len(columnWidths)-rightColumns:])
 }
 
+// effectiveColumnsWidth returns the screen width of the columns with the
+// provided widths. Each column is followed by one cell, its right border or,
+// without borders, the gap to the next column.
 func (t *Table) effectiveColumnsWidth(widths []int) int {
 	columnsWidth := 0
 	for _, width := range widths {
 		columnsWidth += width
 	}
-	if t.borders {
-		columnsWidth += len(widths) // Add space for the borders.
-	}
+	columnsWidth += len(widths) // Add space for the borders or the column gaps.
 	return columnsWidth
 }
 
@@ -1018,7 +2925,7 @@ func (t *Table) drawCellColumnRange(screenWriter TranslateScreenWriter, rows []i
 		for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
 			columnWidth := columnWidths[columnIndex]
 
-			t.drawCellColumn(screenWriter.NewTranslate(posX+1, 0), rows, columnIndex, columnWidth, 1)
+			t.drawCellColumn(screenWriter.NewTranslate(posX+1, 0), rows, columnIndex, columnWidth)
 			isLastColumn := columnIndex == startColumn+columnCount-1
 			t.drawColumnBorders(screenWriter.NewTranslate(posX, 0), rows, columnIndex, columnWidth, isLastColumn)
 			posX += columnWidth
@@ -1027,7 +2934,7 @@ func (t *Table) drawCellColumnRange(screenWriter TranslateScreenWriter, rows []i
 	} else {
 		for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
 			columnWidth := columnWidths[columnIndex]
-			t.drawCellColumn(screenWriter.NewTranslate(posX, 0), rows, columnIndex, columnWidth, 0)
+			t.drawCellColumn(screenWriter.NewTranslate(posX, 0), rows, columnIndex, columnWidth)
 			posX += columnWidth
 			posX++
 		}
@@ -1036,75 +2943,148 @@ func (t *Table) drawCellColumnRange(screenWriter TranslateScreenWriter, rows []i
 }
 
 func (t *Table) drawCellColumn(screenWriter TranslateScreenWriter, rows []int, column int,
-	columnWidth int, verticalSpacing int) {
+	columnWidth int) {
 
 	for rowIndex, row := range rows {
 		// Get the cell.
-		cell := t.content.GetCell(row, column)
+		cell := t.cell(row, column)
 		if cell == nil {
 			continue
 		}
 
-		rowY := verticalSpacing + ((1 + verticalSpacing) * rowIndex)
+		rowY, rowHeight := t.rowTops[rowIndex], t.rowHeights[rowIndex]
 
 		// Draw text.
 		// finalWidth := min(columnWidth, width)
-		cell.x, cell.y = screenWriter.AbsolutePosition(0, rowY)
+		if !t.renderingRegion {
+			cell.x, cell.y = screenWriter.AbsolutePosition(0, rowY)
+		}
 
 		style := cell.Style
-		if style == tcell.StyleDefault {
+		if style == tcell.StyleDefault && row == tableHeaderRow && t.styles.Header != tcell.StyleDefault {
+			style = t.styles.Header
+		} else if style == tcell.StyleDefault {
 			style = tcell.StyleDefault.Background(cell.BackgroundColor).Foreground(cell.Color).Attributes(cell.Attributes)
 		}
+		if _, background, _ := style.Decompose(); t.defaultBackground && background == t.backgroundColor {
+			// Cells with the table's background color leave it to the terminal.
+			style = style.Background(tcell.ColorDefault)
+		}
+		style = t.rowCellStyle(row, cell, style)
+
+		if renderer := t.cellRenderer(row, column, cell); renderer != nil {
+			t.drawRectangleColorScreenWriter(screenWriter, 0, rowY, columnWidth+1, rowHeight, style)
+			drawCellRenderer(renderer, NewClippingScreenWriter(screenWriter, 0, rowY, columnWidth, 1), columnWidth, style, false)
+			continue
+		}
+
+		// Lines of text are padded to the column width, the column gap and
+		// the remaining lines of the cell are colored here.
+		t.drawRectangleColorScreenWriter(screenWriter, columnWidth, rowY, 1, rowHeight, style)
+
+		if cell.Wrap {
+			lines := WordWrap(cell.Text, columnWidth)
+			for index, line := range lines {
+				if index >= rowHeight {
+					break
+				}
+				PrintStyle(screenWriter, []byte(line), 0, rowY+index, columnWidth, cell.Align, style, ' ')
+			}
+			if len(lines) < rowHeight {
+				t.drawRectangleColorScreenWriter(screenWriter, 0, rowY+len(lines), columnWidth, rowHeight-len(lines), style)
+			}
+			continue
+		}
+
+		text := cell.Text
+		if row == tableHeaderRow {
+			text += t.sortIndicator(column)
+		}
+		text = TruncateTagged(text, columnWidth, TruncateEnd)
+		PrintStyle(screenWriter, []byte(text), 0, rowY, columnWidth, cell.Align, style, ' ')
+		t.drawRectangleColorScreenWriter(screenWriter, 0, rowY+1, columnWidth, rowHeight-1, style)
+		if t.findMatches != nil && row != tableHeaderRow {
+			t.highlightMatches(screenWriter, text, rowY, columnWidth, cell.Align)
+		}
+	}
+}
+
+// sortBy sorts the table by the provided column, see SetSortFunc(). If it is
+// already sorted by that column, the sort order is reversed.
+func (t *Table) sortBy(column int) {
+	t.Lock()
+	sort := t.sortFunc
+	if sort == nil || column < 0 {
+		t.Unlock()
+		return
+	}
+	if column == t.sortColumn {
+		t.sortAscending = !t.sortAscending
+	} else {
+		t.sortColumn, t.sortAscending = column, true
+	}
+	ascending := t.sortAscending
+	t.Unlock()
 
-		t.drawRectangleColorScreenWriter(screenWriter, 0, rowY, columnWidth+1, 1, style)
+	sort(column, ascending)
+}
 
-		start, end := PrintStyle(screenWriter, []byte(cell.Text), 0, rowY, columnWidth, cell.Align, style)
-		printed := end - start
-		if TaggedStringWidth(cell.Text)-printed > 0 && printed > 0 {
-			_, _, style, _ := screenWriter.GetContent(cell.width-1, rowY)
-			PrintStyle(screenWriter, []byte(string(SemigraphicsHorizontalEllipsis)), cell.width-1, rowY, 1, AlignLeft, style)
-		}
+// sortIndicator returns the text appended to the header cell of the provided
+// column, the sort indicator if the table is sorted by it.
+func (t *Table) sortIndicator(column int) string {
+	if column != t.sortColumn {
+		return ""
+	}
+	if t.sortAscending {
+		return t.styles.SortAscending
 	}
+	return t.styles.SortDescending
 }
 
 func (t *Table) drawColumnBorders(screenWriter ScreenWriter, rows []int, columnIndex int, columnWidth int,
 	drawRightEdge bool) {
 
-	borderStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.bordersColor)
+	borderStyle := t.styles.Borders
+	if _, background, _ := borderStyle.Decompose(); background == tcell.ColorDefault {
+		borderStyle = borderStyle.Background(t.backgroundFill())
+	}
 
-	leftJointRune := Borders.Cross
+	borders := t.borderSet()
+	leftJointRune := borders.Cross
 	if columnIndex == 0 {
-		leftJointRune = Borders.LeftT
+		leftJointRune = borders.LeftT
 	}
 
 	_, height := screenWriter.Size()
 	for rowIndex, row := range rows {
-		rowY := 2 * rowIndex
+		rowY := t.rowTops[rowIndex] - 1
 
 		topLeftJointRune := leftJointRune
-		if row == 0 {
+		if rowIndex == 0 && row <= 0 {
 			if columnIndex == 0 {
-				topLeftJointRune = Borders.TopLeft
+				topLeftJointRune = borders.TopLeft
 			} else {
-				topLeftJointRune = Borders.TopT
+				topLeftJointRune = borders.TopT
 			}
 		}
 
 		screenWriter.SetContent(0, rowY, topLeftJointRune, nil, borderStyle)
 		for i := range columnWidth {
-			screenWriter.SetContent(i+1, rowY, Borders.Horizontal, nil, borderStyle)
+			screenWriter.SetContent(i+1, rowY, borders.Horizontal, nil, borderStyle)
 		}
-		if rowY+1 < height {
-			screenWriter.SetContent(0, rowY+1, Borders.Vertical, nil, borderStyle)
+		for line := 1; line <= t.rowHeights[rowIndex] && rowY+line < height; line++ {
+			screenWriter.SetContent(0, rowY+line, borders.Vertical, nil, borderStyle)
 		}
 
 		if drawRightEdge {
-			rightJoint := Borders.RightT
-			if row == 0 {
-				rightJoint = Borders.TopRight
+			rightJoint := borders.RightT
+			if rowIndex == 0 && row <= 0 {
+				rightJoint = borders.TopRight
 			}
 			screenWriter.SetContent(columnWidth+1, rowY, rightJoint, nil, borderStyle)
-			screenWriter.SetContent(columnWidth+1, rowY+1, Borders.Vertical, nil, borderStyle)
+			for line := 1; line <= t.rowHeights[rowIndex]; line++ {
+				screenWriter.SetContent(columnWidth+1, rowY+line, borders.Vertical, nil, borderStyle)
+			}
 		}
 	}
 }
@@ -1112,44 +3092,53 @@ func (t *Table) drawColumnBorders(screenWriter ScreenWriter, rows []int, columnI
 func (t *Table) drawCellBackgroundColumnRange(screenWriter ScreenWriter, rows []int, startColumn int,
 	columnCount int, columnWidths []int) {
 
-	verticalSpacing := 0
-	if t.borders {
-		verticalSpacing = 1
+	drawCell := func(columnStartX, position, column, columnWidth int, style tcell.Style) {
+		row := rows[position]
+		if row == tableHeaderRow {
+			return // The header is never selected.
+		}
+		rowY, rowHeight := t.rowTops[position], t.rowHeights[position]
+		contentX := columnStartX
+		if t.borders {
+			t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY-1, columnWidth+2, rowHeight+2, style)
+			contentX++
+		} else {
+			t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY, columnWidth+1, rowHeight, style)
+		}
+
+		// Renderers which draw selected cells themselves draw them again.
+		if cell := t.cell(row, column); cell != nil {
+			if renderer, ok := t.cellRenderer(row, column, cell).(SelectionCellRenderer); ok {
+				drawCellRenderer(renderer, NewClippingScreenWriter(screenWriter, contentX, rowY, columnWidth, 1), columnWidth, style, true)
+			}
+		}
 	}
 
 	if t.rowsSelectable && t.columnsSelectable {
-		for _, rowIndex := range rows {
-			rowSelected := rowIndex == t.selectedRow
-			if rowSelected {
-				columnStartX := 0
-				for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
-					columnWidth := columnWidths[columnIndex]
-					if t.selectedColumn == columnIndex {
-						rowY := verticalSpacing + ((1 + verticalSpacing) * (rowIndex - t.rowOffset))
-						selectStyle := t.getSelectStyleForCell(rowIndex, columnIndex)
-						if t.borders {
-							t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY-1, columnWidth+2, 3, selectStyle)
-						} else {
-							t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY, columnWidth+1, 1, selectStyle)
-						}
-					}
-					columnStartX += columnWidth + 1
+		for position, rowIndex := range rows {
+			rowMarked := t.selection != nil && t.selection.IsSelected(rowIndex)
+			columnStartX := 0
+			for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
+				columnWidth := columnWidths[columnIndex]
+				if rowMarked || rowIndex == t.selectedRow && t.selectedColumn == columnIndex {
+					drawCell(columnStartX, position, columnIndex, columnWidth, t.getSelectStyleForCell(rowIndex, columnIndex))
+				} else if t.inSelectedRange(rowIndex, columnIndex) {
+					drawCell(columnStartX, position, columnIndex, columnWidth, t.getRangeStyleForCell(rowIndex, columnIndex))
 				}
+				columnStartX += columnWidth + 1
 			}
 		}
 	} else if t.rowsSelectable {
-		for _, rowIndex := range rows {
-			rowSelected := rowIndex == t.selectedRow
-			if rowSelected {
-				rowY := verticalSpacing + ((1 + verticalSpacing) * (rowIndex - t.rowOffset))
+		for position, rowIndex := range rows {
+			rowSelected := rowIndex == t.selectedRow || t.selection != nil && t.selection.IsSelected(rowIndex)
+			if rowSelected || t.inSelectedRange(rowIndex, 0) {
 				columnStartX := 0
 				for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
 					columnWidth := columnWidths[columnIndex]
-					selectStyle := t.getSelectStyleForCell(rowIndex, columnIndex)
-					if t.borders {
-						t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY-1, columnWidth+2, 3, selectStyle)
+					if rowSelected {
+						drawCell(columnStartX, position, columnIndex, columnWidth, t.getSelectStyleForCell(rowIndex, columnIndex))
 					} else {
-						t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY, columnWidth+1, 1, selectStyle)
+						drawCell(columnStartX, position, columnIndex, columnWidth, t.getRangeStyleForCell(rowIndex, columnIndex))
 					}
 					columnStartX += columnWidth + 1
 				}
@@ -1160,14 +3149,12 @@ func (t *Table) drawCellBackgroundColumnRange(screenWriter ScreenWriter, rows []
 		for columnIndex := startColumn; columnIndex < startColumn+columnCount; columnIndex++ {
 			columnWidth := columnWidths[columnIndex]
 			if t.selectedColumn == columnIndex {
-				for _, rowIndex := range rows {
-					rowY := verticalSpacing + ((1 + verticalSpacing) * (rowIndex - t.rowOffset))
-					selectStyle := t.getSelectStyleForCell(rowIndex, columnIndex)
-					if t.borders {
-						t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY-1, columnWidth+2, 3, selectStyle)
-					} else {
-						t.drawRectangleColorScreenWriter(screenWriter, columnStartX, rowY, columnWidth+1, 1, selectStyle)
-					}
+				for position, rowIndex := range rows {
+					drawCell(columnStartX, position, columnIndex, columnWidth, t.getSelectStyleForCell(rowIndex, columnIndex))
+				}
+			} else if t.inSelectedRange(0, columnIndex) {
+				for position, rowIndex := range rows {
+					drawCell(columnStartX, position, columnIndex, columnWidth, t.getRangeStyleForCell(rowIndex, columnIndex))
 				}
 			}
 			columnStartX += columnWidth + 1
@@ -1177,24 +3164,37 @@ func (t *Table) drawCellBackgroundColumnRange(screenWriter ScreenWriter, rows []
 
 func (t *Table) getSelectStyleForCell(rowIndex int, columnIndex int) tcell.Style {
 	cell := t.content.GetCell(rowIndex, columnIndex)
+	if cell == nil {
+		cell = &TableCell{}
+	}
 	var selectStyle tcell.Style
 	if cell.SelectedStyle != tcell.StyleDefault {
 		selectStyle = cell.SelectedStyle
-	} else if t.selectedStyle != tcell.StyleDefault {
-		selectStyle = t.selectedStyle
+	} else if t.styles.Cell.Selected != tcell.StyleDefault {
+		selectStyle = t.styles.Cell.Selected
 	} else {
 		textColor := cell.Color
 		backgroundColor := cell.BackgroundColor
 		if cell.Style != tcell.StyleDefault {
 			textColor, backgroundColor, _ = cell.Style.Decompose()
 		}
-		// _, _, style, _ := screen.GetContent(columnStartX+1, rowY)
-		// fg, bg, _ := style.Decompose()
-		selectStyle = tcell.StyleDefault.Background(textColor).Foreground(backgroundColor)
+		if t.defaultBackground && backgroundColor == t.backgroundColor {
+			backgroundColor = tcell.ColorDefault
+		}
+		selectStyle = invertedStyle(textColor, backgroundColor)
 	}
 	return selectStyle
 }
 
+// getRangeStyleForCell returns the style of a cell in a selected range which
+// is not the selected cell.
+func (t *Table) getRangeStyleForCell(rowIndex int, columnIndex int) tcell.Style {
+	if t.styles.Range != tcell.StyleDefault {
+		return t.styles.Range
+	}
+	return t.getSelectStyleForCell(rowIndex, columnIndex)
+}
+
 func (t *Table) drawRectangleColorScreenWriter(screenWriter ScreenWriter, x int, y int, width int, height int, style tcell.Style) {
 	for row := 0; row < height; row++ {
 		for col := 0; col < width; col++ {
@@ -1212,7 +3212,7 @@ func (t *Table) ensureValidSelection(rowCount int, columnCount int) {
 		}
 		for t.selectedRow < rowCount {
 			cell := t.content.GetCell(t.selectedRow, t.selectedColumn)
-			if cell != nil && !cell.NotSelectable {
+			if cell != nil && !cell.NotSelectable && !t.rowHidden(t.selectedRow, rowCount) {
 				break
 			}
 			t.selectedColumn++
@@ -1231,23 +3231,24 @@ func (t *Table) ensureValidSelection(rowCount int, columnCount int) {
 	}
 }
 
-// clampOffsets calculates and adjusts row and column offsets based on selection and constraints.
+// clampOffsets calculates and adjusts row and column offsets based on selection
+// and constraints. The row count is the number of shown rows.
 func (t *Table) clampOffsets(height int, width int, rowCount int, columnCount int) {
 	screenHeightRows := height
 	if t.borders {
 		screenHeightRows = height / 2 // With borders, every table row takes two screen rows.
 	}
-
-	// Clamp row offsets if requested.
-	if t.clampToSelection && t.rowsSelectable {
-		if t.selectedRow >= t.fixedRows && t.selectedRow < t.fixedRows+t.rowOffset {
-			t.rowOffset = t.selectedRow - t.fixedRows
-			t.trackEnd = false
-		}
-		if t.selectedRow+1-t.rowOffset >= screenHeightRows {
-			t.rowOffset = t.selectedRow + 1 - screenHeightRows
-			t.trackEnd = false
-		}
+	screenHeightRows -= t.headerRows()
+
+	// Clamp row offsets if requested. The fixed rows at the bottom are always
+	// visible.
+	footerRows := t.footerRows(rowCount)
+	selected := t.shownIndex(t.selectedRow)
+	if t.clampToSelection && t.rowsSelectable && selected < rowCount-footerRows {
+		t.scrollRowIntoView(selected, screenHeightRows-footerRows)
+	}
+	if revealed := t.shownIndex(t.revealRow); t.reveal && t.revealRow >= 0 && revealed < rowCount-footerRows {
+		t.scrollRowIntoView(revealed, screenHeightRows-footerRows)
 	}
 	if t.rowOffset < 0 {
 		t.rowOffset = 0
@@ -1263,43 +3264,19 @@ func (t *Table) clampOffsets(height int, width int, rowCount int, columnCount in
 		t.rowOffset = 0
 	}
 
+	// Rows with more than one line take up more space.
+	if t.trackEnd {
+		t.fitRowIntoView(rowCount-footerRows-1, height, rowCount)
+	} else if t.clampToSelection && t.rowsSelectable && selected < rowCount-footerRows {
+		t.fitRowIntoView(selected, height, rowCount)
+	}
+
+	rightColumns := t.rightColumns(columnCount)
 	if t.clampToSelection && t.columnsSelectable {
-		if t.columnOffset != -1 {
-			if t.selectedColumn >= t.fixedColumns && t.selectedColumn < t.fixedColumns+t.columnOffset {
-				t.columnOffset = t.selectedColumn - t.fixedColumns
-			}
-
-			if t.selectedColumn >= t.fixedColumns {
-				columnWidths := t.calculateColumnWidths()
-				effectiveWidth := width - t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns])
-
-				maxColumnOffset := columnCount - t.fixedColumns - 1
-				for {
-					selectionRightEdge := t.effectiveColumnsWidth(columnWidths[t.fixedColumns+t.columnOffset : t.selectedColumn+1])
-					if t.columnOffset >= maxColumnOffset || selectionRightEdge > effectiveWidth {
-						if t.columnOffset >= maxColumnOffset {
-							break
-						}
-						t.columnOffset++
-					} else {
-						break
-					}
-				}
-			}
-		} else {
-			// If columnOffset is -1, we use xScroll.
-			if t.selectedColumn >= t.fixedColumns {
-				columnWidths := t.calculateColumnWidths()
-				effectiveWidth := width - t.effectiveColumnsWidth(columnWidths[0:t.fixedColumns])
-
-				left, right := t.normalColumnLeftRightPositions(columnWidths, t.selectedColumn)
-				if left-t.xScroll < 0 {
-					t.xScroll = left
-				} else if right-t.xScroll > effectiveWidth {
-					t.xScroll = -(effectiveWidth - right)
-				}
-			}
-		}
+		t.scrollColumnIntoView(t.selectedColumn, width, columnCount)
+	}
+	if t.reveal && t.revealColumn >= 0 && t.revealColumn < columnCount {
+		t.scrollColumnIntoView(t.revealColumn, width, columnCount)
 	}
 
 	if t.columnOffset == -1 {
@@ -1307,8 +3284,8 @@ func (t *Table) clampOffsets(height int, width int, rowCount int, columnCount in
 		t.xScroll = max(0, t.xScroll)
 	} else {
 		// Avoid invalid column offsets.
-		if t.columnOffset >= columnCount-t.fixedColumns {
-			t.columnOffset = columnCount - t.fixedColumns - 1
+		if t.columnOffset >= columnCount-t.fixedColumns-rightColumns {
+			t.columnOffset = columnCount - t.fixedColumns - rightColumns - 1
 		}
 		if t.columnOffset < 0 {
 			t.columnOffset = 0
@@ -1316,6 +3293,89 @@ func (t *Table) clampOffsets(height int, width int, rowCount int, columnCount in
 	}
 
 	t.clampToSelection = false // Only once.
+	t.reveal = false
+}
+
+// scrollRowIntoView adjusts the row offset so that the given row (an index
+// among the shown rows) is visible.
+func (t *Table) scrollRowIntoView(row, screenHeightRows int) {
+	if row >= t.fixedRows && row < t.fixedRows+t.rowOffset {
+		t.rowOffset = row - t.fixedRows
+		t.trackEnd = false
+	}
+	if row+1-t.rowOffset >= screenHeightRows {
+		t.rowOffset = row + 1 - screenHeightRows
+		t.trackEnd = false
+	}
+}
+
+// fitRowIntoView increases the row offset until the provided row (an index
+// among the shown rows) fits into the provided height, taking the heights of
+// the rows into account.
+func (t *Table) fitRowIntoView(index, height, rowCount int) {
+	if index < t.fixedRows+t.rowOffset {
+		return // Above the scrolled rows.
+	}
+	row := t.shownRow(index)
+	columnWidths := t.calculateColumnWidths()
+	fits := func() bool {
+		rows, rowHeights := t.calculateVisibleRows(height, rowCount, t.rowOffset, columnWidths)
+		rowTops := t.calculateRowTops(rowHeights)
+		for index := range rows {
+			if rows[index] == row {
+				return rowTops[index]+rowHeights[index] <= height
+			}
+		}
+		return false
+	}
+	for t.fixedRows+t.rowOffset < index && !fits() {
+		t.rowOffset++
+	}
+}
+
+// scrollColumnIntoView adjusts the column offset (or the horizontal scroll
+// position) so that the given column is visible.
+func (t *Table) scrollColumnIntoView(column, width, columnCount int) {
+	rightColumns := t.rightColumns(columnCount)
+	if column >= columnCount-rightColumns {
+		return // Always visible.
+	}
+	if t.columnOffset != -1 {
+		if column >= t.fixedColumns && column < t.fixedColumns+t.columnOffset {
+			t.columnOffset = column - t.fixedColumns
+		}
+
+		if column >= t.fixedColumns {
+			columnWidths := t.calculateColumnWidths()
+			effectiveWidth := width - t.frozenColumnsWidth(columnWidths)
+
+			maxColumnOffset := columnCount - t.fixedColumns - rightColumns - 1
+			for {
+				selectionRightEdge := t.effectiveColumnsWidth(columnWidths[t.fixedColumns+t.columnOffset : column+1])
+				if t.columnOffset >= maxColumnOffset || selectionRightEdge > effectiveWidth {
+					if t.columnOffset >= maxColumnOffset {
+						break
+					}
+					t.columnOffset++
+				} else {
+					break
+				}
+			}
+		}
+	} else {
+		// If columnOffset is -1, we use xScroll.
+		if column >= t.fixedColumns {
+			columnWidths := t.calculateColumnWidths()
+			effectiveWidth := width - t.frozenColumnsWidth(columnWidths)
+
+			left, right := t.normalColumnLeftRightPositions(columnWidths, column)
+			if left-t.xScroll < 0 {
+				t.xScroll = left
+			} else if right-t.xScroll > effectiveWidth {
+				t.xScroll = -(effectiveWidth - right)
+			}
+		}
+	}
 }
 
 func (t *Table) normalColumnLeftRightPositions(columnWidths []int, columnIndex int) (left int, right int) {
@@ -1327,51 +3387,235 @@ func (t *Table) normalColumnLeftRightPositions(columnWidths []int, columnIndex i
 	return
 }
 
-// calculateVisibleRows determines which rows should be visible on screen.
-func (t *Table) calculateVisibleRows(height int, rowCount int) (rows []int, allRows []int) {
-
-	rowStep := 1
+// calculateVisibleRows determines which rows should be visible on screen when
+// scrolled to the provided row offset, and their heights. The row count and
+// the row offset refer to the shown rows, see SetFilterFunc().
+func (t *Table) calculateVisibleRows(height int, rowCount int, rowOffset int, columnWidths []int) (rows, rowHeights []int) {
+	rowSpacing := 0
 	if t.borders {
-		rowStep = 2 // With borders, every table row takes two screen rows.
+		rowSpacing = 1 // With borders, every table row is preceded by a border.
 	}
 
-	allRows = make([]int, rowCount)
-	for row := 0; row < rowCount; row++ {
-		allRows[row] = row
+	tableHeight := 0
+	add := func(row int) {
+		if row != tableHeaderRow {
+			row = t.shownRow(row)
+		}
+		rowHeight := t.rowHeight(row, columnWidths)
+		rows = append(rows, row)
+		rowHeights = append(rowHeights, rowHeight)
+		tableHeight += rowHeight + rowSpacing
+	}
+
+	footerRows := t.footerRows(rowCount)
+	footerHeight := 0
+	for row := rowCount - footerRows; row < rowCount; row++ {
+		footerHeight += t.rowHeight(t.shownRow(row), columnWidths) + rowSpacing
+	}
+
+	if t.header != nil { // The header goes above everything else.
+		add(tableHeaderRow)
 	}
 
-	tableHeight := 0
 	for row := 0; row < t.fixedRows && row < rowCount && tableHeight < height; row++ { // Do the fixed rows first.
-		rows = append(rows, row)
-		tableHeight += rowStep
+		add(row)
 	}
 
-	for row := t.fixedRows + t.rowOffset; row < rowCount && tableHeight < height; row++ { // Then the remaining rows.
-		rows = append(rows, row)
-		tableHeight += rowStep
+	for row := t.fixedRows + rowOffset; row < rowCount-footerRows && tableHeight+footerHeight < height; row++ { // Then the remaining rows.
+		add(row)
+	}
+
+	for row := rowCount - footerRows; row < rowCount && tableHeight < height; row++ { // And the fixed rows at the bottom.
+		add(row)
 	}
 
-	return rows, allRows
+	return
+}
+
+// rowHeight returns the number of lines taken up by a row given the widths of
+// the columns. This is 1 unless the row contains cells which wrap their text.
+func (t *Table) rowHeight(row int, columnWidths []int) int {
+	height := 1
+	for column, width := range columnWidths {
+		if cell := t.cell(row, column); cell != nil && cell.Wrap {
+			height = max(height, len(WordWrap(cell.Text, width)))
+		}
+	}
+	return height
+}
+
+// calculateRowTops returns the y-coordinates of the first lines of rows
+// drawn one below the other, given their heights.
+func (t *Table) calculateRowTops(rowHeights []int) []int {
+	tops := make([]int, len(rowHeights))
+	var y int
+	for index, height := range rowHeights {
+		if t.borders {
+			y++ // The border above the row.
+		}
+		tops[index] = y
+		y += height
+	}
+	return tops
 }
 
 // calculateVisibleColumns determines which columns should be visible and their widths.
 func (t *Table) calculateColumnWidths() []int {
+	if t.columnsSource != nil {
+		t.columnsSource.RLock()
+		defer t.columnsSource.RUnlock()
+		if widths := t.columnsSource.calculateColumnWidths(); len(widths) == t.columnCount() {
+			return widths
+		}
+	}
+
 	rowCount := t.content.GetRowCount()
-	columnCount := t.content.GetColumnCount()
+	columnCount := t.columnCount()
+
+	// Content provided with SetContent() may have more rows than can be
+	// measured. Only the fixed rows (at the top and at the bottom) and the
+	// visible rows are measured then, and if SetEvaluateVisibleRowsOnly() was
+	// set.
+	fixedEnd, scrolledStart, scrolledEnd, footerStart := 0, 0, rowCount, rowCount
+	_, defaultContent := t.content.(*tableDefaultContent)
+	if t.columnView != nil {
+		_, defaultContent = t.columnView.TableContent.(*tableDefaultContent)
+	}
+	visibleOnly := !defaultContent || t.visibleRowsOnly
+	if visibleOnly {
+		rowCount = t.shownRowCount(rowCount)
+		fixedEnd = min(t.fixedRows, rowCount)
+		footerStart = rowCount - t.footerRows(rowCount)
+		scrolledStart = min(t.fixedRows+max(t.rowOffset, 0), footerStart)
+		scrolledEnd = min(scrolledStart+t.visibleRows, footerStart)
+	}
 
 	columnWidths := make([]int, columnCount)
+	expansions := make([]int, columnCount)
 	for i := range columnCount {
 		maxWidth := 0
-		for j := range rowCount {
-			if cell := t.content.GetCell(j, i); cell != nil {
-				maxWidth = max(maxWidth, cell.width)
+		measure := func(from, to int) {
+			for j := from; j < to; j++ {
+				row := j
+				if visibleOnly {
+					row = t.shownRow(j)
+				}
+				if cell := t.content.GetCell(row, i); cell != nil {
+					maxWidth = max(maxWidth, t.cellWidth(row, i, cell))
+					expansions[i] = max(expansions[i], cell.Expansion)
+				}
 			}
 		}
+		measure(0, fixedEnd)
+		measure(scrolledStart, scrolledEnd)
+		measure(footerStart, rowCount)
+		if header := t.cell(tableHeaderRow, i); header != nil {
+			maxWidth = max(maxWidth, header.width+TaggedStringWidth(t.sortIndicator(i)))
+			expansions[i] = max(expansions[i], header.Expansion)
+		}
 		columnWidths[i] = maxWidth
 	}
+	t.expandColumns(columnWidths, expansions)
+	if t.autoFit {
+		t.shrinkColumns(columnWidths)
+	}
 	return columnWidths
 }
 
+// expandColumns distributes the width of the table which is not taken by its
+// columns among the columns with an expansion value (see
+// TableCell.SetExpansion), in proportion to that value. The table must be
+// locked.
+func (t *Table) expandColumns(columnWidths, expansions []int) {
+	var total int
+	for _, expansion := range expansions {
+		total += expansion
+	}
+	if total == 0 {
+		return
+	}
+
+	free := t.availableColumnsWidth() - t.effectiveColumnsWidth(columnWidths)
+	if free <= 0 {
+		return
+	}
+
+	var sum, distributed int
+	for column, expansion := range expansions {
+		sum += expansion
+		extra := free*sum/total - distributed
+		columnWidths[column] += extra
+		distributed += extra
+	}
+}
+
+// shrinkColumns reduces the widths of the columns which are wider than the
+// table, see SetAutoFitColumns(). The table must be locked.
+func (t *Table) shrinkColumns(columnWidths []int) {
+	excess := t.effectiveColumnsWidth(columnWidths) - t.availableColumnsWidth()
+	if excess <= 0 {
+		return
+	}
+
+	minWidths := make([]int, len(columnWidths))
+	priorities := make([]int, len(columnWidths))
+	for column := range columnWidths {
+		minWidths[column] = 1
+		if fit, ok := t.columnFits[t.contentColumn(column)]; ok {
+			minWidths[column], priorities[column] = fit.minWidth, fit.priority
+		}
+	}
+	levels := append([]int(nil), priorities...)
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+
+	// Shrink the columns of each priority in proportion to their
+	// shrinkable widths, the highest priority first.
+	for index, level := range levels {
+		if index > 0 && levels[index-1] == level {
+			continue
+		}
+		var total int
+		for column, width := range columnWidths {
+			if priorities[column] == level {
+				total += max(width-minWidths[column], 0)
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		shrink := min(excess, total)
+		var sum, distributed int
+		for column, width := range columnWidths {
+			if priorities[column] != level {
+				continue
+			}
+			sum += max(width-minWidths[column], 0)
+			amount := shrink*sum/total - distributed
+			columnWidths[column] -= amount
+			distributed += amount
+		}
+		excess -= shrink
+		if excess == 0 {
+			return
+		}
+	}
+}
+
+// availableColumnsWidth returns the width available to the columns, as
+// determined by Draw(). The table must be locked.
+func (t *Table) availableColumnsWidth() int {
+	_, _, width, _ := t.GetInnerRect()
+	if t.borders {
+		width--
+	}
+	shownCount := t.shownRowCount(t.content.GetRowCount())
+	footerRows := t.footerRows(shownCount)
+	if t.scrollBar.IsVisible(shownCount-t.fixedRows-footerRows, t.visibleRows-t.fixedRows-footerRows) {
+		width--
+	}
+	return width
+}
+
 // moveSelectionForward moves the selection forward, don't go beyond final cell, return
 // true if a selection was found.
 func (t *Table) moveSelectionForward(finalRow int, finalColumn int) bool {
@@ -1382,7 +3626,7 @@ func (t *Table) moveSelectionForward(finalRow int, finalColumn int) bool {
 	for {
 		// Stop if the current selection is fine.
 		cell := t.content.GetCell(row, column)
-		if cell != nil && !cell.NotSelectable {
+		if cell != nil && !cell.NotSelectable && !t.rowHidden(row, rowCount) {
 			t.selectedRow, t.selectedColumn = row, column
 			return true
 		}
@@ -1414,7 +3658,7 @@ func (t *Table) moveSelectionBackwards(finalRow int, finalColumn int) bool {
 	for {
 		// Stop if the current selection is fine.
 		cell := t.content.GetCell(row, column)
-		if cell != nil && !cell.NotSelectable {
+		if cell != nil && !cell.NotSelectable && !t.rowHidden(row, rowCount) {
 			t.selectedRow = row
 			t.selectedColumn = column
 			return true
@@ -1603,22 +3847,23 @@ func (t *Table) navigateRight() {
 		}
 		t.clampToSelection = true
 	} else {
-		maxColumnOffset := t.content.GetColumnCount() - t.fixedColumns - 1
+		columnCount := t.content.GetColumnCount()
+		maxColumnOffset := columnCount - t.fixedColumns - t.rightColumns(columnCount) - 1
 		t.columnOffset = min(t.columnOffset+1, maxColumnOffset)
 	}
 }
 
 // navigatePageDown moves the selection down by one page.
 func (t *Table) navigatePageDown() {
-	offsetAmount := t.visibleRows - t.fixedRows
+	offsetAmount := t.visibleRows - t.fixedRows - t.footerRows(t.content.GetRowCount())
 	if offsetAmount < 0 {
 		offsetAmount = 0
 	}
 	if t.rowsSelectable {
 		row := t.selectedRow
 		column := t.selectedColumn
-		t.selectedRow += offsetAmount
 		rowCount := t.content.GetRowCount()
+		t.selectedRow = t.shownRow(min(t.shownIndex(t.selectedRow)+offsetAmount, t.shownRowCount(rowCount)-1))
 		if t.selectedRow >= rowCount {
 			t.selectedRow = rowCount - 1
 		}
@@ -1636,14 +3881,14 @@ func (t *Table) navigatePageDown() {
 
 // navigatePageUp moves the selection up by one page.
 func (t *Table) navigatePageUp() {
-	offsetAmount := t.visibleRows - t.fixedRows
+	offsetAmount := t.visibleRows - t.fixedRows - t.footerRows(t.content.GetRowCount())
 	if offsetAmount < 0 {
 		offsetAmount = 0
 	}
 	if t.rowsSelectable {
 		row := t.selectedRow
 		column := t.selectedColumn
-		t.selectedRow -= offsetAmount
+		t.selectedRow = t.shownRow(t.shownIndex(t.selectedRow) - offsetAmount)
 		if t.selectedRow < 0 {
 			t.selectedRow = 0
 		}
@@ -1659,9 +3904,66 @@ func (t *Table) navigatePageUp() {
 	}
 }
 
+// toggleSelectedCell toggles the bool bound to the selected cell, see
+// NewCheckboxCell(). If only rows are selectable, the first such cell of the
+// selected row is toggled. It returns whether or not a cell was toggled.
+func (t *Table) toggleSelectedCell() bool {
+	t.RLock()
+	var renderer *toggleCellRenderer
+	if t.rowsSelectable {
+		from, to := t.selectedColumn, t.selectedColumn+1
+		if !t.columnsSelectable {
+			from, to = 0, t.content.GetColumnCount()
+		}
+		for column := from; column < to; column++ {
+			cell := t.content.GetCell(t.selectedRow, column)
+			if cell == nil {
+				continue
+			}
+			if toggle, ok := cell.GetRenderer().(*toggleCellRenderer); ok {
+				renderer = toggle
+				break
+			}
+		}
+	}
+	t.RUnlock()
+
+	if renderer == nil {
+		return false
+	}
+	renderer.toggle()
+	return true
+}
+
 // InputHandler returns the handler for this primitive.
 func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		if t.hitShortcut(event, t.keys().ShowContextMenu) {
+			t.ShowContextMenuForSelection(setFocus)
+			return
+		}
+
+		if t.hitShortcut(event, t.keys().Select2) && t.toggleSelectedCell() {
+			return
+		}
+
+		if t.rowsMovable && t.rowsSelectable && t.hitShortcut(event, t.keys().MoveRowUp, t.keys().MoveRowDown) {
+			if t.hitShortcut(event, t.keys().MoveRowUp) {
+				t.moveSelectedRowBy(-1)
+			} else {
+				t.moveSelectedRowBy(1)
+			}
+			return
+		}
+		if t.columnsMovable && t.columnsSelectable && t.hitShortcut(event, t.keys().MoveColumnLeft, t.keys().MoveColumnRight) {
+			if t.hitShortcut(event, t.keys().MoveColumnLeft) {
+				t.moveColumnTo(t.selectedColumn, t.selectedColumn-1)
+			} else {
+				t.moveColumnTo(t.selectedColumn, t.selectedColumn+1)
+			}
+			return
+		}
+
 		key := event.Key()
 
 		if (!t.rowsSelectable && !t.columnsSelectable && key == tcell.KeyEnter) ||
@@ -1676,69 +3978,225 @@ func (t *Table) InputHandler() func(event *tcell.EventKey, setFocus func(p Primi
 
 		// Movement functions.
 		previouslySelectedRow, previouslySelectedColumn := t.selectedRow, t.selectedColumn
+		previousAnchorRow, previousAnchorColumn := t.rangeAnchorRow, t.rangeAnchorColumn
 		if t.content.GetRowCount() == 0 {
 			return // No movement on empty tables.
 		}
 
-		switch key {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'g':
+		// Navigation which extends the range or the selection.
+		extend := func() {
+			switch {
+			case t.hitShortcut(event, t.keys().ExtendSelectionUp):
+				t.navigateUp()
+			case t.hitShortcut(event, t.keys().ExtendSelectionDown):
+				t.navigateDown()
+			case t.hitShortcut(event, t.keys().ExtendSelectionLeft):
+				t.navigateLeft()
+			case t.hitShortcut(event, t.keys().ExtendSelectionRight):
+				t.navigateRight()
+			case t.hitShortcut(event, t.keys().ExtendSelectionFirst):
 				t.navigateHome()
-			case 'G':
+			case t.hitShortcut(event, t.keys().ExtendSelectionLast):
 				t.navigateEnd()
-			case 'j':
-				t.navigateDown()
-			case 'k':
+			case t.hitShortcut(event, t.keys().ExtendSelectionPageUp):
+				t.navigatePageUp()
+				t.smoothScroll.animate()
+			case t.hitShortcut(event, t.keys().ExtendSelectionPageDown):
+				t.navigatePageDown()
+				t.smoothScroll.animate()
+			}
+		}
+		extendRows := t.hitShortcut(event, t.keys().ExtendSelectionUp, t.keys().ExtendSelectionDown,
+			t.keys().ExtendSelectionPageUp, t.keys().ExtendSelectionPageDown)
+		extendBoth := t.hitShortcut(event, t.keys().ExtendSelectionFirst, t.keys().ExtendSelectionLast)
+
+		extendSelection, extendRange := false, false
+		if t.rangeSelectable && (t.rowsSelectable && extendRows ||
+			t.columnsSelectable && t.hitShortcut(event, t.keys().ExtendSelectionLeft, t.keys().ExtendSelectionRight) ||
+			(t.rowsSelectable || t.columnsSelectable) && extendBoth) {
+			if t.rangeAnchorRow < 0 {
+				t.rangeAnchorRow, t.rangeAnchorColumn = t.selectedRow, t.selectedColumn
+			}
+			extend()
+			extendRange = true
+		} else if t.selection != nil && t.rowsSelectable && t.hitShortcut(event, t.keys().ToggleSelection) {
+			t.selection.Toggle(t.selectedRow)
+			return
+		} else if t.selection != nil && t.rowsSelectable && (extendRows || extendBoth) {
+			extend()
+			extendSelection = true
+		}
+
+		if !extendSelection && !extendRange {
+			switch {
+			case t.hitShortcut(event, t.keys().MoveFirst, t.keys().MoveFirst2):
+				t.navigateHome()
+			case t.hitShortcut(event, t.keys().MoveLast, t.keys().MoveLast2):
+				t.navigateEnd()
+			case t.hitShortcut(event, t.keys().MoveUp, t.keys().MoveUp2):
 				t.navigateUp()
-			case 'h':
+			case t.hitShortcut(event, t.keys().MoveDown, t.keys().MoveDown2):
+				t.navigateDown()
+			case t.hitShortcut(event, t.keys().MoveLeft, t.keys().MoveLeft2):
 				t.navigateLeft()
-			case 'l':
+			case t.hitShortcut(event, t.keys().MoveRight, t.keys().MoveRight2):
 				t.navigateRight()
+			case t.hitShortcut(event, t.keys().MoveNextPage):
+				t.navigatePageDown()
+				t.smoothScroll.animate()
+			case t.hitShortcut(event, t.keys().MovePreviousPage):
+				t.navigatePageUp()
+				t.smoothScroll.animate()
+			case t.hitShortcut(event, t.keys().Select):
+				if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
+					t.selected(t.selectedRow, t.selectedColumn)
+				}
 			}
-		case tcell.KeyHome:
-			t.navigateHome()
-		case tcell.KeyEnd:
-			t.navigateEnd()
-		case tcell.KeyUp:
-			t.navigateUp()
-		case tcell.KeyDown:
-			t.navigateDown()
-		case tcell.KeyLeft:
-			t.navigateLeft()
-		case tcell.KeyRight:
-			t.navigateRight()
-		case tcell.KeyPgDn, tcell.KeyCtrlF:
-			t.navigatePageDown()
-		case tcell.KeyPgUp, tcell.KeyCtrlB:
-			t.navigatePageUp()
-		case tcell.KeyEnter:
-			if (t.rowsSelectable || t.columnsSelectable) && t.selected != nil {
-				t.selected(t.selectedRow, t.selectedColumn)
+
+			// Moving the selection collapses the range.
+			if previouslySelectedRow != t.selectedRow || previouslySelectedColumn != t.selectedColumn {
+				t.rangeAnchorRow = -1
 			}
 		}
 
+		// Update the selection model.
+		if t.selection != nil && t.rowsSelectable && previouslySelectedRow != t.selectedRow {
+			t.selection.follow(previouslySelectedRow, t.selectedRow, extendSelection)
+		}
+
 		// If the selection has changed, notify the handler.
 		if t.selectionChanged != nil &&
 			(t.rowsSelectable && previouslySelectedRow != t.selectedRow ||
 				t.columnsSelectable && previouslySelectedColumn != t.selectedColumn) {
 			t.selectionChanged(t.selectedRow, t.selectedColumn)
 		}
+		if t.selectionRangeChanged != nil &&
+			(previouslySelectedRow != t.selectedRow || previouslySelectedColumn != t.selectedColumn ||
+				previousAnchorRow != t.rangeAnchorRow || previousAnchorColumn != t.rangeAnchorColumn) {
+			t.selectionRangeChanged(t.selectedRange())
+		}
 	})
 }
 
 // MouseHandler returns the mouse handler for this primitive.
 func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Pass events to the context menu, close it when clicking elsewhere.
+		if menu := t.activeMenu(); menu != nil {
+			if menu.ContextMenuList().InRect(event.Position()) {
+				return menu.ContextMenuList().MouseHandler()(action, event, setFocus)
+			}
+			if action == MouseLeftClick || action == MouseLeftDown {
+				menu.HideContextMenu(setFocus)
+				return true, nil
+			}
+		}
+
+		// Pass events to the scroll bars.
+		if offset, ok, dragging := t.scrollBar.HandleMouse(action, event); ok {
+			t.trackEnd = false
+			t.rowOffset = offset
+			if dragging {
+				capture = t
+			}
+			return true, capture
+		}
+		if offset, ok, dragging := t.horizontalScrollBar.HandleMouse(action, event); ok {
+			t.xScroll, t.columnOffset = offset, -1
+			if dragging {
+				capture = t
+			}
+			return true, capture
+		}
+
+		// Move the column whose header cell is dragged.
 		x, y := event.Position()
+		if t.draggedColumn >= 0 {
+			switch action {
+			case MouseMove:
+				if _, column := t.CellAt(x, y); column >= 0 && t.moveColumnTo(t.draggedColumn, column) {
+					t.draggedColumn, t.draggedColumnMoved = column, true
+				}
+				return true, t
+			case MouseLeftUp:
+				column, moved := t.draggedColumn, t.draggedColumnMoved
+				t.draggedColumn, t.draggedColumnMoved = -1, false
+				if !moved {
+					t.sortBy(column)
+				}
+				return true, nil
+			}
+		}
+
+		// Move the selected row while the mouse is dragged.
+		if t.rowMoving {
+			switch action {
+			case MouseMove:
+				if row, _ := t.CellAt(x, y); row >= 0 {
+					t.moveSelectedRow(row)
+				}
+				return true, t
+			case MouseLeftUp:
+				t.rowMoving = false
+				return true, nil
+			}
+		}
+
+		// Extend a range while the mouse is dragged.
+		if t.rangeDragging {
+			switch action {
+			case MouseMove:
+				if row, column := t.CellAt(x, y); row >= 0 && (column >= 0 || !t.columnsSelectable) && (row != t.selectedRow || column != t.selectedColumn) {
+					t.Lock()
+					t.selectedRow, t.selectedColumn = row, column
+					t.clampToSelection = true
+					t.Unlock()
+					if t.selectionChanged != nil {
+						t.selectionChanged(row, column)
+					}
+					if t.selectionRangeChanged != nil {
+						t.selectionRangeChanged(t.GetSelectedRange())
+					}
+				}
+				return true, t
+			case MouseLeftUp:
+				t.rangeDragging = false
+				return true, nil
+			}
+		}
+
+		if action == MouseLeave {
+			return t.setHovered(-1, -1), nil
+		}
 		if !t.InRect(x, y) {
 			return false, nil
 		}
 
 		switch action {
+		case MouseMove:
+			row, column := t.CellAt(x, y)
+			if _, header := t.headerColumnAt(x, y); header {
+				row, column = -1, -1
+			}
+			consumed = t.setHovered(row, column)
+
 		case MouseLeftDown:
 			setFocus(t)
 
+			if column, ok := t.headerColumnAt(x, y); ok {
+				t.RLock()
+				movable := t.columnsMovable && t.columnMovable(column)
+				t.RUnlock()
+				if movable {
+					// Move the column while the mouse is dragged, sort when
+					// the button is released.
+					t.draggedColumn, t.draggedColumnMoved = column, false
+					return true, t
+				}
+				t.sortBy(column)
+				return true, nil
+			}
+
 			selectEvent := true
 			row, column := t.CellAt(x, y)
 			cell := t.content.GetCell(row, column)
@@ -1747,30 +4205,113 @@ func (t *Table) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 					selectEvent = false
 				}
 			}
+			t.RLock()
+			movable := t.rowsMovable && t.rowsSelectable && t.rowMovable(row)
+			t.RUnlock()
+			if row >= 0 && selectEvent && movable && event.Modifiers()&(tcell.ModCtrl|tcell.ModShift) == 0 {
+				// Select the row and move it while the mouse is dragged.
+				if t.selection != nil {
+					t.selection.click(row, event.Modifiers())
+				}
+				if t.selectedRow != row || t.selectedColumn != column {
+					t.Select(row, column)
+				}
+				t.rowMoving = true
+				return true, t
+			}
+			if row >= 0 && (column >= 0 || !t.columnsSelectable) && selectEvent && t.rangeSelectable && (t.rowsSelectable || t.columnsSelectable) {
+				// Start a new range or, with Shift, extend the current one.
+				t.Lock()
+				if event.Modifiers()&tcell.ModShift == 0 {
+					t.rangeAnchorRow, t.rangeAnchorColumn = row, column
+				} else if t.rangeAnchorRow < 0 {
+					t.rangeAnchorRow, t.rangeAnchorColumn = t.selectedRow, t.selectedColumn
+				}
+				changed := row != t.selectedRow || column != t.selectedColumn
+				t.selectedRow, t.selectedColumn = row, column
+				t.rangeDragging = true
+				t.Unlock()
+				if changed && t.selectionChanged != nil {
+					t.selectionChanged(row, column)
+				}
+				if t.selectionRangeChanged != nil {
+					t.selectionRangeChanged(t.GetSelectedRange())
+				}
+				return true, t
+			}
+			if row >= 0 && t.selection != nil && t.rowsSelectable {
+				t.selection.click(row, event.Modifiers())
+				if event.Modifiers()&(tcell.ModCtrl|tcell.ModShift) != 0 {
+					// Only move the current row, the selection model was updated.
+					t.Lock()
+					t.selectedRow, t.selectedColumn = row, column
+					t.Unlock()
+					consumed = true
+					break
+				}
+			}
 			isAlreadySelected := t.selectedRow == row && t.selectedColumn == column
 			if !isAlreadySelected && selectEvent && (t.rowsSelectable || t.columnsSelectable) {
 				t.Select(row, column)
 			}
 
-			if isAlreadySelected {
-				now := time.Now()
-				if !t.lastMouseDown.IsZero() && (now.Sub(t.lastMouseDown) < t.doubleClickDuration) {
-					// Double-click: Notify the handler.
-					if t.doubleClick != nil {
-						t.doubleClick(row, column)
-					}
+			consumed = true
+
+		case MouseLeftDoubleClick:
+			row, column := t.CellAt(x, y)
+			if row >= 0 && t.doubleClick != nil {
+				t.doubleClick(row, column)
+			}
+			consumed = true
+
+		case MouseRightDown:
+			row, column := t.CellAt(x, y)
+			if _, header := t.headerColumnAt(x, y); header {
+				if t.GetColumnsHideable() {
+					setFocus(t)
+					t.ShowColumnChooser(x, y+1, setFocus)
+					consumed = true
+					break
 				}
+				row, column = -1, -1
+			}
+			t.RLock()
+			rightClick, selectable := t.rightClick, t.rowsSelectable || t.columnsSelectable
+			t.RUnlock()
+			if rightClick == nil && len(t.ContextMenuList().items) == 0 {
+				break
+			}
+
+			setFocus(t)
+			var cell *TableCell
+			if row >= 0 && column >= 0 {
+				cell = t.content.GetCell(row, column)
+			}
+			if row >= 0 && selectable && (cell == nil || !cell.NotSelectable) && (t.selectedRow != row || t.selectedColumn != column) {
+				t.Select(row, column)
+			}
+			if rightClick != nil {
+				rightClick(row, column, x, y)
+			}
+
+			// Anchor the context menu below the clicked cell.
+			menuX, menuY := x, y+1
+			if cell != nil {
+				cellX, cellY, _ := cell.GetLastPosition()
+				menuX, menuY = max(cellX, 0), cellY+1
 			}
-			t.lastMouseDown = time.Now()
+			t.ShowContextMenu(row, menuX, menuY, setFocus)
 			consumed = true
 
 		case MouseScrollUp:
 			t.trackEnd = false
 			t.rowOffset--
+			t.smoothScroll.animate()
 			consumed = true
 
 		case MouseScrollDown:
 			t.rowOffset++
+			t.smoothScroll.animate()
 			consumed = true
 		}
 
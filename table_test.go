@@ -2,7 +2,11 @@ package nuview
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 var tableTestCases = generateTableTestCases()
@@ -104,3 +108,1207 @@ func tc(c *tableTestCase) *Table {
 
 	return table
 }
+
+func TestTableRenderRegion(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			tb.SetCellSimple(row, column, fmt.Sprintf("%c%d", 'a'+column, row))
+		}
+	}
+	tb.GetCell(1, 1).SetTextColor(tcell.ColorRed)
+	tb.SetSelectable(true, false)
+	tb.SetSelectedStyle(tcell.StyleDefault.Reverse(true))
+	tb.Select(2, 0)
+
+	cells := tb.RenderRegion(1, 1, 5, 2)
+	if len(cells) != 2 || len(cells[0]) != 5 {
+		t.Fatalf("failed to render region: expected 2x5 cells, got %v", cells)
+	}
+	for y, expected := range []string{"b1 c1", "b2 c2"} {
+		for x, r := range expected {
+			if cells[y][x].Rune != r {
+				t.Errorf("failed to render region: expected %q at %d/%d, got %q", r, x, y, cells[y][x].Rune)
+			}
+		}
+	}
+	if foreground, _, _ := cells[0][0].Style.Decompose(); foreground != tcell.ColorRed {
+		t.Errorf("failed to render cell style: expected red, got %v", foreground)
+	}
+	if _, _, attributes := cells[1][0].Style.Decompose(); attributes&tcell.AttrReverse == 0 {
+		t.Errorf("failed to render selected row: got %v", cells[1][0].Style)
+	}
+
+	if cells := tb.RenderRegion(5, 0, 6, 2); cells != nil {
+		t.Errorf("failed to render region: expected no cells outside of the table, got %v", cells)
+	}
+
+	cells = [][]ImageCell{{
+		{Rune: 'a', Style: tcell.StyleDefault.Foreground(tcell.ColorRed)},
+		{Rune: 'b', Style: tcell.StyleDefault.Bold(true).Background(tcell.NewRGBColor(1, 2, 3))},
+		{Rune: 'c', Style: tcell.StyleDefault},
+	}, {
+		{Rune: 'd', Style: tcell.StyleDefault},
+	}}
+	expected := "\x1b[0;91ma\x1b[0;1;48;2;1;2;3mb\x1b[0mc\nd"
+	if text := CellsToANSI(cells); text != expected {
+		t.Errorf("failed to convert cells to ANSI: expected %q, got %q", expected, text)
+	}
+}
+
+func TestTableColumnGaps(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	for column, text := range []string{"aa", "bbb", "c"} {
+		tb.SetCellSimple(0, column, text)
+	}
+	widths := tb.calculateColumnWidths()
+	if width := tb.effectiveColumnsWidth(widths); width != 9 {
+		t.Errorf("failed to count column gaps: expected width 9, got %d", width)
+	}
+
+	var b strings.Builder
+	cells := tb.RenderRegion(0, 0, 0, 2)
+	for _, cell := range cells[0] {
+		b.WriteRune(cell.Rune)
+	}
+	if text := b.String(); text != "aa bbb c" {
+		t.Errorf("failed to render region without borders: expected \"aa bbb c\", got %q", text)
+	}
+
+	tb.SetBorders(true)
+	if width := tb.effectiveColumnsWidth(widths); width != 9 {
+		t.Errorf("failed to count column borders: expected width 9, got %d", width)
+	}
+	if cells := tb.RenderRegion(0, 0, 0, 2); len(cells[0]) != 10 {
+		t.Errorf("failed to render region with borders: expected width 10, got %d", len(cells[0]))
+	}
+}
+
+func TestTableContextMenu(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	for row := 0; row < 3; row++ {
+		tb.SetCellSimple(row, 0, fmt.Sprintf("row %d", row))
+	}
+	tb.SetSelectable(true, false)
+	tb.Select(1, 0)
+
+	var selectedRow = -1
+	tb.AddContextItem("Delete", 'd', func(index int) {
+		selectedRow = index
+	})
+
+	app, err := newTestApp(tb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetRoot(tb, false)
+	tb.SetRect(0, 0, 20, 10)
+	app.SetFocus(tb)
+	app.draw()
+
+	app.dispatchKey(tcell.NewEventKey(tcell.KeyF10, 0, tcell.ModShift), "")
+	if !tb.ContextMenuVisible() || app.GetFocus() != tb.ContextMenuList() {
+		t.Fatal("failed to open context menu with keyboard")
+	}
+	if x, y, _, _ := tb.ContextMenuList().GetRect(); x != 0 || y != 2 {
+		t.Errorf("failed to anchor context menu: expected 0/2, got %d/%d", x, y)
+	}
+
+	app.dispatchKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), "")
+	if selectedRow != 1 {
+		t.Errorf("failed to select context item: expected row 1, got %d", selectedRow)
+	}
+	if tb.ContextMenuVisible() || app.GetFocus() != tb {
+		t.Error("failed to close context menu")
+	}
+
+	if !app.OpenContextMenu(5, 1) {
+		t.Fatal("failed to open context menu programmatically")
+	}
+	app.draw()
+	if x, y, _, _ := tb.ContextMenuList().GetRect(); x != 5 || y != 1 {
+		t.Errorf("failed to position context menu: expected 5/1, got %d/%d", x, y)
+	}
+
+	app.SetFocus(NewBox())
+	if app.OpenContextMenu(-1, -1) {
+		t.Error("failed to ignore primitive without context menu")
+	}
+}
+
+func TestTableEnsureCellVisible(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 20, 5)
+	if first, last := tb.GetVisibleColumnRange(); first != -1 || last != -1 {
+		t.Errorf("failed to get visible columns of undrawn table: expected -1 -1, got %d %d", first, last)
+	}
+	for row := 0; row < 100; row++ {
+		for column := 0; column < 10; column++ {
+			tb.SetCellSimple(row, column, fmt.Sprintf("%c%02d", 'a'+column, row))
+		}
+	}
+	tb.SetFixed(0, 1)
+	tb.SetSelectable(true, true)
+	tb.Draw(sc)
+	if first, last := tb.GetVisibleColumnRange(); first != 0 || last != 4 {
+		t.Errorf("failed to get visible columns: expected 0 4, got %d %d", first, last)
+	}
+
+	tb.EnsureCellVisible(50, 8)
+	tb.Draw(sc)
+	if row, _ := tb.GetOffset(); row > 50 || row+5 <= 50 {
+		t.Errorf("failed to scroll row into view: got row offset %d", row)
+	}
+	if first, last := tb.GetVisibleColumnRange(); first != 0 || last != 8 {
+		t.Errorf("failed to scroll column into view: expected columns 0 to 8, got %d %d", first, last)
+	}
+	if row, column := tb.GetSelection(); row != 0 || column != 0 {
+		t.Errorf("failed to keep selection: expected 0 0, got %d %d", row, column)
+	}
+
+	tb.ScrollColumnIntoView(2)
+	tb.Draw(sc)
+	if _, last := tb.GetVisibleColumnRange(); last > 5 {
+		t.Errorf("failed to scroll column back into view: got last column %d", last)
+	}
+}
+
+// virtualContent is a read-only TableContent with computed cells.
+type virtualContent struct {
+	TableContentReadOnly
+	requested int
+}
+
+func (c *virtualContent) GetCell(row, column int) *TableCell {
+	c.requested++
+	return NewTableCell(fmt.Sprintf("%d:%d", row, column))
+}
+
+func (c *virtualContent) GetRowCount() int {
+	return 1000000
+}
+
+func (c *virtualContent) GetColumnCount() int {
+	return 2
+}
+
+func TestTableContent(t *testing.T) {
+	t.Parallel()
+
+	content := &virtualContent{}
+	tb := NewTable()
+	tb.SetContent(content)
+	tb.SetRect(0, 0, 20, 5)
+
+	if count := tb.GetRowCount(); count != 1000000 {
+		t.Errorf("failed to use content: expected 1000000 rows, got %d", count)
+	}
+	tb.SetCell(0, 0, NewTableCell("ignored"))
+	if text := tb.GetCell(0, 0).Text; text != "0:0" {
+		t.Errorf("failed to keep read-only content: expected 0:0, got %s", text)
+	}
+
+	app, err := newTestApp(tb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content.requested = 0
+	tb.Draw(app.screen)
+	if content.requested > 100 {
+		t.Errorf("failed to render lazily: expected only visible cells, got %d requests", content.requested)
+	}
+
+	tb.SetContent(nil)
+	if count := tb.GetRowCount(); count != 0 {
+		t.Errorf("failed to reset content: expected 0 rows, got %d", count)
+	}
+}
+
+func TestTableRangeSelection(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+
+	tb := NewTable()
+	for row := 0; row < 4; row++ {
+		for column := 0; column < 4; column++ {
+			tb.SetCellSimple(row, column, fmt.Sprintf("r%dc%d", row, column))
+		}
+	}
+	tb.SetSelectable(true, true)
+	tb.SetRangeSelectable(true)
+	tb.SetRect(0, 0, 20, 5)
+	tb.Draw(sc)
+
+	key := func(k tcell.Key, mod tcell.ModMask) {
+		tb.InputHandler()(tcell.NewEventKey(k, 0, mod), func(Primitive) {})
+	}
+	key(tcell.KeyRight, tcell.ModShift)
+	key(tcell.KeyDown, tcell.ModShift)
+	if fromRow, fromColumn, toRow, toColumn := tb.GetSelectedRange(); fromRow != 0 || fromColumn != 0 || toRow != 1 || toColumn != 1 {
+		t.Errorf("failed to extend range with keyboard: expected 0/0-1/1, got %d/%d-%d/%d", fromRow, fromColumn, toRow, toColumn)
+	}
+	if cells := tb.GetSelectedCells(); len(cells) != 2 || len(cells[1]) != 2 || cells[1][0].Text != "r1c0" {
+		t.Errorf("failed to get selected cells: got %v", cells)
+	}
+
+	key(tcell.KeyDown, tcell.ModNone)
+	if fromRow, fromColumn, toRow, toColumn := tb.GetSelectedRange(); fromRow != 2 || fromColumn != 1 || toRow != 2 || toColumn != 1 {
+		t.Errorf("failed to collapse range: expected 2/1-2/1, got %d/%d-%d/%d", fromRow, fromColumn, toRow, toColumn)
+	}
+
+	// Drag from the first cell to the third row and column.
+	mouse := func(action MouseAction, x, y int) {
+		tb.MouseHandler()(action, tcell.NewEventMouse(x, y, tcell.ButtonPrimary, tcell.ModNone), func(Primitive) {})
+	}
+	mouse(MouseLeftDown, 0, 0)
+	mouse(MouseMove, 10, 2)
+	mouse(MouseLeftUp, 10, 2)
+	if fromRow, fromColumn, toRow, toColumn := tb.GetSelectedRange(); fromRow != 0 || fromColumn != 0 || toRow != 2 || toColumn != 2 {
+		t.Errorf("failed to select range with mouse: expected 0/0-2/2, got %d/%d-%d/%d", fromRow, fromColumn, toRow, toColumn)
+	}
+
+	tb.Draw(sc)
+	if _, _, style, _ := sc.GetContent(5, 1); style != Styles.Table.Range {
+		t.Errorf("failed to draw range: expected range style, got %v", style)
+	}
+	if _, _, style, _ := sc.GetContent(15, 1); style == Styles.Table.Range {
+		t.Error("failed to draw cell outside of range")
+	}
+
+	// Extend the range to the end and to the beginning.
+	var ranges []string
+	tb.SetSelectionRangeChangedFunc(func(fromRow, fromColumn, toRow, toColumn int) {
+		ranges = append(ranges, fmt.Sprintf("%d/%d-%d/%d", fromRow, fromColumn, toRow, toColumn))
+	})
+	key(tcell.KeyEnd, tcell.ModShift)
+	key(tcell.KeyPgUp, tcell.ModShift)
+	key(tcell.KeyHome, tcell.ModShift)
+	key(tcell.KeyHome, tcell.ModNone)
+	if got := strings.Join(ranges, " "); got != "0/0-3/3 0/0-0/3 0/0-0/0" {
+		t.Errorf("failed to extend range with Shift+End/PageUp/Home: got %q", got)
+	}
+}
+
+func TestTableFixedFooterAndRight(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 5)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 20; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Ten rows of ten columns, each three characters wide.
+	tb := NewTable()
+	for r := 0; r < 10; r++ {
+		for c := 0; c < 10; c++ {
+			tb.SetCellSimple(r, c, fmt.Sprintf("%d%d ", r, c))
+		}
+	}
+	tb.SetFixed(1, 1)
+	tb.SetFixedFooter(1)
+	tb.SetFixedRight(1)
+	tb.SetRect(0, 0, 20, 5)
+	tb.SetOffset(3, 2)
+	tb.Draw(sc)
+
+	if text := row(0); text != "00  03  04  05  09  " {
+		t.Errorf("failed to draw header: got %q", text)
+	}
+	if text := row(1); text != "40  43  44  45  49  " {
+		t.Errorf("failed to draw scrolled row: got %q", text)
+	}
+	if text := row(4); text != "90  93  94  95  99  " {
+		t.Errorf("failed to draw footer: got %q", text)
+	}
+	if r, c := tb.CellAt(17, 4); r != 9 || c != 9 {
+		t.Errorf("failed to find fixed cell: expected 9/9, got %d/%d", r, c)
+	}
+	if r, c := tb.CellAt(5, 3); r != 6 || c != 3 {
+		t.Errorf("failed to find scrolled cell: expected 6/3, got %d/%d", r, c)
+	}
+
+	// Scrolling to the far right keeps the fixed columns.
+	tb.SetXScroll(100)
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(1); !strings.HasPrefix(text, "40  ") || !strings.HasSuffix(strings.TrimRight(text, " "), "48  49") {
+		t.Errorf("failed to keep fixed columns while scrolling: got %q", text)
+	}
+
+	// The fixed columns on the right follow the other columns if they fit.
+	tb.Clear()
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			tb.SetCellSimple(r, c, fmt.Sprintf("%d%d", r, c))
+		}
+	}
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(2); text != "20 21 22            " {
+		t.Errorf("failed to draw short table: got %q", text)
+	}
+}
+
+func TestTableWrap(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	for r := 0; r < 5; r++ {
+		tb.SetCellSimple(r, 0, fmt.Sprint(r))
+		cell := NewTableCell("hello world foo")
+		cell.SetMaxWidth(6)
+		cell.SetWrap(r%2 == 0)
+		tb.SetCell(r, 1, cell)
+	}
+	tb.SetSelectable(true, false)
+	tb.SetRect(0, 0, 10, 4)
+	tb.Draw(sc)
+
+	for y, expected := range []string{"0 hello   ", "  world   ", "  foo     ", "1 hello…  "} {
+		if text := row(y); text != expected {
+			t.Errorf("failed to draw line %d: expected %q, got %q", y, expected, text)
+		}
+	}
+	if r, _ := tb.CellAt(0, 2); r != 0 {
+		t.Errorf("failed to find wrapped row: expected 0, got %d", r)
+	}
+	if r, _ := tb.CellAt(0, 3); r != 1 {
+		t.Errorf("failed to find row below wrapped row: expected 1, got %d", r)
+	}
+
+	// The selected row is scrolled into view entirely.
+	tb.Select(4, 0)
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(3); text != "  foo     " {
+		t.Errorf("failed to scroll wrapped row into view: got %q", text)
+	}
+}
+
+func TestTableHorizontalScrollBar(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	for c := 0; c < 5; c++ {
+		tb.SetCellSimple(0, c, fmt.Sprintf("col%d", c))
+	}
+	tb.SetRect(0, 0, 10, 4)
+	tb.SetHorizontalScrollBarVisibility(ScrollBarAuto)
+	tb.Draw(sc)
+
+	if text := row(3); text != "▓░░░░░░░░░" {
+		t.Errorf("failed to draw horizontal scroll bar: got %q", text)
+	}
+
+	// Drag the handle to the end.
+	mouse := func(action MouseAction, x, y int) {
+		tb.MouseHandler()(action, tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+	}
+	mouse(MouseLeftDown, 0, 3)
+	mouse(MouseMove, 9, 3)
+	mouse(MouseLeftUp, 9, 3)
+	if x, maximum := tb.GetXScroll(), tb.MaximumXOffset(); x != maximum {
+		t.Errorf("failed to drag horizontal scroll bar: expected offset %d, got %d", maximum, x)
+	}
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(0); !strings.Contains(text, "col4") {
+		t.Errorf("failed to scroll horizontally: got %q", text)
+	}
+	if text := row(3); text != "░░░░░░░░░▓" {
+		t.Errorf("failed to move horizontal scroll bar handle: got %q", text)
+	}
+}
+
+func TestTableHeader(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 3)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetSelectable(true, false)
+	tb.SetHeader([]*TableCell{NewTableCell("ab"), NewTableCell("cd")})
+	for r := 0; r < 5; r++ {
+		tb.SetCellSimple(r, 0, fmt.Sprintf("a%d", r))
+		tb.SetCellSimple(r, 1, fmt.Sprintf("c%d", r))
+	}
+	var sorted []string
+	tb.SetSortFunc(func(column int, ascending bool) {
+		sorted = append(sorted, fmt.Sprintf("%d:%t", column, ascending))
+	})
+	tb.SetRect(0, 0, 12, 3)
+	tb.Draw(sc)
+
+	if text := row(0); text != "ab cd       " {
+		t.Errorf("failed to draw header: got %q", text)
+	}
+	if text := row(1); text != "a0 c0       " {
+		t.Errorf("failed to draw first row below header: got %q", text)
+	}
+	if count := tb.GetRowCount(); count != 5 {
+		t.Errorf("failed to exclude header from row count: expected 5, got %d", count)
+	}
+	if r, _ := tb.CellAt(0, 0); r != -1 {
+		t.Errorf("failed to exclude header from cells: expected row -1, got %d", r)
+	}
+
+	// The header stays visible while scrolling.
+	tb.Select(4, 0)
+	sc.Clear()
+	tb.Draw(sc)
+	if text := row(0); text != "ab cd       " {
+		t.Errorf("failed to keep header visible: got %q", text)
+	}
+	if text := row(2); text != "a4 c4       " {
+		t.Errorf("failed to scroll selection into view: got %q", text)
+	}
+
+	// Clicking a header cell sorts and shows the indicator.
+	click := func(x int) {
+		tb.MouseHandler()(MouseLeftDown, tcell.NewEventMouse(x, 0, tcell.Button1, tcell.ModNone), func(p Primitive) {})
+		sc.Clear()
+		tb.Draw(sc)
+	}
+	click(3)
+	if text := row(0); text != "ab cd ▲     " {
+		t.Errorf("failed to draw ascending indicator: got %q", text)
+	}
+	click(3)
+	if text := row(0); text != "ab cd ▼     " {
+		t.Errorf("failed to draw descending indicator: got %q", text)
+	}
+	click(0)
+	if text := strings.Join(sorted, " "); text != "1:true 1:false 0:true" {
+		t.Errorf("failed to sort: got %q", text)
+	}
+	if r, _ := tb.GetSelection(); r != 4 {
+		t.Errorf("failed to keep selection when clicking header: expected row 4, got %d", r)
+	}
+}
+
+func TestTableStableSelection(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	tb := NewTable()
+	tb.SetSelectable(true, false)
+	tb.SetStableSelection(true)
+	set := func(row int, name string) {
+		cell := NewTableCell(name)
+		cell.SetReference(name)
+		tb.SetCell(row, 0, cell)
+	}
+	for r, name := range []string{"a", "b", "c", "d", "e"} {
+		set(r, name)
+	}
+	tb.SetRect(0, 0, 10, 3)
+	tb.Select(3, 0)
+	tb.Draw(sc)
+
+	// Rows inserted above the selection.
+	tb.InsertRow(0)
+	set(0, "x")
+	tb.InsertRow(0)
+	set(0, "y")
+	tb.Draw(sc)
+	if r, _ := tb.GetSelection(); r != 5 {
+		t.Errorf("failed to follow inserted rows: expected row 5, got %d", r)
+	}
+	if r, _ := tb.GetOffset(); r > 5 || r+3 <= 5 {
+		t.Errorf("failed to scroll to selection: row offset %d", r)
+	}
+
+	// A row removed above the selection.
+	tb.RemoveRow(0)
+	tb.Draw(sc)
+	if r, _ := tb.GetSelection(); r != 4 || tb.GetCell(r, 0).Text != "d" {
+		t.Errorf("failed to follow removed row: expected row 4, got %d", r)
+	}
+
+	// The selected row removed keeps the index.
+	tb.RemoveRow(4)
+	tb.Draw(sc)
+	if r, _ := tb.GetSelection(); r != 4 || tb.GetCell(r, 0).Text != "e" {
+		t.Errorf("failed to keep index of removed row: expected row 4, got %d", r)
+	}
+
+	// Without a stable selection, the index is kept.
+	tb.SetStableSelection(false)
+	tb.InsertRow(0)
+	set(0, "z")
+	tb.Draw(sc)
+	if r, _ := tb.GetSelection(); r != 4 {
+		t.Errorf("failed to keep index: expected row 4, got %d", r)
+	}
+}
+
+func TestTableForEachCell(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	tb.SetHeader([]*TableCell{NewTableCell("h")})
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 2, "b")
+	tb.SetCellSimple(1, 1, "c")
+	tb.SetCellSimple(2, 0, "d")
+
+	var visited []string
+	tb.ForEachCell(func(row, column int, cell *TableCell) bool {
+		visited = append(visited, fmt.Sprintf("%d%d%s", row, column, cell.Text))
+		return cell.Text != "c"
+	})
+	if text := strings.Join(visited, " "); text != "00a 01 02b 10 11c" {
+		t.Errorf("failed to iterate cells: got %q", text)
+	}
+}
+
+func TestTableFilter(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(6, 3)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 6; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	// Show even rows only.
+	tb := NewTable()
+	for r := 0; r < 10; r++ {
+		tb.SetCellSimple(r, 0, fmt.Sprintf("r%d", r))
+	}
+	tb.SetSelectable(true, false)
+	tb.SetFilterFunc(func(row int) bool {
+		return row%2 == 0
+	})
+	tb.SetRect(0, 0, 6, 3)
+	draw := func() {
+		sc.Clear()
+		tb.Draw(sc)
+	}
+	draw()
+	if text := row(0) + row(1) + row(2); text != "r0    r2    r4    " {
+		t.Errorf("failed to hide rows: got %q", text)
+	}
+	if count := tb.GetRowCount(); count != 10 {
+		t.Errorf("failed to keep rows: expected 10, got %d", count)
+	}
+
+	// The selection skips hidden rows and keeps the original indices.
+	key := func(k tcell.Key) {
+		tb.InputHandler()(tcell.NewEventKey(k, 0, tcell.ModNone), func(p Primitive) {})
+		draw()
+	}
+	key(tcell.KeyDown)
+	key(tcell.KeyDown)
+	key(tcell.KeyDown)
+	if r, _ := tb.GetSelection(); r != 6 {
+		t.Errorf("failed to skip hidden rows: expected row 6, got %d", r)
+	}
+	if text := row(2); text != "r6    " {
+		t.Errorf("failed to scroll to selection: got %q", text)
+	}
+	if r, _ := tb.CellAt(0, 1); r != 4 {
+		t.Errorf("failed to map cell to row: expected 4, got %d", r)
+	}
+
+	// A hidden selected row moves the selection to the next shown row.
+	tb.SetFilterFunc(func(row int) bool {
+		return row > 6
+	})
+	draw()
+	if r, _ := tb.GetSelection(); r != 7 {
+		t.Errorf("failed to move selection off hidden row: expected 7, got %d", r)
+	}
+	if text := row(0); text != "r7    " {
+		t.Errorf("failed to draw filtered rows: got %q", text)
+	}
+
+	// Removing the filter keeps the selected row in view.
+	tb.SetFilterFunc(nil)
+	draw()
+	if text := row(0) + row(1) + row(2); text != "r5    r6    r7    " {
+		t.Errorf("failed to remove filter: got %q", text)
+	}
+
+	// Without matching rows, the placeholder is shown.
+	tb.SetFilterFunc(func(row int) bool {
+		return false
+	})
+	tb.SetPlaceholderText("none")
+	draw()
+	if text := row(1); !strings.Contains(text, "none") {
+		t.Errorf("failed to draw placeholder: got %q", text)
+	}
+}
+
+func TestTableFind(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 4)
+
+	tb := NewTable()
+	for r, text := range []string{"apple", "banana", "cherry", "[red]pineapple"} {
+		tb.SetCellSimple(r, 0, text)
+	}
+	tb.SetSelectable(true, true)
+	tb.SetRect(0, 0, 12, 4)
+	tb.Draw(sc)
+
+	if !tb.Find("AP") {
+		t.Fatal("failed to find text")
+	}
+	if r, _ := tb.GetSelection(); r != 0 {
+		t.Errorf("failed to keep matching selection: expected row 0, got %d", r)
+	}
+	tb.FindNext()
+	if r, _ := tb.GetSelection(); r != 3 {
+		t.Errorf("failed to find next match: expected row 3, got %d", r)
+	}
+	tb.FindNext()
+	if r, _ := tb.GetSelection(); r != 0 {
+		t.Errorf("failed to wrap around: expected row 0, got %d", r)
+	}
+	tb.FindPrev()
+	if r, _ := tb.GetSelection(); r != 3 {
+		t.Errorf("failed to find previous match: expected row 3, got %d", r)
+	}
+
+	// The matches in other cells are highlighted.
+	tb.Draw(sc)
+	_, match, _ := tb.GetStyles().Match.Decompose()
+	background := func(x, y int) tcell.Color {
+		_, _, style, _ := sc.GetContent(x, y)
+		_, bg, _ := style.Decompose()
+		return bg
+	}
+	if background(0, 0) != match || background(1, 0) != match || background(2, 0) == match {
+		t.Error("failed to highlight match")
+	}
+
+	if !tb.FindRegexp(regexp.MustCompile("an+a$")) {
+		t.Fatal("failed to find regular expression")
+	}
+	if r, _ := tb.GetSelection(); r != 1 {
+		t.Errorf("failed to find regular expression: expected row 1, got %d", r)
+	}
+	if tb.Find("kiwi") {
+		t.Error("failed to report missing match")
+	}
+}
+
+func TestTableMoveRow(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 5)
+
+	tb := NewTable()
+	for row, text := range []string{"#", "a", "b", "c", "d"} {
+		tb.SetCellSimple(row, 0, text)
+	}
+	order := func() string {
+		var b strings.Builder
+		for row := range tb.GetRowCount() {
+			b.WriteString(tb.GetCell(row, 0).Text)
+		}
+		return b.String()
+	}
+	var moves []string
+	tb.SetRowMovedFunc(func(from, to int) {
+		moves = append(moves, fmt.Sprintf("%d>%d", from, to))
+	})
+	model := NewSelectionModel(SelectionMultiple)
+	tb.SetSelectionModel(model)
+	tb.SetFixed(1, 0)
+	tb.SetSelectable(true, false)
+	tb.SetRowsMovable(true)
+	tb.SetRect(0, 0, 10, 5)
+	tb.Select(1, 0)
+	model.SetSelected([]int{1, 3})
+
+	// Move with the keyboard, but not into the fixed row.
+	tb.InputHandler()(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModAlt), func(Primitive) {})
+	tb.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModAlt), func(Primitive) {})
+	tb.InputHandler()(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModAlt), func(Primitive) {})
+	if got := order(); got != "#abcd" {
+		t.Errorf("failed to move row with keys: expected #abcd, got %s", got)
+	}
+	if got := strings.Join(moves, " "); got != "1>2 2>1" {
+		t.Errorf("failed to notify moved rows: expected \"1>2 2>1\", got %q", got)
+	}
+
+	// Move to the end.
+	tb.MoveRow(1, 4)
+	if got := order(); got != "#bcda" {
+		t.Errorf("failed to move row: expected #bcda, got %s", got)
+	}
+	if row, _ := tb.GetSelection(); row != 4 {
+		t.Errorf("failed to follow moved row: expected row 4, got %d", row)
+	}
+	if got := fmt.Sprint(model.GetSelected()); got != "[2 4]" {
+		t.Errorf("failed to update selection model: expected [2 4], got %s", got)
+	}
+
+	// Drag a row with the mouse.
+	moves = nil
+	tb.Draw(sc)
+	handler := tb.MouseHandler()
+	if _, capture := handler(MouseLeftDown, tcell.NewEventMouse(0, 1, tcell.Button1, tcell.ModNone), func(Primitive) {}); capture != tb {
+		t.Fatal("failed to capture mouse while moving row")
+	}
+	handler(MouseMove, tcell.NewEventMouse(0, 3, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseLeftUp, tcell.NewEventMouse(0, 3, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if got := order(); got != "#cdba" {
+		t.Errorf("failed to drag row: expected #cdba, got %s", got)
+	}
+	if got := strings.Join(moves, " "); got != "1>3" {
+		t.Errorf("failed to notify dragged row: expected \"1>3\", got %q", got)
+	}
+}
+
+func TestTableRowStyles(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(5, 4)
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 5, 4)
+	for row := 0; row < 4; row++ {
+		tb.SetCellSimple(row, 0, "a")
+	}
+	own := NewTableCell("b")
+	own.SetBackgroundColor(tcell.ColorGreen)
+	tb.SetCell(3, 1, own)
+	tb.SetAlternateRowStyle(tcell.StyleDefault.Background(tcell.ColorGray))
+	tb.SetRowStyleFunc(func(row int) tcell.Style {
+		if row == 2 {
+			return tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
+		}
+		return tcell.StyleDefault
+	})
+	tb.Draw(sc)
+
+	_, _, normal, _ := sc.GetContent(0, 0)
+	_, normalBackground, _ := normal.Decompose()
+	for row, expected := range []tcell.Color{normalBackground, tcell.ColorGray, normalBackground, tcell.ColorGray} {
+		_, _, style, _ := sc.GetContent(0, row)
+		if _, background, _ := style.Decompose(); background != expected {
+			t.Errorf("failed to stripe row %d: expected %v, got %v", row, expected, background)
+		}
+	}
+	_, _, style, _ := sc.GetContent(0, 2)
+	if foreground, _, attributes := style.Decompose(); foreground != tcell.ColorRed || attributes&tcell.AttrBold == 0 {
+		t.Errorf("failed to apply row style: expected bold red, got %v, %v", foreground, attributes)
+	}
+	_, _, style, _ = sc.GetContent(2, 3)
+	if _, background, _ := style.Decompose(); background != tcell.ColorGreen {
+		t.Errorf("failed to keep cell background: expected %v, got %v", tcell.ColorGreen, background)
+	}
+}
+
+func TestTableRightClick(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 10)
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 20, 10)
+	tb.SetSelectable(true, false)
+	for row := 0; row < 3; row++ {
+		tb.SetCellSimple(row, 0, "row")
+	}
+	tb.Draw(sc)
+
+	var clicked []int
+	tb.SetRightClickFunc(func(row, column, x, y int) {
+		clicked = []int{row, column, x, y}
+	})
+	handler := tb.MouseHandler()
+	handler(MouseRightDown, tcell.NewEventMouse(1, 2, tcell.Button2, tcell.ModNone), func(Primitive) {})
+	if fmt.Sprint(clicked) != "[2 0 1 2]" {
+		t.Errorf("failed to report right click: expected [2 0 1 2], got %v", clicked)
+	}
+	if row, _ := tb.GetSelection(); row != 2 {
+		t.Errorf("failed to select clicked row: expected 2, got %d", row)
+	}
+	if tb.ContextMenuVisible() {
+		t.Error("failed to skip empty context menu: expected hidden, got visible")
+	}
+
+	var deleted = -1
+	tb.AddContextItem("Delete", 'd', func(index int) {
+		deleted = index
+	})
+	handler(MouseRightDown, tcell.NewEventMouse(1, 1, tcell.Button2, tcell.ModNone), func(Primitive) {})
+	if !tb.ContextMenuVisible() {
+		t.Fatal("failed to show context menu: expected visible, got hidden")
+	}
+	tb.ContextMenuList().InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	if deleted != 1 {
+		t.Errorf("failed to pass clicked row to context item: expected 1, got %d", deleted)
+	}
+}
+
+func TestTableEvaluateVisibleRowsOnly(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 2)
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 20, 2)
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(1, 0, "a")
+	tb.SetCellSimple(5, 0, "a long cell")
+	tb.Draw(sc)
+	if x, _, _ := tb.GetCell(0, 1).GetLastPosition(); x != 12 {
+		t.Errorf("failed to measure all rows: expected column at 12, got %d", x)
+	}
+
+	if tb.GetEvaluateVisibleRowsOnly() {
+		t.Error("failed to initialize Table: expected all rows to be measured")
+	}
+	tb.SetEvaluateVisibleRowsOnly(true)
+	if !tb.GetEvaluateVisibleRowsOnly() {
+		t.Error("failed to set visible rows only: expected true, got false")
+	}
+	tb.Draw(sc)
+	if x, _, _ := tb.GetCell(0, 1).GetLastPosition(); x != 2 {
+		t.Errorf("failed to measure visible rows only: expected column at 2, got %d", x)
+	}
+}
+
+func TestTableApplyUpdates(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	var changes int
+	tb.SetContentChangedFunc(func() {
+		changes++
+		tb.GetCell(0, 0) // The table is not locked.
+	})
+	tb.ApplyUpdates(func(content TableContent) {
+		for row := 0; row < 100; row++ {
+			content.SetCell(row, 0, NewTableCell("x"))
+		}
+		content.RemoveRow(0)
+	})
+	if changes != 1 {
+		t.Errorf("failed to notify content change once: expected 1, got %d", changes)
+	}
+	if rows := tb.GetRowCount(); rows != 99 {
+		t.Errorf("failed to apply updates: expected 99 rows, got %d", rows)
+	}
+}
+
+func TestTableHover(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(20, 10)
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 20, 10)
+	for row := 0; row < 3; row++ {
+		tb.SetCellSimple(row, 0, "row")
+		tb.SetCellSimple(row, 1, "cell")
+	}
+	tb.SetHoverStyle(tcell.StyleDefault.Background(tcell.ColorBlue))
+	tb.Draw(sc)
+
+	var hovered [][]int
+	tb.SetHoverFunc(func(row, column int) {
+		hovered = append(hovered, []int{row, column})
+	})
+	handler := tb.MouseHandler()
+	if consumed, _ := handler(MouseMove, tcell.NewEventMouse(5, 1, tcell.ButtonNone, tcell.ModNone), func(Primitive) {}); !consumed {
+		t.Error("failed to consume hover change: expected consumed, got not consumed")
+	}
+	handler(MouseMove, tcell.NewEventMouse(6, 1, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if row, column := tb.GetHoveredCell(); row != 1 || column != 1 {
+		t.Errorf("failed to track hovered cell: expected 1,1, got %d,%d", row, column)
+	}
+	tb.Draw(sc)
+	for row, expected := range []bool{false, true, false} {
+		_, _, style, _ := sc.GetContent(0, row)
+		if _, background, _ := style.Decompose(); (background == tcell.ColorBlue) != expected {
+			t.Errorf("failed to style row %d: expected hovered %t, got background %v", row, expected, background)
+		}
+	}
+
+	handler(MouseLeave, tcell.NewEventMouse(25, 1, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if fmt.Sprint(hovered) != "[[1 1] [-1 -1]]" {
+		t.Errorf("failed to report hovered cells: expected [[1 1] [-1 -1]], got %v", hovered)
+	}
+}
+
+func TestTableColumnAttributes(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 3)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 12, 3)
+	tb.SetCellSimple(0, 0, "a")
+	bold := tcell.StyleDefault.Bold(true)
+	tb.SetColumnAttributes(1, AlignRight, bold, 1, 3)
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(1, 1, "long")
+	own := NewTableCell("c")
+	own.SetAlign(AlignCenter)
+	tb.SetCell(2, 1, own)
+
+	if cell := tb.GetCell(0, 1); cell.Align != AlignRight || cell.Style != bold || cell.Expansion != 1 || cell.MaxWidth != 3 {
+		t.Errorf("failed to apply column attributes: got align %d, style %v, expansion %d, max width %d", cell.Align, cell.Style, cell.Expansion, cell.MaxWidth)
+	}
+	if cell := tb.GetCell(2, 1); cell.Align != AlignCenter {
+		t.Errorf("failed to keep cell alignment: expected %d, got %d", AlignCenter, cell.Align)
+	}
+	if cell := tb.GetCell(0, 0); cell.Align != AlignLeft {
+		t.Errorf("failed to leave other columns: expected %d, got %d", AlignLeft, cell.Align)
+	}
+
+	// The column expands to the width of the table.
+	tb.Draw(sc)
+	if got := row(0) + "|" + row(1); got != "a         b |       long " {
+		t.Errorf("failed to expand column: got %q", got)
+	}
+}
+
+func TestTableKeys(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	for row := 0; row < 5; row++ {
+		tb.SetCellSimple(row, 0, "row")
+	}
+	tb.SetSelectable(true, false)
+	app, err := newTestApp(tb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.GetScreen().Init(); err != nil {
+		t.Fatal(err)
+	}
+	app.SetRoot(tb, false)
+	tb.SetRect(0, 0, 80, 24)
+	app.draw()
+
+	key := func(ch rune) {
+		app.dispatchKey(tcell.NewEventKey(tcell.KeyRune, ch, tcell.ModNone), "")
+	}
+	key('j')
+	key('j')
+	if row, _ := tb.GetSelection(); row != 2 {
+		t.Errorf("failed to move down with vim key: expected row 2, got %d", row)
+	}
+	key('G')
+	if row, _ := tb.GetSelection(); row != 4 {
+		t.Errorf("failed to move to last row: expected row 4, got %d", row)
+	}
+
+	keys := Keys
+	keys.MoveDown2 = nil
+	keys.MoveUp2 = []string{"p"}
+	app.SetKeys(keys)
+	key('p')
+	key('j')
+	if row, _ := tb.GetSelection(); row != 3 {
+		t.Errorf("failed to rebind navigation keys: expected row 3, got %d", row)
+	}
+}
+
+func TestTableAutoFitColumns(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 1)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetRect(0, 0, 12, 1)
+	tb.SetCellSimple(0, 0, "aaaaaaaa")
+	tb.SetCellSimple(0, 1, "bbbbbbbb")
+	tb.SetCellSimple(0, 2, "cc")
+	tb.SetAutoFitColumns(true)
+
+	// All columns shrink in proportion to their widths.
+	tb.Draw(sc)
+	if got := row(0); got != "aaa… bbb… … " {
+		t.Errorf("failed to shrink columns: got %q", got)
+	}
+
+	// Columns with a higher priority shrink first.
+	tb.SetColumnFit(1, 2, 1)
+	tb.Draw(sc)
+	if got := row(0); got != "aaaaa… b… … " {
+		t.Errorf("failed to shrink columns by priority: got %q", got)
+	}
+}
+
+func TestTableViewport(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+
+	tb := NewTable()
+	tb.SetHeader([]*TableCell{NewTableCell("Name")})
+	for row := 0; row < 10; row++ {
+		tb.SetCellSimple(row, 0, fmt.Sprintf("row %d", row))
+		tb.SetCellSimple(row, 1, "wide cell")
+	}
+	tb.SetRect(0, 0, 10, 4)
+
+	var viewports []string
+	tb.SetViewportChangedFunc(func(rowOffset, xScroll int) {
+		viewports = append(viewports, fmt.Sprintf("%d/%d", rowOffset, xScroll))
+	})
+	tb.Draw(sc)
+	if first, last := tb.GetVisibleRowRange(); first != 0 || last != 2 {
+		t.Errorf("failed to get visible rows: expected 0-2, got %d-%d", first, last)
+	}
+
+	tb.SetOffset(4, 0)
+	tb.Draw(sc)
+	tb.Draw(sc)
+	if first, last := tb.GetVisibleRowRange(); first != 4 || last != 6 {
+		t.Errorf("failed to get scrolled rows: expected 4-6, got %d-%d", first, last)
+	}
+	tb.SetXScroll(3)
+	tb.Draw(sc)
+	if got := strings.Join(viewports, " "); got != "0/0 4/0 4/3" {
+		t.Errorf("failed to report viewport changes: expected \"0/0 4/0 4/3\", got %q", got)
+	}
+}
@@ -0,0 +1,520 @@
+package nuview
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// tableColumnView presents the columns of a table's content in the order set
+// with Table.SetColumnOrder(), leaving out the columns hidden with
+// Table.SetColumnVisible(). Column indices passed to it are positions in that
+// order, the content keeps its own order.
+type tableColumnView struct {
+	TableContent
+
+	// The content column at each position of the order, hidden columns
+	// included. Positions beyond the end show the content column with the
+	// same index.
+	order []int
+
+	// The hidden content columns.
+	hidden map[int]bool
+
+	// The content columns shown at the positions up to the last column which
+	// is ordered or hidden, and the content column shown at the position after
+	// them. See update().
+	positions []int
+	next      int
+}
+
+// update recalculates the shown positions after the order or the hidden
+// columns have changed.
+func (v *tableColumnView) update() {
+	limit := len(v.order)
+	for column := range v.hidden {
+		limit = max(limit, column+1)
+	}
+	v.positions = v.positions[:0]
+	for _, column := range v.order {
+		if !v.hidden[column] {
+			v.positions = append(v.positions, column)
+		}
+	}
+	for column := len(v.order); column < limit; column++ {
+		if !v.hidden[column] {
+			v.positions = append(v.positions, column)
+		}
+	}
+	v.next = limit
+}
+
+// column returns the content column shown at the provided position.
+func (v *tableColumnView) column(position int) int {
+	if position < 0 {
+		return position
+	}
+	if position < len(v.positions) {
+		return v.positions[position]
+	}
+	return v.next + position - len(v.positions)
+}
+
+// position returns the position at which the provided content column is
+// shown, -1 if it is hidden.
+func (v *tableColumnView) position(column int) int {
+	if v.hidden[column] {
+		return -1
+	}
+	if column >= v.next {
+		return len(v.positions) + column - v.next
+	}
+	for position, c := range v.positions {
+		if c == column {
+			return position
+		}
+	}
+	return -1
+}
+
+// shownCount returns the number of shown columns out of the provided number
+// of content columns.
+func (v *tableColumnView) shownCount(count int) int {
+	for column := range v.hidden {
+		if column < count {
+			count--
+		}
+	}
+	return count
+}
+
+// GetColumnCount returns the number of shown columns.
+func (v *tableColumnView) GetColumnCount() int {
+	return v.shownCount(v.TableContent.GetColumnCount())
+}
+
+// GetCell returns the cell at the provided position.
+func (v *tableColumnView) GetCell(row, column int) *TableCell {
+	return v.TableContent.GetCell(row, v.column(column))
+}
+
+// SetCell sets the cell at the provided position.
+func (v *tableColumnView) SetCell(row, column int, cell *TableCell) {
+	v.TableContent.SetCell(row, v.column(column), cell)
+}
+
+// RemoveColumn removes the content column shown at the provided position.
+func (v *tableColumnView) RemoveColumn(column int) {
+	removed := v.column(column)
+	v.TableContent.RemoveColumn(removed)
+	defer v.update()
+	v.hidden = shiftedColumns(v.hidden, removed+1, -1)
+	index := slices.Index(v.order, removed)
+	if column < 0 || index < 0 {
+		return
+	}
+	v.order = slices.Delete(v.order, index, index+1)
+	for index, c := range v.order {
+		if c > removed {
+			v.order[index]--
+		}
+	}
+}
+
+// InsertColumn inserts a content column in front of the one shown at the
+// provided position and shows it at that position.
+func (v *tableColumnView) InsertColumn(column int) {
+	inserted := v.column(column)
+	v.TableContent.InsertColumn(inserted)
+	defer v.update()
+	v.hidden = shiftedColumns(v.hidden, inserted, 1)
+	index := slices.Index(v.order, inserted)
+	if column < 0 || index < 0 {
+		return
+	}
+	for index, c := range v.order {
+		if c >= inserted {
+			v.order[index]++
+		}
+	}
+	v.order = slices.Insert(v.order, index, inserted)
+}
+
+// shiftedColumns returns the provided set of content columns with the
+// columns starting at the provided one shifted by the provided offset. A
+// column shifted onto the one before the start is dropped.
+func shiftedColumns(columns map[int]bool, from, offset int) map[int]bool {
+	if len(columns) == 0 {
+		return columns
+	}
+	shifted := make(map[int]bool)
+	for column := range columns {
+		if column >= from {
+			column += offset
+		} else if offset < 0 && column == from-1 {
+			continue
+		}
+		shifted[column] = true
+	}
+	return shifted
+}
+
+// contentColumn returns the content column shown at the provided position,
+// see SetColumnOrder(). The table must be locked.
+func (t *Table) contentColumn(column int) int {
+	if t.columnView == nil {
+		return column
+	}
+	return t.columnView.column(column)
+}
+
+// SetColumnOrder sets the order in which the columns of the content are shown,
+// e.g. to restore the order in which the user arranged them (see
+// SetColumnsMovable). order[i] is the content column shown at position i.
+// Invalid and duplicate entries are ignored, content columns missing from the
+// order are shown in their own order after the last column in it. Provide nil
+// (the default) to show the columns in the order of the content.
+//
+// While an order is set, the column indices used by the table's functions and
+// handlers, e.g. GetCell(), Select(), CellAt() or the header cells' sort
+// column, are positions in that order. The content keeps its order: the cells
+// of the content set with SetContent() are requested from their own columns
+// and SetHeader() takes the header cells in the order of the content.
+func (t *Table) SetColumnOrder(order []int) {
+	t.Lock()
+	defer t.Unlock()
+
+	if order == nil {
+		if t.columnView != nil {
+			t.columnView.order = nil
+			t.updateColumnView()
+		}
+		return
+	}
+
+	var normalized []int
+	seen := make(map[int]bool)
+	last := -1
+	for _, column := range order {
+		if column >= 0 && !seen[column] {
+			normalized = append(normalized, column)
+			seen[column] = true
+			last = max(last, column)
+		}
+	}
+	for column := 0; column < last; column++ {
+		if !seen[column] {
+			normalized = append(normalized, column)
+		}
+	}
+	t.ensureColumnView()
+	t.columnView.order = normalized
+	t.columnView.update()
+}
+
+// ensureColumnView presents the content through a column view if it isn't
+// already. The table must be locked.
+func (t *Table) ensureColumnView() {
+	if t.columnView == nil {
+		t.columnView = &tableColumnView{TableContent: t.content}
+		t.content = t.columnView
+	}
+}
+
+// updateColumnView recalculates the positions of the column view after its
+// order or its hidden columns changed, or removes it if it has neither. The
+// table must be locked.
+func (t *Table) updateColumnView() {
+	if len(t.columnView.order) == 0 && len(t.columnView.hidden) == 0 {
+		t.content = t.columnView.TableContent
+		t.columnView = nil
+		return
+	}
+	t.columnView.update()
+}
+
+// GetColumnOrder returns the order in which the columns of the content are
+// shown, nil if they are shown in their own order. See SetColumnOrder().
+func (t *Table) GetColumnOrder() []int {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.columnView == nil {
+		return nil
+	}
+	return append([]int(nil), t.columnView.order...)
+}
+
+// MoveColumn moves the column shown at one position to another, shifting the
+// columns between them by one, by changing the column order (see
+// SetColumnOrder). The content is left unchanged. The selection and the sort
+// column follow the moved columns. If either position is out of range, this
+// function has no effect.
+func (t *Table) MoveColumn(from, to int) {
+	t.Lock()
+	defer t.Unlock()
+	t.moveColumn(from, to)
+}
+
+// moveColumn moves a column, see MoveColumn(). The table must be locked.
+func (t *Table) moveColumn(from, to int) {
+	columnCount := t.columnCount()
+	if from == to || from < 0 || to < 0 || from >= columnCount || to >= columnCount {
+		return
+	}
+
+	t.ensureColumnView()
+	v := t.columnView
+	moved, target := v.column(from), v.column(to)
+	order := v.order
+	for column := len(order); column < max(t.contentColumnCount(), moved+1, target+1); column++ {
+		order = append(order, column)
+	}
+	order = slices.Delete(order, slices.Index(order, moved), slices.Index(order, moved)+1)
+	index := slices.Index(order, target)
+	if from < to {
+		index++ // Moving right places the column after the target.
+	}
+	v.order = slices.Insert(order, index, moved)
+	v.update()
+
+	if t.selectedColumn >= 0 {
+		t.selectedColumn = movedIndex(t.selectedColumn, from, to)
+	}
+	if t.rangeAnchorRow >= 0 {
+		t.rangeAnchorColumn = movedIndex(t.rangeAnchorColumn, from, to)
+	}
+	if t.sortColumn >= 0 {
+		t.sortColumn = movedIndex(t.sortColumn, from, to)
+	}
+	t.clampToSelection = true
+}
+
+// SetColumnsMovable sets whether or not the user may move columns. If columns
+// are selectable (see SetSelectable), the selected column is moved with
+// Keys.MoveColumnLeft and Keys.MoveColumnRight. Columns may also be moved by
+// dragging their header cells (see SetHeader) with the mouse, the header cells
+// are then sorted by (see SetSortFunc) when the mouse button is released
+// without moving them. Fixed columns (see SetFixed and SetFixedRight) are not
+// moved and columns are not moved past them.
+//
+// Moving columns changes the column order, see SetColumnOrder() and
+// SetColumnMovedFunc(). The default is false.
+func (t *Table) SetColumnsMovable(movable bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.columnsMovable = movable
+}
+
+// GetColumnsMovable returns whether or not the user may move columns, see
+// SetColumnsMovable().
+func (t *Table) GetColumnsMovable() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.columnsMovable
+}
+
+// SetColumnMovedFunc sets a handler which is called after the user moved the
+// column at one position to another (see SetColumnsMovable). When dragging a
+// column with the mouse, the handler is called each time it moves.
+func (t *Table) SetColumnMovedFunc(handler func(from, to int)) {
+	t.Lock()
+	defer t.Unlock()
+	t.columnMoved = handler
+}
+
+// columnMovable returns whether or not the user may move the column at the
+// provided position, or another column to its place. The table must be
+// locked.
+func (t *Table) columnMovable(column int) bool {
+	columnCount := t.columnCount()
+	return column >= t.fixedColumns && column < columnCount-t.rightColumns(columnCount)
+}
+
+// moveColumnTo moves the column at one position to another if the user may
+// move both and notifies the column moved handler. It returns whether the
+// column was moved.
+func (t *Table) moveColumnTo(from, to int) bool {
+	t.Lock()
+	if from == to || !t.columnMovable(from) || !t.columnMovable(to) {
+		t.Unlock()
+		return false
+	}
+	t.moveColumn(from, to)
+	moved := t.columnMoved
+	t.Unlock()
+
+	if moved != nil {
+		moved(from, to)
+	}
+	return true
+}
+
+// SetColumnVisible shows or hides a column of the content, e.g. to let users
+// trim a wide table down to the columns they need (see SetColumnsHideable).
+// The column is the index of the column in the content, as for
+// SetColumnOrder(). Hidden columns are neither drawn nor measured and cannot
+// be selected. Like with a column order, the column indices used by the
+// table's other functions and handlers, e.g. GetCell(), Select() or CellAt(),
+// are positions among the shown columns. The selection and the sort column
+// follow the shown columns, the sort indicator is removed if its column is
+// hidden. Columns are visible by default.
+func (t *Table) SetColumnVisible(column int, visible bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.setColumnVisible(column, visible)
+}
+
+// GetColumnVisible returns whether or not the provided column of the content
+// is shown, see SetColumnVisible().
+func (t *Table) GetColumnVisible(column int) bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.columnVisible(column)
+}
+
+// columnVisible returns whether or not the provided content column is shown.
+// The table must be locked.
+func (t *Table) columnVisible(column int) bool {
+	return t.columnView == nil || !t.columnView.hidden[column]
+}
+
+// setColumnVisible shows or hides a content column, see SetColumnVisible().
+// The table must be locked.
+func (t *Table) setColumnVisible(column int, visible bool) {
+	if column < 0 || t.columnVisible(column) == visible {
+		return
+	}
+
+	// Adjust the column positions stored by the table.
+	adjust := func(shift func(position int) int) {
+		if t.selectedColumn >= 0 {
+			t.selectedColumn = max(shift(t.selectedColumn), 0)
+		}
+		if t.rangeAnchorRow >= 0 {
+			t.rangeAnchorColumn = max(shift(t.rangeAnchorColumn), 0)
+		}
+		if t.sortColumn >= 0 {
+			t.sortColumn = shift(t.sortColumn)
+		}
+		t.clampToSelection = true
+	}
+
+	t.ensureColumnView()
+	v := t.columnView
+	if visible {
+		delete(v.hidden, column)
+		t.updateColumnView()
+		position := column
+		if t.columnView != nil {
+			position = t.columnView.position(column)
+		}
+		adjust(func(p int) int {
+			if p >= position {
+				return p + 1
+			}
+			return p
+		})
+		return
+	}
+
+	position := v.position(column)
+	if v.hidden == nil {
+		v.hidden = make(map[int]bool)
+	}
+	v.hidden[column] = true
+	v.update()
+	sortColumn := t.sortColumn
+	adjust(func(p int) int {
+		if p > position {
+			return p - 1
+		}
+		return p
+	})
+	if sortColumn == position {
+		t.sortColumn = -1
+	}
+}
+
+// SetColumnsHideable sets whether or not the user may hide and show columns.
+// Right-clicking the header row (see SetHeader) then shows the column
+// chooser instead of the context menu, see ShowColumnChooser(). The default is
+// false.
+func (t *Table) SetColumnsHideable(hideable bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.columnsHideable = hideable
+}
+
+// GetColumnsHideable returns whether or not the user may hide and show
+// columns, see SetColumnsHideable().
+func (t *Table) GetColumnsHideable() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.columnsHideable
+}
+
+// ShowColumnChooser shows a menu at the provided screen position which lists
+// all columns of the content, with check marks showing which of them are
+// visible. Selecting a column shows or hides it (see SetColumnVisible()) and
+// closes the menu. The last shown column cannot be hidden. The columns are
+// listed in the order in which they are shown, hidden columns included, and
+// titled with the text of their header cells (see SetHeader) or "Column 1",
+// "Column 2", and so on.
+func (t *Table) ShowColumnChooser(x, y int, setFocus func(Primitive)) {
+	t.Lock()
+	if t.columnChooser == nil {
+		t.columnChooser = NewContextMenu(t)
+	}
+	chooser := t.columnChooser
+	columns := make([]int, t.contentColumnCount())
+	for column := range columns {
+		columns[column] = column
+	}
+	if t.columnView != nil && len(t.columnView.order) > 0 {
+		order := t.columnView.order
+		columns = append(slices.Clone(order), columns[min(len(order), len(columns)):]...)
+	}
+	items := make([]string, len(columns))
+	for index, column := range columns {
+		title := fmt.Sprintf("Column %d", column+1)
+		if column < len(t.header) && t.header[column] != nil {
+			if text := strings.TrimSpace(stripTags(t.header[column].Text)); text != "" {
+				title = text
+			}
+		}
+		check := "  "
+		if t.columnVisible(column) {
+			check = "✓ "
+		}
+		items[index] = check + Escape(title)
+	}
+	t.Unlock()
+
+	chooser.ClearContextMenu()
+	for index, column := range columns {
+		chooser.AddContextItem(items[index], 0, func(int) {
+			t.Lock()
+			defer t.Unlock()
+			visible := t.columnVisible(column)
+			if visible && t.columnCount() <= 1 {
+				return // Keep at least one column.
+			}
+			t.setColumnVisible(column, !visible)
+		})
+	}
+	chooser.ShowContextMenu(-1, x, y, setFocus)
+}
+
+// activeMenu returns the context menu or the column chooser if it is open,
+// nil otherwise. The menus' locks are not acquired as this is also called
+// while they change the focus.
+func (t *Table) activeMenu() *ContextMenu {
+	if t.columnChooser != nil && t.columnChooser.open {
+		return t.columnChooser
+	}
+	if t.ContextMenu.open {
+		return t.ContextMenu
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package nuview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTableColumnOrder(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetHeader([]*TableCell{NewTableCell("A"), NewTableCell("B"), NewTableCell("C")})
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(0, 2, "c")
+	tb.SetRect(0, 0, 10, 3)
+
+	tb.SetColumnOrder([]int{2, 5, 2})
+	if got := fmt.Sprint(tb.GetColumnOrder()); got != "[2 5 0 1 3 4]" {
+		t.Errorf("failed to normalize column order: expected [2 5 0 1 3 4], got %s", got)
+	}
+	tb.SetColumnOrder([]int{2})
+	tb.Draw(sc)
+	if got := row(0) + "|" + row(1); got != "C A B     |c a b     " {
+		t.Errorf("failed to draw column order: got %q", got)
+	}
+	if cell := tb.GetCell(0, 0); cell.Text != "c" {
+		t.Errorf("failed to get cell at position: expected c, got %s", cell.Text)
+	}
+	if cell := tb.GetContent().GetCell(0, 0); cell.Text != "a" {
+		t.Errorf("failed to keep content order: expected a, got %s", cell.Text)
+	}
+	tb.SetColumnOrder(nil)
+
+	// Move with the keyboard.
+	var moves []string
+	tb.SetColumnMovedFunc(func(from, to int) {
+		moves = append(moves, fmt.Sprintf("%d>%d", from, to))
+	})
+	var sorted int
+	tb.SetSortFunc(func(column int, ascending bool) {
+		sorted++
+	})
+	tb.SetSelectable(false, true)
+	tb.SetColumnsMovable(true)
+	tb.Select(0, 0)
+	tb.InputHandler()(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModAlt), func(Primitive) {})
+	if got := fmt.Sprint(tb.GetColumnOrder()); got != "[1 0 2]" {
+		t.Errorf("failed to move column with keys: expected [1 0 2], got %s", got)
+	}
+	if _, column := tb.GetSelection(); column != 1 {
+		t.Errorf("failed to follow moved column: expected column 1, got %d", column)
+	}
+
+	// Drag a header cell with the mouse, clicking it sorts.
+	tb.Draw(sc)
+	handler := tb.MouseHandler()
+	handler(MouseLeftDown, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseMove, tcell.NewEventMouse(4, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseLeftUp, tcell.NewEventMouse(4, 0, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if got := fmt.Sprint(tb.GetColumnOrder()); got != "[0 2 1]" {
+		t.Errorf("failed to drag column: expected [0 2 1], got %s", got)
+	}
+	if got := strings.Join(moves, " "); got != "0>1 0>2" {
+		t.Errorf("failed to notify moved columns: expected \"0>1 0>2\", got %q", got)
+	}
+	if sorted != 0 {
+		t.Errorf("failed to drag column: expected no sort, got %d", sorted)
+	}
+	handler(MouseLeftDown, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseLeftUp, tcell.NewEventMouse(0, 0, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if sorted != 1 {
+		t.Errorf("failed to sort by clicked column: expected 1 sort, got %d", sorted)
+	}
+}
+
+func TestTableColumnVisibility(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 5)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetHeader([]*TableCell{NewTableCell("A"), NewTableCell("B"), NewTableCell("C")})
+	tb.SetCellSimple(0, 0, "a")
+	tb.SetCellSimple(0, 1, "b")
+	tb.SetCellSimple(0, 2, "c")
+	tb.SetRect(0, 0, 10, 5)
+	tb.SetSelectable(true, true)
+	tb.Select(0, 2)
+	tb.SetSortColumn(1, true)
+
+	tb.SetColumnVisible(1, false)
+	tb.Draw(sc)
+	if got := row(0) + "|" + row(1); got != "A C       |a c       " {
+		t.Errorf("failed to hide column: got %q", got)
+	}
+	if tb.GetColumnVisible(1) || tb.GetColumnCount() != 2 {
+		t.Errorf("failed to hide column: expected 2 columns, got %d", tb.GetColumnCount())
+	}
+	if _, column := tb.CellAt(2, 1); column != 1 || tb.GetCell(0, column).Text != "c" {
+		t.Errorf("failed to skip hidden column: expected column 1 (c), got %d", column)
+	}
+	if _, column := tb.GetSelection(); column != 1 {
+		t.Errorf("failed to follow selected column: expected 1, got %d", column)
+	}
+	if column, _ := tb.GetSortColumn(); column != -1 {
+		t.Errorf("failed to remove sort indicator: expected -1, got %d", column)
+	}
+	tb.InputHandler()(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone), func(Primitive) {})
+	if _, column := tb.GetSelection(); column != 0 {
+		t.Errorf("failed to navigate shown columns: expected 0, got %d", column)
+	}
+
+	// Show it again with the column chooser.
+	tb.SetColumnsHideable(true)
+	tb.MouseHandler()(MouseRightDown, tcell.NewEventMouse(0, 0, tcell.Button2, tcell.ModNone), func(Primitive) {})
+	menu := tb.columnChooser.ContextMenuList()
+	if !tb.columnChooser.ContextMenuVisible() || menu.GetItemCount() != 3 {
+		t.Fatal("failed to show column chooser")
+	}
+	if text := menu.GetItem(1).GetMainText(); text != "  B" {
+		t.Errorf("failed to list hidden column: got %q", text)
+	}
+	menu.SetCurrentItem(1)
+	menu.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(Primitive) {})
+	tb.Draw(sc)
+	if got := row(0); got != "A B C     " {
+		t.Errorf("failed to show column: got %q", got)
+	}
+	if tb.GetColumnOrder() != nil {
+		t.Errorf("failed to remove column view: got order %v", tb.GetColumnOrder())
+	}
+}
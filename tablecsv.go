@@ -0,0 +1,145 @@
+package nuview
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVOptions configures the import and export of comma-separated values, see
+// Table.LoadCSV() and Table.WriteCSV().
+type CSVOptions struct {
+	// The character separating the fields, ',' if 0. Use '\t' for
+	// tab-separated values.
+	Comma rune
+
+	// Whether or not the first record holds the column titles. When loading,
+	// they become the header row of the table (see Table.SetHeader), when
+	// writing, the header row is written first if the table has one.
+	Header bool
+
+	// When writing, whether or not only the columns visible the last time the
+	// table was drawn are written.
+	VisibleColumns bool
+
+	// When writing, whether or not only the selection is written: the rows
+	// selected with a SelectionModel if there are any, the selected range of
+	// cells otherwise (see Table.GetSelectedRange).
+	Selection bool
+}
+
+// comma returns the field separator, ',' if none was set.
+func (o CSVOptions) comma() rune {
+	if o.Comma == 0 {
+		return ','
+	}
+	return o.Comma
+}
+
+// LoadCSV replaces the content of the table with the comma-separated values
+// read from the provided reader, one row per record, like Clear() followed by
+// calls to SetCell(). Records may have different numbers of fields. The fields
+// are escaped (see Escape()) so they are shown as they are. The table is left
+// unchanged if the values cannot be read.
+func (t *Table) LoadCSV(r io.Reader, options CSVOptions) error {
+	reader := csv.NewReader(r)
+	reader.Comma = options.comma()
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if options.Header && len(records) > 0 {
+		t.header = make([]*TableCell, len(records[0]))
+		for column, field := range records[0] {
+			t.header[column] = NewTableCell(Escape(field))
+		}
+		records = records[1:]
+	}
+	t.content.Clear()
+	if t.selection != nil {
+		t.selection.Clear()
+	}
+	for row, record := range records {
+		for column, field := range record {
+			t.content.SetCell(row, column, NewTableCell(Escape(field)))
+		}
+	}
+	return nil
+}
+
+// WriteCSV writes the content of the table to the provided writer as
+// comma-separated values, one record per row, without style tags. Missing
+// cells are written as empty fields, rows hidden by a filter (see
+// Table.SetFilterFunc) are skipped. The options restrict the output to the
+// visible columns or to the selection.
+func (t *Table) WriteCSV(w io.Writer, options CSVOptions) error {
+	t.RLock()
+	defer t.RUnlock()
+
+	rowCount, columnCount := t.content.GetRowCount(), t.content.GetColumnCount()
+	rows := make([]int, 0, rowCount)
+	for row := range rowCount {
+		rows = append(rows, row)
+	}
+	columns := make([]int, 0, columnCount)
+	for column := range columnCount {
+		columns = append(columns, column)
+	}
+	if options.VisibleColumns && len(t.visibleColumnIndices) > 0 {
+		columns = append(columns[:0], t.visibleColumnIndices...)
+	}
+
+	if options.Selection {
+		fromRow, fromColumn, toRow, toColumn := t.selectedRange()
+		if t.selection != nil && t.rowsSelectable && t.selection.GetSelectedCount() > 0 {
+			rows = t.selection.GetSelected()
+		} else {
+			rows = rows[:0]
+			for row := max(fromRow, 0); row <= toRow && row < rowCount; row++ {
+				rows = append(rows, row)
+			}
+			selected := columns[:0]
+			for _, column := range columns {
+				if column >= fromColumn && column <= toColumn {
+					selected = append(selected, column)
+				}
+			}
+			columns = selected
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = options.comma()
+	record := make([]string, len(columns))
+	if options.Header && t.header != nil {
+		for index, column := range columns {
+			record[index] = ""
+			if cell := t.cell(tableHeaderRow, column); cell != nil {
+				record[index] = stripTags(cell.Text)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if t.rowHidden(row, rowCount) {
+			continue
+		}
+		for index, column := range columns {
+			record[index] = ""
+			if cell := t.content.GetCell(row, column); cell != nil {
+				record[index] = stripTags(cell.Text)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
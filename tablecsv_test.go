@@ -0,0 +1,62 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableCSV(t *testing.T) {
+	t.Parallel()
+
+	tb := NewTable()
+	input := "name,size\nfoo,1\n\"[red]bar, baz\",22\nqux\n"
+	if err := tb.LoadCSV(strings.NewReader(input), CSVOptions{Header: true}); err != nil {
+		t.Fatal(err)
+	}
+	if count := tb.GetRowCount(); count != 3 {
+		t.Errorf("failed to load rows: expected 3, got %d", count)
+	}
+	if header := tb.GetHeader(); len(header) != 2 || header[1].Text != "size" {
+		t.Errorf("failed to load header: got %v", header)
+	}
+
+	var b strings.Builder
+	if err := tb.WriteCSV(&b, CSVOptions{Header: true}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "name,size\nfoo,1\n\"[red]bar, baz\",22\nqux,\n" {
+		t.Errorf("failed to write CSV: got %q", b.String())
+	}
+
+	// Only the selected range, as tab-separated values.
+	tb.SetSelectable(true, true)
+	tb.SetRangeSelectable(true)
+	tb.SelectRange(1, 1, 2, 1)
+	b.Reset()
+	if err := tb.WriteCSV(&b, CSVOptions{Comma: '\t', Selection: true}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "22\n\n" {
+		t.Errorf("failed to write selection: got %q", b.String())
+	}
+
+	// Only the rows of the selection model.
+	tb.SetSelectable(true, false)
+	tb.SetSelectionModel(NewSelectionModel(SelectionMultiple))
+	tb.GetSelectionModel().SetSelected([]int{0, 2})
+	b.Reset()
+	if err := tb.WriteCSV(&b, CSVOptions{Selection: true}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "foo,1\nqux,\n" {
+		t.Errorf("failed to write selected rows: got %q", b.String())
+	}
+
+	// Invalid input leaves the table unchanged.
+	if err := tb.LoadCSV(strings.NewReader("a,\"b\n"), CSVOptions{}); err == nil {
+		t.Error("failed to report invalid CSV")
+	}
+	if count := tb.GetRowCount(); count != 3 {
+		t.Errorf("failed to keep rows: expected 3, got %d", count)
+	}
+}
@@ -0,0 +1,220 @@
+package nuview
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SliceOptions configures how a slice of structs is turned into the rows of a
+// table, see NewTableFromSlice() and Table.LoadSlice().
+//
+// The columns are derived from the exported fields of the struct, in the order
+// in which they are declared. A struct tag configures a field's column, e.g.:
+//
+//	type Server struct {
+//		Name   string  `table:"Server"`
+//		Load   float64 `table:"Load,right,format=%.2f"`
+//		Secret string  `table:"-"`
+//	}
+//
+// The first value of the tag is the column title, the field name if empty. A
+// title of "-" skips the field. It may be followed by the alignment ("left",
+// "center", or "right"; numbers are right-aligned by default), a format
+// string for fmt.Sprintf() ("format=..."), and the maximum width of the
+// column's cells ("maxwidth=...").
+type SliceOptions struct {
+	// The key of the struct tag which configures the columns, "table" if
+	// empty.
+	Tag string
+
+	// Whether or not the column titles are shown in a header row (see
+	// Table.SetHeader).
+	Header bool
+
+	// An optional function which formats the value of a field whose tag does
+	// not specify a format. It returns the text of the cell and true, or false
+	// to use the default format (fmt.Sprint()).
+	Format func(field reflect.StructField, value interface{}) (string, bool)
+}
+
+// tag returns the key of the struct tag, "table" if none was set.
+func (o SliceOptions) tag() string {
+	if o.Tag == "" {
+		return "table"
+	}
+	return o.Tag
+}
+
+// sliceColumn describes a column derived from a struct field.
+type sliceColumn struct {
+	field    reflect.StructField
+	title    string
+	align    int
+	format   string
+	maxWidth int
+}
+
+// sliceColumns returns the columns derived from the fields of the provided
+// struct type.
+func sliceColumns(structType reflect.Type, options SliceOptions) ([]sliceColumn, error) {
+	var columns []sliceColumn
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		values := strings.Split(field.Tag.Get(options.tag()), ",")
+		if values[0] == "-" {
+			continue
+		}
+		column := sliceColumn{
+			field: field,
+			title: values[0],
+			align: AlignLeft,
+		}
+		if column.title == "" {
+			column.title = field.Name
+		}
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			column.align = AlignRight
+		}
+		for _, value := range values[1:] {
+			switch {
+			case value == "left":
+				column.align = AlignLeft
+			case value == "center":
+				column.align = AlignCenter
+			case value == "right":
+				column.align = AlignRight
+			case strings.HasPrefix(value, "format="):
+				column.format = strings.TrimPrefix(value, "format=")
+			case strings.HasPrefix(value, "maxwidth="):
+				width, err := strconv.Atoi(strings.TrimPrefix(value, "maxwidth="))
+				if err != nil {
+					return nil, fmt.Errorf("invalid maximum width of field %s: %w", field.Name, err)
+				}
+				column.maxWidth = width
+			default:
+				return nil, fmt.Errorf("invalid tag value %q of field %s", value, field.Name)
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// NewTableFromSlice returns a new table with one row for each element of the
+// provided slice of structs (or of pointers to structs), see SliceOptions for
+// how the columns are derived. The Reference of each cell points to the
+// element of its row: the pointer for slices of pointers, a pointer to the
+// element in the slice otherwise. An error is returned if the data is not
+// such a slice or if a struct tag is invalid.
+func NewTableFromSlice(data interface{}, options SliceOptions) (*Table, error) {
+	t := NewTable()
+	if err := t.LoadSlice(data, options); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadSlice replaces the content of the table with the elements of the
+// provided slice of structs, like Clear() followed by calls to SetCell(). See
+// NewTableFromSlice() for details. The table is left unchanged if an error is
+// returned.
+func (t *Table) LoadSlice(data interface{}, options SliceOptions) error {
+	slice := reflect.ValueOf(data)
+	if slice.Kind() == reflect.Pointer && slice.Elem().Kind() == reflect.Slice {
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice of structs, got %T", data)
+	}
+	elementType := slice.Type().Elem()
+	pointers := elementType.Kind() == reflect.Pointer
+	if pointers {
+		elementType = elementType.Elem()
+	}
+	if elementType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a slice of structs, got %T", data)
+	}
+	columns, err := sliceColumns(elementType, options)
+	if err != nil {
+		return err
+	}
+
+	// Create the cells.
+	var header []*TableCell
+	if options.Header {
+		header = make([]*TableCell, len(columns))
+		for index, column := range columns {
+			header[index] = NewTableCell(Escape(column.title))
+		}
+	}
+	rows := make([][]*TableCell, slice.Len())
+	for row := range rows {
+		element := slice.Index(row)
+		var reference interface{}
+		if pointers {
+			reference = element.Interface()
+			element = element.Elem()
+		} else if element.CanAddr() {
+			reference = element.Addr().Interface()
+		} else {
+			reference = element.Interface()
+		}
+		rows[row] = make([]*TableCell, len(columns))
+		for index, column := range columns {
+			var text string
+			if element.IsValid() {
+				if value, err := element.FieldByIndexErr(column.field.Index); err == nil {
+					text = column.text(value, options)
+				}
+			}
+			cell := NewTableCell(Escape(text))
+			cell.SetAlign(column.align)
+			cell.SetMaxWidth(column.maxWidth)
+			cell.SetReference(reference)
+			rows[row][index] = cell
+		}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if options.Header {
+		t.header = header
+	}
+	t.content.Clear()
+	if t.selection != nil {
+		t.selection.Clear()
+	}
+	for row, cells := range rows {
+		for column, cell := range cells {
+			t.content.SetCell(row, column, cell)
+		}
+	}
+	return nil
+}
+
+// text returns the text of a cell showing the provided field value.
+func (c sliceColumn) text(value reflect.Value, options SliceOptions) string {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if c.format != "" {
+		return fmt.Sprintf(c.format, value.Interface())
+	}
+	if options.Format != nil {
+		if text, ok := options.Format(c.field, value.Interface()); ok {
+			return text
+		}
+	}
+	return fmt.Sprint(value.Interface())
+}
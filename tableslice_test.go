@@ -0,0 +1,77 @@
+package nuview
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableFromSlice(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Name   string  `table:"Server"`
+		Load   float64 `table:"Load,format=%.2f"`
+		Port   int
+		Note   *string `table:",center"`
+		secret string
+		Hidden bool `table:"-"`
+	}
+	note := "[primary]"
+	servers := []server{
+		{Name: "alpha", Load: 0.5, Port: 80, Note: &note},
+		{Name: "beta", Load: 1.25, Port: 8080},
+	}
+
+	table, err := NewTableFromSlice(servers, SliceOptions{Header: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, cell := range table.GetHeader() {
+		titles = append(titles, cell.Text)
+	}
+	if strings.Join(titles, ",") != "Server,Load,Port,Note" {
+		t.Errorf("failed to derive columns: expected %q, got %q", "Server,Load,Port,Note", strings.Join(titles, ","))
+	}
+	for _, expected := range []struct {
+		row, column int
+		text        string
+		align       int
+	}{
+		{0, 0, "alpha", AlignLeft},
+		{0, 1, "0.50", AlignRight},
+		{1, 2, "8080", AlignRight},
+		{0, 3, Escape("[primary]"), AlignCenter},
+		{1, 3, "", AlignCenter},
+	} {
+		cell := table.GetCell(expected.row, expected.column)
+		if text := cell.Text; text != expected.text || cell.Align != expected.align {
+			t.Errorf("failed to fill cell %d,%d: expected %q aligned %d, got %q aligned %d", expected.row, expected.column, expected.text, expected.align, text, cell.Align)
+		}
+	}
+	if reference := table.GetCell(1, 0).GetReference(); reference != &servers[1] {
+		t.Errorf("failed to reference row value: expected %p, got %v", &servers[1], reference)
+	}
+
+	// Custom formats, slices of pointers.
+	err = table.LoadSlice([]*server{{Name: "gamma", Port: 22}}, SliceOptions{
+		Format: func(field reflect.StructField, value interface{}) (string, bool) {
+			if field.Name == "Port" {
+				return fmt.Sprintf(":%d", value), true
+			}
+			return "", false
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows, text := table.GetRowCount(), table.GetCell(0, 2).Text; rows != 1 || text != ":22" {
+		t.Errorf("failed to load slice of pointers: expected 1 row and %q, got %d and %q", ":22", rows, text)
+	}
+
+	if _, err := NewTableFromSlice([]int{1}, SliceOptions{}); err == nil {
+		t.Error("failed to reject slice of non-structs")
+	}
+}
@@ -0,0 +1,287 @@
+package nuview
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TableSplitView shows the content of a table in two vertically stacked panes
+// which scroll independently, like the split panes of a spreadsheet, e.g. to
+// compare rows far apart. The top pane is the provided table, the bottom pane a
+// second table showing the same content (see GetSplitTable). A split bar
+// between the panes may be dragged with the mouse to resize them, dragging it
+// to the top removes the split.
+//
+// The bottom pane follows the columns of the top pane: it uses the same content,
+// column order, column widths, fixed columns, borders, selectable flags and
+// filter, and both panes are scrolled horizontally together. Each pane keeps
+// its own row offset and selection. The header and the fixed rows are only
+// shown in the top pane.
+type TableSplitView struct {
+	*Box
+
+	// The tables shown in the top and in the bottom pane.
+	table, split *Table
+
+	// The height of the top pane in rows, 0 if the view is not split.
+	splitHeight int
+
+	// Set to true while the split bar is dragged with the mouse.
+	dragging bool
+
+	// An optional function which is called when the user resizes the panes.
+	changed func(height int)
+
+	sync.RWMutex
+}
+
+// NewTableSplitView returns a new split view of the provided table. It is not
+// split initially, see SetSplit().
+func NewTableSplitView(table *Table) *TableSplitView {
+	v := &TableSplitView{
+		Box:   NewBox(),
+		table: table,
+		split: NewTable(),
+	}
+	v.focus = v
+	return v
+}
+
+// GetTable returns the table shown in the top pane, the one provided to
+// NewTableSplitView().
+func (v *TableSplitView) GetTable() *Table {
+	return v.table
+}
+
+// GetSplitTable returns the table shown in the bottom pane, e.g. to set its
+// handlers or to scroll it (see Table.SetOffset).
+func (v *TableSplitView) GetSplitTable() *Table {
+	return v.split
+}
+
+// SetSplit sets the height of the top pane in rows. The bottom pane takes the
+// rest of the view, minus one row for the split bar. The top pane is made
+// smaller if the view is too small to show at least one row in the bottom pane.
+// Provide 0 to remove the split and show only the top pane.
+func (v *TableSplitView) SetSplit(height int) {
+	v.Lock()
+	defer v.Unlock()
+
+	if height > 0 && v.splitHeight == 0 {
+		// The bottom pane starts where the top pane is scrolled to.
+		row, _ := v.table.GetOffset()
+		v.split.SetOffset(row, 0)
+	}
+	v.splitHeight = max(height, 0)
+}
+
+// GetSplit returns the height of the top pane in rows, 0 if the view is not
+// split.
+func (v *TableSplitView) GetSplit() int {
+	v.RLock()
+	defer v.RUnlock()
+
+	return v.splitHeight
+}
+
+// SetSplitChangedFunc sets a handler which is called when the user resized the
+// panes by dragging the split bar. It receives the new height of the top pane,
+// 0 if the split was removed.
+func (v *TableSplitView) SetSplitChangedFunc(handler func(height int)) {
+	v.Lock()
+	defer v.Unlock()
+
+	v.changed = handler
+}
+
+// Focus is called when this primitive receives focus.
+func (v *TableSplitView) Focus(delegate func(p Primitive)) {
+	v.RLock()
+	split := v.splitHeight > 0
+	v.RUnlock()
+
+	if split && v.split.HasFocus() {
+		delegate(v.split)
+		return
+	}
+	delegate(v.table)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (v *TableSplitView) HasFocus() bool {
+	return v.table.HasFocus() || v.split.HasFocus()
+}
+
+// layout returns the height of the top pane and the position of the split bar
+// given the inner rectangle of the view. The height is 0 if the view is not
+// split.
+func (v *TableSplitView) layout() (height, barY int) {
+	_, y, _, innerHeight := v.GetInnerRect()
+	v.RLock()
+	height = min(v.splitHeight, innerHeight-2)
+	v.RUnlock()
+
+	if height <= 0 {
+		return 0, -1
+	}
+	return height, y + height
+}
+
+// Draw draws this primitive onto the screen.
+func (v *TableSplitView) Draw(screen tcell.Screen) {
+	if !v.GetVisible() {
+		return
+	}
+
+	v.Box.Draw(screen)
+
+	x, y, width, height := v.GetInnerRect()
+	topHeight, barY := v.layout()
+	if topHeight == 0 {
+		v.table.SetRect(x, y, width, height)
+		v.table.Draw(screen)
+		return
+	}
+
+	// The pane with focus determines the horizontal scroll position.
+	v.split.followColumns(v.table)
+	v.table.SetRect(x, y, width, topHeight)
+	v.split.SetRect(x, barY+1, width, height-topHeight-1)
+	leading, following := v.table, v.split
+	if v.split.HasFocus() {
+		leading, following = v.split, v.table
+	}
+	leading.Draw(screen)
+	following.setHorizontalOffset(leading.horizontalOffset())
+	following.Draw(screen)
+
+	// Draw the split bar.
+	v.RLock()
+	dragging := v.dragging
+	v.RUnlock()
+	style := tcell.StyleDefault.Background(v.GetBackgroundColor()).Foreground(Styles.BorderColor)
+	if dragging {
+		style = style.Foreground(Styles.TertiaryTextColor)
+	}
+	for column := x; column < x+width; column++ {
+		screen.SetContent(column, barY, Borders.Horizontal, nil, style)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *TableSplitView) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		for _, table := range []*Table{v.split, v.table} {
+			if table.HasFocus() {
+				if handler := table.InputHandler(); handler != nil {
+					handler(event, setFocus)
+				}
+				return
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (v *TableSplitView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return v.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		v.RLock()
+		dragging := v.dragging
+		v.RUnlock()
+		if !dragging && !v.InRect(event.Position()) {
+			return false, nil
+		}
+
+		// Drag the split bar.
+		_, y := event.Position()
+		topHeight, barY := v.layout()
+		switch {
+		case action == MouseLeftDown && topHeight > 0 && y == barY:
+			v.Lock()
+			v.dragging = true
+			v.Unlock()
+			return true, v
+		case action == MouseMove && dragging:
+			_, innerY, _, _ := v.GetInnerRect()
+			height := max(y-innerY, 0)
+			v.Lock()
+			changed := height != v.splitHeight
+			v.splitHeight = height
+			handler := v.changed
+			v.Unlock()
+			if changed && handler != nil {
+				handler(height)
+			}
+			return true, v
+		case action == MouseLeftUp && dragging:
+			v.Lock()
+			v.dragging = false
+			v.Unlock()
+			return true, nil
+		}
+
+		// Pass mouse events on to the panes.
+		panes := []*Table{v.table}
+		if topHeight > 0 {
+			panes = append(panes, v.split)
+		}
+		for _, table := range panes {
+			consumed, capture = table.MouseHandler()(action, event, setFocus)
+			if consumed {
+				return
+			}
+		}
+		return
+	})
+}
+
+// followColumns makes the table show the columns of the provided table the way
+// it does, see TableSplitView. Neither table may be locked.
+func (t *Table) followColumns(source *Table) {
+	source.RLock()
+	content, order, hidden := source.content, []int(nil), map[int]bool(nil)
+	if source.columnView != nil {
+		content, order = source.columnView.TableContent, append([]int(nil), source.columnView.order...)
+		hidden = maps.Clone(source.columnView.hidden)
+	}
+	fixedColumns, fixedRightColumns := source.fixedColumns, source.fixedRightColumns
+	borders, separator := source.borders, source.separator
+	rowsSelectable, columnsSelectable := source.rowsSelectable, source.columnsSelectable
+	filter, columnRenderers := source.filter, source.columnRenderers
+	source.RUnlock()
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnsSource = source
+	t.content, t.columnView = content, nil
+	if order != nil || hidden != nil {
+		t.columnView = &tableColumnView{TableContent: content, order: order, hidden: hidden}
+		t.columnView.update()
+		t.content = t.columnView
+	}
+	t.fixedColumns, t.fixedRightColumns = fixedColumns, fixedRightColumns
+	t.borders, t.separator = borders, separator
+	t.rowsSelectable, t.columnsSelectable = rowsSelectable, columnsSelectable
+	t.filter, t.columnRenderers = filter, columnRenderers
+}
+
+// horizontalOffset returns the column offset and the horizontal scroll
+// position of the table.
+func (t *Table) horizontalOffset() (columnOffset, xScroll int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.columnOffset, t.xScroll
+}
+
+// setHorizontalOffset sets the column offset and the horizontal scroll
+// position of the table.
+func (t *Table) setHorizontalOffset(columnOffset, xScroll int) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.columnOffset, t.xScroll = columnOffset, xScroll
+}
@@ -0,0 +1,77 @@
+package nuview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTableSplitView(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 8)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 12; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tb := NewTable()
+	tb.SetHeader([]*TableCell{NewTableCell("Number"), NewTableCell("X")})
+	for r := 0; r < 30; r++ {
+		tb.SetCellSimple(r, 0, fmt.Sprint(r))
+		tb.SetCellSimple(r, 1, "x")
+	}
+	v := NewTableSplitView(tb)
+	v.SetRect(0, 0, 12, 8)
+	v.Draw(sc)
+	if got := row(7); got != "6      x    " {
+		t.Errorf("failed to draw unsplit table: expected %q, got %q", "6      x    ", got)
+	}
+
+	v.SetSplit(3)
+	v.GetSplitTable().SetOffset(20, 0)
+	v.Draw(sc)
+	for y, expected := range []string{
+		"Number X    ",
+		"0      x    ",
+		"1      x    ",
+		"────────────",
+		"20     x    ",
+	} {
+		if got := row(y); got != expected {
+			t.Errorf("failed to draw split view row %d: expected %q, got %q", y, expected, got)
+		}
+	}
+
+	// Drag the split bar down by two rows, then to the top.
+	var heights []int
+	v.SetSplitChangedFunc(func(height int) {
+		heights = append(heights, height)
+	})
+	v.MouseHandler()(MouseLeftDown, tcell.NewEventMouse(0, 3, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	v.MouseHandler()(MouseMove, tcell.NewEventMouse(0, 5, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	v.MouseHandler()(MouseLeftUp, tcell.NewEventMouse(0, 5, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if split := v.GetSplit(); split != 5 {
+		t.Errorf("failed to drag split bar: expected split 5, got %d", split)
+	}
+	v.Draw(sc)
+	if got := row(5); got != "────────────" {
+		t.Errorf("failed to move split bar: expected %q, got %q", "────────────", got)
+	}
+	v.MouseHandler()(MouseLeftDown, tcell.NewEventMouse(0, 5, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	v.MouseHandler()(MouseMove, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	v.MouseHandler()(MouseLeftUp, tcell.NewEventMouse(0, 0, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if fmt.Sprint(heights) != "[5 0]" {
+		t.Errorf("failed to notify split changes: expected [5 0], got %v", heights)
+	}
+}
@@ -0,0 +1,440 @@
+package nuview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TaskState is the state of a Task.
+type TaskState int
+
+// The states of a task.
+const (
+	TaskRunning TaskState = iota
+	TaskSucceeded
+	TaskFailed
+	TaskCanceled
+)
+
+// String returns the text shown for the state.
+func (s TaskState) String() string {
+	switch s {
+	case TaskSucceeded:
+		return "Done"
+	case TaskFailed:
+		return "Failed"
+	case TaskCanceled:
+		return "Canceled"
+	default:
+		return "Running"
+	}
+}
+
+// Task is a function running in the background whose progress is shown by a
+// TaskList, see TaskList.Run().
+type Task struct {
+	// The name of the task.
+	name string
+
+	// The state of the task, the error it failed with, if any, and its
+	// progress.
+	state          TaskState
+	err            error
+	done, total    int
+	started, ended time.Time
+
+	// Cancels the context of the task.
+	cancel context.CancelFunc
+
+	// Closed when the task ended.
+	finished chan struct{}
+
+	sync.RWMutex
+}
+
+// GetName returns the name of the task.
+func (t *Task) GetName() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.name
+}
+
+// GetState returns the state of the task and, if it failed, its error.
+func (t *Task) GetState() (state TaskState, err error) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.state, t.err
+}
+
+// GetProgress returns the progress of the task as last reported by it. If the
+// total is not positive, the progress is unknown.
+func (t *Task) GetProgress() (done, total int) {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.done, t.total
+}
+
+// GetElapsed returns the time the task has been running or, if it ended, the
+// time it took.
+func (t *Task) GetElapsed() time.Duration {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.state != TaskRunning {
+		return t.ended.Sub(t.started)
+	}
+	return time.Since(t.started)
+}
+
+// Cancel cancels the context of the task. The task ends as canceled when its
+// function returns.
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+// Wait waits for the task to end.
+func (t *Task) Wait() {
+	<-t.finished
+}
+
+// TaskList shows the tasks running in the background, e.g. downloads or
+// builds, with their names, progress bars, states and elapsed times. Tasks are
+// started with Run(), which runs a function in its own goroutine and provides
+// it with a context and a function to report its progress. Running tasks may be
+// canceled by clicking the "[x]" at the end of their rows or with
+// Keys.CancelTask.
+//
+// Tasks which ended are removed after a while, see SetRetention(). The list is
+// redrawn when tasks report progress, every second while tasks are running and
+// when tasks end.
+type TaskList struct {
+	*Box
+
+	// The tasks, in the order they were started.
+	tasks []*Task
+
+	// The index of the selected task.
+	selected int
+
+	// How long tasks which ended are shown. Negative values keep them.
+	retention time.Duration
+
+	// An optional function which is called when a task ended.
+	ended func(task *Task)
+
+	sync.RWMutex
+}
+
+// NewTaskList returns a new, empty task list.
+func NewTaskList() *TaskList {
+	return &TaskList{
+		Box:       NewBox(),
+		retention: 5 * time.Second,
+	}
+}
+
+// SetRetention sets how long tasks are shown after they ended, 5 seconds by
+// default. Provide 0 to remove them right away or a negative duration to keep
+// them until RemoveEnded() is called.
+func (l *TaskList) SetRetention(retention time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.retention = retention
+}
+
+// SetEndedFunc sets a handler which is called when a task ended, e.g. to show
+// the error of a failed task. It is called from the task's goroutine, see
+// Application.QueueUpdateDraw().
+func (l *TaskList) SetEndedFunc(handler func(task *Task)) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.ended = handler
+}
+
+// Run starts a task with the provided name which runs the provided function in
+// its own goroutine and returns it. The function should return when the
+// provided context is done, i.e. when the task is canceled, and may report its
+// progress by calling the provided function with the amount of work done and
+// the total amount of work. The task fails if the function returns an error
+// other than the context's error.
+func (l *TaskList) Run(name string, fn func(ctx context.Context, progress func(done, total int)) error) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &Task{
+		name:     name,
+		started:  time.Now(),
+		cancel:   cancel,
+		finished: make(chan struct{}),
+	}
+
+	l.Lock()
+	l.tasks = append(l.tasks, task)
+	l.Unlock()
+
+	// Redraw every second to update the elapsed time.
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				requestAnimationFrame()
+			case <-task.finished:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		err := fn(ctx, func(done, total int) {
+			task.Lock()
+			task.done, task.total = done, total
+			task.Unlock()
+			requestAnimationFrame()
+		})
+
+		task.Lock()
+		switch {
+		case ctx.Err() != nil && (err == nil || errors.Is(err, ctx.Err())):
+			task.state = TaskCanceled
+		case err != nil:
+			task.state, task.err = TaskFailed, err
+		default:
+			task.state = TaskSucceeded
+		}
+		task.ended = time.Now()
+		task.Unlock()
+		cancel()
+		close(task.finished)
+
+		l.RLock()
+		retention, ended := l.retention, l.ended
+		l.RUnlock()
+		if retention > 0 {
+			time.AfterFunc(retention, requestAnimationFrame)
+		}
+		requestAnimationFrame()
+		if ended != nil {
+			ended(task)
+		}
+	}()
+
+	requestAnimationFrame()
+	return task
+}
+
+// GetTasks returns the tasks shown by the list, in the order they were
+// started.
+func (l *TaskList) GetTasks() []*Task {
+	l.Lock()
+	defer l.Unlock()
+
+	l.removeExpired()
+	return append([]*Task(nil), l.tasks...)
+}
+
+// RemoveEnded removes all tasks which ended.
+func (l *TaskList) RemoveEnded() {
+	l.Lock()
+	defer l.Unlock()
+
+	l.removeTasks(func(task *Task) bool {
+		state, _ := task.GetState()
+		return state != TaskRunning
+	})
+}
+
+// removeExpired removes the tasks which ended longer than the retention time
+// ago. The list must be locked.
+func (l *TaskList) removeExpired() {
+	if l.retention < 0 {
+		return
+	}
+	now := time.Now()
+	l.removeTasks(func(task *Task) bool {
+		task.RLock()
+		defer task.RUnlock()
+		return task.state != TaskRunning && now.Sub(task.ended) >= l.retention
+	})
+}
+
+// removeTasks removes the tasks for which the provided function returns true.
+// The list must be locked.
+func (l *TaskList) removeTasks(remove func(task *Task) bool) {
+	tasks := l.tasks[:0]
+	for index, task := range l.tasks {
+		if !remove(task) {
+			tasks = append(tasks, task)
+		} else if index < l.selected {
+			l.selected--
+		}
+	}
+	l.tasks = tasks
+	l.selected = max(min(l.selected, len(l.tasks)-1), 0)
+}
+
+// formatElapsed returns the text shown for the elapsed time of a task.
+func formatElapsed(elapsed time.Duration) string {
+	seconds := int(elapsed / time.Second)
+	if seconds < 3600 {
+		return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// The widths of the columns of a task list other than the name.
+const (
+	taskListBarWidth     = 12
+	taskListStateWidth   = 8
+	taskListElapsedWidth = 7
+	taskListCancelWidth  = 3
+)
+
+// Draw draws this primitive onto the screen.
+func (l *TaskList) Draw(screen tcell.Screen) {
+	if !l.GetVisible() {
+		return
+	}
+
+	l.Box.Draw(screen)
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.removeExpired()
+	x, y, width, height := l.GetInnerRect()
+	background := l.GetBackgroundColor()
+	hasFocus := l.HasFocus()
+
+	// Narrow lists leave out the progress bars.
+	barWidth := taskListBarWidth
+	if width < 2*(taskListBarWidth+taskListStateWidth+taskListElapsedWidth+taskListCancelWidth+4) {
+		barWidth = 0
+	}
+	nameWidth := width - barWidth - taskListStateWidth - taskListElapsedWidth - taskListCancelWidth - 3
+	if barWidth > 0 {
+		nameWidth--
+	}
+
+	// Keep the selected task visible.
+	offset := max(l.selected-height+1, 0)
+	writer := NewTranslateScreenWriterAdapter(screen)
+	for index := offset; index < len(l.tasks) && index-offset < height; index++ {
+		task := l.tasks[index]
+		rowY := y + index - offset
+		task.RLock()
+		state, err, done, total := task.state, task.err, task.done, task.total
+		task.RUnlock()
+		elapsed := task.GetElapsed()
+
+		style := tcell.StyleDefault.Background(background).Foreground(Styles.PrimaryTextColor)
+		if index == l.selected && hasFocus {
+			style = style.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastPrimaryTextColor)
+			for column := x; column < x+width; column++ {
+				screen.SetContent(column, rowY, ' ', nil, style)
+			}
+		}
+
+		name := task.GetName()
+		if err != nil {
+			name += ": " + err.Error()
+		}
+		columnX := x
+		PrintStyle(screen, []byte(Escape(name)), columnX, rowY, nameWidth, AlignLeft, style)
+		columnX += max(nameWidth, 0) + 1
+		if barWidth > 0 {
+			if total > 0 {
+				bar := NewProgressCellRenderer(done, total)
+				bar.Draw(NewClippingScreenWriter(writer, columnX, rowY, barWidth, 1), barWidth, style.Foreground(Styles.TertiaryTextColor))
+			}
+			columnX += barWidth + 1
+		}
+
+		stateStyle := style
+		switch state {
+		case TaskSucceeded:
+			stateStyle = style.Foreground(Styles.TertiaryTextColor)
+		case TaskFailed:
+			stateStyle = style.Foreground(Styles.ErrorTextColor)
+		case TaskCanceled:
+			stateStyle = style.Foreground(Styles.SecondaryTextColor)
+		}
+		PrintStyle(screen, []byte(state.String()), columnX, rowY, taskListStateWidth, AlignLeft, stateStyle)
+		columnX += taskListStateWidth + 1
+		PrintStyle(screen, []byte(formatElapsed(elapsed)), columnX, rowY, taskListElapsedWidth, AlignRight, style)
+		columnX += taskListElapsedWidth + 1
+		if state == TaskRunning {
+			for offset, r := range "[x]" {
+				screen.SetContent(columnX+offset, rowY, r, nil, style)
+			}
+		}
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (l *TaskList) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return l.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		l.Lock()
+		var task *Task
+		switch {
+		case l.hitShortcut(event, l.keys().MoveUp, l.keys().MoveUp2):
+			l.selected = max(l.selected-1, 0)
+		case l.hitShortcut(event, l.keys().MoveDown, l.keys().MoveDown2):
+			l.selected = max(min(l.selected+1, len(l.tasks)-1), 0)
+		case l.hitShortcut(event, l.keys().MoveFirst, l.keys().MoveFirst2):
+			l.selected = 0
+		case l.hitShortcut(event, l.keys().MoveLast, l.keys().MoveLast2):
+			l.selected = max(len(l.tasks)-1, 0)
+		case l.hitShortcut(event, l.keys().CancelTask):
+			if l.selected < len(l.tasks) {
+				task = l.tasks[l.selected]
+			}
+		}
+		l.Unlock()
+
+		if task != nil {
+			task.Cancel()
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (l *TaskList) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return l.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		if !l.InRect(event.Position()) {
+			return false, nil
+		}
+		if action != MouseLeftClick {
+			return false, nil
+		}
+
+		setFocus(l)
+		x, y, width, height := l.GetInnerRect()
+		mouseX, mouseY := event.Position()
+		l.Lock()
+		offset := max(l.selected-height+1, 0)
+		index := offset + mouseY - y
+		var task *Task
+		if index < len(l.tasks) {
+			l.selected = index
+			if mouseX >= x+width-taskListCancelWidth {
+				task = l.tasks[index]
+			}
+		}
+		l.Unlock()
+
+		if task != nil {
+			task.Cancel()
+		}
+		return true, nil
+	})
+}
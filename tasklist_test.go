@@ -0,0 +1,79 @@
+package nuview
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTaskList(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(80, 3)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 80; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	l := NewTaskList()
+	l.SetRect(0, 0, 80, 3)
+	l.SetRetention(-1)
+
+	progressed := make(chan struct{})
+	download := l.Run("download", func(ctx context.Context, progress func(done, total int)) error {
+		progress(1, 2)
+		close(progressed)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	build := l.Run("build", func(ctx context.Context, progress func(done, total int)) error {
+		return errors.New("broken")
+	})
+	build.Wait()
+	<-progressed
+
+	l.Draw(sc)
+	if got := row(0); !strings.HasPrefix(got, "download") || !strings.Contains(got, "██████       Running") || !strings.HasSuffix(got, "[x]") {
+		t.Errorf("failed to draw running task: got %q", got)
+	}
+	if got := row(1); !strings.HasPrefix(got, "build: broken") || !strings.Contains(got, "Failed") || strings.HasSuffix(got, "[x]") {
+		t.Errorf("failed to draw failed task: got %q", got)
+	}
+
+	// Cancel the running task by clicking its cancel button.
+	l.MouseHandler()(MouseLeftClick, tcell.NewEventMouse(78, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	download.Wait()
+	if state, _ := download.GetState(); state != TaskCanceled {
+		t.Errorf("failed to cancel task: expected %v, got %v", TaskCanceled, state)
+	}
+
+	l.RemoveEnded()
+	if tasks := l.GetTasks(); len(tasks) != 0 {
+		t.Errorf("failed to remove ended tasks: expected 0, got %d", len(tasks))
+	}
+
+	// Tasks are removed after the retention time.
+	l.SetRetention(0)
+	l.Run("quick", func(ctx context.Context, progress func(done, total int)) error {
+		return nil
+	}).Wait()
+	if tasks := l.GetTasks(); len(tasks) != 0 {
+		t.Errorf("failed to remove expired tasks: expected 0, got %d", len(tasks))
+	}
+
+	if got := formatElapsed(3725 * time.Second); got != "1:02:05" {
+		t.Errorf("failed to format elapsed time: expected %q, got %q", "1:02:05", got)
+	}
+}
@@ -0,0 +1,226 @@
+package nuview
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+)
+
+// AnnotationSeverity is the severity of a TextAnnotation.
+type AnnotationSeverity int
+
+// Annotation severities, in increasing order.
+const (
+	AnnotationHint AnnotationSeverity = iota
+	AnnotationWarning
+	AnnotationError
+)
+
+// TextAnnotation marks a range of the text of a TextView, e.g. a misspelled
+// word reported by a spell checker or a problem reported by a linter. The
+// range is underlined in the color of its severity. The user may cycle through
+// the annotations with Keys.NextAnnotation and Keys.PreviousAnnotation which
+// shows the message of the current annotation below it.
+type TextAnnotation struct {
+	// The index of the annotated line of the text, counting from 0. Lines are
+	// separated by newlines, wrapped lines count as one line.
+	Line int
+
+	// The range of the annotated characters in the line, from From up to but
+	// not including To. Characters are counted from 0 and exclude any tags.
+	From, To int
+
+	// The severity of the annotation.
+	Severity AnnotationSeverity
+
+	// The message explaining the annotation.
+	Message string
+}
+
+// SetAnnotations replaces the annotations attached by the provided source,
+// e.g. "spelling" or the name of a linter, with the provided annotations.
+// Annotations of other sources are not affected, so several checkers may
+// annotate the same text. Provide nil to remove the annotations of a source.
+func (t *TextView) SetAnnotations(source string, annotations []*TextAnnotation) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.annotations == nil {
+		t.annotations = make(map[string][]*TextAnnotation)
+	}
+	if len(annotations) == 0 {
+		delete(t.annotations, source)
+	} else {
+		t.annotations[source] = annotations
+	}
+
+	// Sort all annotations by position.
+	t.sortedAnnotations = nil
+	current := t.currentAnnotation
+	t.currentAnnotation = nil
+	for _, list := range t.annotations {
+		for _, annotation := range list {
+			t.sortedAnnotations = append(t.sortedAnnotations, annotation)
+			if annotation == current {
+				t.currentAnnotation = current
+			}
+		}
+	}
+	sort.SliceStable(t.sortedAnnotations, func(i, j int) bool {
+		a, b := t.sortedAnnotations[i], t.sortedAnnotations[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.From < b.From
+	})
+}
+
+// GetAnnotations returns the annotations of all sources, sorted by position.
+func (t *TextView) GetAnnotations() []*TextAnnotation {
+	t.RLock()
+	defer t.RUnlock()
+
+	return append([]*TextAnnotation(nil), t.sortedAnnotations...)
+}
+
+// GetCurrentAnnotation returns the annotation the user moved to with
+// Keys.NextAnnotation or Keys.PreviousAnnotation, or nil if there is none.
+func (t *TextView) GetCurrentAnnotation() *TextAnnotation {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.currentAnnotation
+}
+
+// SetAnnotationSelectedFunc sets a handler which is called when the user moves
+// to an annotation, e.g. to show its message in a status bar.
+func (t *TextView) SetAnnotationSelectedFunc(handler func(annotation *TextAnnotation)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.annotationSelected = handler
+}
+
+// annotationColor returns the color of annotations with the provided severity.
+func (t *TextView) annotationColor(severity AnnotationSeverity) tcell.Color {
+	switch severity {
+	case AnnotationError:
+		return t.theme().ErrorTextColor
+	case AnnotationWarning:
+		return t.theme().SecondaryTextColor
+	default:
+		return t.theme().TertiaryTextColor
+	}
+}
+
+// lineAnnotations returns the annotations of the provided line of the text.
+// The text view must be locked.
+func (t *TextView) lineAnnotations(line int) []*TextAnnotation {
+	from := sort.Search(len(t.sortedAnnotations), func(i int) bool {
+		return t.sortedAnnotations[i].Line >= line
+	})
+	to := from
+	for to < len(t.sortedAnnotations) && t.sortedAnnotations[to].Line == line {
+		to++
+	}
+	return t.sortedAnnotations[from:to]
+}
+
+// annotationAt returns the annotation with the highest severity covering the
+// provided character of a line, out of the line's annotations. The current
+// annotation takes precedence. It returns nil if there is none.
+func (t *TextView) annotationAt(annotations []*TextAnnotation, character int) (found *TextAnnotation) {
+	for _, annotation := range annotations {
+		if character < annotation.From || character >= annotation.To {
+			continue
+		}
+		if annotation == t.currentAnnotation {
+			return annotation
+		}
+		if found == nil || annotation.Severity > found.Severity {
+			found = annotation
+		}
+	}
+	return
+}
+
+// rowCharacters returns the number of characters in the rows of the index
+// which precede the provided row and belong to the same line of the text. The
+// text view must be locked.
+func (t *TextView) rowCharacters(row int) (characters int) {
+	for previous := row - 1; previous >= 0 && t.index[previous].Line == t.index[row].Line; previous-- {
+		characters += uniseg.GraphemeClusterCount(string(t.rowText(previous)))
+	}
+	return
+}
+
+// moveAnnotation moves to the next (or previous) annotation, starting over at
+// the other end, and scrolls it into view. It returns the annotation selected
+// handler to be called outside the lock, if any. The text view must be locked.
+func (t *TextView) moveAnnotation(forward bool) func() {
+	if len(t.sortedAnnotations) == 0 {
+		return nil
+	}
+	current := -1
+	for index, annotation := range t.sortedAnnotations {
+		if annotation == t.currentAnnotation {
+			current = index
+			break
+		}
+	}
+	if forward {
+		current = (current + 1) % len(t.sortedAnnotations)
+	} else if current <= 0 {
+		current = len(t.sortedAnnotations) - 1
+	} else {
+		current--
+	}
+	annotation := t.sortedAnnotations[current]
+	t.currentAnnotation = annotation
+
+	// Find the row of the annotation and scroll to it.
+	t.reindexBuffer(t.indexWidth)
+	var characters int
+	for row, info := range t.index {
+		if info.Line < annotation.Line {
+			continue
+		} else if info.Line > annotation.Line {
+			break
+		}
+		text := string(t.rowText(row))
+		count := uniseg.GraphemeClusterCount(text)
+		if annotation.From >= characters+count && row+1 < len(t.index) && t.index[row+1].Line == info.Line {
+			characters += count
+			continue
+		}
+		if row < t.lineOffset || row >= t.lineOffset+t.pageSize {
+			t.trackEnd = false
+			t.lineOffset = max(row-t.pageSize/2, 0)
+		}
+		if !t.wrap && t.align == AlignLeft {
+			// Bring the start of the annotation into view horizontally.
+			var column int
+			iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				if characters >= annotation.From {
+					return true
+				}
+				characters++
+				column = screenPos + screenWidth
+				return false
+			})
+			if column < t.columnOffset || column >= t.columnOffset+t.lastWidth {
+				t.columnOffset = max(column-t.lastWidth/4, 0)
+			}
+		}
+		break
+	}
+
+	if t.annotationSelected == nil {
+		return nil
+	}
+	handler := t.annotationSelected
+	return func() {
+		handler(annotation)
+	}
+}
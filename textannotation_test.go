@@ -0,0 +1,77 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTextViewAnnotations(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 10; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	tv := NewTextView()
+	tv.SetText("hello world\nfoo bar\nbaz")
+	tv.SetRect(0, 0, 10, 4)
+
+	typo := &TextAnnotation{Line: 0, From: 6, To: 11, Severity: AnnotationWarning, Message: "typo"}
+	unused := &TextAnnotation{Line: 1, From: 0, To: 3, Severity: AnnotationError, Message: "unused"}
+	tv.SetAnnotations("spelling", []*TextAnnotation{typo})
+	tv.SetAnnotations("lint", []*TextAnnotation{unused})
+	if annotations := tv.GetAnnotations(); len(annotations) != 2 || annotations[0] != typo || annotations[1] != unused {
+		t.Errorf("failed to sort annotations: got %v", annotations)
+	}
+
+	// "world" is wrapped, the second row starts with the annotated "d".
+	tv.Draw(sc)
+	for _, position := range [][2]int{{6, 0}, {0, 1}, {0, 2}} {
+		_, _, style, _ := sc.GetContent(position[0], position[1])
+		if _, _, attributes := style.Decompose(); attributes&tcell.AttrUnderline == 0 {
+			t.Errorf("failed to underline annotation at %v", position)
+		}
+	}
+	_, _, style, _ := sc.GetContent(5, 0)
+	if _, _, attributes := style.Decompose(); attributes&tcell.AttrUnderline != 0 {
+		t.Errorf("failed to leave text outside annotations alone: got %#v", style)
+	}
+
+	// Cycle through the annotations.
+	var selected []*TextAnnotation
+	tv.SetAnnotationSelectedFunc(func(annotation *TextAnnotation) {
+		selected = append(selected, annotation)
+	})
+	handler := tv.InputHandler()
+	handler(tcell.NewEventKey(tcell.KeyF8, 0, tcell.ModNone), func(Primitive) {})
+	handler(tcell.NewEventKey(tcell.KeyF8, 0, tcell.ModNone), func(Primitive) {})
+	if current := tv.GetCurrentAnnotation(); current != unused || len(selected) != 2 || selected[0] != typo {
+		t.Errorf("failed to move to next annotation: got %v", current)
+	}
+	tv.Draw(sc)
+	if got := row(3); got != " unused   " {
+		t.Errorf("failed to show annotation message: expected %q, got %q", " unused   ", got)
+	}
+	handler(tcell.NewEventKey(tcell.KeyF8, 0, tcell.ModShift), func(Primitive) {})
+	if current := tv.GetCurrentAnnotation(); current != typo {
+		t.Errorf("failed to move to previous annotation: got %v", current)
+	}
+
+	// Removing a source keeps the others.
+	tv.SetAnnotations("spelling", nil)
+	if annotations := tv.GetAnnotations(); len(annotations) != 1 || tv.GetCurrentAnnotation() != nil {
+		t.Errorf("failed to remove annotations: got %v", annotations)
+	}
+}
@@ -3,7 +3,9 @@ package nuview
 import (
 	"bytes"
 	"regexp"
+	"strconv"
 	"sync"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -21,6 +23,7 @@ var (
 var (
 	openColorRegex  = regexp.MustCompile(`\[([a-zA-Z]*|#[0-9a-zA-Z]*)$`)
 	openRegionRegex = regexp.MustCompile(`\["[a-zA-Z0-9_,;: \-\.]*"?$`)
+	openEscapeRegex = regexp.MustCompile(`\x1b(\[[0-9;?]*)?$`)
 )
 
 // textViewIndex contains information about each line displayed in the text
@@ -36,6 +39,12 @@ type textViewIndex struct {
 	Region          []byte // The starting region ID.
 }
 
+// textViewPosition is a position in the text view's index: a row and the
+// screen column within the row's text (without tags).
+type textViewPosition struct {
+	row, column int
+}
+
 // textViewRegion contains information about a region.
 type textViewRegion struct {
 	// The region ID.
@@ -46,6 +55,24 @@ type textViewRegion struct {
 	FromX, FromY, ToX, ToY int
 }
 
+// textViewBatch collects the writes to a text view which are applied to its
+// buffer at once (see TextView.SetWriteBatching).
+type textViewBatch struct {
+	// The time after which the collected writes are applied (0 = disabled).
+	interval time.Duration
+
+	// The maximum number of collected bytes (0 = unlimited).
+	limit int
+
+	// The collected bytes.
+	pending []byte
+
+	// The timer which applies the collected writes, nil if none are scheduled.
+	timer *time.Timer
+
+	sync.Mutex
+}
+
 // TextView is a box which displays text. It implements the io.Writer interface
 // so you can stream text to it. This does not trigger a redraw automatically
 // but if a handler is installed via SetChangedFunc(), you can cause it to be
@@ -64,6 +91,13 @@ type textViewRegion struct {
 //   - G, end: Move to the bottom.
 //   - Ctrl-F, page down: Move down by one page.
 //   - Ctrl-B, page up: Move up by one page.
+//   - w, b: Move right or left to the next word start in the visible lines
+//     (not in wrap mode).
+//   - }, ], {, [: Move to the start of the next or previous paragraph.
+//   - z t, z z, z b: Scroll the current line to the top, middle or bottom. This
+//     is the first highlighted line or, if there is none, the middle line.
+//
+// These keys can be changed in Keys.
 //
 // If the text is not scrollable, any text above the top visible line is
 // discarded.
@@ -97,6 +131,13 @@ type textViewRegion struct {
 //
 // The ScrollToHighlight() function can be used to jump to the currently
 // highlighted region once when the text view is drawn the next time.
+//
+// # Progress Lines
+//
+// Command line tools often redraw progress bars in place with carriage returns
+// and cursor movements. If line rewriting is enabled via SetLineRewriting(),
+// such output updates the lines written before instead of adding a line for
+// each update.
 type TextView struct {
 	*Box
 
@@ -162,11 +203,14 @@ type TextView struct {
 	// navigated when the text is longer than what fits into the box.
 	scrollable bool
 
-	// Visibility of the scroll bar.
-	scrollBarVisibility ScrollBarVisibility
+	// The vertical scroll bar.
+	scrollBar *ScrollBar
+
+	// Animates scrolling with the mouse wheel and by pages.
+	smoothScroll smoothScroll
 
-	// The scroll bar color.
-	scrollBarColor tcell.Color
+	// Reports changes of the line offset, see SetScrollChangedFunc().
+	scrollChanged scrollNotifier
 
 	// If set to true, lines that are longer than the available width are wrapped
 	// onto the next line. If set to false, any characters beyond the available
@@ -216,6 +260,40 @@ type TextView struct {
 	// highlighted.
 	highlighted func(added, removed, remaining []string)
 
+	// Writes which were not applied to the buffer yet.
+	batch textViewBatch
+
+	// If set to true, carriage returns and cursor movements rewrite lines of
+	// the buffer.
+	rewriteLines bool
+
+	// The number of lines between the line being written and the last line of
+	// the buffer, and whether or not the next text replaces that line.
+	cursorUp      int
+	cursorReplace bool
+
+	// The loading spinner and the error message.
+	contentState contentState
+
+	// The start and the end of the mouse selection, whether the mouse button
+	// is still held down, and whether any text is selected.
+	selectionAnchor, selectionEnd textViewPosition
+	selecting, selected           bool
+
+	// The screen position of the first row of the index and the width of the
+	// text as of the last call to Draw().
+	drawnX, drawnY, drawnWidth int
+
+	// The annotations mapped to the sources which attached them, all
+	// annotations sorted by position, and the annotation the user moved to.
+	annotations       map[string][]*TextAnnotation
+	sortedAnnotations []*TextAnnotation
+	currentAnnotation *TextAnnotation
+
+	// An optional function which is called when the user moves to an
+	// annotation.
+	annotationSelected func(annotation *TextAnnotation)
+
 	sync.RWMutex
 }
 
@@ -227,8 +305,7 @@ func NewTextView() *TextView {
 		lineOffset:          -1,
 		reindex:             true,
 		scrollable:          true,
-		scrollBarVisibility: ScrollBarAuto,
-		scrollBarColor:      Styles.ScrollBarColor,
+		scrollBar:           NewScrollBar(),
 		align:               AlignLeft,
 		valign:              AlignTop,
 		wrap:                true,
@@ -253,18 +330,35 @@ func (t *TextView) SetScrollable(scrollable bool) {
 
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (t *TextView) SetScrollBarVisibility(visibility ScrollBarVisibility) {
-	t.Lock()
-	defer t.Unlock()
-
-	t.scrollBarVisibility = visibility
+	t.scrollBar.SetVisibility(visibility)
 }
 
 // SetScrollBarColor sets the color of the scroll bar.
 func (t *TextView) SetScrollBarColor(color tcell.Color) {
+	t.scrollBar.SetColor(color)
+}
+
+// GetScrollBar returns the vertical scroll bar of the text view.
+func (t *TextView) GetScrollBar() *ScrollBar {
+	return t.scrollBar
+}
+
+// SetSmoothScrolling sets whether or not scrolling with the mouse wheel and
+// by pages is animated over a few frames instead of jumping to the new
+// position. See also DisableSmoothScrolling.
+func (t *TextView) SetSmoothScrolling(enable bool) {
 	t.Lock()
 	defer t.Unlock()
 
-	t.scrollBarColor = color
+	t.smoothScroll.enabled = enable
+}
+
+// GetSmoothScrolling returns whether or not smooth scrolling is enabled.
+func (t *TextView) GetSmoothScrolling() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.smoothScroll.enabled
 }
 
 // SetWrap sets the flag that, if true, leads to lines that are longer than the
@@ -384,6 +478,44 @@ func (t *TextView) GetText(stripTags bool) string {
 	return string(t.GetBytes(stripTags))
 }
 
+// SetLoading sets whether or not the text of the text view is being loaded.
+// While it is, a spinner (see SpinnerFrames) is drawn in the center of the
+// text view in place of its text.
+func (t *TextView) SetLoading(loading bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setLoading(loading)
+}
+
+// GetLoading returns whether or not the text of the text view is being loaded.
+func (t *TextView) GetLoading() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.loading
+}
+
+// SetError sets an error message which is drawn centered in place of the text
+// of the text view, e.g. when loading it failed. Provide an empty string to
+// draw the text again, which is the default. The message is not interpreted
+// for style tags.
+func (t *TextView) SetError(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setError(text)
+}
+
+// GetError returns the error message drawn in place of the text of the text
+// view, see SetError().
+func (t *TextView) GetError() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.err
+}
+
 // GetBufferSize returns the number of lines and the length of the longest line
 // in the text buffer. The screen size of the widget is available via GetRect.
 func (t *TextView) GetBufferSize() (rows int, maxLen int) {
@@ -393,6 +525,47 @@ func (t *TextView) GetBufferSize() (rows int, maxLen int) {
 	return len(t.buffer), t.longestLine
 }
 
+// PreferredWidth returns the screen width of the longest line of text plus the
+// border and padding, limited to the provided maximum width.
+func (t *TextView) PreferredWidth(maxWidth int) int {
+	t.RLock()
+	defer t.RUnlock()
+
+	var width int
+	for _, line := range t.buffer {
+		_, _, _, _, _, strippedStr, _ := decomposeText(line, t.dynamicColors, t.regions)
+		width = max(width, runewidth.StringWidth(string(strippedStr)))
+	}
+	if t.scrollBar.GetVisibility() == ScrollBarAlways {
+		width++
+	}
+	frameWidth, _ := t.frameSize()
+	width += frameWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
+
+// PreferredHeight returns the number of lines of text plus the border and
+// padding if the text view has the provided width. Lines are wrapped if
+// wrapping is enabled.
+func (t *TextView) PreferredHeight(width int) int {
+	t.Lock()
+	defer t.Unlock()
+
+	frameWidth, frameHeight := t.frameSize()
+	if !t.wrap {
+		return len(t.buffer) + frameHeight
+	}
+	width -= frameWidth
+	if t.scrollBar.GetVisibility() == ScrollBarAlways {
+		width--
+	}
+	t.reindexBuffer(max(width, 1))
+	return len(t.index) + frameHeight
+}
+
 // SetDynamicColors sets the flag that allows the text color to be changed
 // dynamically. See class description for details.
 func (t *TextView) SetDynamicColors(dynamic bool) {
@@ -405,6 +578,46 @@ func (t *TextView) SetDynamicColors(dynamic bool) {
 	t.dynamicColors = dynamic
 }
 
+// SetLineRewriting sets the flag that allows text written to the text view to
+// update lines in place, e.g. the progress bars of command line tools such as
+// curl or pip. The following control characters and escape sequences are then
+// interpreted:
+//
+//   - "\r": The following text replaces the current line.
+//   - ESC[nA, ESC[nF: Move up n lines (default 1). The following text replaces
+//     that line.
+//   - ESC[nB, ESC[nE: Move down n lines, at most to the last line. The
+//     following text replaces that line.
+//   - ESC[K, ESC[2K: Erase the current line.
+//
+// A "\n" moves to the next line. Lines below the last line are added to the
+// buffer. Other escape sequences are removed. Text is never partially
+// overwritten, so shorter updates don't leave remnants of longer ones.
+//
+// Use ANSIWriter() to also translate ANSI colors. It passes the sequences
+// above through to a text view with line rewriting enabled.
+func (t *TextView) SetLineRewriting(rewrite bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.rewriteLines = rewrite
+	t.cursorUp, t.cursorReplace = 0, false
+}
+
+// GetLineRewriting returns whether or not line rewriting is enabled.
+func (t *TextView) GetLineRewriting() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.rewriteLines
+}
+
+// rewritesLines is called by ANSIWriter() to determine whether or not cursor
+// movements are passed through.
+func (t *TextView) rewritesLines() bool {
+	return t.GetLineRewriting()
+}
+
 // SetRegions sets the flag that allows to define regions in the text. See class
 // description for details.
 func (t *TextView) SetRegions(regions bool) {
@@ -440,6 +653,20 @@ func (t *TextView) SetChangedFunc(handler func()) {
 	t.changed = handler
 }
 
+// SetScrollChangedFunc sets a handler which is called when the text view is
+// drawn scrolled to a different line or with a different number of lines, e.g.
+// to keep a minimap or a "new lines below" badge in sync. It receives the index
+// of the first visible line and the total number of lines (after wrapping). It
+// is called during drawing, after the text view was unlocked, so changes to
+// other primitives are drawn when the screen is drawn next, see
+// Application.QueueUpdateDraw(). Provide nil to remove the handler.
+func (t *TextView) SetScrollChangedFunc(handler func(firstVisible, total int)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.scrollChanged.set(handler)
+}
+
 // SetDoneFunc sets a handler which is called when the user presses on the
 // following keys: Escape, Enter, Tab, Backtab. The key is passed to the
 // handler.
@@ -469,6 +696,7 @@ func (t *TextView) clipBuffer() {
 	lenbuf := len(t.buffer)
 	if lenbuf > t.maxLines {
 		t.buffer = t.buffer[lenbuf-t.maxLines:]
+		t.cursorUp = min(t.cursorUp, t.maxLines-1)
 	}
 }
 
@@ -529,6 +757,54 @@ func (t *TextView) GetScrollOffset() (row, column int) {
 	return t.lineOffset, t.columnOffset
 }
 
+// Search scrolls to the next line below the top visible line (or the previous
+// line above it if backwards is true) which contains the query, ignoring case.
+// Returns whether such a line was found. This implements the Searchable
+// interface.
+func (t *TextView) Search(query string, backwards bool) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.scrollable || len(t.buffer) == 0 {
+		return false
+	}
+
+	// Determine the buffer line at the top of the view.
+	current := t.lineOffset
+	if t.index != nil {
+		if current >= 0 && current < len(t.index) {
+			current = t.index[current].Line
+		} else if current >= len(t.index) && len(t.index) > 0 {
+			current = t.index[len(t.index)-1].Line
+		}
+	}
+	if current < 0 {
+		current = 0
+	}
+
+	line := searchIndex(len(t.buffer), current, backwards, func(index int) bool {
+		return searchMatches(StripTags(t.buffer[index], t.dynamicColors, t.regions), query)
+	})
+	if line < 0 {
+		return false
+	}
+
+	// Translate the buffer line into a row of the index.
+	row := line
+	if t.index != nil {
+		for index, info := range t.index {
+			if info.Line == line {
+				row = index
+				break
+			}
+		}
+	}
+	t.lineOffset = row
+	t.columnOffset = 0
+	t.trackEnd = false
+	return true
+}
+
 // Clear removes all text from the buffer.
 func (t *TextView) Clear() {
 	t.Lock()
@@ -538,13 +814,196 @@ func (t *TextView) Clear() {
 }
 
 func (t *TextView) clear() {
+	t.batch.Lock()
+	t.batch.pending = nil
+	t.batch.Unlock()
+
 	t.buffer = nil
 	t.recentBytes = nil
+	t.cursorUp, t.cursorReplace = 0, false
+	t.selecting, t.selected = false, false
 	if t.reindex {
 		t.index = nil
 	}
 }
 
+// GetSelectedText returns the text (without any tags) that is currently
+// selected with the mouse, or an empty string if no text is selected. Text
+// is selected by dragging the mouse over it, a word by double-clicking it, and
+// a line by triple-clicking it. If the clipboard manager of the application is
+// set to copy on select, the selected text is copied when the selection is
+// complete, see Application.SetClipboard().
+func (t *TextView) GetSelectedText() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	if !t.selected {
+		return ""
+	}
+	return t.selectedText()
+}
+
+// ClearSelection removes the mouse selection.
+func (t *TextView) ClearSelection() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.selecting, t.selected = false, false
+}
+
+// textPosition returns the position in the index which corresponds to the
+// provided screen coordinates, as of the last call to Draw(). Coordinates
+// outside of the text are moved to the nearest position. The text view must
+// be locked.
+func (t *TextView) textPosition(x, y int) textViewPosition {
+	if len(t.index) == 0 {
+		return textViewPosition{}
+	}
+	row := y - t.drawnY
+	if row < 0 {
+		return textViewPosition{}
+	}
+	if row >= len(t.index) {
+		return textViewPosition{row: len(t.index) - 1, column: t.index[len(t.index)-1].Width}
+	}
+
+	// Calculate the position of the line, as in Draw().
+	var start int
+	if t.align == AlignLeft {
+		start = -t.columnOffset
+	} else if t.align == AlignRight {
+		start = t.drawnWidth - t.index[row].Width - t.columnOffset
+	} else { // AlignCenter.
+		start = (t.drawnWidth-t.index[row].Width)/2 - t.columnOffset
+	}
+	return textViewPosition{row: row, column: max(x-t.drawnX-start, 0)}
+}
+
+// inSelection returns whether or not the character at the provided row and
+// screen column (with the provided screen width) is part of the mouse
+// selection. The text view must be locked.
+func (t *TextView) inSelection(row, column, width int) bool {
+	if !t.selected {
+		return false
+	}
+	from, to := t.selectionAnchor, t.selectionEnd
+	if to.row < from.row || to.row == from.row && to.column < from.column {
+		from, to = to, from
+	}
+	if row < from.row || row > to.row {
+		return false
+	}
+	if row == from.row && column+width <= from.column {
+		return false
+	}
+	return row != to.row || column <= to.column
+}
+
+// selectWord selects the word (letters, digits and underscores) at the
+// provided position, even if it was wrapped onto several rows. Other
+// characters are selected on their own. Nothing is selected if there is no
+// character at the position. The text view must be locked.
+func (t *TextView) selectWord(position textViewPosition) {
+	t.selecting, t.selected = false, false
+	if position.row >= len(t.index) {
+		return
+	}
+	type character struct {
+		position textViewPosition
+		word     bool
+	}
+	var characters []character
+	clicked := -1
+	from, to := t.lineRows(position.row)
+	for row := from; row <= to; row++ {
+		iterateString(string(t.rowText(row)), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+			if row == position.row && position.column >= screenPos && position.column < screenPos+screenWidth {
+				clicked = len(characters)
+			}
+			characters = append(characters, character{
+				position: textViewPosition{row: row, column: screenPos},
+				word:     main == '_' || unicode.IsLetter(main) || unicode.IsDigit(main),
+			})
+			return false
+		})
+	}
+	if clicked < 0 {
+		return
+	}
+
+	first, last := clicked, clicked
+	if characters[clicked].word {
+		for first > 0 && characters[first-1].word {
+			first--
+		}
+		for last < len(characters)-1 && characters[last+1].word {
+			last++
+		}
+	}
+	t.selectionAnchor, t.selectionEnd = characters[first].position, characters[last].position
+	t.selected = true
+}
+
+// selectLine selects the entire line at the provided position, including all
+// of its rows if it was wrapped. The text view must be locked.
+func (t *TextView) selectLine(position textViewPosition) {
+	t.selecting, t.selected = false, false
+	if position.row >= len(t.index) {
+		return
+	}
+	from, to := t.lineRows(position.row)
+	t.selectionAnchor = textViewPosition{row: from}
+	t.selectionEnd = textViewPosition{row: to, column: t.index[to].Width}
+	t.selected = true
+}
+
+// lineRows returns the first and the last row of the index which belong to
+// the same line as the provided row. The text view must be locked.
+func (t *TextView) lineRows(row int) (from, to int) {
+	from, to = row, row
+	for from > 0 && t.index[from-1].Line == t.index[row].Line {
+		from--
+	}
+	for to < len(t.index)-1 && t.index[to+1].Line == t.index[row].Line {
+		to++
+	}
+	return
+}
+
+// copiedSelection returns the selected text if the clipboard manager of the
+// provided application copies on select, an empty string otherwise. The text
+// view must be locked.
+func (t *TextView) copiedSelection(app *Application) string {
+	if !t.selected || !app.GetClipboard().GetCopyOnSelect() {
+		return ""
+	}
+	return t.selectedText()
+}
+
+// selectedText returns the text of the mouse selection without any tags.
+// Rows which were wrapped are joined without a line break. The text view must
+// be locked.
+func (t *TextView) selectedText() string {
+	from, to := t.selectionAnchor.row, t.selectionEnd.row
+	if to < from {
+		from, to = to, from
+	}
+	var buffer bytes.Buffer
+	for row := from; row <= to && row < len(t.index); row++ {
+		if row > from && t.index[row].Line != t.index[row-1].Line {
+			buffer.WriteByte('\n')
+		}
+		text := string(t.rowText(row))
+		iterateString(text, func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+			if t.inSelection(row, screenPos, screenWidth) {
+				buffer.WriteString(text[textPos : textPos+textWidth])
+			}
+			return false
+		})
+	}
+	return buffer.String()
+}
+
 // Highlight specifies which regions should be highlighted. If highlight
 // toggling is set to true (see SetToggleHighlights()), the highlight of the
 // provided regions is toggled (highlighted regions are un-highlighted and vice
@@ -755,8 +1214,15 @@ func (t *TextView) HasFocus() bool {
 
 // Write lets us implement the io.Writer interface. Tab characters will be
 // replaced with TabSize space characters. A "\n" or "\r\n" will be interpreted
-// as a new line.
+// as a new line. See SetLineRewriting() to update lines in place.
+//
+// If write batching is enabled (see SetWriteBatching()), the text is not
+// added to the buffer immediately.
 func (t *TextView) Write(p []byte) (n int, err error) {
+	if t.batchWrite(p) {
+		return len(p), nil
+	}
+
 	t.Lock()
 	changed := t.changed
 	if changed != nil {
@@ -797,17 +1263,30 @@ func (t *TextView) write(p []byte) (n int, err error) {
 		}
 	}
 
+	// If we have a trailing incomplete escape sequence, exclude it.
+	if t.rewriteLines {
+		location := openEscapeRegex.FindIndex(newBytes)
+		if location != nil {
+			t.recentBytes = append(append([]byte(nil), newBytes[location[0]:]...), t.recentBytes...)
+			newBytes = newBytes[:location[0]]
+		}
+	}
+
 	// Transform the new bytes into strings.
 	newBytes = bytes.Replace(newBytes, []byte{'\t'}, bytes.Repeat([]byte{' '}, TabSize), -1)
-	for index, line := range bytes.Split(newBytes, []byte("\n")) {
-		if index == 0 {
-			if len(t.buffer) == 0 {
-				t.buffer = [][]byte{line}
+	if t.rewriteLines {
+		t.writeLines(newBytes)
+	} else {
+		for index, line := range bytes.Split(newBytes, []byte("\n")) {
+			if index == 0 {
+				if len(t.buffer) == 0 {
+					t.buffer = [][]byte{line}
+				} else {
+					t.buffer[len(t.buffer)-1] = append(t.buffer[len(t.buffer)-1], line...)
+				}
 			} else {
-				t.buffer[len(t.buffer)-1] = append(t.buffer[len(t.buffer)-1], line...)
+				t.buffer = append(t.buffer, line)
 			}
-		} else {
-			t.buffer = append(t.buffer, line)
 		}
 	}
 
@@ -821,6 +1300,170 @@ func (t *TextView) write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// writeLines adds the provided text to the buffer, interpreting carriage
+// returns and cursor movements (see SetLineRewriting()).
+func (t *TextView) writeLines(text []byte) {
+	if len(t.buffer) == 0 {
+		t.buffer = [][]byte{nil}
+	}
+	for len(text) > 0 {
+		line := len(t.buffer) - 1 - t.cursorUp
+
+		// Add the text up to the next control character.
+		end := bytes.IndexAny(text, "\r\n\x1b")
+		if end < 0 {
+			end = len(text)
+		}
+		if end > 0 {
+			if t.cursorReplace {
+				t.buffer[line] = append([]byte(nil), text[:end]...)
+				t.cursorReplace = false
+			} else {
+				t.buffer[line] = append(t.buffer[line], text[:end]...)
+			}
+			text = text[end:]
+			continue
+		}
+
+		switch text[0] {
+		case '\r':
+			t.cursorReplace = true
+			text = text[1:]
+		case '\n':
+			if t.cursorUp > 0 {
+				t.cursorUp--
+				t.cursorReplace = true
+			} else {
+				t.buffer = append(t.buffer, nil)
+				t.cursorReplace = false
+			}
+			text = text[1:]
+		default: // Escape sequence.
+			if len(text) < 2 || text[1] != '[' {
+				text = text[min(len(text), 2):] // Not a control sequence, ignore.
+				break
+			}
+			end := 2
+			for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+				end++
+			}
+			if end == len(text) {
+				return // Malformed sequence.
+			}
+			parameter := string(text[2:end])
+			count, _ := strconv.Atoi(parameter)
+			count = max(count, 1)
+			switch text[end] {
+			case 'A', 'F': // Cursor up.
+				t.cursorUp = min(t.cursorUp+count, len(t.buffer)-1)
+				t.cursorReplace = true
+			case 'B', 'E': // Cursor down.
+				t.cursorUp = max(t.cursorUp-count, 0)
+				t.cursorReplace = true
+			case 'K': // Erase in line.
+				if parameter == "2" || t.cursorReplace && parameter != "1" {
+					t.buffer[line] = nil
+					t.cursorReplace = false
+				}
+			}
+			text = text[end+1:]
+		}
+	}
+}
+
+// SetWriteBatching enables the batching of writes, which is useful when text
+// is written to the text view at a high rate, e.g. by many goroutines. Instead
+// of locking the text view and calling the "changed" handler for each write
+// (see SetChangedFunc()), writes are collected and added to the buffer at most
+// once per interval, followed by a single call of the "changed" handler. An
+// interval which matches the application's frame rate, e.g. 16 milliseconds,
+// coalesces the redraws caused by the writes into one per frame. Collected
+// writes are also added when the text view is drawn.
+//
+// If maxPending is greater than 0, at most this many bytes are collected. When
+// more are written, the oldest collected lines are dropped so writers are
+// never blocked by a slow consumer. Otherwise, all writes are kept.
+//
+// An interval of 0 disables batching, which is the default. Collected writes
+// are added to the buffer immediately in this case. Call Flush() to add
+// collected writes to the buffer, e.g. before calling GetText().
+func (t *TextView) SetWriteBatching(interval time.Duration, maxPending int) {
+	t.batch.Lock()
+	t.batch.interval = interval
+	t.batch.limit = maxPending
+	t.batch.Unlock()
+
+	if interval <= 0 {
+		t.Flush()
+	}
+}
+
+// Flush adds all collected writes to the buffer (see SetWriteBatching()). The
+// "changed" handler is called if there were any.
+func (t *TextView) Flush() {
+	t.Lock()
+	flushed := t.flush()
+	changed := t.changed
+	t.Unlock()
+
+	if flushed && changed != nil {
+		changed()
+	}
+}
+
+// flush adds all collected writes to the buffer. It returns whether or not
+// there were any. The text view must be locked.
+func (t *TextView) flush() bool {
+	t.batch.Lock()
+	pending := t.batch.pending
+	t.batch.pending = nil
+	if t.batch.timer != nil {
+		t.batch.timer.Stop()
+		t.batch.timer = nil
+	}
+	t.batch.Unlock()
+
+	if len(pending) == 0 {
+		return false
+	}
+	t.write(pending)
+	return true
+}
+
+// batchWrite collects the provided bytes if write batching is enabled and
+// schedules them to be added to the buffer. It returns false if write batching
+// is disabled.
+func (t *TextView) batchWrite(p []byte) bool {
+	t.batch.Lock()
+	defer t.batch.Unlock()
+
+	if t.batch.interval <= 0 {
+		return false
+	}
+
+	t.batch.pending = append(t.batch.pending, p...)
+	if limit := t.batch.limit; limit > 0 && len(t.batch.pending) > limit {
+		// Drop the oldest lines. If the newest line is too long, drop its
+		// oldest characters.
+		pending := t.batch.pending
+		excess := len(pending) - limit
+		if index := bytes.IndexByte(pending[excess:], '\n'); index >= 0 {
+			pending = pending[excess+index+1:]
+		} else {
+			pending = pending[excess:]
+			for len(pending) > 0 && !utf8.RuneStart(pending[0]) {
+				pending = pending[1:]
+			}
+		}
+		t.batch.pending = append([]byte(nil), pending...)
+	}
+
+	if t.batch.timer == nil {
+		t.batch.timer = time.AfterFunc(t.batch.interval, t.Flush)
+	}
+	return true
+}
+
 // SetWrapWidth set the maximum width of lines when wrapping is enabled.
 // When set to 0 the width of the TextView is used.
 func (t *TextView) SetWrapWidth(width int) {
@@ -1033,9 +1676,20 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	t.Box.Draw(screen)
 
+	var notifyScroll func()
+	defer func() {
+		if notifyScroll != nil {
+			notifyScroll()
+		}
+	}()
+
 	t.Lock()
 	defer t.Unlock()
 
+	// Add collected writes. The text view is being drawn, so the "changed"
+	// handler is not called.
+	t.flush()
+
 	// Get the available size.
 	x, y, width, height := t.GetInnerRect()
 	if height == 0 {
@@ -1043,12 +1697,17 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.pageSize = height
 
+	// Draw the loading spinner or the error message instead of the text.
+	if t.contentState.draw(screen, x, y, width, height, false, t.backgroundFill()) {
+		return
+	}
+
 	if t.index == nil || width != t.lastWidth || height != t.lastHeight {
 		t.reindexBuffer(width)
 	}
 	t.lastWidth, t.lastHeight = width, height
 
-	showVerticalScrollBar := t.scrollBarVisibility == ScrollBarAlways || (t.scrollBarVisibility == ScrollBarAuto && len(t.index) > height)
+	showVerticalScrollBar := t.scrollBar.IsVisible(len(t.index), height)
 	if showVerticalScrollBar {
 		width-- // Subtract space for scroll bar.
 	}
@@ -1060,22 +1719,14 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 	// Draw scroll bar last.
 	defer func() {
-		if !showVerticalScrollBar {
-			return
-		}
+		items, offset := len(t.index), t.lineOffset
 
-		items := len(t.index)
-		cursor := int(float64(len(t.index)) * (float64(t.lineOffset) / float64(len(t.index)-height)))
-
-		// Render cursor at the bottom when tracking end
-		if t.trackEnd && items <= height {
-			items = height + 1
-			cursor = height
+		// Render the handle at the bottom when tracking end.
+		if showVerticalScrollBar && t.trackEnd && items <= height {
+			items, offset = height+1, 1
 		}
 
-		for printed := 0; printed < height; printed++ {
-			RenderScrollBar(screen, t.scrollBarVisibility, x+width, y+printed, height, items, cursor, printed, t.hasFocus, t.scrollBarColor)
-		}
+		t.scrollBar.Draw(screen, x+width, y, height, items, height, offset, t.hasFocus)
 	}()
 
 	// If we don't have an index, there's nothing to draw.
@@ -1116,6 +1767,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	if t.lineOffset < 0 {
 		t.lineOffset = 0
 	}
+	notifyScroll = t.scrollChanged.update(t.lineOffset, len(t.index))
 
 	// Adjust column offset.
 	if t.align == AlignLeft {
@@ -1156,16 +1808,34 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
-	// Draw the buffer.
-	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundColor)
-	for line := t.lineOffset; line < len(t.index); line++ {
+	// Draw the buffer, starting at the (possibly animated) line offset.
+	lineOffset := t.smoothScroll.offset(t.lineOffset)
+	t.drawnX, t.drawnY, t.drawnWidth = x, y+verticalOffset-lineOffset, width
+	defaultStyle := tcell.StyleDefault.Foreground(t.textColor).Background(t.backgroundFill())
+
+	// The number of characters in the preceding rows of the current line, for
+	// annotations, and the screen position of the current annotation.
+	var characters int
+	if len(t.sortedAnnotations) > 0 && lineOffset < len(t.index) {
+		characters = t.rowCharacters(lineOffset)
+	}
+	tooltipX, tooltipY := -1, -1
+
+	for line := lineOffset; line < len(t.index); line++ {
 		// Are we done?
-		if line-t.lineOffset >= height {
+		if line-lineOffset >= height {
 			break
 		}
 
 		// Get the text for this line.
 		index := t.index[line]
+		var annotations []*TextAnnotation
+		if len(t.sortedAnnotations) > 0 {
+			if line > lineOffset && index.Line != t.index[line-1].Line {
+				characters = 0
+			}
+			annotations = t.lineAnnotations(index.Line)
+		}
 		text := t.buffer[index.Line][index.Pos:index.NextPos]
 		foregroundColor := index.ForegroundColor
 		backgroundColor := index.BackgroundColor
@@ -1175,14 +1845,14 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			if len(t.regionInfos) > 0 && !bytes.Equal(t.regionInfos[len(t.regionInfos)-1].ID, regionID) {
 				// End last region.
 				t.regionInfos[len(t.regionInfos)-1].ToX = x
-				t.regionInfos[len(t.regionInfos)-1].ToY = y + line - t.lineOffset
+				t.regionInfos[len(t.regionInfos)-1].ToY = y + line - lineOffset
 			}
 			if len(regionID) > 0 && (len(t.regionInfos) == 0 || !bytes.Equal(t.regionInfos[len(t.regionInfos)-1].ID, regionID)) {
 				// Start a new region.
 				t.regionInfos = append(t.regionInfos, &textViewRegion{
 					ID:    regionID,
 					FromX: x,
-					FromY: y + line - t.lineOffset,
+					FromY: y + line - lineOffset,
 					ToX:   -1,
 					ToY:   -1,
 				})
@@ -1206,12 +1876,18 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			posX = 0
 		}
 
-		drawAtY := y + line - t.lineOffset + verticalOffset
+		drawAtY := y + line - lineOffset + verticalOffset
 
 		// Print the line.
 		if drawAtY >= 0 {
-			var colorPos, regionPos, escapePos, tagOffset, skipped int
+			var colorPos, regionPos, escapePos, tagOffset, skipped, character int
 			iterateString(string(strippedText), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
+				var annotation *TextAnnotation
+				if len(annotations) > 0 {
+					annotation = t.annotationAt(annotations, characters+character)
+					character++
+				}
+
 				// Process tags.
 				for {
 					if colorPos < len(colorTags) && textPos+tagOffset >= colorTagIndices[colorPos][0] && textPos+tagOffset < colorTagIndices[colorPos][1] {
@@ -1224,7 +1900,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 						if len(regionID) > 0 && len(t.regionInfos) > 0 && bytes.Equal(t.regionInfos[len(t.regionInfos)-1].ID, regionID) {
 							// End last region.
 							t.regionInfos[len(t.regionInfos)-1].ToX = x + posX
-							t.regionInfos[len(t.regionInfos)-1].ToY = y + line - t.lineOffset
+							t.regionInfos[len(t.regionInfos)-1].ToY = y + line - lineOffset
 						}
 						regionID = regions[regionPos][1]
 						if len(regionID) > 0 {
@@ -1232,7 +1908,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 							t.regionInfos = append(t.regionInfos, &textViewRegion{
 								ID:    regionID,
 								FromX: x + posX,
-								FromY: y + line - t.lineOffset,
+								FromY: y + line - lineOffset,
 								ToX:   -1,
 								ToY:   -1,
 							})
@@ -1256,7 +1932,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 				style := overlayStyle(background, defaultStyle, foregroundColor, backgroundColor, attributes)
 
 				// Do we highlight this character?
-				var highlighted bool
+				highlighted := t.inSelection(line, screenPos, screenWidth)
 				if len(regionID) > 0 {
 					if _, ok := t.highlights[string(regionID)]; ok {
 						highlighted = true
@@ -1266,7 +1942,7 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					fg := t.highlightForeground
 					bg := t.highlightBackground
 					if fg == tcell.ColorDefault {
-						fg = Styles.PrimaryTextColor
+						fg = t.theme().PrimaryTextColor
 						if fg == tcell.ColorDefault {
 							fg = tcell.ColorWhite.TrueColor()
 						}
@@ -1284,9 +1960,19 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					style = style.Foreground(fg).Background(bg)
 				}
 
-				// Skip to the right.
+				// Underline annotated characters.
+				if annotation != nil {
+					style = style.Underline(tcell.UnderlineStyleCurly, t.annotationColor(annotation.Severity))
+				}
+
+				// Skip to the right. If a wide character is cut in half, its
+				// visible half is left blank.
 				if !t.wrap && skipped < skip {
 					skipped += screenWidth
+					for ; skipped > skip && posX < width; skipped-- {
+						screen.SetContent(x+posX, drawAtY, ' ', nil, style)
+						posX++
+					}
 					return false
 				}
 
@@ -1295,6 +1981,11 @@ func (t *TextView) Draw(screen tcell.Screen) {
 					return true
 				}
 
+				// Remember where the current annotation starts.
+				if annotation != nil && annotation == t.currentAnnotation && tooltipY < 0 {
+					tooltipX, tooltipY = x+posX, drawAtY
+				}
+
 				// Draw the character.
 				for offset := screenWidth - 1; offset >= 0; offset-- {
 					if offset == 0 {
@@ -1309,6 +2000,23 @@ func (t *TextView) Draw(screen tcell.Screen) {
 				return false
 			})
 		}
+		if len(annotations) > 0 {
+			characters += uniseg.GraphemeClusterCount(string(t.rowText(line)))
+		}
+	}
+
+	// Show the message of the current annotation below it (or above it in the
+	// last row).
+	if tooltipY >= 0 && t.currentAnnotation.Message != "" {
+		tooltipY++
+		if tooltipY >= y+height {
+			tooltipY -= 2
+		}
+		if tooltipY >= y {
+			style := tcell.StyleDefault.Foreground(t.theme().InverseTextColor).Background(t.annotationColor(t.currentAnnotation.Severity))
+			message := Escape(" " + t.currentAnnotation.Message + " ")
+			printWithStyle(screen, message, tooltipX, tooltipY, 0, x+width-tooltipX, AlignLeft, style, false, 0)
+		}
 	}
 
 	// If this view is not scrollable, we'll purge the buffer of lines that have
@@ -1329,13 +2037,23 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 	return t.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
 		key := event.Key()
 
-		if HitShortcut(event, Keys.Cancel, Keys.Select, Keys.Select2, Keys.MovePreviousField, Keys.MoveNextField) {
+		if t.hitShortcut(event, t.keys().Cancel, t.keys().Select, t.keys().Select2, t.keys().MovePreviousField, t.keys().MoveNextField) {
 			if t.done != nil {
 				t.done(key)
 			}
 			return
 		}
 
+		if t.hitShortcut(event, t.keys().NextAnnotation, t.keys().PreviousAnnotation) {
+			t.Lock()
+			selected := t.moveAnnotation(t.hitShortcut(event, t.keys().NextAnnotation))
+			t.Unlock()
+			if selected != nil {
+				selected()
+			}
+			return
+		}
+
 		t.Lock()
 		defer t.Unlock()
 
@@ -1343,52 +2061,225 @@ func (t *TextView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 			return
 		}
 
-		if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		if t.hitShortcut(event, t.keys().ScrollViewTop) {
+			t.scrollCurrentLine(0)
+		} else if t.hitShortcut(event, t.keys().ScrollViewCenter) {
+			t.scrollCurrentLine(t.pageSize / 2)
+		} else if t.hitShortcut(event, t.keys().ScrollViewBottom) {
+			t.scrollCurrentLine(t.pageSize - 1)
+		} else if t.hitShortcut(event, t.keys().MoveWordLeft) {
+			t.moveWord(false)
+		} else if t.hitShortcut(event, t.keys().MoveWordRight) {
+			t.moveWord(true)
+		} else if t.hitShortcut(event, t.keys().MovePreviousParagraph) {
+			t.moveParagraph(false)
+		} else if t.hitShortcut(event, t.keys().MoveNextParagraph) {
+			t.moveParagraph(true)
+		} else if t.hitShortcut(event, t.keys().MoveFirst, t.keys().MoveFirst2) {
 			t.trackEnd = false
 			t.lineOffset = 0
 			t.columnOffset = 0
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if t.hitShortcut(event, t.keys().MoveLast, t.keys().MoveLast2) {
 			t.trackEnd = true
 			t.columnOffset = 0
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+		} else if t.hitShortcut(event, t.keys().MoveUp, t.keys().MoveUp2) {
 			t.trackEnd = false
 			t.lineOffset--
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+		} else if t.hitShortcut(event, t.keys().MoveDown, t.keys().MoveDown2) {
 			t.lineOffset++
-		} else if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+		} else if t.hitShortcut(event, t.keys().MoveLeft, t.keys().MoveLeft2) {
 			t.columnOffset--
-		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+		} else if t.hitShortcut(event, t.keys().MoveRight, t.keys().MoveRight2) {
 			t.columnOffset++
-		} else if HitShortcut(event, Keys.MovePreviousPage) {
+		} else if t.hitShortcut(event, t.keys().MovePreviousPage) {
 			t.trackEnd = false
 			t.lineOffset -= t.pageSize
-		} else if HitShortcut(event, Keys.MoveNextPage) {
+			t.smoothScroll.animate()
+		} else if t.hitShortcut(event, t.keys().MoveNextPage) {
 			t.lineOffset += t.pageSize
+			t.smoothScroll.animate()
 		}
 	})
 }
 
+// rowText returns the text of a row of the index without any tags. The text
+// view must be locked.
+func (t *TextView) rowText(row int) []byte {
+	info := t.index[row]
+	return StripTags(t.buffer[info.Line][info.Pos:info.NextPos], t.dynamicColors, t.regions)
+}
+
+// moveWord scrolls horizontally to the start of the next (or previous) word in
+// the visible lines. It has no effect in wrap mode. The text view must be
+// locked.
+func (t *TextView) moveWord(forward bool) {
+	t.reindexBuffer(t.indexWidth)
+	if t.wrap || t.align != AlignLeft || t.index == nil {
+		return
+	}
+
+	target := -1
+	if !forward {
+		target = 0
+	}
+	for row := max(t.lineOffset, 0); row < len(t.index) && row < t.lineOffset+t.pageSize; row++ {
+		var x int
+		space := true
+		for _, r := range string(t.rowText(row)) {
+			if space && !unicode.IsSpace(r) {
+				if forward && x > t.columnOffset && (target < 0 || x < target) {
+					target = x
+				} else if !forward && x < t.columnOffset && x > target {
+					target = x
+				}
+			}
+			space = unicode.IsSpace(r)
+			x += runewidth.RuneWidth(r)
+		}
+	}
+	if target >= 0 {
+		t.columnOffset = target
+	}
+}
+
+// moveParagraph scrolls to the start of the next (or previous) paragraph, i.e.
+// the next (or previous) non-empty line following an empty line. The text view
+// must be locked.
+func (t *TextView) moveParagraph(forward bool) {
+	t.reindexBuffer(t.indexWidth)
+	if t.index == nil {
+		return
+	}
+	blank := func(row int) bool {
+		return len(bytes.TrimSpace(t.rowText(row))) == 0
+	}
+	start := func(row int) bool {
+		return !blank(row) && (row == 0 || blank(row-1))
+	}
+
+	row := min(max(t.lineOffset, 0), len(t.index)-1)
+	if forward {
+		for row++; row < len(t.index) && !start(row); row++ {
+		}
+		if row >= len(t.index) {
+			row = len(t.index) - 1 // No more paragraphs, move to the end.
+		}
+	} else {
+		for row--; row > 0 && !start(row); row-- {
+		}
+		if row < 0 {
+			return
+		}
+	}
+	t.trackEnd = false
+	t.lineOffset = row
+}
+
+// scrollCurrentLine scrolls the view such that the current line (the first
+// highlighted line or, if there is none, the middle line of the view) ends up
+// in the provided row of the view. The text view must be locked.
+func (t *TextView) scrollCurrentLine(row int) {
+	t.reindexBuffer(t.indexWidth)
+	if t.index == nil {
+		return
+	}
+	line := t.fromHighlight
+	if line < 0 {
+		line = max(t.lineOffset, 0) + t.pageSize/2
+	}
+	t.trackEnd = false
+	t.lineOffset = max(line-row, 0)
+}
+
+// regionAt returns the ID of the region drawn at the provided screen position
+// and whether or not there is one.
+func (t *TextView) regionAt(x, y int) (regionID string, ok bool) {
+	for _, region := range t.regionInfos {
+		if y == region.FromY && x < region.FromX ||
+			y == region.ToY && x >= region.ToX ||
+			region.FromY >= 0 && y < region.FromY ||
+			region.ToY >= 0 && y > region.ToY {
+			continue
+		}
+		return string(region.ID), true
+	}
+	return "", false
+}
+
 // MouseHandler returns the mouse handler for this primitive.
 func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		// Pass events to the scroll bar.
+		if t.scrollable {
+			if offset, ok, dragging := t.scrollBar.HandleMouse(action, event); ok {
+				t.Lock()
+				if offset != t.lineOffset {
+					t.trackEnd = false
+					t.lineOffset = offset
+				}
+				t.Unlock()
+				if dragging {
+					capture = t
+				}
+				return true, capture
+			}
+		}
+
+		// Extend or complete the mouse selection, even outside the text view.
 		x, y := event.Position()
+		if t.selecting && (action == MouseMove || action == MouseLeftUp) {
+			t.Lock()
+			if position := t.textPosition(x, y); position != t.selectionEnd {
+				t.selectionEnd = position
+				t.selected = true
+			}
+			if action == MouseMove {
+				t.Unlock()
+				return true, t
+			}
+			t.selecting = false
+			app := t.app.Load()
+			text := t.copiedSelection(app)
+			t.Unlock()
+			if text != "" {
+				app.Copy(text)
+			}
+			return true, nil
+		}
+
 		if !t.InRect(x, y) {
 			return false, nil
 		}
 
 		switch action {
+		case MouseLeftDown:
+			// Start a new mouse selection.
+			t.Lock()
+			t.selectionAnchor = t.textPosition(x, y)
+			t.selectionEnd = t.selectionAnchor
+			t.selecting, t.selected = true, false
+			t.Unlock()
+			consumed, capture = true, t
+		case MouseLeftDoubleClick, MouseLeftTripleClick:
+			// Select the word or the line under the mouse.
+			t.Lock()
+			if action == MouseLeftDoubleClick {
+				t.selectWord(t.textPosition(x, y))
+			} else {
+				t.selectLine(t.textPosition(x, y))
+			}
+			app := t.app.Load()
+			text := t.copiedSelection(app)
+			t.Unlock()
+			if text != "" {
+				app.Copy(text)
+			}
+			consumed = true
 		case MouseLeftClick:
 			if t.regions {
 				// Find a region to highlight.
-				for _, region := range t.regionInfos {
-					if y == region.FromY && x < region.FromX ||
-						y == region.ToY && x >= region.ToX ||
-						region.FromY >= 0 && y < region.FromY ||
-						region.ToY >= 0 && y > region.ToY {
-						continue
-					}
-					t.Highlight(string(region.ID))
-					break
+				if regionID, ok := t.regionAt(x, y); ok {
+					t.Highlight(regionID)
 				}
 			}
 			consumed = true
@@ -1397,11 +2288,13 @@ func (t *TextView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 			if t.scrollable {
 				t.trackEnd = false
 				t.lineOffset--
+				t.smoothScroll.animate()
 				consumed = true
 			}
 		case MouseScrollDown:
 			if t.scrollable {
 				t.lineOffset++
+				t.smoothScroll.animate()
 				consumed = true
 			}
 		}
@@ -3,7 +3,12 @@ package nuview
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -353,6 +358,96 @@ func TestTextViewMaxLines(t *testing.T) {
 	}
 }
 
+func TestTextViewWriteBatching(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	var changes int
+	var changesLock sync.Mutex
+	tv.SetChangedFunc(func() {
+		changesLock.Lock()
+		changes++
+		changesLock.Unlock()
+	})
+	tv.SetWriteBatching(time.Hour, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				fmt.Fprintln(tv, "line")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if text := tv.GetText(true); text != "" {
+		t.Errorf("failed to batch writes: expected empty buffer before flushing, got %d bytes", len(text))
+	}
+	tv.Flush()
+	if count := bytes.Count(tv.GetBytes(true), []byte("\n")); count != 100 {
+		t.Errorf("failed to flush writes: expected 100 lines, got %d", count)
+	}
+	if changes != 1 {
+		t.Errorf("failed to coalesce changes: expected 1 call, got %d", changes)
+	}
+
+	// Backpressure.
+
+	tv.Clear()
+	tv.SetWriteBatching(time.Hour, 10)
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(tv, "L%d\n", i)
+	}
+	tv.Flush()
+	if text := tv.GetText(true); text != "L7\nL8\nL9\n" {
+		t.Errorf("failed to drop oldest lines: expected L7 to L9, got %q", text)
+	}
+
+	// Disabling batching flushes collected writes.
+
+	fmt.Fprint(tv, "end")
+	tv.SetWriteBatching(0, 0)
+	if text := tv.GetText(true); text != "L7\nL8\nL9\nend" {
+		t.Errorf("failed to flush when disabling batching: got %q", text)
+	}
+}
+
+func TestTextViewLineRewriting(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetLineRewriting(true)
+
+	// Carriage returns replace the current line, also across writes.
+	fmt.Fprint(tv, "get\n 10%\r")
+	fmt.Fprint(tv, " 50%\r100%\r\ndone\n")
+	if text := tv.GetText(true); text != "get\n100%\ndone\n" {
+		t.Errorf("failed to rewrite line: expected %q, got %q", "get\n100%\ndone\n", text)
+	}
+
+	// Cursor movements rewrite lines above, split escape sequences are kept.
+	tv.Clear()
+	fmt.Fprint(tv, "a 0%\nb 0%\n")
+	fmt.Fprint(tv, "\x1b[2A\x1b[2Ka 50%\n\x1b")
+	fmt.Fprint(tv, "[2Kb 20%\n")
+	if text := tv.GetText(true); text != "a 50%\nb 20%\n" {
+		t.Errorf("failed to move cursor: expected %q, got %q", "a 50%\nb 20%\n", text)
+	}
+
+	// ANSI colors are translated, cursor movements are passed through.
+	tv.Clear()
+	tv.SetDynamicColors(true)
+	w := ANSIWriter(tv)
+	fmt.Fprint(w, "\x1b[31mx\x1b[0m 0%\n")
+	fmt.Fprint(w, "\x1b[1F\x1b[Kx 99%\n")
+	if text := tv.GetText(true); text != "x 99%\n" {
+		t.Errorf("failed to rewrite ANSI output: expected %q, got %q", "x 99%\n", text)
+	}
+}
+
 func generateTestCases() []*textViewTestCase {
 	var cases []*textViewTestCase
 	for i := 0; i < 2; i++ {
@@ -436,3 +531,254 @@ func prepareAppendTextView(t *TextView) ([]byte, error) {
 
 	return b, nil
 }
+
+func TestTextViewNavigation(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 4)
+
+	tv := NewTextView()
+	tv.SetWrap(false)
+	tv.SetText("one two three four\n1\n2\n\nsecond paragraph\n3\n4\n5\n\nthird\n6\n7\n8\n9")
+	tv.SetRect(0, 0, 10, 4)
+	app := NewApplication()
+	app.SetScreen(sc)
+	app.SetRoot(tv, false)
+	app.draw()
+
+	// Keys go through the application which resolves key chords.
+	key := func(r rune) {
+		for _, k := range app.resolveChord(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)) {
+			app.dispatchKey(k.event, k.chord)
+		}
+		app.draw()
+	}
+
+	key('w')
+	if _, column := tv.GetScrollOffset(); column != 4 {
+		t.Errorf("failed to move to next word: expected column 4, got %d", column)
+	}
+	key('w')
+	key('b')
+	if _, column := tv.GetScrollOffset(); column != 4 {
+		t.Errorf("failed to move to previous word: expected column 4, got %d", column)
+	}
+
+	key('}')
+	if row, _ := tv.GetScrollOffset(); row != 4 {
+		t.Errorf("failed to move to next paragraph: expected row 4, got %d", row)
+	}
+	key(']')
+	if row, _ := tv.GetScrollOffset(); row != 9 {
+		t.Errorf("failed to move to next paragraph: expected row 9, got %d", row)
+	}
+	key('{')
+	if row, _ := tv.GetScrollOffset(); row != 4 {
+		t.Errorf("failed to move to previous paragraph: expected row 4, got %d", row)
+	}
+
+	// The middle line (row 6) is scrolled to the top, then to the bottom.
+	key('z')
+	key('t')
+	if row, _ := tv.GetScrollOffset(); row != 6 {
+		t.Errorf("failed to scroll line to top: expected row 6, got %d", row)
+	}
+	key('z')
+	key('b')
+	if row, _ := tv.GetScrollOffset(); row != 5 {
+		t.Errorf("failed to scroll line to bottom: expected row 5, got %d", row)
+	}
+}
+
+func TestTextViewScrollChanged(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	tv := NewTextView()
+	tv.SetText("1\n2\n3\n4\n5")
+	tv.SetRect(0, 0, 10, 3)
+	var reported []string
+	tv.SetScrollChangedFunc(func(firstVisible, total int) {
+		reported = append(reported, fmt.Sprintf("%d/%d", firstVisible, total))
+		tv.GetScrollOffset() // The text view is not locked.
+	})
+
+	tv.Draw(sc)
+	tv.Draw(sc) // Unchanged, not reported.
+	tv.ScrollTo(2, 0)
+	tv.Draw(sc)
+	fmt.Fprint(tv, "\n6") // A new line below.
+	tv.Draw(sc)
+	if text := fmt.Sprint(reported); text != "[0/5 2/5 2/6]" {
+		t.Errorf("failed to report scroll changes: got %s", text)
+	}
+}
+
+// selectedForeground returns whether the provided style has the provided
+// foreground color.
+func selectedForeground(style tcell.Style, color tcell.Color) bool {
+	foreground, _, _ := style.Decompose()
+	return foreground == color
+}
+
+func TestTextViewSelection(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	tv := NewTextView()
+	tv.SetText("hello world\nfoo bar")
+	app := NewApplication()
+	app.SetScreen(sc)
+	app.SetRoot(tv, false)
+	tv.SetRect(0, 0, 10, 3)
+	app.draw()
+
+	var copied []string
+	clipboard := &ClipboardManager{}
+	clipboard.SetCopyOnSelect(true)
+	clipboard.SetWriteFunc(func(text string) {
+		copied = append(copied, text)
+	})
+	app.SetClipboard(clipboard)
+
+	// Drag from "world" (wrapped onto the second row) to "foo".
+	handler := tv.MouseHandler()
+	if _, capture := handler(MouseLeftDown, tcell.NewEventMouse(7, 0, tcell.Button1, tcell.ModNone), func(Primitive) {}); capture != tv {
+		t.Errorf("failed to capture mouse when selecting: got %v", capture)
+	}
+	handler(MouseMove, tcell.NewEventMouse(2, 2, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if text := tv.GetSelectedText(); text != "orld\nfoo" {
+		t.Errorf("failed to select text: expected %q, got %q", "orld\nfoo", text)
+	}
+	if len(copied) != 0 {
+		t.Errorf("failed to wait for the selection to complete: got %q", copied)
+	}
+	handler(MouseLeftUp, tcell.NewEventMouse(2, 2, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if len(copied) != 1 || copied[0] != "orld\nfoo" || clipboard.GetText() != "orld\nfoo" {
+		t.Errorf("failed to copy selection: expected %q, got %q", "orld\nfoo", copied)
+	}
+
+	tv.Draw(sc)
+	if _, _, style, _ := sc.GetContent(0, 1); !selectedForeground(style, tv.highlightForeground) {
+		t.Errorf("failed to highlight selection: got %#v", style)
+	}
+
+	// A click without moving removes the selection.
+	handler(MouseLeftDown, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseLeftUp, tcell.NewEventMouse(0, 0, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if text := tv.GetSelectedText(); text != "" || len(copied) != 1 {
+		t.Errorf("failed to clear selection: got %q", text)
+	}
+
+	// Without a write function, the text is sent to the application's terminal.
+	clipboard.SetWriteFunc(nil)
+	handler(MouseLeftDown, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	handler(MouseLeftUp, tcell.NewEventMouse(4, 0, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+	if data := string(sc.GetClipboardData()); data != "hello" {
+		t.Errorf("failed to copy selection to terminal: expected %q, got %q", "hello", data)
+	}
+	if text := Clipboard.GetText(); text != "" {
+		t.Errorf("failed to use application clipboard: got %q in global clipboard", text)
+	}
+}
+
+func TestTextViewSelectionClicks(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	tv := NewTextView()
+	tv.SetText("hello world\nfoo_1 bar")
+	app := NewApplication()
+	app.SetScreen(sc)
+	app.SetRoot(tv, false)
+	tv.SetRect(0, 0, 10, 3)
+	app.draw()
+
+	var copied []string
+	clipboard := &ClipboardManager{}
+	clipboard.SetCopyOnSelect(true)
+	clipboard.SetWriteFunc(func(text string) {
+		copied = append(copied, text)
+	})
+	app.SetClipboard(clipboard)
+
+	handler := tv.MouseHandler()
+	for _, test := range []struct {
+		action   MouseAction
+		x, y     int
+		expected string
+	}{
+		{MouseLeftDoubleClick, 0, 1, "world"}, // Wrapped onto the second row.
+		{MouseLeftDoubleClick, 8, 0, "world"},
+		{MouseLeftDoubleClick, 3, 2, "foo_1"},
+		{MouseLeftDoubleClick, 5, 0, " "},
+		{MouseLeftDoubleClick, 9, 1, ""},
+		{MouseLeftTripleClick, 0, 1, "hello world"},
+		{MouseLeftTripleClick, 8, 2, "foo_1 bar"},
+	} {
+		handler(test.action, tcell.NewEventMouse(test.x, test.y, tcell.ButtonNone, tcell.ModNone), func(Primitive) {})
+		if text := tv.GetSelectedText(); text != test.expected {
+			t.Errorf("failed to select text at %d,%d: expected %q, got %q", test.x, test.y, test.expected, text)
+		}
+		if test.expected != "" && (len(copied) == 0 || copied[len(copied)-1] != test.expected) {
+			t.Errorf("failed to copy selection at %d,%d: expected %q, got %q", test.x, test.y, test.expected, copied)
+		}
+	}
+}
+
+func TestTextViewScrollView(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	var text strings.Builder
+	for line := 0; line < 100; line++ {
+		fmt.Fprintf(&text, "%d\n", line)
+	}
+	tv.SetText(text.String())
+	app := NewApplication()
+	app.SetRoot(tv, true)
+
+	h := NewHeadless(app, 10, 5)
+	if err := h.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Stop()
+
+	for _, test := range []struct {
+		keys   []string
+		offset int
+	}{
+		{[]string{"z", "t"}, 2},
+		{[]string{"z", "t"}, 4},
+		{[]string{"z", "b"}, 2},
+		{[]string{"z", "z"}, 2},
+		{[]string{"z", "j"}, 3},
+	} {
+		if err := h.SendKeys(test.keys...); err != nil {
+			t.Fatal(err)
+		}
+		h.Sync()
+		if row, _ := tv.GetScrollOffset(); row != test.offset {
+			t.Errorf("failed to scroll view with %q: expected offset %d, got %d", test.keys, test.offset, row)
+		}
+	}
+}
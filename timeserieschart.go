@@ -0,0 +1,453 @@
+package nuview
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// The colors of series added with tcell.ColorDefault, in the order they are
+// assigned.
+var timeSeriesColors = []tcell.Color{
+	tcell.ColorLimeGreen.TrueColor(),
+	tcell.ColorDodgerBlue.TrueColor(),
+	tcell.ColorOrange.TrueColor(),
+	tcell.ColorFuchsia.TrueColor(),
+	tcell.ColorAqua.TrueColor(),
+	tcell.ColorYellow.TrueColor(),
+}
+
+// The spacings of the ticks of the time axis, the smallest one which doesn't
+// crowd the axis is used.
+var timeSeriesTickSteps = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// The bits of the dots of a braille pattern, indexed by row and column.
+var timeSeriesBrailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// timePoint is a point of a time series.
+type timePoint struct {
+	time  time.Time
+	value float64
+}
+
+// TimeSeries is a series of timestamped values shown by a TimeSeriesChart. It
+// is created with TimeSeriesChart.AddSeries() and may be updated from any
+// goroutine.
+type TimeSeries struct {
+	// The chart showing the series. Its mutex guards the series.
+	chart *TimeSeriesChart
+
+	// The name shown in the legend.
+	name string
+
+	// The color of the plot.
+	color tcell.Color
+
+	// The points in chronological order.
+	points []timePoint
+}
+
+// Add adds a point to the series. Points are expected to be added in
+// chronological order but may arrive late. Points which are older than the
+// chart's window, measured from the newest point, are discarded, except for
+// the newest of them which connects the plot to the left edge. Adding a point
+// causes running applications to redraw at the next animation frame (see
+// AnimationFrameInterval), so many points per second don't cause as many
+// redraws.
+func (s *TimeSeries) Add(t time.Time, value float64) {
+	s.chart.Lock()
+	index := len(s.points)
+	if index > 0 && t.Before(s.points[index-1].time) {
+		index = sort.Search(len(s.points), func(i int) bool {
+			return s.points[i].time.After(t)
+		})
+	}
+	s.points = append(s.points, timePoint{})
+	copy(s.points[index+1:], s.points[index:])
+	s.points[index] = timePoint{time: t, value: value}
+
+	// Discard points which left the window.
+	start := s.points[len(s.points)-1].time.Add(-s.chart.window)
+	if expired := sort.Search(len(s.points), func(i int) bool {
+		return !s.points[i].time.Before(start)
+	}) - 1; expired > 0 {
+		s.points = append(s.points[:0], s.points[expired:]...)
+	}
+	s.chart.Unlock()
+
+	requestAnimationFrame()
+}
+
+// GetName returns the name of the series.
+func (s *TimeSeries) GetName() string {
+	s.chart.RLock()
+	defer s.chart.RUnlock()
+
+	return s.name
+}
+
+// SetColor sets the color of the series.
+func (s *TimeSeries) SetColor(color tcell.Color) {
+	s.chart.Lock()
+	defer s.chart.Unlock()
+
+	s.color = color
+}
+
+// GetLength returns the number of points of the series which were not
+// discarded yet.
+func (s *TimeSeries) GetLength() int {
+	s.chart.RLock()
+	defer s.chart.RUnlock()
+
+	return len(s.points)
+}
+
+// Clear removes all points of the series.
+func (s *TimeSeries) Clear() {
+	s.chart.Lock()
+	defer s.chart.Unlock()
+
+	s.points = nil
+}
+
+// TimeSeriesChart plots one or more series of timestamped values (see
+// TimeSeries) over a sliding time window, e.g. for monitoring. Values are
+// plotted as lines using braille patterns, which provide a resolution of two
+// by four dots per cell. Below the plot, a time axis shows tick labels whose
+// spacing adapts to the window and the width of the chart. The range of the
+// values is shown to the left of the plot and a legend with the names of the
+// series above it.
+//
+// The window ends at the current time, so the plot moves to the left whenever
+// the chart is drawn. Only the points within the window are kept and drawn,
+// regardless of how long the series has been running.
+type TimeSeriesChart struct {
+	*Box
+
+	// The series shown in the chart.
+	series []*TimeSeries
+
+	// The duration of the time window.
+	window time.Duration
+
+	// The end of the time window, the current time if zero.
+	end time.Time
+
+	// The fixed range of the values. If the minimum is not less than the
+	// maximum, the range is determined from the visible points.
+	minimum, maximum float64
+
+	// Whether or not the legend is shown.
+	legend bool
+
+	// The color of the axes and their labels.
+	axisColor tcell.Color
+
+	sync.RWMutex
+}
+
+// NewTimeSeriesChart returns a new chart showing the last minute.
+func NewTimeSeriesChart() *TimeSeriesChart {
+	return &TimeSeriesChart{
+		Box:       NewBox(),
+		window:    time.Minute,
+		legend:    true,
+		axisColor: Styles.SecondaryTextColor,
+	}
+}
+
+// AddSeries adds a series with the provided name and returns it. If the color
+// is tcell.ColorDefault, one of a set of distinct colors is assigned.
+func (c *TimeSeriesChart) AddSeries(name string, color tcell.Color) *TimeSeries {
+	c.Lock()
+	defer c.Unlock()
+
+	if color == tcell.ColorDefault {
+		color = timeSeriesColors[len(c.series)%len(timeSeriesColors)]
+	}
+	series := &TimeSeries{
+		chart: c,
+		name:  name,
+		color: color,
+	}
+	c.series = append(c.series, series)
+	return series
+}
+
+// RemoveSeries removes a series from the chart.
+func (c *TimeSeriesChart) RemoveSeries(series *TimeSeries) {
+	c.Lock()
+	defer c.Unlock()
+
+	for index, s := range c.series {
+		if s == series {
+			c.series = append(c.series[:index], c.series[index+1:]...)
+			return
+		}
+	}
+}
+
+// GetSeries returns the series of the chart.
+func (c *TimeSeriesChart) GetSeries() []*TimeSeries {
+	c.RLock()
+	defer c.RUnlock()
+
+	return append([]*TimeSeries(nil), c.series...)
+}
+
+// SetWindow sets the duration of the time window, one minute by default.
+// Points which are already discarded are not restored when it grows.
+func (c *TimeSeriesChart) SetWindow(window time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+
+	if window > 0 {
+		c.window = window
+	}
+}
+
+// GetWindow returns the duration of the time window.
+func (c *TimeSeriesChart) GetWindow() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.window
+}
+
+// SetEndTime sets the end of the time window, e.g. to freeze the chart. The
+// zero time, the default, lets the window end at the current time.
+func (c *TimeSeriesChart) SetEndTime(end time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.end = end
+}
+
+// SetRange sets a fixed range for the values, e.g. 0 to 100 for percentages.
+// If the minimum is not less than the maximum, the range is determined from
+// the visible points, which is the default.
+func (c *TimeSeriesChart) SetRange(minimum, maximum float64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.minimum, c.maximum = minimum, maximum
+}
+
+// SetLegendVisible sets whether or not the legend is shown above the plot. It
+// is shown by default.
+func (c *TimeSeriesChart) SetLegendVisible(visible bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.legend = visible
+}
+
+// SetAxisColor sets the color of the axes and their labels.
+func (c *TimeSeriesChart) SetAxisColor(color tcell.Color) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.axisColor = color
+}
+
+// visiblePoints returns the points of a series which are drawn for the window
+// from start to end, including the last point before and the first point after
+// the window.
+func (s *TimeSeries) visiblePoints(start, end time.Time) []timePoint {
+	from := sort.Search(len(s.points), func(i int) bool {
+		return !s.points[i].time.Before(start)
+	})
+	to := sort.Search(len(s.points), func(i int) bool {
+		return s.points[i].time.After(end)
+	})
+	return s.points[max(from-1, 0):min(to+1, len(s.points))]
+}
+
+// valueRange returns the range of the values shown for the window from start
+// to end. The chart must be locked.
+func (c *TimeSeriesChart) valueRange(start, end time.Time) (minimum, maximum float64) {
+	if c.minimum < c.maximum {
+		return c.minimum, c.maximum
+	}
+	minimum, maximum = math.Inf(1), math.Inf(-1)
+	for _, series := range c.series {
+		for _, point := range series.visiblePoints(start, end) {
+			minimum = math.Min(minimum, point.value)
+			maximum = math.Max(maximum, point.value)
+		}
+	}
+	if math.IsInf(minimum, 1) {
+		return 0, 1
+	}
+	if minimum == maximum {
+		return minimum - 1, maximum + 1
+	}
+	return minimum, maximum
+}
+
+// timeSeriesTicks returns the spacing of the ticks of a time axis spanning the
+// provided window which is width cells wide and the format of their labels.
+func timeSeriesTicks(window time.Duration, width int) (step time.Duration, format string) {
+	for _, step = range timeSeriesTickSteps {
+		format = "15:04:05"
+		if step >= 24*time.Hour {
+			format = "Jan 2"
+		} else if step >= time.Minute {
+			format = "15:04"
+		}
+		if int(window/step)*(len(format)+2) <= width {
+			break
+		}
+	}
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (c *TimeSeriesChart) Draw(screen tcell.Screen) {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.Box.Draw(screen)
+
+	c.RLock()
+	defer c.RUnlock()
+
+	x, y, width, height := c.GetInnerRect()
+	background := c.GetBackgroundColor()
+	axisStyle := tcell.StyleDefault.Background(background).Foreground(c.axisColor)
+
+	// Draw the legend.
+	if c.legend && len(c.series) > 0 && height > 0 {
+		legendX := x
+		for _, series := range c.series {
+			if legendX >= x+width {
+				break
+			}
+			screen.SetContent(legendX, y, '■', nil, tcell.StyleDefault.Background(background).Foreground(series.color))
+			_, printed := PrintStyle(screen, []byte(Escape(series.name)), legendX+2, y, x+width-legendX-2, AlignLeft, axisStyle.Foreground(Styles.PrimaryTextColor))
+			legendX += printed + 4
+		}
+		y++
+		height--
+	}
+
+	end := c.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := end.Add(-c.window)
+	minimum, maximum := c.valueRange(start, end)
+
+	// Determine the layout: the value labels, the vertical axis, the plot and,
+	// if there is enough room, the time axis with its labels.
+	maxLabel, minLabel := strconv.FormatFloat(maximum, 'g', 4, 64), strconv.FormatFloat(minimum, 'g', 4, 64)
+	labelWidth := max(len(maxLabel), len(minLabel))
+	plotX, plotWidth, plotHeight := x+labelWidth+1, width-labelWidth-1, height
+	timeAxis := height >= 4
+	if timeAxis {
+		plotHeight -= 2
+	}
+	if plotWidth < 2 || plotHeight < 1 {
+		return
+	}
+
+	// Draw the axes.
+	Print(screen, []byte(maxLabel), x, y, labelWidth, AlignRight, c.axisColor)
+	Print(screen, []byte(minLabel), x, y+plotHeight-1, labelWidth, AlignRight, c.axisColor)
+	for row := y; row < y+plotHeight; row++ {
+		screen.SetContent(plotX-1, row, Borders.Vertical, nil, axisStyle)
+	}
+	if timeAxis {
+		axisY := y + plotHeight
+		screen.SetContent(plotX-1, axisY, Borders.BottomLeft, nil, axisStyle)
+		for column := plotX; column < plotX+plotWidth; column++ {
+			screen.SetContent(column, axisY, Borders.Horizontal, nil, axisStyle)
+		}
+		step, format := timeSeriesTicks(c.window, plotWidth)
+		labelEnd := plotX
+		for tick := start.Truncate(step); !tick.After(end); tick = tick.Add(step) {
+			if tick.Before(start) {
+				continue
+			}
+			tickX := plotX + int(float64(tick.Sub(start))/float64(c.window)*float64(plotWidth-1))
+			screen.SetContent(tickX, axisY, BoxDrawingsLightDownAndHorizontal, nil, axisStyle)
+			label := tick.Format(format)
+			labelX := min(max(tickX-len(label)/2, plotX), plotX+plotWidth-len(label))
+			if labelX >= labelEnd {
+				Print(screen, []byte(label), labelX, axisY+1, len(label), AlignLeft, c.axisColor)
+				labelEnd = labelX + len(label) + 1
+			}
+		}
+	}
+
+	// Plot the series into a grid of braille dots.
+	dotsWidth, dotsHeight := plotWidth*2, plotHeight*4
+	dots := make([]rune, plotWidth*plotHeight)
+	colors := make([]tcell.Color, plotWidth*plotHeight)
+	for _, series := range c.series {
+		setDot := func(dotX, dotY int) {
+			if dotX < 0 || dotY < 0 || dotX >= dotsWidth || dotY >= dotsHeight {
+				return
+			}
+			cell := dotY/4*plotWidth + dotX/2
+			dots[cell] |= timeSeriesBrailleDots[dotY%4][dotX%2]
+			colors[cell] = series.color
+		}
+		previousX, previousY := 0, 0
+		for index, point := range series.visiblePoints(start, end) {
+			dotX := int(math.Round(float64(point.time.Sub(start)) / float64(c.window) * float64(dotsWidth-1)))
+			dotY := int(math.Round((maximum - point.value) / (maximum - minimum) * float64(dotsHeight-1)))
+			if index == 0 {
+				setDot(dotX, dotY)
+			} else {
+				timeSeriesLine(previousX, previousY, dotX, dotY, dotsWidth, setDot)
+			}
+			previousX, previousY = dotX, dotY
+		}
+	}
+	for index, bits := range dots {
+		if bits != 0 {
+			style := tcell.StyleDefault.Background(background).Foreground(colors[index])
+			screen.SetContent(plotX+index%plotWidth, y+index/plotWidth, 0x2800+bits, nil, style)
+		}
+	}
+}
+
+// timeSeriesLine calls setDot for the dots of a line from one dot to another.
+// The line is clipped to the columns of dots from 0 to width-1.
+func timeSeriesLine(fromX, fromY, toX, toY, width int, setDot func(x, y int)) {
+	clip := func(x, y, otherX, otherY, edge int) (int, int) {
+		return edge, y + int(math.Round(float64((otherY-y)*(edge-x))/float64(otherX-x)))
+	}
+	if fromX < 0 && toX >= 0 {
+		fromX, fromY = clip(fromX, fromY, toX, toY, 0)
+	}
+	if toX >= width && fromX < width {
+		toX, toY = clip(toX, toY, fromX, fromY, width-1)
+	}
+
+	dx, dy := toX-fromX, toY-fromY
+	steps := max(dx, -dx, dy, -dy)
+	if steps == 0 {
+		setDot(toX, toY)
+		return
+	}
+	for step := 0; step <= steps; step++ {
+		setDot(fromX+int(math.Round(float64(dx*step)/float64(steps))), fromY+int(math.Round(float64(dy*step)/float64(steps))))
+	}
+}
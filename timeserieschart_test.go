@@ -0,0 +1,67 @@
+package nuview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTimeSeriesChart(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(30, 8)
+	row := func(y int) string {
+		var b strings.Builder
+		for x := 0; x < 30; x++ {
+			r, _, _, _ := sc.GetContent(x, y)
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	end := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := NewTimeSeriesChart()
+	c.SetRect(0, 0, 30, 8)
+	c.SetWindow(20 * time.Second)
+	c.SetEndTime(end)
+	c.SetRange(0, 10)
+	cpu := c.AddSeries("cpu", tcell.ColorDefault)
+	memory := c.AddSeries("mem", tcell.ColorRed)
+
+	// Old points are discarded except for the last one before the window.
+	for second := -60; second <= 0; second++ {
+		cpu.Add(end.Add(time.Duration(second)*time.Second), 10)
+	}
+	if length := cpu.GetLength(); length != 22 {
+		t.Errorf("failed to discard old points: expected 22, got %d", length)
+	}
+	memory.Add(end.Add(-20*time.Second), 0)
+	memory.Add(end, 0)
+	c.Draw(sc)
+
+	if got := row(0); !strings.HasPrefix(got, "■ cpu  ■ mem") {
+		t.Errorf("failed to draw legend: expected %q prefix, got %q", "■ cpu  ■ mem", got)
+	}
+	if got := row(1); !strings.HasPrefix(got, "10│⠉⠉⠉") {
+		t.Errorf("failed to plot maximum: expected %q prefix, got %q", "10│⠉⠉⠉", got)
+	}
+	if got := row(5); !strings.HasPrefix(got, " 0│⣀⣀⣀") {
+		t.Errorf("failed to plot minimum: expected %q prefix, got %q", " 0│⣀⣀⣀", got)
+	}
+	if _, _, style, _ := sc.GetContent(3, 5); style != tcell.StyleDefault.Background(c.GetBackgroundColor()).Foreground(tcell.ColorRed) {
+		t.Errorf("failed to color series: expected red, got %#v", style)
+	}
+	if got := row(7); got != "   11:59:40 11:59:50  12:00:00" {
+		t.Errorf("failed to draw time axis labels: expected %q, got %q", "   11:59:40 11:59:50  12:00:00", got)
+	}
+
+	if step, format := timeSeriesTicks(time.Hour, 60); step != 10*time.Minute || format != "15:04" {
+		t.Errorf("failed to choose ticks: expected 10m0s and %q, got %v and %q", "15:04", step, format)
+	}
+}
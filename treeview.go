@@ -319,6 +319,9 @@ type TreeView struct {
 	// Vertical scroll offset.
 	offsetY int
 
+	// Reports changes of the scroll offset, see SetScrollChangedFunc().
+	scrollChanged scrollNotifier
+
 	// If set to true, all node texts will be aligned horizontally.
 	align bool
 
@@ -334,11 +337,11 @@ type TreeView struct {
 	// The color of the lines.
 	graphicsColor tcell.Color
 
-	// Visibility of the scroll bar.
-	scrollBarVisibility ScrollBarVisibility
+	// The vertical scroll bar.
+	scrollBar *ScrollBar
 
-	// The scroll bar color.
-	scrollBarColor tcell.Color
+	// The placeholder and the loading spinner.
+	contentState contentState
 
 	// An optional function called when the focused tree item changes.
 	changed func(node *TreeNode)
@@ -346,23 +349,31 @@ type TreeView struct {
 	// An optional function called when a tree item is selected.
 	selected func(node *TreeNode)
 
+	// An optional function called when a tree item is double-clicked.
+	doubleClick func(node *TreeNode)
+
 	// An optional function called when the user moves away from this primitive.
 	done func(key tcell.Key)
 
 	// The visible nodes, top-down, as set by process().
 	nodes []*TreeNode
 
+	// An optional model of the selected nodes, see SetSelectionModel().
+	selection *SelectionModel
+
+	// Whether or not the pending movement extends the selection.
+	extendSelection bool
+
 	sync.RWMutex
 }
 
 // NewTreeView returns a new tree view.
 func NewTreeView() *TreeView {
 	return &TreeView{
-		Box:                 NewBox(),
-		scrollBarVisibility: ScrollBarAuto,
-		graphics:            true,
-		graphicsColor:       Styles.GraphicsColor,
-		scrollBarColor:      Styles.ScrollBarColor,
+		Box:           NewBox(),
+		scrollBar:     NewScrollBar(),
+		graphics:      true,
+		graphicsColor: Styles.GraphicsColor,
 	}
 }
 
@@ -383,6 +394,39 @@ func (t *TreeView) GetRoot() *TreeNode {
 	return t.root
 }
 
+// Walk traverses the tree starting at the root node in depth-first, pre-order
+// (NLR) order, including the children of collapsed nodes, and calls the
+// provided callback on each node with its depth (0 for the root node). The
+// callback returns whether traversal should continue with the node's children
+// (true) or not recurse any deeper (false). Unlike TreeNode.Walk(), no lock is
+// held while the callback runs so it may modify the tree.
+func (t *TreeView) Walk(callback func(node *TreeNode, depth int) bool) {
+	root := t.GetRoot()
+	if root == nil {
+		return
+	}
+
+	type entry struct {
+		node  *TreeNode
+		depth int
+	}
+	entries := []entry{{root, 0}}
+	for len(entries) > 0 {
+		// Pop the top node and process it.
+		e := entries[len(entries)-1]
+		entries = entries[:len(entries)-1]
+		if !callback(e.node, e.depth) {
+			continue
+		}
+
+		// Add children in reverse order.
+		children := e.node.GetChildren()
+		for index := len(children) - 1; index >= 0; index-- {
+			entries = append(entries, entry{children[index], e.depth + 1})
+		}
+	}
+}
+
 // SetCurrentNode focuses a node or, when provided with nil, clears focus.
 // Selected nodes must be visible and selectable, or else the selection will be
 // changed to the top-most selectable and visible node.
@@ -392,6 +436,13 @@ func (t *TreeView) SetCurrentNode(node *TreeNode) {
 	t.Lock()
 	defer t.Unlock()
 
+	if t.selection != nil && node != nil && node != t.currentNode {
+		previous, current := t.nodeIndex(t.currentNode), t.nodeIndex(node)
+		t.Unlock()
+		t.selection.follow(previous, current, false)
+		t.Lock()
+	}
+
 	t.currentNode = node
 	if t.currentNode.focused != nil {
 		t.Unlock()
@@ -400,6 +451,71 @@ func (t *TreeView) SetCurrentNode(node *TreeNode) {
 	}
 }
 
+// SetSelectionModel sets a model of the selected nodes which allows more than
+// the current node to be selected, see SelectionModel for details. The model's
+// items are the indices of the nodes in a depth-first traversal of all nodes,
+// starting with the root at index 0 and including the children of collapsed
+// nodes. Use GetSelectedNodes() to retrieve the selected nodes. Set to nil
+// (the default) to only select the current node.
+func (t *TreeView) SetSelectionModel(model *SelectionModel) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.selection = model
+}
+
+// GetSelectionModel returns the model of the selected nodes or nil if there
+// is none.
+func (t *TreeView) GetSelectionModel() *SelectionModel {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.selection
+}
+
+// GetSelectedNodes returns the nodes selected in the selection model, in
+// depth-first order. It returns nil if there is no selection model.
+func (t *TreeView) GetSelectedNodes() []*TreeNode {
+	t.RLock()
+	defer t.RUnlock()
+
+	if t.selection == nil || t.root == nil {
+		return nil
+	}
+	var nodes []*TreeNode
+	index := 0
+	t.root.walk(func(node, parent *TreeNode) bool {
+		if t.selection.IsSelected(index) {
+			nodes = append(nodes, node)
+		}
+		index++
+		return true
+	})
+	return nodes
+}
+
+// nodeIndices returns the indices of all nodes in a depth-first traversal
+// starting at the root. See SetSelectionModel() for details.
+func (t *TreeView) nodeIndices() map[*TreeNode]int {
+	indices := make(map[*TreeNode]int)
+	if t.root != nil {
+		t.root.walk(func(node, parent *TreeNode) bool {
+			indices[node] = len(indices)
+			return true
+		})
+	}
+	return indices
+}
+
+// nodeIndex returns the index of a node in a depth-first traversal starting
+// at the root or -1 if the node is not part of the tree.
+func (t *TreeView) nodeIndex(node *TreeNode) int {
+	if index, ok := t.nodeIndices()[node]; ok {
+		return index
+	}
+	return -1
+}
+
 // GetCurrentNode returns the currently selected node or nil of no node is
 // currently selected.
 func (t *TreeView) GetCurrentNode() *TreeNode {
@@ -409,6 +525,59 @@ func (t *TreeView) GetCurrentNode() *TreeNode {
 	return t.currentNode
 }
 
+// Search selects the next selectable node after the current node (or the
+// previous one if backwards is true), in depth-first order, whose text
+// contains the query, ignoring case. Collapsed nodes are searched as well;
+// the ancestors of a match are expanded. Returns whether a match was found.
+// This implements the Searchable interface.
+//
+// This function does NOT trigger the "changed" callback.
+func (t *TreeView) Search(query string, backwards bool) bool {
+	t.RLock()
+	root, current := t.root, t.currentNode
+	t.RUnlock()
+
+	if root == nil {
+		return false
+	}
+
+	// Collect all nodes, including the children of collapsed nodes.
+	var (
+		nodes        []*TreeNode
+		currentIndex = -1
+	)
+	parents := make(map[*TreeNode]*TreeNode)
+	root.Walk(func(node, parent *TreeNode) bool {
+		if node == current {
+			currentIndex = len(nodes)
+		}
+		parents[node] = parent
+		nodes = append(nodes, node)
+		return true
+	})
+	if currentIndex < 0 && backwards {
+		currentIndex = len(nodes)
+	}
+
+	index := searchIndex(len(nodes), currentIndex, backwards, func(index int) bool {
+		node := nodes[index]
+		node.RLock()
+		defer node.RUnlock()
+		return node.selectable && searchMatches(StripTags([]byte(node.text), true, false), query)
+	})
+	if index < 0 {
+		return false
+	}
+
+	// Make the match visible.
+	match := nodes[index]
+	for parent := parents[match]; parent != nil; parent = parents[parent] {
+		parent.SetExpanded(true)
+	}
+	t.SetCurrentNode(match)
+	return true
+}
+
 // SetTopLevel sets the first tree level that is visible with 0 referring to the
 // root, 1 to the root's child nodes, and so on. Nodes above the top level are
 // not displayed.
@@ -481,18 +650,76 @@ func (t *TreeView) SetGraphicsColor(color tcell.Color) {
 
 // SetScrollBarVisibility specifies the display of the scroll bar.
 func (t *TreeView) SetScrollBarVisibility(visibility ScrollBarVisibility) {
+	t.scrollBar.SetVisibility(visibility)
+}
+
+// SetScrollBarColor sets the color of the scroll bar.
+func (t *TreeView) SetScrollBarColor(color tcell.Color) {
+	t.scrollBar.SetColor(color)
+}
+
+// GetScrollBar returns the vertical scroll bar of the tree view.
+func (t *TreeView) GetScrollBar() *ScrollBar {
+	return t.scrollBar
+}
+
+// SetPlaceholder sets a primitive which is drawn in place of the nodes when
+// the tree view has none, e.g. a text view saying "No results" or describing an
+// error. It is drawn only and doesn't receive any input. Provide nil to draw
+// no placeholder, which is the default.
+func (t *TreeView) SetPlaceholder(placeholder Primitive) {
 	t.Lock()
 	defer t.Unlock()
 
-	t.scrollBarVisibility = visibility
+	t.contentState.setPlaceholder(placeholder)
 }
 
-// SetScrollBarColor sets the color of the scroll bar.
-func (t *TreeView) SetScrollBarColor(color tcell.Color) {
+// SetPlaceholderText sets a text which is drawn centered in place of the
+// nodes when the tree view has none (see SetPlaceholder()). The text may contain
+// color tags. Provide an empty string to draw no placeholder.
+func (t *TreeView) SetPlaceholderText(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setPlaceholderText(text)
+}
+
+// SetLoading sets whether or not the content of the tree view is being loaded.
+// While it is, a spinner (see SpinnerFrames) is drawn in the center of the
+// tree view in place of its nodes.
+func (t *TreeView) SetLoading(loading bool) {
 	t.Lock()
 	defer t.Unlock()
 
-	t.scrollBarColor = color
+	t.contentState.setLoading(loading)
+}
+
+// GetLoading returns whether or not the content of the tree view is being loaded.
+func (t *TreeView) GetLoading() bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.loading
+}
+
+// SetError sets an error message which is drawn centered in place of the nodes
+// of the tree view, e.g. when loading them failed. Provide an empty string to draw
+// the nodes again, which is the default. The text is not interpreted for style
+// tags.
+func (t *TreeView) SetError(text string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.contentState.setError(text)
+}
+
+// GetError returns the error message drawn in place of the nodes of the tree view, see
+// SetError().
+func (t *TreeView) GetError() string {
+	t.RLock()
+	defer t.RUnlock()
+
+	return t.contentState.err
 }
 
 // SetChangedFunc sets the function which is called when the user navigates to
@@ -513,6 +740,16 @@ func (t *TreeView) SetSelectedFunc(handler func(node *TreeNode)) {
 	t.selected = handler
 }
 
+// SetDoubleClickFunc sets a handler which is called whenever the user
+// double-clicks a selectable node. The node is selected by the first click.
+// See Application.SetDoubleClickInterval for how double clicks are detected.
+func (t *TreeView) SetDoubleClickFunc(handler func(node *TreeNode)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.doubleClick = handler
+}
+
 // SetDoneFunc sets a handler which is called whenever the user presses the
 // Escape, Tab, or Backtab key.
 func (t *TreeView) SetDoneFunc(handler func(key tcell.Key)) {
@@ -522,6 +759,21 @@ func (t *TreeView) SetDoneFunc(handler func(key tcell.Key)) {
 	t.done = handler
 }
 
+// SetScrollChangedFunc sets a handler which is called when the tree view is
+// drawn scrolled to a different node row or with a different number of node
+// rows, e.g. to keep a scroll indicator in sync. It receives the index of the
+// first visible node row (see GetScrollOffset()) and the number of node rows,
+// i.e. nodes which are not hidden in collapsed nodes. It is called during
+// drawing, after the tree view was unlocked, so changes to other primitives are
+// drawn when the screen is drawn next, see Application.QueueUpdateDraw().
+// Provide nil to remove the handler.
+func (t *TreeView) SetScrollChangedFunc(handler func(firstVisible, total int)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.scrollChanged.set(handler)
+}
+
 // GetScrollOffset returns the number of node rows that were skipped at the top
 // of the tree view. Note that when the user navigates the tree view, this value
 // is only updated after the tree view has been redrawn.
@@ -701,6 +953,12 @@ func (t *TreeView) process() {
 		t.currentNode = t.nodes[newSelectedIndex]
 		if newSelectedIndex != selectedIndex {
 			t.movement = treeNone
+			if t.selection != nil {
+				previous, current := t.nodeIndex(t.nodes[selectedIndex]), t.nodeIndex(t.currentNode)
+				t.Unlock()
+				t.selection.follow(previous, current, t.extendSelection)
+				t.Lock()
+			}
 			if t.changed != nil {
 				t.Unlock()
 				t.changed(t.currentNode)
@@ -714,6 +972,8 @@ func (t *TreeView) process() {
 		}
 		selectedIndex = newSelectedIndex
 
+		t.extendSelection = false
+
 		// Move selection into viewport.
 		if selectedIndex-t.offsetY >= height {
 			t.offsetY = selectedIndex - height + 1
@@ -746,17 +1006,30 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 
 	t.Box.Draw(screen)
 
+	var notifyScroll func()
+	defer func() {
+		if notifyScroll != nil {
+			notifyScroll()
+		}
+	}()
+
 	t.Lock()
 	defer t.Unlock()
 
+	x, y, width, height := t.GetInnerRect()
+	if t.root != nil {
+		t.process()
+	}
+
+	// Draw the placeholder or the loading spinner instead of the nodes.
+	if t.contentState.draw(screen, x, y, width, height, t.root == nil || len(t.nodes) == 0, t.backgroundFill()) {
+		return
+	}
 	if t.root == nil {
 		return
 	}
 
-	t.process()
-
 	// Scroll the tree.
-	x, y, width, height := t.GetInnerRect()
 	switch t.movement {
 	case treeUp:
 		t.offsetY--
@@ -781,13 +1054,16 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 		t.offsetY = 0
 	}
 
-	// Calculate scroll bar position.
-	rows := len(t.nodes)
-	cursor := int(float64(rows) * (float64(t.offsetY) / float64(rows-height)))
+	// Determine the indices of the nodes for the selection model.
+	var indices map[*TreeNode]int
+	if t.selection != nil {
+		indices = t.nodeIndices()
+	}
 
 	// Draw the tree.
 	posY := y
-	lineStyle := tcell.StyleDefault.Background(t.backgroundColor).Foreground(t.graphicsColor)
+	lineStyle := tcell.StyleDefault.Background(t.backgroundFill()).Foreground(t.graphicsColor)
+	borders := t.borderSet()
 	for index, node := range t.nodes {
 		// Skip invisible parts.
 		if posY >= y+height {
@@ -809,10 +1085,10 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 				// Draw a branch if this ancestor is not a last child.
 				if ancestor.parent.children[len(ancestor.parent.children)-1] != ancestor {
 					if posY-1 >= y && ancestor.textX > ancestor.graphicsX {
-						PrintJoinedSemigraphics(screen, x+ancestor.graphicsX, posY-1, Borders.Vertical, t.graphicsColor)
+						PrintJoinedSemigraphics(screen, x+ancestor.graphicsX, posY-1, borders.Vertical, t.graphicsColor)
 					}
 					if posY < y+height {
-						screen.SetContent(x+ancestor.graphicsX, posY, Borders.Vertical, nil, lineStyle)
+						screen.SetContent(x+ancestor.graphicsX, posY, borders.Vertical, nil, lineStyle)
 					}
 				}
 				ancestor = ancestor.parent
@@ -821,14 +1097,14 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 			if node.textX > node.graphicsX && node.graphicsX < width {
 				// Connect to the node above.
 				if posY-1 >= y && t.nodes[index-1].graphicsX <= node.graphicsX && t.nodes[index-1].textX > node.graphicsX {
-					PrintJoinedSemigraphics(screen, x+node.graphicsX, posY-1, Borders.TopLeft, t.graphicsColor)
+					PrintJoinedSemigraphics(screen, x+node.graphicsX, posY-1, borders.TopLeft, t.graphicsColor)
 				}
 
 				// Join this node.
 				if posY < y+height {
-					screen.SetContent(x+node.graphicsX, posY, Borders.BottomLeft, nil, lineStyle)
+					screen.SetContent(x+node.graphicsX, posY, borders.BottomLeft, nil, lineStyle)
 					for pos := node.graphicsX + 1; pos < node.textX && pos < width; pos++ {
-						screen.SetContent(x+pos, posY, Borders.Horizontal, nil, lineStyle)
+						screen.SetContent(x+pos, posY, borders.Horizontal, nil, lineStyle)
 					}
 				}
 			}
@@ -845,27 +1121,63 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 			// Text.
 			if node.textX+prefixWidth < width {
 				style := tcell.StyleDefault.Foreground(node.color)
-				if node == t.currentNode {
-					backgroundColor := node.color
-					foregroundColor := t.backgroundColor
-					if t.selectedTextColor != nil {
-						foregroundColor = *t.selectedTextColor
-					}
-					if t.selectedBackgroundColor != nil {
-						backgroundColor = *t.selectedBackgroundColor
+				if node == t.currentNode || t.selection != nil && t.selection.IsSelected(indices[node]) {
+					style = invertedStyle(node.color, t.backgroundFill())
+					if t.selectedTextColor != nil || t.selectedBackgroundColor != nil {
+						backgroundColor := node.color
+						foregroundColor := t.backgroundColor
+						if t.selectedTextColor != nil {
+							foregroundColor = *t.selectedTextColor
+						}
+						if t.selectedBackgroundColor != nil {
+							backgroundColor = *t.selectedBackgroundColor
+						}
+						style = tcell.StyleDefault.Background(backgroundColor).Foreground(foregroundColor)
 					}
-					style = tcell.StyleDefault.Background(backgroundColor).Foreground(foregroundColor)
 				}
 				PrintStyle(screen, []byte(node.text), x+node.textX+prefixWidth, posY, width-node.textX-prefixWidth, AlignLeft, style)
 			}
 		}
 
-		// Draw scroll bar.
-		RenderScrollBar(screen, t.scrollBarVisibility, x+(width-1), posY, height, rows, cursor, posY-y, t.hasFocus, t.scrollBarColor)
-
 		// Advance.
 		posY++
 	}
+
+	// Draw the scroll bar.
+	t.scrollBar.Draw(screen, x+(width-1), y, height, len(t.nodes), height, t.offsetY, t.hasFocus)
+	notifyScroll = t.scrollChanged.update(t.offsetY, len(t.nodes))
+}
+
+// scrollTo scrolls the tree to the provided offset. If the current node is
+// then no longer visible, the nearest visible selectable node becomes the
+// current node, which is returned (nil if the current node did not change).
+func (t *TreeView) scrollTo(offset int) *TreeNode {
+	_, _, _, height := t.GetInnerRect()
+	t.offsetY = offset
+
+	current := -1
+	for index, node := range t.nodes {
+		if node == t.currentNode {
+			current = index
+			break
+		}
+	}
+	if current < 0 || current >= offset && current < offset+height {
+		return nil
+	}
+
+	// Find the nearest visible selectable node.
+	from, to, step := offset, offset+height, 1
+	if current >= offset+height {
+		from, to, step = offset+height-1, offset-1, -1
+	}
+	for index := from; index != to; index += step {
+		if index >= 0 && index < len(t.nodes) && t.nodes[index].selectable {
+			t.currentNode = t.nodes[index]
+			return t.currentNode
+		}
+	}
+	return nil
 }
 
 // InputHandler returns the handler for this primitive.
@@ -895,25 +1207,38 @@ func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 
 		// Because the tree is flattened into a list only at drawing time, we also
 		// postpone the (selection) movement to drawing time.
-		if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+		if t.hitShortcut(event, t.keys().Cancel, t.keys().MovePreviousField, t.keys().MoveNextField) {
 			if t.done != nil {
 				t.Unlock()
 				t.done(event.Key())
 				t.Lock()
 			}
-		} else if HitShortcut(event, Keys.MoveFirst, Keys.MoveFirst2) {
+		} else if t.selection != nil && t.hitShortcut(event, t.keys().ToggleSelection) {
+			if t.currentNode != nil {
+				index := t.nodeIndex(t.currentNode)
+				t.Unlock()
+				t.selection.Toggle(index)
+				t.Lock()
+			}
+		} else if t.selection != nil && t.hitShortcut(event, t.keys().ExtendSelectionUp) {
+			t.movement = treeUp
+			t.extendSelection = true
+		} else if t.selection != nil && t.hitShortcut(event, t.keys().ExtendSelectionDown) {
+			t.movement = treeDown
+			t.extendSelection = true
+		} else if t.hitShortcut(event, t.keys().MoveFirst, t.keys().MoveFirst2) {
 			t.movement = treeHome
-		} else if HitShortcut(event, Keys.MoveLast, Keys.MoveLast2) {
+		} else if t.hitShortcut(event, t.keys().MoveLast, t.keys().MoveLast2) {
 			t.movement = treeEnd
-		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+		} else if t.hitShortcut(event, t.keys().MoveUp, t.keys().MoveUp2) {
 			t.movement = treeUp
-		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+		} else if t.hitShortcut(event, t.keys().MoveDown, t.keys().MoveDown2) {
 			t.movement = treeDown
-		} else if HitShortcut(event, Keys.MovePreviousPage) {
+		} else if t.hitShortcut(event, t.keys().MovePreviousPage) {
 			t.movement = treePageUp
-		} else if HitShortcut(event, Keys.MoveNextPage) {
+		} else if t.hitShortcut(event, t.keys().MoveNextPage) {
 			t.movement = treePageDown
-		} else if HitShortcut(event, Keys.Select, Keys.Select2) {
+		} else if t.hitShortcut(event, t.keys().Select, t.keys().Select2) {
 			t.Unlock()
 			selectNode()
 			t.Lock()
@@ -926,6 +1251,22 @@ func (t *TreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p Pr
 // MouseHandler returns the mouse handler for this primitive.
 func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
 	return t.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		t.Lock()
+		defer t.Unlock()
+
+		// Pass events to the scroll bar.
+		if offset, ok, dragging := t.scrollBar.HandleMouse(action, event); ok {
+			if node := t.scrollTo(offset); node != nil && t.changed != nil {
+				t.Unlock()
+				t.changed(node)
+				t.Lock()
+			}
+			if dragging {
+				capture = t
+			}
+			return true, capture
+		}
+
 		x, y := event.Position()
 		if !t.InRect(x, y) {
 			return false, nil
@@ -934,21 +1275,57 @@ func (t *TreeView) MouseHandler() func(action MouseAction, event *tcell.EventMou
 		switch action {
 		case MouseLeftClick:
 			_, rectY, _, _ := t.GetInnerRect()
-			y -= rectY
+			y += t.offsetY - rectY
 			if y >= 0 && y < len(t.nodes) {
 				node := t.nodes[y]
-				if node.selectable {
-					if t.currentNode != node && t.changed != nil {
+				if node.selectable && t.selection != nil {
+					index := t.nodeIndex(node)
+					t.Unlock()
+					t.selection.click(index, event.Modifiers())
+					t.Lock()
+					if event.Modifiers()&(tcell.ModCtrl|tcell.ModShift) != 0 {
+						// Only change the selection.
+						changed := t.currentNode != node
+						t.currentNode = node
+						if changed && t.changed != nil {
+							t.Unlock()
+							t.changed(node)
+							t.Lock()
+						}
+						node = nil
+					}
+				}
+				if node != nil && node.selectable {
+					changed := t.currentNode != node
+					t.currentNode = node
+					if changed && t.changed != nil {
+						t.Unlock()
 						t.changed(node)
+						t.Lock()
 					}
 					if t.selected != nil {
+						t.Unlock()
 						t.selected(node)
+						t.Lock()
 					}
-					t.currentNode = node
 				}
 			}
 			consumed = true
+			t.Unlock()
 			setFocus(t)
+			t.Lock()
+		case MouseLeftDoubleClick:
+			_, rectY, _, _ := t.GetInnerRect()
+			y += t.offsetY - rectY
+			if y >= 0 && y < len(t.nodes) {
+				node := t.nodes[y]
+				if node.selectable && t.doubleClick != nil {
+					t.Unlock()
+					t.doubleClick(node)
+					t.Lock()
+				}
+			}
+			consumed = true
 		case MouseScrollUp:
 			t.movement = treeUp
 			consumed = true
@@ -1,7 +1,11 @@
 package nuview
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
 )
 
 const (
@@ -69,3 +73,67 @@ func TestTreeView(t *testing.T) {
 		t.Errorf("failed to initialize TreeView: incorrect row count: expected 1, got %d", tr.GetRowCount())
 	}
 }
+
+func TestTreeViewWalk(t *testing.T) {
+	t.Parallel()
+
+	root := NewTreeNode("a")
+	b, d := NewTreeNode("b"), NewTreeNode("d")
+	b.AddChild(NewTreeNode("c"))
+	b.SetExpanded(false)
+	root.AddChild(b)
+	root.AddChild(d)
+	d.AddChild(NewTreeNode("e"))
+	tr := NewTreeView()
+	tr.SetRoot(root)
+
+	var visited []string
+	tr.Walk(func(node *TreeNode, depth int) bool {
+		visited = append(visited, fmt.Sprintf("%s%d", node.GetText(), depth))
+		return node != d
+	})
+	if text := strings.Join(visited, " "); text != "a0 b1 c2 d1" {
+		t.Errorf("failed to walk tree: got %q", text)
+	}
+}
+
+func TestTreeViewMouseScrolled(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(10, 3)
+
+	root := NewTreeNode("0")
+	var last *TreeNode
+	for i := 1; i < 10; i++ {
+		last = NewTreeNode(fmt.Sprint(i))
+		root.AddChild(last)
+	}
+	tr := NewTreeView()
+	tr.SetRoot(root)
+	tr.SetCurrentNode(last)
+	tr.SetRect(0, 0, 10, 3)
+	tr.Draw(sc)
+	if offset := tr.GetScrollOffset(); offset != 7 {
+		t.Fatalf("failed to scroll tree view: expected offset 7, got %d", offset)
+	}
+
+	var doubleClicked *TreeNode
+	tr.SetDoubleClickFunc(func(node *TreeNode) {
+		doubleClicked = node
+	})
+	handler := tr.MouseHandler()
+	handler(MouseLeftDoubleClick, tcell.NewEventMouse(2, 1, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if doubleClicked == nil {
+		t.Error("failed to double-click scrolled node: expected 8, got nil")
+	} else if text := doubleClicked.GetText(); text != "8" {
+		t.Errorf("failed to double-click scrolled node: expected 8, got %s", text)
+	}
+	handler(MouseLeftClick, tcell.NewEventMouse(2, 0, tcell.Button1, tcell.ModNone), func(Primitive) {})
+	if current := tr.GetCurrentNode().GetText(); current != "7" {
+		t.Errorf("failed to click scrolled node: expected 7, got %s", current)
+	}
+}
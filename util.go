@@ -42,7 +42,7 @@ const (
 var (
 	colorPattern  = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([bdilrsu]+|\-)?)?)?\]`)
 	regionPattern = regexp.MustCompile(`\["([a-zA-Z0-9_,;: \-\.]*)"\]`)
-	escapePattern = regexp.MustCompile(`\[([a-zA-Z0-9_,;: \-\."#]+)\[(\[*)\]`)
+	escapePattern = regexp.MustCompile(`(\[[a-zA-Z0-9_,;: \-\."#]+\[*)\]`)
 
 	// Regular expression used to unescape escaped style/region tags.
 	unescapePattern = regexp.MustCompile(`(\[[a-zA-Z0-9_,;: \-\."#]+\[*)\[\]`)
@@ -186,6 +186,17 @@ func styleFromTag(fgColor, bgColor, attributes string, tagSubstrings [][]byte) (
 	return fgColor, bgColor, attributes
 }
 
+// invertedStyle returns a style which swaps the provided foreground and
+// background colors, e.g. to highlight a selection. If the background color
+// is tcell.ColorDefault, the colors are reversed by the terminal instead so the
+// highlight remains visible on the terminal's own background.
+func invertedStyle(foreground, background tcell.Color) tcell.Style {
+	if background == tcell.ColorDefault {
+		return tcell.StyleDefault.Foreground(foreground).Reverse(true)
+	}
+	return tcell.StyleDefault.Foreground(background).Background(foreground)
+}
+
 // overlayStyle mixes a background color with a foreground color (fgColor),
 // a (possibly new) background color (bgColor), and style attributes, and
 // returns the resulting style. For a definition of the colors and attributes,
@@ -330,15 +341,28 @@ func decomposeText(text []byte, findColors, findRegions bool) (colorIndices [][]
 	return
 }
 
+// PrintWithStyle prints text containing color tags onto the screen like
+// PrintStyle(). The skipWidth parameter specifies the number of cells skipped
+// at the beginning of the text, which is useful for horizontally scrolled
+// content. It returns the start index, end index (exclusively), and screen
+// width of the text actually printed. If maintainBackground is true, the
+// existing screen background is not changed. If pad is not 0, the cells of
+// the width not covered by the text are filled with the pad rune (usually a
+// space) so that they get the style of aligned or short text, too.
+func PrintWithStyle(screen ScreenWriter, text string, x, y, skipWidth, maxWidth, align int, style tcell.Style, maintainBackground bool, pad rune) (start, end, printedWidth int) {
+	return printWithStyle(screen, text, x, y, skipWidth, maxWidth, align, style, maintainBackground, pad)
+}
+
 // printWithStyle works like [Print] but it takes a style instead of just a
 // foreground color. The skipWidth parameter specifies the number of cells
 // skipped at the beginning of the text. It returns the start index, end index
 // (exclusively), and screen width of the text actually printed. If
 // maintainBackground is "true", the existing screen background is not changed
-// (i.e. the style's background color is ignored).
-func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth, align int, style tcell.Style, maintainBackground bool) (start, end, printedWidth int) {
+// (i.e. the style's background color is ignored). If pad is not 0, the cells
+// not covered by the text are filled with the pad rune.
+func printWithStyle(screen ScreenWriter, text string, x, y, skipWidth, maxWidth, align int, style tcell.Style, maintainBackground bool, pad rune) (start, end, printedWidth int) {
 	totalWidth, totalHeight := screen.Size()
-	if maxWidth <= 0 || len(text) == 0 || y < 0 || y >= totalHeight {
+	if maxWidth <= 0 || y < 0 || y >= totalHeight {
 		return 0, 0, 0
 	}
 
@@ -347,6 +371,14 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth,
 		style = style.Background(tcell.ColorDefault)
 	}
 
+	right := x + maxWidth
+	if len(text) == 0 {
+		if pad != 0 {
+			padCells(screen, x, right, y, pad, style, maintainBackground)
+		}
+		return 0, 0, 0
+	}
+
 	// Skip beginning and measure width.
 	var textWidth int
 	state := &stepState{
@@ -368,7 +400,17 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth,
 	}
 	state = &newState
 
+	// If a wide character was cut in half, leave its visible half blank.
+	if skipWidth < 0 {
+		for ; skipWidth < 0 && maxWidth > 0 && x < totalWidth; skipWidth++ {
+			screen.SetContent(x, y, ' ', nil, style)
+			x++
+			maxWidth--
+		}
+	}
+
 	// Reduce all alignments to AlignLeft.
+	left := x
 	if align == AlignRight {
 		// Chop off characters on the left until it fits.
 		for len(text) > 0 && textWidth > maxWidth {
@@ -401,6 +443,9 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth,
 			break // We don't care about the style at the end.
 		}
 		width := state.Width()
+		if x+width > rightBorder {
+			break // Don't cut a wide character in half.
+		}
 
 		if width > 0 {
 			finalStyle := state.Style()
@@ -428,9 +473,32 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth,
 		printedWidth += width
 	}
 
+	// Fill the cells not covered by the text.
+	if pad != 0 {
+		padCells(screen, left, x-printedWidth, y, pad, style, maintainBackground)
+		padCells(screen, x, right, y, pad, style, maintainBackground)
+	}
+
 	return
 }
 
+// padCells fills the cells of row y from x up to right (exclusively) with the
+// pad rune, not exceeding the screen. If maintainBackground is true and the
+// style has no background color, the cells keep their background color.
+func padCells(screen ScreenWriter, x, right, y int, pad rune, style tcell.Style, maintainBackground bool) {
+	totalWidth, _ := screen.Size()
+	_, background, _ := style.Decompose()
+	for ; x < right && x < totalWidth; x++ {
+		cellStyle := style
+		if maintainBackground && background == tcell.ColorDefault {
+			_, _, existingStyle, _ := screen.GetContent(x, y)
+			_, existing, _ := existingStyle.Decompose()
+			cellStyle = style.Background(existing)
+		}
+		screen.SetContent(x, y, pad, nil, cellStyle)
+	}
+}
+
 // Print prints text onto the screen into the given box at (x,y,maxWidth,1),
 // not exceeding that box. "align" is one of AlignLeft, AlignCenter, or
 // AlignRight. The screen's background color will not be changed.
@@ -445,10 +513,24 @@ func Print(screen ScreenWriter, text []byte, x, y, maxWidth, align int, color tc
 }
 
 // PrintStyle works like Print() but it takes a style instead of just a
-// foreground color.
-func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, style tcell.Style) (int, int) {
+// foreground color. If a pad rune (usually a space) is provided, the cells of
+// the box not covered by the text are filled with it so that they get the
+// style of aligned or short text, too. Like the text, they keep the screen's
+// background color if the style has none.
+func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, style tcell.Style, pad ...rune) (int, int) {
+	printed, width, start := printStyle(screen, text, x, y, maxWidth, align, style)
+	if len(pad) > 0 && maxWidth > 0 {
+		padCells(screen, x, start, y, pad[0], style, true)
+		padCells(screen, start+width, x+maxWidth, y, pad[0], style, true)
+	}
+	return printed, width
+}
+
+// printStyle implements PrintStyle() without padding. It additionally returns
+// the x-coordinate where the text starts.
+func printStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, style tcell.Style) (int, int, int) {
 	if maxWidth <= 0 || len(text) == 0 {
-		return 0, 0
+		return 0, 0, x
 	}
 
 	// Decompose the text.
@@ -458,13 +540,14 @@ func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, sty
 	if align == AlignRight {
 		if strippedWidth <= maxWidth {
 			// There's enough space for the entire text.
-			return PrintStyle(screen, text, x+maxWidth-strippedWidth, y, maxWidth, AlignLeft, style)
+			return printStyle(screen, text, x+maxWidth-strippedWidth, y, maxWidth, AlignLeft, style)
 		}
 		// Trim characters off the beginning.
 		var (
-			bytes, width, colorPos, escapePos, tagOffset int
-			foregroundColor, backgroundColor, attributes string
+			bytes, width, start, colorPos, escapePos, tagOffset int
+			foregroundColor, backgroundColor, attributes        string
 		)
+		start = x
 		_, originalBackground, _ := style.Decompose()
 		iterateString(string(strippedText), func(main rune, comb []rune, textPos, textWidth, screenPos, screenWidth int) bool {
 			// Update color/escape tag offset and style.
@@ -486,20 +569,20 @@ func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, sty
 					text = append(text[:escapeCharPos], text[escapeCharPos+1:]...)
 				}
 				// Print and return.
-				bytes, width = PrintStyle(screen, text[textPos+tagOffset:], x, y, maxWidth, AlignLeft, style)
+				bytes, width, start = printStyle(screen, text[textPos+tagOffset:], x, y, maxWidth, AlignLeft, style)
 				return true
 			}
 			return false
 		})
-		return bytes, width
+		return bytes, width, start
 	} else if align == AlignCenter {
 		if strippedWidth == maxWidth {
 			// Use the exact space.
-			return PrintStyle(screen, text, x, y, maxWidth, AlignLeft, style)
+			return printStyle(screen, text, x, y, maxWidth, AlignLeft, style)
 		} else if strippedWidth < maxWidth {
 			// We have more space than we need.
 			half := (maxWidth - strippedWidth) / 2
-			return PrintStyle(screen, text, x+half, y, maxWidth-half, AlignLeft, style)
+			return printStyle(screen, text, x+half, y, maxWidth-half, AlignLeft, style)
 		} else {
 			// Chop off runes until we have a perfect fit.
 			var choppedLeft, choppedRight, leftIndex, rightIndex int
@@ -554,7 +637,7 @@ func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, sty
 					escapePos++
 				}
 			}
-			return PrintStyle(screen, text[leftIndex+tagOffset:], x, y, maxWidth, AlignLeft, style)
+			return printStyle(screen, text[leftIndex+tagOffset:], x, y, maxWidth, AlignLeft, style)
 		}
 	}
 
@@ -605,12 +688,12 @@ func PrintStyle(screen ScreenWriter, text []byte, x, y, maxWidth, align int, sty
 		return false
 	})
 
-	return drawn + tagOffset + len(escapeIndices), drawnWidth
+	return drawn + tagOffset + len(escapeIndices), drawnWidth, x
 }
 
 // PrintSimple prints white text to the screen at the given position.
-func PrintSimple(screen tcell.Screen, text []byte, x, y int) {
-	Print(screen, text, x, y, math.MaxInt32, AlignLeft, Styles.PrimaryTextColor)
+func PrintSimple(screen ScreenWriter, text []byte, x, y int) {
+	Print(screen, text, x, y, math.MaxInt32, AlignLeft, applicationOf(screen).theme().PrimaryTextColor)
 }
 
 // TaggedTextWidth returns the width of the given string needed to print it on
@@ -730,7 +813,7 @@ var (
 )
 
 // RenderScrollBar renders a scroll bar at the specified position.
-func RenderScrollBar(screen tcell.Screen, visibility ScrollBarVisibility, x int, y int, height int, items int, cursor int, printed int, focused bool, color tcell.Color) {
+func RenderScrollBar(screen ScreenWriter, visibility ScrollBarVisibility, x int, y int, height int, items int, cursor int, printed int, focused bool, color tcell.Color) {
 	if visibility == ScrollBarNever || (visibility == ScrollBarAuto && items <= height) {
 		return
 	}
@@ -1,6 +1,8 @@
 package nuview
 
 import (
+	"testing"
+
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -17,3 +19,56 @@ func newTestApp(root Primitive) (*Application, error) {
 
 	return app, nil
 }
+
+func TestInvertedStyle(t *testing.T) {
+	t.Parallel()
+
+	if style := invertedStyle(tcell.ColorWhite, tcell.ColorBlack); style != tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite) {
+		t.Errorf("failed to invert style: expected swapped colors, got %v", style)
+	}
+	if style := invertedStyle(tcell.ColorWhite, tcell.ColorDefault); style != tcell.StyleDefault.Foreground(tcell.ColorWhite).Reverse(true) {
+		t.Errorf("failed to invert style: expected reversed colors, got %v", style)
+	}
+}
+
+func TestPrintWithStylePad(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(12, 4)
+	style := tcell.StyleDefault.Background(tcell.ColorBlue)
+	row := func(y int) (text string, filled bool) {
+		filled = true
+		for x := 0; x < 12; x++ {
+			r, _, cellStyle, _ := sc.GetContent(x, y)
+			text += string(r)
+			if _, background, _ := cellStyle.Decompose(); (background == tcell.ColorBlue) != (x < 10) {
+				filled = false
+			}
+		}
+		return
+	}
+
+	PrintWithStyle(sc, "[red]abc", 0, 0, 0, 10, AlignRight, style, false, '.')
+	if text, filled := row(0); text != ".......abc  " || !filled {
+		t.Errorf("failed to fill right-aligned text: got %q (filled %t)", text, filled)
+	}
+	PrintWithStyle(sc, "abc", 0, 1, 0, 10, AlignCenter, style, false, ' ')
+	if text, filled := row(1); text != "    abc     " || !filled {
+		t.Errorf("failed to fill centered text: got %q (filled %t)", text, filled)
+	}
+	PrintStyle(sc, []byte("[red]abc"), 0, 2, 10, AlignCenter, style, '.')
+	if text, filled := row(2); text != "...abc....  " || !filled {
+		t.Errorf("failed to fill text printed with PrintStyle: got %q (filled %t)", text, filled)
+	}
+
+	// The background is kept if requested.
+	FillRect(sc, 0, 3, 10, 1, ' ', style)
+	PrintWithStyle(sc, "abc", 0, 3, 0, 10, AlignRight, tcell.StyleDefault.Background(tcell.ColorRed), true, '.')
+	if text, filled := row(3); text != ".......abc  " || !filled {
+		t.Errorf("failed to keep background when filling: got %q (filled %t)", text, filled)
+	}
+}
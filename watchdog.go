@@ -0,0 +1,148 @@
+package nuview
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// The maximum size of a goroutine dump taken by the watchdog.
+const watchdogDumpSize = 1 << 20
+
+// SetWatchdog enables a watchdog which detects when the application's event
+// loop is blocked, e.g. by an event handler or a function passed to
+// QueueUpdate() which performs blocking I/O. When processing an event or an
+// update takes longer than the provided timeout, the handler is called with
+// the time the event loop has been blocked and a dump of the stack traces of
+// all goroutines. If the handler is nil, the dump is written with the
+// standard logger instead (see log.SetOutput()). Each blocking event is
+// reported once.
+//
+// The handler is called from the watchdog's own goroutine while the event loop
+// is still blocked. It must therefore not call functions which wait for the
+// event loop, such as QueueUpdate().
+//
+// A timeout of 0 disables the watchdog, which is the default. The watchdog
+// runs while Run() is in progress.
+func (a *Application) SetWatchdog(timeout time.Duration, handler func(blocked time.Duration, dump []byte)) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.watchdogTimeout = timeout
+	a.watchdogFunc = handler
+	if a.watchdogStop != nil {
+		// Run() is in progress. Restart the watchdog.
+		a.stopWatchdog()
+		a.startWatchdog()
+	}
+}
+
+// SetWatchdogOverlay sets whether or not the watchdog displays a warning in
+// the top row of the screen while the event loop is blocked. The warning is
+// removed by redrawing the screen once the event loop resumes.
+func (a *Application) SetWatchdogOverlay(show bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.watchdogOverlay = show
+}
+
+// startWatchdog starts the watchdog goroutine if the watchdog is enabled. The
+// application must be locked.
+func (a *Application) startWatchdog() {
+	a.watchdogStop = make(chan struct{})
+	if a.watchdogTimeout > 0 {
+		go a.watch(a.watchdogTimeout, a.watchdogStop)
+	}
+}
+
+// stopWatchdog stops the watchdog goroutine. The application must be locked.
+func (a *Application) stopWatchdog() {
+	if a.watchdogStop != nil {
+		close(a.watchdogStop)
+		a.watchdogStop = nil
+	}
+}
+
+// busy marks the start of the processing of an event or update.
+func (a *Application) busy() {
+	a.busySince.Store(time.Now().UnixNano())
+}
+
+// done marks the end of the processing of an event or update.
+func (a *Application) done() {
+	a.busySince.Store(0)
+}
+
+// watch checks periodically whether the event loop has been blocked for
+// longer than the timeout until the stop channel is closed.
+func (a *Application) watch(timeout time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(max(timeout/4, 10*time.Millisecond))
+	defer ticker.Stop()
+
+	var reported int64 // The start time of the last reported blocking event.
+	var overlay bool   // Whether or not the warning overlay is displayed.
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		since := a.busySince.Load()
+		if overlay && since != reported {
+			// The event loop resumed. Remove the overlay.
+			overlay = false
+			go a.Draw()
+		}
+		if since == 0 || since == reported {
+			continue
+		}
+		blocked := time.Since(time.Unix(0, since))
+		if blocked < timeout {
+			continue
+		}
+		reported = since
+
+		a.RLock()
+		handler, showOverlay := a.watchdogFunc, a.watchdogOverlay
+		a.RUnlock()
+
+		dump := make([]byte, watchdogDumpSize)
+		dump = dump[:runtime.Stack(dump, true)]
+		if handler != nil {
+			handler(blocked, dump)
+		} else {
+			log.Printf("event loop blocked for %s\n%s", blocked, dump)
+		}
+
+		if showOverlay {
+			overlay = a.drawWatchdogOverlay(blocked)
+		}
+	}
+}
+
+// drawWatchdogOverlay draws a warning about the blocked event loop in the top
+// row of the screen. It returns whether or not the warning was drawn.
+func (a *Application) drawWatchdogOverlay(blocked time.Duration) bool {
+	// The blocked goroutine may hold the lock.
+	if !a.TryRLock() {
+		return false
+	}
+	screen := a.screen
+	a.RUnlock()
+	if screen == nil {
+		return false
+	}
+	width, _ := screen.Size()
+
+	theme := a.theme()
+	style := tcell.StyleDefault.Foreground(theme.PrimaryTextColor).Background(theme.ContrastBackgroundColor).Bold(true)
+	message := fmt.Sprintf("Application not responding (blocked for %s)", blocked.Round(time.Second))
+	PrintWithStyle(screen, Escape(message), 0, 0, 0, width, AlignCenter, style, false, ' ')
+	screen.Show()
+	return true
+}
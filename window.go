@@ -21,6 +21,10 @@ type Window struct {
 	dragX, dragY   int
 	dragWX, dragWY int
 
+	// Whether the window should be sized to its content and centered the
+	// next time it is drawn by its window manager.
+	sizeToContent, center bool
+
 	sync.RWMutex
 }
 
@@ -54,6 +58,88 @@ func (w *Window) SetFullscreen(fullscreen bool) {
 	}
 }
 
+// SizeToContent resizes the window to the preferred size of its primitive
+// (see PreferredSize), limited to the area of the window manager. The size is
+// calculated the next time the window is drawn. Primitives which do not
+// implement PreferredSize keep the current size of the window.
+func (w *Window) SizeToContent() {
+	w.Lock()
+	defer w.Unlock()
+
+	w.sizeToContent = true
+}
+
+// CenterOnScreen moves the window to the center of the area of the window
+// manager. The position is calculated the next time the window is drawn, i.e.
+// after SizeToContent() was applied.
+func (w *Window) CenterOnScreen() {
+	w.Lock()
+	defer w.Unlock()
+
+	w.center = true
+}
+
+// PreferredWidth returns the preferred width of the window's primitive plus
+// the border and padding of the window. If the primitive does not implement
+// PreferredSize, the current width is returned.
+func (w *Window) PreferredWidth(maxWidth int) int {
+	w.RLock()
+	defer w.RUnlock()
+
+	preferred, ok := w.primitive.(PreferredSize)
+	if !ok {
+		_, _, width, _ := w.GetRect()
+		return width
+	}
+	frameWidth, _ := w.frameSize()
+	if maxWidth > 0 {
+		maxWidth = max(maxWidth-frameWidth, 1)
+	}
+	return preferred.PreferredWidth(maxWidth) + frameWidth
+}
+
+// PreferredHeight returns the preferred height of the window's primitive at
+// the provided width plus the border and padding of the window. If the
+// primitive does not implement PreferredSize, the current height is returned.
+func (w *Window) PreferredHeight(width int) int {
+	w.RLock()
+	defer w.RUnlock()
+
+	preferred, ok := w.primitive.(PreferredSize)
+	if !ok {
+		_, _, _, height := w.GetRect()
+		return height
+	}
+	frameWidth, frameHeight := w.frameSize()
+	return preferred.PreferredHeight(max(width-frameWidth, 0)) + frameHeight
+}
+
+// applyLayout sizes and positions the window within the provided area if this
+// was requested with SizeToContent() or CenterOnScreen().
+func (w *Window) applyLayout(x, y, width, height int) {
+	w.RLock()
+	sizeToContent, center := w.sizeToContent, w.center
+	w.RUnlock()
+	if !sizeToContent && !center {
+		return
+	}
+
+	wx, wy, ww, wh := w.GetRect()
+	if sizeToContent {
+		ww = max(min(w.PreferredWidth(width), width), w.theme().WindowMinWidth)
+		wh = max(min(w.PreferredHeight(ww), height), w.theme().WindowMinHeight)
+	}
+	if center {
+		wx = x + (width-ww)/2
+		wy = y + max((height-wh)/2, 0)
+	}
+	w.SetRect(wx, wy, ww, wh)
+
+	w.Lock()
+	w.sizeToContent, w.center = false, false
+	w.Unlock()
+}
+
 // Focus is called when this primitive receives focus.
 func (w *Window) Focus(delegate func(p Primitive)) {
 	w.Lock()
@@ -100,7 +186,7 @@ func (w *Window) Draw(screen tcell.Screen) {
 
 	x, y, width, height := w.GetInnerRect()
 	w.primitive.SetRect(x, y, width, height)
-	w.primitive.Draw(screen)
+	drawPrimitive(NewClippedScreen(screen, x, y, width, height), w.primitive)
 }
 
 // InputHandler returns the handler for this primitive.
@@ -0,0 +1,52 @@
+package nuview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestWindowSizeToContent(t *testing.T) {
+	t.Parallel()
+
+	sc := tcell.NewSimulationScreen("UTF-8")
+	if err := sc.Init(); err != nil {
+		t.Fatal(err)
+	}
+	sc.SetSize(40, 20)
+
+	textView := NewTextView()
+	textView.SetText("Hello, World!\nSecond line")
+
+	w := NewWindow(textView)
+	wm := NewWindowManager()
+	wm.SetRect(0, 0, 40, 20)
+	wm.Add(w)
+
+	w.SizeToContent()
+	w.CenterOnScreen()
+	wm.Draw(sc)
+
+	// The text is 13 cells wide and 2 lines high, plus the window border.
+	if x, y, width, height := w.GetRect(); x != 12 || y != 8 || width != 15 || height != 4 {
+		t.Errorf("failed to size window to content: expected 12,8 15x4, got %d,%d %dx%d", x, y, width, height)
+	}
+
+	// Wrapped lines are limited to the window manager's area.
+	textView.SetText("This line of text is longer than the window manager is wide")
+	w.SizeToContent()
+	wm.Draw(sc)
+	if _, _, width, height := w.GetRect(); width != 40 || height != 4 {
+		t.Errorf("failed to size window to wrapped content: expected 40x4, got %dx%d", width, height)
+	}
+
+	// Primitives without a preferred size keep the window's size.
+	box := NewWindow(NewBox())
+	box.SetRect(0, 0, 10, 5)
+	wm.Add(box)
+	box.SizeToContent()
+	wm.Draw(sc)
+	if _, _, width, height := box.GetRect(); width != 10 || height != 5 {
+		t.Errorf("failed to keep window size: expected 10x5, got %dx%d", width, height)
+	}
+}
@@ -81,6 +81,9 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 
 	x, y, width, height := wm.GetInnerRect()
 
+	// Windows may be moved partly outside of the window manager.
+	clipped := NewClippedScreen(screen, x, y, width, height)
+
 	var hasFullScreen bool
 	for _, w := range wm.windows {
 		if !w.fullscreen || !w.GetVisible() {
@@ -90,7 +93,7 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 		hasFullScreen = true
 		w.SetRect(x-1, y, width+2, height+1)
 
-		w.Draw(screen)
+		drawPrimitive(clipped, w)
 	}
 	if hasFullScreen {
 		return
@@ -101,6 +104,8 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 			continue
 		}
 
+		w.applyLayout(x, y, width, height)
+
 		// Reposition out of bounds windows
 		margin := 3
 		wx, wy, ww, wh := w.GetRect()
@@ -121,7 +126,7 @@ func (wm *WindowManager) Draw(screen tcell.Screen) {
 			w.SetRect(wx, wy, ww, wh)
 		}
 
-		w.Draw(screen)
+		drawPrimitive(clipped, w)
 	}
 }
 
@@ -151,14 +156,14 @@ func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.Ev
 					if w.dragX == -1 {
 						offsetX := w.x - mouseX
 
-						if w.width+offsetX >= Styles.WindowMinWidth {
+						if w.width+offsetX >= w.theme().WindowMinWidth {
 							w.x -= offsetX
 							w.width += offsetX
 						}
 					} else {
 						offsetX := mouseX - (w.x + w.width) + 1
 
-						if w.width+offsetX >= Styles.WindowMinWidth {
+						if w.width+offsetX >= w.theme().WindowMinWidth {
 							w.width += offsetX
 						}
 					}
@@ -171,13 +176,13 @@ func (wm *WindowManager) MouseHandler() func(action MouseAction, event *tcell.Ev
 					if w.dragY == -1 {
 						offsetY := mouseY - (w.y + w.height) + 1
 
-						if w.height+offsetY >= Styles.WindowMinHeight {
+						if w.height+offsetY >= w.theme().WindowMinHeight {
 							w.height += offsetY
 						}
 					} else {
 						offsetY := w.y - mouseY
 
-						if w.height+offsetY >= Styles.WindowMinHeight {
+						if w.height+offsetY >= w.theme().WindowMinHeight {
 							w.y -= offsetY
 							w.height += offsetY
 						}